@@ -0,0 +1,772 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/publish"
+	"github.com/audi70r/gitstat/internal/scan"
+	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		var run func([]string) error
+		switch os.Args[1] {
+		case "suggest-reviewers":
+			run = runSuggestReviewers
+		case "label":
+			run = runLabel
+		case "quick-stats":
+			run = runQuickStats
+		case "publish":
+			run = runPublish
+		case "check":
+			run = runCheck
+		case "review":
+			run = runReview
+		case "daemon":
+			run = runDaemon
+		case "codeowners":
+			run = runCodeOwners
+		case "health":
+			run = runHealth
+		case "replay":
+			run = runReplay
+		}
+		if run != nil {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "gitstat:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	query := flag.String("query", "", `print the result of a filter query and exit, e.g. "authors where commits > 50"`)
+	noTUI := flag.Bool("no-tui", false, "scan without the UI and write the statistics in the --output format")
+	output := flag.String("output", "json", "output format for --no-tui: json")
+	out := flag.String("out", "", "file to write with --no-tui, default stdout")
+	snapshot := flag.Bool("snapshot", false, "with --no-tui, also record the scan for the Trends view, e.g. from cron")
+	fetchGitHub := flag.Bool("github", false, "with --no-tui, also fetch the reviews of the pull requests from GitHub; needs a token")
+	repo := flag.String("repo", ".", "repository to scan with --query or --no-tui")
+	since := flag.String("since", "", "start date (YYYY-MM-DD) for --query or --no-tui, default one year ago")
+	until := flag.String("until", "", "end date (YYYY-MM-DD) for --query or --no-tui, default today")
+	include := flag.String("include", "", `comma-separated path globs files must match to be analyzed, e.g. "src/**"`)
+	exclude := flag.String("exclude", "", `comma-separated path globs of files to leave out, e.g. "vendor/**,*.lock"`)
+	record := flag.String("record", "", "file to write the parsed commits to while scanning, for gitstat replay")
+	lang := flag.String("lang", "", "interface language: "+strings.Join(i18n.Tags(), ", ")+"; default from LC_ALL, LC_MESSAGES or LANG")
+	flag.Parse()
+
+	if !i18n.Use(*lang) {
+		fmt.Fprintf(os.Stderr, "gitstat: unknown language %q, using English\n", *lang)
+	}
+
+	flags := scanFlags{include: splitList(*include), exclude: splitList(*exclude), record: *record}
+	if err := stats.CheckGlobs(append(flags.include, flags.exclude...)); err != nil {
+		fmt.Fprintln(os.Stderr, "gitstat:", err)
+		os.Exit(1)
+	}
+
+	if *noTUI {
+		flags.github = *fetchGitHub
+		if err := runExport(*output, *out, *repo, *since, *until, *snapshot, flags); err != nil {
+			fmt.Fprintln(os.Stderr, "gitstat:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *query == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "gitstat:", err)
+			os.Exit(1)
+		}
+		flags.apply(cfg)
+		app, err := ui.NewApp(cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "gitstat:", err)
+			os.Exit(1)
+		}
+		if err := app.Run(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := runQuery(*query, *repo, *since, *until, flags); err != nil {
+		fmt.Fprintln(os.Stderr, "gitstat:", err)
+		os.Exit(1)
+	}
+}
+
+// runQuery scans a repository without the UI and prints the query result
+func runQuery(query, repoPath, since, until string, flags scanFlags) error {
+	// Parse first so syntax errors are reported before a long scan
+	q, err := stats.ParseQuery(query)
+	if err != nil {
+		return err
+	}
+
+	repoStats, err := scanRepository(repoPath, since, until, flags)
+	if err != nil {
+		return err
+	}
+
+	result := q.Run(repoStats)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(result.Columns, "\t")))
+	for _, row := range result.Rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	fmt.Fprintf(os.Stderr, "%d of %d %s matched\n", result.Matched, result.Scanned, q.Entity)
+	return nil
+}
+
+// runExport scans a repository without the UI and writes its statistics to
+// path, or to stdout when path is empty. With snapshot the scan is also
+// added to the repository's trends.
+func runExport(format, path, repoPath, since, until string, snapshot bool, flags scanFlags) error {
+	if format != "json" {
+		return fmt.Errorf("unknown output format %q, supported: json", format)
+	}
+
+	repoStats, err := scanRepository(repoPath, since, until, flags)
+	if err != nil {
+		return err
+	}
+	if snapshot {
+		trends := config.TrendsPath([]string{repoPath})
+		if trends == "" {
+			return fmt.Errorf("no config directory to record the snapshot in")
+		}
+		if err := stats.AppendTrend(trends, repoStats.Snapshot(time.Now())); err != nil {
+			return err
+		}
+	}
+
+	return writeExport(repoStats, path)
+}
+
+// writeExport writes the JSON export of repoStats to path, or to stdout
+// when path is empty
+func writeExport(repoStats *stats.Repository, path string) error {
+	data, err := json.MarshalIndent(repoStats.GetExport(config.Default().Timezone), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// runReplay aggregates a scan record written with --record, without git,
+// and writes the statistics like --no-tui
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat replay [flags] <record>")
+		fs.PrintDefaults()
+	}
+	out := fs.String("out", "", "file to write, default stdout")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("a scan record is required")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repoStats, err := scan.NewController(ctx, cfg, &scan.Headless{}).Replay(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeExport(repoStats, *out)
+}
+
+// runSuggestReviewers ranks reviewers for the given paths, or for the files
+// of a diff read from stdin when no paths are given
+func runSuggestReviewers(args []string) error {
+	fs := flag.NewFlagSet("suggest-reviewers", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat suggest-reviewers [flags] -- path...")
+		fmt.Fprintln(fs.Output(), "       git diff main | gitstat suggest-reviewers [flags]")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	limit := fs.Int("limit", 5, "number of reviewers to suggest")
+	exclude := fs.String("exclude", "", "comma-separated emails to skip, e.g. the change's author")
+	fs.Parse(args)
+
+	var err error
+	paths := fs.Args()
+	if len(paths) == 0 {
+		if paths, err = stdinDiffPaths(); err != nil {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return fmt.Errorf("no paths given and no diff on stdin")
+	}
+
+	var excluded []string
+	for _, email := range strings.Split(*exclude, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			excluded = append(excluded, email)
+		}
+	}
+
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+
+	suggestions := repoStats.SuggestReviewers(paths, excluded, *limit)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no history for the given paths")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tREVIEWER\tEMAIL\tSCORE\tFILES\tCOMMITS\tRECENT\tLAST ACTIVE")
+	for i, s := range suggestions {
+		lastActive := "-"
+		if !s.LastCommit.IsZero() {
+			lastActive = s.LastCommit.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%d\t%d\t%d\t%s\n",
+			i+1, s.Name, s.Email, s.Score, s.Files, s.Commits, s.RecentCommits, lastActive)
+	}
+	w.Flush()
+	fmt.Fprintf(os.Stderr, "%d path(s) considered\n", len(paths))
+	return nil
+}
+
+// runCheck checks files against the hotspot and ownership policies for CI:
+// the given paths, the files of a diff read from stdin, or every file. It
+// fails when a file breaches a policy.
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat check [flags] [-- path...]")
+		fmt.Fprintln(fs.Output(), "       git diff origin/main... | gitstat check [flags]")
+		fs.PrintDefaults()
+	}
+	policy := stats.DefaultPolicy()
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	format := fs.String("format", "text", "violation format: text, github (Actions annotations) or checkstyle (XML)")
+	fs.Float64Var(&policy.MaxRisk, "max-risk", policy.MaxRisk, "hotspot risk score from which a file breaches the hotspot policy")
+	fs.Float64Var(&policy.MaxOwnerShare, "max-owner-share", policy.MaxOwnerShare, "percent of a file's commits by one author from which it breaches the ownership policy")
+	fs.IntVar(&policy.MinCommits, "min-commits", policy.MinCommits, "commits a file needs before its ownership is checked")
+	fs.Parse(args)
+
+	if *format != "text" && *format != "github" && *format != "checkstyle" {
+		return fmt.Errorf("unknown format %q, supported: text, github, checkstyle", *format)
+	}
+	var err error
+	paths := fs.Args()
+	if len(paths) == 0 {
+		if paths, err = stdinDiffPaths(); err != nil {
+			return err
+		}
+	}
+
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+	violations := repoStats.CheckPolicy(policy, paths)
+
+	switch *format {
+	case "github":
+		fmt.Print(stats.GitHubAnnotations(violations))
+	case "checkstyle":
+		data, err := stats.Checkstyle(violations)
+		if err != nil {
+			return err
+		}
+		os.Stdout.Write(data)
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, v := range violations {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", v.Path, v.Rule, v.Message)
+		}
+		w.Flush()
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d policy violation(s)", len(violations))
+	}
+	return nil
+}
+
+// runReview describes the files of a pending change for its review: their
+// hotspot risk, owners and bus factor, and who should review it
+func runReview(args []string) error {
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat review [flags] --merge-base main")
+		fmt.Fprintln(fs.Output(), "       gitstat review [flags] -- path...")
+		fmt.Fprintln(fs.Output(), "       git diff main | gitstat review [flags]")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	mergeBase := fs.String("merge-base", "", "review the changes since the merge base with this branch, committed or not")
+	limit := fs.Int("limit", 5, "number of reviewers to suggest")
+	exclude := fs.String("exclude", "", "comma-separated emails to skip, e.g. the change's author")
+	fs.Parse(args)
+
+	var err error
+	paths := fs.Args()
+	switch {
+	case *mergeBase != "" && len(paths) > 0:
+		return fmt.Errorf("give either --merge-base or paths")
+	case *mergeBase != "":
+		if paths, err = git.ChangedFiles(context.Background(), *repo, *mergeBase); err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("no changes since the merge base with %s", *mergeBase)
+		}
+	case len(paths) == 0:
+		if paths, err = stdinDiffPaths(); err != nil {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return fmt.Errorf("no paths, --merge-base or diff on stdin given")
+	}
+
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+	files := repoStats.ReviewChange(paths)
+
+	hotspots, silos := 0, 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tRISK\tCOMMITS\tCHANGES\tBUS FACTOR\tOWNERS")
+	for _, f := range files {
+		if f.Commits == 0 {
+			fmt.Fprintf(w, "%s\t-\t0\t0\t-\tno history\n", f.Path)
+			continue
+		}
+		if f.RiskScore >= stats.HighRiskScore {
+			hotspots++
+		}
+		if f.BusFactor == 1 {
+			silos++
+		}
+		owners := make([]string, len(f.Owners))
+		for i, o := range f.Owners {
+			owners[i] = fmt.Sprintf("%s %.0f%%", o.Name, o.Share)
+		}
+		fmt.Fprintf(w, "%s\t%.0f\t%d\t%d\t%d\t%s\n",
+			f.Path, f.RiskScore, f.Commits, f.Changes, f.BusFactor, strings.Join(owners, ", "))
+	}
+	w.Flush()
+	fmt.Printf("\n%d file(s): %d high-risk hotspot(s), %d with a bus factor of 1\n", len(files), hotspots, silos)
+
+	var excluded []string
+	for _, email := range strings.Split(*exclude, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			excluded = append(excluded, email)
+		}
+	}
+	suggestions := repoStats.SuggestReviewers(paths, excluded, *limit)
+	if len(suggestions) == 0 {
+		return nil
+	}
+	fmt.Println("\nSuggested reviewers")
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tREVIEWER\tEMAIL\tSCORE\tFILES\tRECENT")
+	for i, s := range suggestions {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%d\t%d\n", i+1, s.Name, s.Email, s.Score, s.Files, s.RecentCommits)
+	}
+	w.Flush()
+	return nil
+}
+
+// stdinDiffPaths returns the files changed by a diff piped to stdin, or
+// none when stdin is a terminal
+func stdinDiffPaths() ([]string, error) {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return nil, nil
+	}
+	diff, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	return stats.ParseDiffPaths(string(diff)), nil
+}
+
+// runCodeOwners writes a draft CODEOWNERS file from the directory
+// ownership
+func runCodeOwners(args []string) error {
+	fs := flag.NewFlagSet("codeowners", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat codeowners [flags] > .github/CODEOWNERS")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	minShare := fs.Float64("min-share", 20, "percent of a directory's lines changed an author needs to own it")
+	maxOwners := fs.Int("max-owners", 3, "owners per directory at most, 0 for no limit")
+	out := fs.String("out", "", "file to write, default stdout")
+	fs.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+
+	rules := repoStats.CodeOwners(*minShare, *maxOwners)
+	header := fmt.Sprintf("Draft generated by gitstat codeowners from the history (%s).\n"+
+		"Owners hold at least %.0f%% of a top-level directory's lines changed; review before committing.",
+		repoStats.DateRange, *minShare)
+	data := []byte(stats.CodeOwnersFile(rules, cfg.Handles, header))
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0644)
+}
+
+// runHealth prints the health score of a repository with its breakdown,
+// failing below a minimum score so CI can gate on it
+func runHealth(args []string) error {
+	fs := flag.NewFlagSet("health", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat health [flags]")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	minScore := fs.Float64("min", 0, "fail when the score is below this")
+	fs.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+
+	health := repoStats.Health(cfg.HealthWeights)
+	fmt.Print(health.String())
+	if health.Measured() && health.Score < *minScore {
+		return fmt.Errorf("health score %.0f is below %.0f", health.Score, *minScore)
+	}
+	return nil
+}
+
+// runQuickStats prints the author contributions in the output format of
+// git-quick-stats, for scripts built around it
+func runQuickStats(args []string) error {
+	fs := flag.NewFlagSet("quick-stats", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat quick-stats [flags]")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	csv := fs.Bool("csv", false, "print CSV like git quick-stats -V instead of the detailed stats of -T")
+	fs.Parse(args)
+
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+	if *csv {
+		fmt.Print(repoStats.QuickStatsCSV())
+	} else {
+		fmt.Print(repoStats.QuickStats())
+	}
+	return nil
+}
+
+// runPublish sends the Markdown report of a repository to a target of the
+// configuration file, e.g. weekly from cron
+func runPublish(args []string) error {
+	fs := flag.NewFlagSet("publish", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: gitstat publish [flags] <%s>\n", strings.Join(publish.Targets, "|"))
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository to scan")
+	since := fs.String("since", "", "start date (YYYY-MM-DD), default one year ago")
+	until := fs.String("until", "", "end date (YYYY-MM-DD), default today")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("a target is required")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := publish.Check(cfg, fs.Arg(0)); err != nil {
+		return err
+	}
+
+	repoStats, err := scanRepository(*repo, *since, *until, scanFlags{})
+	if err != nil {
+		return err
+	}
+	report := repoStats.MarkdownReport(cfg.MaxAuthors, cfg.MaxFiles)
+	if err := publish.Publish(context.Background(), cfg, fs.Arg(0), repoStats.ReportTitle(), report); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "published to %s\n", fs.Arg(0))
+	return nil
+}
+
+// runDaemon keeps the commit caches of repositories fresh in the
+// background, fetching and parsing new commits on a schedule, so the
+// interface opens even huge repositories near-instantly
+func runDaemon(args []string) error {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat daemon [flags] [repo...]")
+		fs.PrintDefaults()
+	}
+	once := fs.Bool("once", false, "warm the caches once and exit, e.g. from cron")
+	since := fs.String("since", "", "start date (YYYY-MM-DD) of the cached history, default one year ago")
+	fs.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	repos := fs.Args()
+	if len(repos) == 0 {
+		repos = cfg.DaemonRepos
+	}
+	if len(repos) == 0 {
+		fs.Usage()
+		return fmt.Errorf("no repositories given or configured under daemon in the configuration file")
+	}
+	for i, repo := range repos {
+		if repo == "~" || strings.HasPrefix(repo, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				repos[i] = filepath.Join(home, repo[1:])
+			}
+		}
+	}
+
+	// Midnight, as the setup screen's dates are, so the interface's
+	// default period of a year falls within the cached history
+	from := time.Now().AddDate(-1, 0, 0).Format("2006-01-02")
+	if *since != "" {
+		from = *since
+	}
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for {
+		for _, repo := range repos {
+			began := time.Now()
+			commits, err := warmCache(ctx, repo, cfg, start)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", time.Now().Format(time.DateTime), repo, err)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "%s %s: %d commits cached in %s\n",
+				time.Now().Format(time.DateTime), repo, commits, time.Since(began).Round(time.Millisecond))
+		}
+		if *once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cfg.DaemonInterval):
+		}
+	}
+}
+
+// warmCache fetches a repository, if configured, and brings its commit
+// cache up to date with HEAD. The commits are parsed with patch-ids, so
+// the cache also serves scans that deduplicate. Only the git binary backend
+// keeps a cache, so it is used whatever Config.GitBackend says.
+func warmCache(ctx context.Context, repoPath string, cfg *config.Config, since time.Time) (int, error) {
+	if !git.IsGitRepo(repoPath) {
+		return 0, fmt.Errorf("not a git repository")
+	}
+	if cfg.DaemonFetch {
+		// Offline repositories still get their local commits cached
+		if err := git.Fetch(ctx, repoPath); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", time.Now().Format(time.DateTime), repoPath, err)
+		}
+	}
+
+	parser := git.NewExecParser(repoPath)
+	parser.SkipGenerated = cfg.SkipGenerated
+	parser.WithPatchIDs = true
+	parser.NoMerges = cfg.ExcludeMerges
+	parser.FirstParent = cfg.FirstParent
+	parser.Cache = git.OpenCommitCache(repoPath)
+	commits := 0
+	err := parser.Parse(ctx, since, time.Time{}, nil, func(*git.Commit) { commits++ })
+	return commits, err
+}
+
+// runLabel adds labels to a commit's gitstat note, or removes them
+func runLabel(args []string) error {
+	fs := flag.NewFlagSet("label", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: gitstat label [flags] <commit> <label>...")
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "repository holding the commit")
+	remove := fs.Bool("remove", false, "remove the labels instead of adding them")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return fmt.Errorf("a commit and at least one label are required")
+	}
+	commit, labels := fs.Arg(0), fs.Args()[1:]
+
+	edit := git.AddLabels
+	if *remove {
+		edit = git.RemoveLabels
+	}
+	result, err := edit(context.Background(), *repo, commit, labels)
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		fmt.Printf("%s: no labels\n", commit)
+	} else {
+		fmt.Printf("%s: %s\n", commit, strings.Join(result, ", "))
+	}
+	return nil
+}
+
+// scanFlags are the scan settings of the command line: the --include and
+// --exclude path globs, replacing those of the configuration file where
+// given, the --record file, and whether --github fetches the pull
+// requests' reviews
+type scanFlags struct {
+	include []string
+	exclude []string
+	record  string
+	github  bool
+}
+
+func (f scanFlags) apply(cfg *config.Config) {
+	if len(f.include) > 0 {
+		cfg.IncludeGlobs = f.include
+	}
+	if len(f.exclude) > 0 {
+		cfg.ExcludeGlobs = f.exclude
+	}
+	if f.record != "" {
+		cfg.RecordPath = f.record
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// scanRepository aggregates a repository's history without the UI
+func scanRepository(repoPath, since, until string, flags scanFlags) (*stats.Repository, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	flags.apply(cfg)
+	cfg.Until = time.Now()
+	cfg.Since = cfg.Until.AddDate(-1, 0, 0)
+	if since != "" {
+		if cfg.Since, err = time.ParseInLocation("2006-01-02", since, cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		if cfg.Until, err = time.ParseInLocation("2006-01-02", until, cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid --until: %w", err)
+		}
+		cfg.Until = cfg.Until.Add(24*time.Hour - time.Second)
+	}
+
+	// Ctrl-C cancels the scan and its git subprocesses
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !git.IsGitRepo(repoPath) {
+		return nil, fmt.Errorf("%s is not a git repository", repoPath)
+	}
+
+	// The same scan as the UI's, without the blame pass, and without the
+	// GitHub requests unless asked for
+	headless := &scan.Headless{}
+	cfg.RepoPath, cfg.RepoPaths = repoPath, nil
+	controller := scan.NewController(ctx, cfg, headless)
+	controller.GitHub = flags.github
+	if err := controller.Scan(ctx, []string{repoPath}); err != nil {
+		return nil, err
+	}
+	return headless.Result.Stats, nil
+}
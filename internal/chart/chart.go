@@ -0,0 +1,237 @@
+// Package chart renders statistics as SVG and PNG charts for slides and
+// documents. It uses the standard library only: both formats are drawn by
+// the same layout code onto a canvas, the PNG one writing its text in a
+// built-in bitmap font.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Kinds of charts
+const (
+	Bars  = "bars"  // one bar per label, of the first series
+	Lines = "lines" // one line per series
+)
+
+// Size of the charts in pixels
+const (
+	Width  = 1200
+	Height = 600
+)
+
+// Series is a named row of values, one per label
+type Series struct {
+	Name   string
+	Values []float64
+	Color  color.RGBA // zero picks the next palette color
+}
+
+// Chart is the data of a chart: labels along the x-axis and one or more
+// series of values
+type Chart struct {
+	Title  string
+	Kind   string // Bars or Lines
+	Labels []string
+	Series []Series
+}
+
+// Palette of series without a color
+var Palette = []color.RGBA{
+	{0x2e, 0x86, 0xde, 0xff}, // blue
+	{0xe6, 0x7e, 0x22, 0xff}, // orange
+	{0x27, 0xae, 0x60, 0xff}, // green
+	{0xc0, 0x39, 0x2b, 0xff}, // red
+	{0x8e, 0x44, 0xad, 0xff}, // purple
+	{0x16, 0xa0, 0x85, 0xff}, // teal
+}
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	ink        = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	muted      = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	grid       = color.RGBA{0xe4, 0xe4, 0xe4, 0xff}
+)
+
+// Margins around the plot area
+const (
+	marginLeft   = 80
+	marginRight  = 30
+	marginTop    = 70
+	marginBottom = 80
+)
+
+// Text sizes in pixels
+const (
+	titleSize = 24
+	labelSize = 12
+)
+
+// Text anchors, as in SVG
+const (
+	anchorStart  = "start"
+	anchorMiddle = "middle"
+	anchorEnd    = "end"
+)
+
+// canvas is what charts are drawn on; y grows downwards and text is placed
+// by its baseline
+type canvas interface {
+	fillRect(x, y, w, h float64, c color.RGBA)
+	line(x1, y1, x2, y2, width float64, c color.RGBA)
+	text(x, y, size float64, anchor, s string, c color.RGBA)
+}
+
+// WriteSVG writes the chart as an SVG image
+func (c *Chart) WriteSVG(w io.Writer) error {
+	cv := newSVGCanvas()
+	c.draw(cv)
+	_, err := io.WriteString(w, cv.String())
+	return err
+}
+
+// WritePNG writes the chart as a PNG image
+func (c *Chart) WritePNG(w io.Writer) error {
+	cv := newPNGCanvas()
+	c.draw(cv)
+	return cv.encode(w)
+}
+
+// Save writes the chart to path as PNG or SVG, by the file extension
+func (c *Chart) Save(path string) error {
+	var buf bytes.Buffer
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		err = c.WritePNG(&buf)
+	case ".svg":
+		err = c.WriteSVG(&buf)
+	default:
+		return fmt.Errorf("%s: charts are written as .png or .svg", path)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// color returns the color of series i
+func (c *Chart) color(i int) color.RGBA {
+	if col := c.Series[i].Color; col.A != 0 {
+		return col
+	}
+	return Palette[i%len(Palette)]
+}
+
+// draw lays the chart out on cv: title, grid with the y-axis values, bars or
+// lines, x-axis labels and, for several series, a legend
+func (c *Chart) draw(cv canvas) {
+	cv.fillRect(0, 0, Width, Height, background)
+	cv.text(Width/2, 40, titleSize, anchorMiddle, c.Title, ink)
+
+	left, top := float64(marginLeft), float64(marginTop)
+	width, height := float64(Width-marginLeft-marginRight), float64(Height-marginTop-marginBottom)
+	bottom := top + height
+
+	if len(c.Labels) == 0 || len(c.Series) == 0 {
+		cv.text(Width/2, Height/2, labelSize, anchorMiddle, "No data", muted)
+		return
+	}
+
+	// Grid lines at round steps up to the largest value
+	highest := 0.0
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			highest = math.Max(highest, v)
+		}
+	}
+	step := niceStep(highest / 5)
+	ceiling := math.Max(step, math.Ceil(highest/step)*step)
+	for v := 0.0; v <= ceiling+step/2; v += step {
+		y := bottom - v/ceiling*height
+		cv.line(left, y, left+width, y, 1, grid)
+		cv.text(left-8, y+4, labelSize, anchorEnd, formatValue(v), muted)
+	}
+	scale := func(v float64) float64 { return bottom - v/ceiling*height }
+
+	// One slot per label
+	n := len(c.Labels)
+	slot := width / float64(n)
+	switch c.Kind {
+	case Lines:
+		for i := range c.Series {
+			values := c.Series[i].Values
+			for j := 1; j < len(values) && j < n; j++ {
+				cv.line(left+(float64(j)-0.5)*slot, scale(values[j-1]),
+					left+(float64(j)+0.5)*slot, scale(values[j]), 2, c.color(i))
+			}
+			if n == 1 && len(values) > 0 {
+				cv.fillRect(left+slot/2-2, scale(values[0])-2, 4, 4, c.color(i))
+			}
+		}
+	default:
+		gap := 0.0
+		if slot >= 4 {
+			gap = slot * 0.2
+		}
+		for j, v := range c.Series[0].Values {
+			if j >= n || v <= 0 {
+				continue
+			}
+			cv.fillRect(left+float64(j)*slot+gap/2, scale(v), slot-gap, bottom-scale(v), c.color(0))
+		}
+	}
+	cv.line(left, bottom, left+width, bottom, 1, muted)
+
+	// X-axis labels, thinned out so they do not overlap
+	longest := 0
+	for _, label := range c.Labels {
+		longest = max(longest, len(label))
+	}
+	every := max(1, int(math.Ceil(float64(longest+2)*labelSize/slot)))
+	for j := 0; j < n; j += every {
+		cv.text(left+(float64(j)+0.5)*slot, bottom+20, labelSize, anchorMiddle, c.Labels[j], muted)
+	}
+
+	// Legend of the series
+	if len(c.Series) > 1 || c.Kind == Lines {
+		x := left
+		for i, s := range c.Series {
+			cv.fillRect(x, Height-30, 14, 4, c.color(i))
+			cv.text(x+20, Height-24, labelSize, anchorStart, s.Name, ink)
+			x += 20 + float64(len(s.Name)+3)*labelSize
+		}
+	}
+}
+
+// niceStep rounds a grid step up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if raw <= m*magnitude {
+			return math.Max(1, m*magnitude)
+		}
+	}
+	return 10 * magnitude
+}
+
+// formatValue formats a y-axis value, abbreviating thousands
+func formatValue(v float64) string {
+	switch {
+	case v >= 1e6:
+		return fmt.Sprintf("%gM", v/1e6)
+	case v >= 1e4:
+		return fmt.Sprintf("%gK", v/1e3)
+	}
+	return fmt.Sprintf("%g", v)
+}
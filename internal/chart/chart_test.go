@@ -0,0 +1,61 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testChart() *Chart {
+	return &Chart{
+		Title:  "Commits <2024>",
+		Kind:   Bars,
+		Labels: []string{"2024-01", "2024-02", "2024-03"},
+		Series: []Series{{Name: "Commits", Values: []float64{12, 0, 40}}},
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testChart().WriteSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("not an SVG document: %.60q", svg)
+	}
+	if !strings.Contains(svg, "Commits &lt;2024&gt;") {
+		t.Error("title missing or not escaped")
+	}
+	// Two bars, the empty month has none
+	if got := strings.Count(svg, `fill="#2e86de"`); got != 2 {
+		t.Errorf("bars = %d, want 2", got)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	for _, kind := range []string{Bars, Lines} {
+		c := testChart()
+		c.Kind = kind
+		var buf bytes.Buffer
+		if err := c.WritePNG(&buf); err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+			t.Errorf("%s: size = %dx%d", kind, b.Dx(), b.Dy())
+		}
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	for raw, want := range map[float64]float64{0: 1, 0.3: 1, 3: 5, 8: 10, 13: 20, 420: 500} {
+		if got := niceStep(raw); got != want {
+			t.Errorf("niceStep(%g) = %g, want %g", raw, got, want)
+		}
+	}
+}
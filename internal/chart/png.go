@@ -0,0 +1,144 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// pngCanvas draws onto an RGBA image
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNGCanvas() *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, Width, Height))}
+}
+
+func (cv *pngCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(cv.img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// line steps along the longer axis, stamping a square of the width
+func (cv *pngCanvas) line(x1, y1, x2, y2, width float64, c color.RGBA) {
+	steps := max(1, int(math.Ceil(math.Max(math.Abs(x2-x1), math.Abs(y2-y1)))))
+	half := width / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x, y := x1+(x2-x1)*t, y1+(y2-y1)*t
+		cv.fillRect(math.Floor(x-half+0.5), math.Floor(y-half+0.5), width, width, c)
+	}
+}
+
+// text writes s in the bitmap font, scaled to roughly size pixels; letters
+// are capitals and characters without a glyph question marks
+func (cv *pngCanvas) text(x, y, size float64, anchor, s string, c color.RGBA) {
+	scale := max(1, int(math.Round(size/7)))
+	s = strings.ToUpper(s)
+	width := float64((len([]rune(s))*(glyphWidth+1) - 1) * scale)
+	switch anchor {
+	case anchorMiddle:
+		x -= width / 2
+	case anchorEnd:
+		x -= width
+	}
+	top := int(y) - glyphHeight*scale
+	left := int(x)
+	for _, r := range s {
+		rows, ok := glyphs[r]
+		if !ok {
+			rows = glyphs['?']
+			if unicode.IsSpace(r) {
+				rows = glyphs[' ']
+			}
+		}
+		for row, bits := range strings.Fields(rows) {
+			for col, bit := range bits {
+				if bit == '#' {
+					cv.fillRect(float64(left+col*scale), float64(top+row*scale), float64(scale), float64(scale), c)
+				}
+			}
+		}
+		left += (glyphWidth + 1) * scale
+	}
+}
+
+func (cv *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, cv.img)
+}
+
+// Size of the bitmap font's glyphs
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// glyphs of the bitmap font: rows of glyphWidth cells, top to bottom
+var glyphs = map[rune]string{
+	' ':  "..... ..... ..... ..... ..... ..... .....",
+	'A':  ".###. #...# #...# ##### #...# #...# #...#",
+	'B':  "####. #...# #...# ####. #...# #...# ####.",
+	'C':  ".###. #...# #.... #.... #.... #...# .###.",
+	'D':  "####. #...# #...# #...# #...# #...# ####.",
+	'E':  "##### #.... #.... ####. #.... #.... #####",
+	'F':  "##### #.... #.... ####. #.... #.... #....",
+	'G':  ".###. #...# #.... #.### #...# #...# .####",
+	'H':  "#...# #...# #...# ##### #...# #...# #...#",
+	'I':  ".###. ..#.. ..#.. ..#.. ..#.. ..#.. .###.",
+	'J':  "..### ...#. ...#. ...#. ...#. #..#. .##..",
+	'K':  "#...# #..#. #.#.. ##... #.#.. #..#. #...#",
+	'L':  "#.... #.... #.... #.... #.... #.... #####",
+	'M':  "#...# ##.## #.#.# #.#.# #...# #...# #...#",
+	'N':  "#...# #...# ##..# #.#.# #..## #...# #...#",
+	'O':  ".###. #...# #...# #...# #...# #...# .###.",
+	'P':  "####. #...# #...# ####. #.... #.... #....",
+	'Q':  ".###. #...# #...# #...# #.#.# #..#. .##.#",
+	'R':  "####. #...# #...# ####. #.#.. #..#. #...#",
+	'S':  ".#### #.... #.... .###. ....# ....# ####.",
+	'T':  "##### ..#.. ..#.. ..#.. ..#.. ..#.. ..#..",
+	'U':  "#...# #...# #...# #...# #...# #...# .###.",
+	'V':  "#...# #...# #...# #...# #...# .#.#. ..#..",
+	'W':  "#...# #...# #...# #.#.# #.#.# #.#.# .#.#.",
+	'X':  "#...# #...# .#.#. ..#.. .#.#. #...# #...#",
+	'Y':  "#...# #...# .#.#. ..#.. ..#.. ..#.. ..#..",
+	'Z':  "##### ....# ...#. ..#.. .#... #.... #####",
+	'0':  ".###. #...# #..## #.#.# ##..# #...# .###.",
+	'1':  "..#.. .##.. ..#.. ..#.. ..#.. ..#.. .###.",
+	'2':  ".###. #...# ....# ...#. ..#.. .#... #####",
+	'3':  "##### ...#. ..#.. ...#. ....# #...# .###.",
+	'4':  "...#. ..##. .#.#. #..#. ##### ...#. ...#.",
+	'5':  "##### #.... ####. ....# ....# #...# .###.",
+	'6':  "..##. .#... #.... ####. #...# #...# .###.",
+	'7':  "##### ....# ...#. ..#.. .#... .#... .#...",
+	'8':  ".###. #...# #...# .###. #...# #...# .###.",
+	'9':  ".###. #...# #...# .#### ....# ...#. .##..",
+	'-':  "..... ..... ..... ##### ..... ..... .....",
+	'_':  "..... ..... ..... ..... ..... ..... #####",
+	'.':  "..... ..... ..... ..... ..... .##.. .##..",
+	',':  "..... ..... ..... ..... .##.. ..#.. .#...",
+	':':  "..... .##.. .##.. ..... .##.. .##.. .....",
+	'/':  "....# ....# ...#. ..#.. .#... #.... #....",
+	'%':  "##..# ##..# ...#. ..#.. .#... #..## #..##",
+	'(':  "...#. ..#.. .#... .#... .#... ..#.. ...#.",
+	')':  ".#... ..#.. ...#. ...#. ...#. ..#.. .#...",
+	'[':  ".###. .#... .#... .#... .#... .#... .###.",
+	']':  ".###. ...#. ...#. ...#. ...#. ...#. .###.",
+	'#':  ".#.#. .#.#. ##### .#.#. ##### .#.#. .#.#.",
+	'+':  "..... ..#.. ..#.. ##### ..#.. ..#.. .....",
+	'=':  "..... ..... ##### ..... ##### ..... .....",
+	'*':  "..... ..#.. #.#.# .###. #.#.# ..#.. .....",
+	'<':  "...#. ..#.. .#... #.... .#... ..#.. ...#.",
+	'>':  ".#... ..#.. ...#. ....# ...#. ..#.. .#...",
+	'@':  ".###. #...# #.### #.#.# #.### #.... .####",
+	'&':  ".##.. #..#. #.#.. .#... #.#.# #..#. .##.#",
+	'!':  "..#.. ..#.. ..#.. ..#.. ..#.. ..... ..#..",
+	'?':  ".###. #...# ....# ...#. ..#.. ..... ..#..",
+	'\'': "..#.. ..#.. .#... ..... ..... ..... .....",
+	'"':  ".#.#. .#.#. ..... ..... ..... ..... .....",
+}
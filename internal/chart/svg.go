@@ -0,0 +1,43 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"strings"
+)
+
+// svgCanvas collects the elements of an SVG image
+type svgCanvas struct {
+	sb strings.Builder
+}
+
+func newSVGCanvas() *svgCanvas {
+	cv := &svgCanvas{}
+	fmt.Fprintf(&cv.sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		Width, Height, Width, Height)
+	return cv
+}
+
+func (cv *svgCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	fmt.Fprintf(&cv.sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n", x, y, w, h, hex(c))
+}
+
+func (cv *svgCanvas) line(x1, y1, x2, y2, width float64, c color.RGBA) {
+	fmt.Fprintf(&cv.sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%g"/>`+"\n",
+		x1, y1, x2, y2, hex(c), width)
+}
+
+func (cv *svgCanvas) text(x, y, size float64, anchor, s string, c color.RGBA) {
+	fmt.Fprintf(&cv.sb, `<text x="%.1f" y="%.1f" font-size="%g" text-anchor="%s" fill="%s">%s</text>`+"\n",
+		x, y, size, anchor, hex(c), html.EscapeString(s))
+}
+
+// String closes the image and returns it
+func (cv *svgCanvas) String() string {
+	return cv.sb.String() + "</svg>\n"
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
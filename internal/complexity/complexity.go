@@ -0,0 +1,73 @@
+// Package complexity measures the cyclomatic complexity of source files,
+// with an analyzer per language; Go is built in and others are added with
+// Register
+package complexity
+
+import "sync"
+
+// File is the measured complexity of a source file
+type File struct {
+	Functions int // functions and methods measured
+	Total     int // sum of their cyclomatic complexity
+	Max       int // complexity of the most complex one
+}
+
+// add counts a function of the given complexity
+func (f *File) add(complexity int) {
+	f.Functions++
+	f.Total += complexity
+	f.Max = max(f.Max, complexity)
+}
+
+// Analyzer measures the source files of one language
+type Analyzer interface {
+	// Supports reports whether the analyzer handles the file at path,
+	// usually by its extension
+	Supports(path string) bool
+	// Measure returns the complexity of a file's content, or an error if
+	// it does not parse
+	Measure(path string, content []byte) (File, error)
+}
+
+var (
+	mu        sync.RWMutex
+	analyzers = []Analyzer{goAnalyzer{}}
+)
+
+// Register adds an analyzer, tried before those registered earlier, so a
+// later one can replace the built-in Go analyzer
+func Register(a Analyzer) {
+	mu.Lock()
+	defer mu.Unlock()
+	analyzers = append([]Analyzer{a}, analyzers...)
+}
+
+// Supported reports whether an analyzer handles the file at path
+func Supported(path string) bool {
+	return analyzer(path) != nil
+}
+
+// Measure returns the complexity of the file at path with content, and
+// false if no analyzer handles it or it does not parse
+func Measure(path string, content []byte) (File, bool) {
+	a := analyzer(path)
+	if a == nil {
+		return File{}, false
+	}
+	file, err := a.Measure(path, content)
+	if err != nil {
+		return File{}, false
+	}
+	return file, true
+}
+
+func analyzer(path string) Analyzer {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, a := range analyzers {
+		if a.Supports(path) {
+			return a
+		}
+	}
+	return nil
+}
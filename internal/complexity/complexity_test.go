@@ -0,0 +1,82 @@
+package complexity
+
+import (
+	"strings"
+	"testing"
+)
+
+const goSource = `package p
+
+func simple() int { return 1 }
+
+func branches(xs []int, ch chan int) int {
+	n := 0
+	for _, x := range xs {
+		if x > 0 && x < 10 || x == 42 {
+			n++
+		}
+	}
+	switch n {
+	case 0:
+		return 0
+	case 1, 2:
+		n = 2
+	default:
+	}
+	select {
+	case v := <-ch:
+		n += v
+	default:
+	}
+	inc := func() {
+		if n > 100 {
+			n = 100
+		}
+	}
+	inc()
+	return n
+}
+
+type T struct{}
+
+func (T) method(ok bool) bool { return !ok }
+`
+
+func TestMeasureGo(t *testing.T) {
+	file, ok := Measure("p.go", []byte(goSource))
+	if !ok {
+		t.Fatal("p.go was not measured")
+	}
+	// branches: 1 + range + if + && + || + 2 cases + select case + closure if
+	want := File{Functions: 3, Total: 1 + 9 + 1, Max: 9}
+	if file != want {
+		t.Errorf("got %+v, want %+v", file, want)
+	}
+
+	if _, ok := Measure("p.go", []byte("package p\nfunc {")); ok {
+		t.Error("a file that does not parse was measured")
+	}
+	if Supported("README.md") {
+		t.Error("README.md is supported")
+	}
+}
+
+type lineAnalyzer struct{}
+
+func (lineAnalyzer) Supports(path string) bool { return strings.HasSuffix(path, ".txt") }
+
+func (lineAnalyzer) Measure(path string, content []byte) (File, error) {
+	var file File
+	for range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		file.add(2)
+	}
+	return file, nil
+}
+
+func TestRegister(t *testing.T) {
+	Register(lineAnalyzer{})
+	file, ok := Measure("notes.txt", []byte("a\nb\n"))
+	if !ok || file.Total != 4 || file.Max != 2 {
+		t.Errorf("got %+v, %v from the registered analyzer, want a total of 4", file, ok)
+	}
+}
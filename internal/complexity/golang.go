@@ -0,0 +1,60 @@
+package complexity
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// goAnalyzer measures Go files from their syntax tree
+type goAnalyzer struct{}
+
+// Supports implements Analyzer
+func (goAnalyzer) Supports(path string) bool {
+	return strings.HasSuffix(path, ".go")
+}
+
+// Measure implements Analyzer. Each function and method scores one plus
+// its branches: if, for and range statements, case and select clauses other
+// than default, and && and || operators. Function literals count toward the
+// function declaring them.
+func (goAnalyzer) Measure(path string, content []byte) (File, error) {
+	parsed, err := parser.ParseFile(token.NewFileSet(), path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return File{}, err
+	}
+
+	var file File
+	for _, decl := range parsed.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			file.add(goComplexity(fn.Body))
+		}
+	}
+	return file, nil
+}
+
+// goComplexity returns the cyclomatic complexity of a function body
+func goComplexity(body *ast.BlockStmt) int {
+	complexity := 1
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if n.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
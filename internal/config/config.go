@@ -2,6 +2,9 @@ package config
 
 import (
 	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/stats"
 )
 
 // Config holds application configuration
@@ -12,33 +15,243 @@ type Config struct {
 	Since     time.Time
 	Until     time.Time
 
+	// Refs scanned together instead of HEAD, e.g. "main" and "release/*"
+	ScanRefs []string
+
+	// When scanning several refs or repositories, count a commit present in
+	// more than one of them once, matching copies by hash and patch-id
+	DeduplicateCommits bool
+
+	// Upstream repository among RepoPaths; the others are scanned as its
+	// forks, splitting their commits into upstreamed and fork-only. It is
+	// scanned first and implies deduplication.
+	UpstreamRepo string
+
 	// Display settings
 	Timezone      *time.Location
 	TimeFormat24h bool
 
+	// Abbreviate large line counts as 1.2K or 3.4M instead of showing exact
+	// values with thousands separators; toggled with 'f'
+	AbbreviateNumbers bool
+
+	// Keys replacing the default bindings, by action name: "rescan" for the
+	// action in every view that has it, "Leaderboard.reverse" for one view.
+	// Set in the configuration file (see Load).
+	KeyBindings map[string][]string
+
+	// Named step sequences run from the command palette, e.g. "weekly" =
+	// ["range 7d", "scan", "export md", "open"]. Set in the configuration
+	// file; see the ui package for the steps.
+	Macros map[string][]string
+
+	// Directories offered in the setup directory browser besides the home
+	// directory and the usual source directories. Set in the configuration
+	// file.
+	Bookmarks []string
+
+	// Where the publish macro step and gitstat publish send the Markdown
+	// report; nil when not set up. Set in the configuration file.
+	Confluence *Confluence
+	Notion     *Notion
+
+	// Fetches review data of the numbered pull requests after each scan;
+	// nil without a token in the configuration file or GitHubTokenEnv
+	GitHub *GitHub
+
+	// Append the headline metrics of every scan to a trends file per set of
+	// repositories, charted by the Trends view (see TrendsPath)
+	RecordTrends bool
+
+	// Keep the parsed commits of each repository in the user cache
+	// directory, so a rescan only parses the commits added since
+	CacheCommits bool
+
+	// Write the commits parsed by each scan to this file as they arrive,
+	// one JSON object per line, so gitstat replay can aggregate them again
+	// without git even after an interrupted scan. Empty records nothing;
+	// set with --record.
+	RecordPath string
+
+	// Repositories whose commit caches gitstat daemon keeps fresh, how
+	// often, and whether it fetches their remotes first. Set in the
+	// configuration file.
+	DaemonRepos    []string
+	DaemonInterval time.Duration
+	DaemonFetch    bool
+
+	// Restore the last view, sort orders and query when the same
+	// repositories are opened again (see UIState)
+	RestoreUIState bool
+
 	// Limits
 	MaxAuthors int
 	MaxFiles   int
 
 	// Timeline settings
-	SparklineWidth int
-	RollingWindow  int // Days for rolling average
+	RollingWindow int // Days for rolling average
 
 	// Hotspot thresholds
 	HotspotChurnThreshold  float64
 	HotspotAuthorThreshold int
+
+	// Commit size categories: inclusive upper bounds in changed lines for
+	// trivial, small, medium and large; anything bigger is huge
+	CommitSizeThresholds []int
+
+	// Period comparison
+	ComparePrevious        bool    // also scan the preceding equal-length period
+	TurnoverShiftThreshold float64 // ownership shift (percentage points) counted as turnover
+
+	// Offboarding risk: authors inactive for this many weeks who still hold
+	// at least OffboardingMinShare percent of a directory's churn
+	OffboardingInactiveWeeks int
+	OffboardingMinShare      float64
+
+	// Commits from UnhealthyAfter until UnhealthyBefore o'clock, and on
+	// weekends with UnhealthyWeekends, count against a sustainable pace in
+	// the Work Hours view
+	UnhealthyAfter    int
+	UnhealthyBefore   int
+	UnhealthyWeekends bool
+
+	// Days off besides weekends, shaded in the Timeline's daily chart: the
+	// holiday's name by date ("2024-12-25"). Set in the configuration file.
+	Holidays map[string]string
+
+	// Work estimate: commits at most SessionMaxGap apart form one session,
+	// and every session is credited SessionStart before its first commit
+	SessionMaxGap time.Duration
+	SessionStart  time.Duration
+
+	// Churn and touches of files halve in weight every ChurnHalfLife before
+	// the end of the range, so old churn fades from the hotspot risk; 0
+	// weighs every commit the same
+	ChurnHalfLife time.Duration
+
+	// Author email -> GitHub or GitLab handle, used by gitstat codeowners
+	Handles map[string]string
+
+	// Weights of the components of the health score (see
+	// stats.HealthComponents); a component weighing 0 is left out
+	HealthWeights map[string]float64
+
+	// Comment keywords reported as tech-debt markers; empty disables the scan
+	DebtMarkers []string
+
+	// Files at HEAD blamed for the Code Age view, spread over the tree; 0
+	// disables the pass
+	CodeAgeSample int
+
+	// Keep blame results in the user cache directory so rescans only blame
+	// files whose content changed
+	BlameCache bool
+
+	// License header compliance; the check is skipped when LicenseHeader is empty
+	LicenseHeader      string   // regular expression expected near the top of source files
+	LicenseHeaderLines int      // number of leading lines searched for the header
+	LicenseExtensions  []string // source file extensions to check
+
+	// Release branch globs checked for backports (e.g. "release/*")
+	BackportBranches []string
+
+	// Directories treated as one logical component: directory -> primary
+	// directory, primaries map to themselves. Re-applied after each rescan.
+	DirMerges map[string]string
+
+	// Aggregation collectors to skip (see stats.CollectorNames), e.g.
+	// "survival" or "coupling" to speed up scans of very large histories
+	DisabledCollectors []string
+
+	// How the history is read: git.BackendExec runs the git binary,
+	// git.BackendGoGit reads the repository in Go where git is not
+	// installed. Blame, notes, fetch and the commit cache still need git.
+	GitBackend string
+
+	// Leave files marked linguist-generated or linguist-vendored in
+	// .gitattributes out of all statistics
+	SkipGenerated bool
+
+	// Leave merge commits out of every statistic but the pull requests,
+	// and follow only the first parent of merges, leaving out the commits
+	// of merged branches; toggled on the setup screen
+	ExcludeMerges bool
+	FirstParent   bool
+
+	// Leave commits that only touch lockfiles, version files and changelogs
+	// out of churn, leaderboards and all other statistics
+	ExcludeMechanical bool
+
+	// Files or directories and author emails left out of all statistics,
+	// e.g. added with the batch exclude action of the tables
+	ExcludePaths   []string
+	ExcludeAuthors []string
+
+	// Rules mapping author emails onto one identity as commits are
+	// aggregated, e.g. an old company domain onto the new one. Set in the
+	// configuration file.
+	AuthorAliases []stats.AliasRule
+
+	// Path globs restricting all statistics to the matching files, e.g.
+	// "src/**", and leaving out others, e.g. "vendor/**" or "*.lock"; see
+	// stats.MatchGlob. Set on the setup screen, with --include and --exclude
+	// or in the configuration file.
+	IncludeGlobs []string
+	ExcludeGlobs []string
+
+	// Directory coupling thresholds
+	CouplingMinShared int     // minimum commits two directories must share
+	CouplingThreshold float64 // score (0-100) above which a pair is strongly coupled
+}
+
+// Source file extensions checked for a license header by default
+var defaultLicenseExtensions = []string{
+	".go", ".js", ".jsx", ".ts", ".tsx", ".py", ".java", ".kt", ".c", ".h",
+	".cc", ".cpp", ".hpp", ".cs", ".rs", ".rb", ".php", ".swift", ".scala",
 }
 
 // Default returns default configuration
 func Default() *Config {
 	return &Config{
-		Timezone:               time.Local,
-		TimeFormat24h:          true,
-		MaxAuthors:             20,
-		MaxFiles:               30,
-		SparklineWidth:         52,
-		RollingWindow:          7,
-		HotspotChurnThreshold:  0.7,
-		HotspotAuthorThreshold: 3,
+		Timezone:                 time.Local,
+		TimeFormat24h:            true,
+		RestoreUIState:           true,
+		RecordTrends:             true,
+		CacheCommits:             true,
+		DaemonInterval:           15 * time.Minute,
+		DaemonFetch:              true,
+		AbbreviateNumbers:        true,
+		MaxAuthors:               20,
+		MaxFiles:                 30,
+		RollingWindow:            7,
+		HotspotChurnThreshold:    0.7,
+		HotspotAuthorThreshold:   3,
+		CommitSizeThresholds:     []int{10, 50, 250, 1000},
+		ComparePrevious:          true,
+		TurnoverShiftThreshold:   20,
+		OffboardingInactiveWeeks: 12,
+		OffboardingMinShare:      25,
+		UnhealthyAfter:           22,
+		UnhealthyBefore:          6,
+		UnhealthyWeekends:        true,
+		SessionMaxGap:            2 * time.Hour,
+		SessionStart:             2 * time.Hour,
+		DebtMarkers:              []string{"TODO", "FIXME", "HACK"},
+		BlameCache:               true,
+		DeduplicateCommits:       true,
+		GitBackend:               git.BackendExec,
+		SkipGenerated:            true,
+		LicenseHeaderLines:       20,
+		LicenseExtensions:        defaultLicenseExtensions,
+		BackportBranches:         []string{"release/*", "release-*"},
+		CouplingMinShared:        3,
+		CouplingThreshold:        50,
+		HealthWeights: map[string]float64{
+			stats.HealthBusFactor:    25,
+			stats.HealthHotspots:     20,
+			stats.HealthChurn:        20,
+			stats.HealthPRSize:       15,
+			stats.HealthContributors: 20,
+		},
 	}
 }
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// fileConfig is the content of the configuration file. Settings it leaves
+// out keep their defaults.
+type fileConfig struct {
+	// Keys per action: "action" applies to the action in every view that
+	// has it, "View.action" to one view only
+	Keys map[string][]string `json:"keys,omitempty"`
+
+	// Macros by name: steps run in order from the command palette
+	Macros map[string][]string `json:"macros,omitempty"`
+
+	// Directories offered in the setup directory browser; ~ is the home
+	// directory
+	Bookmarks []string `json:"bookmarks,omitempty"`
+
+	// Scan defaults of the setup screen toggles, also used by the
+	// commands without the interface
+	ExcludeMerges bool `json:"exclude_merges,omitempty"`
+	FirstParent   bool `json:"first_parent,omitempty"`
+
+	// Author emails mapped onto one identity: "match" is a regular
+	// expression, "email" the identity to count the commits under or
+	// "username" that of the author to merge with, both expanded with the
+	// groups of the match
+	AuthorAliases []stats.AliasRule `json:"author_aliases,omitempty"`
+
+	// Handles named in generated CODEOWNERS files, by author email, e.g.
+	// "alice@example.com": "@alice"
+	Handles map[string]string `json:"handles,omitempty"`
+
+	// Days after which a file's churn counts half toward hotspot risk; 0,
+	// the default, weighs all commits the same
+	ChurnHalfLifeDays int `json:"churn_half_life_days,omitempty"`
+
+	// Weights of health score components by name, replacing the default
+	// weight of each component given; 0 leaves a component out
+	HealthWeights map[string]float64 `json:"health_weights,omitempty"`
+
+	// Files blamed for the Code Age view; 0, the default, skips the pass
+	CodeAgeSample int `json:"code_age_sample,omitempty"`
+
+	// History backend, "exec" (the default) or "go-git"
+	GitBackend string `json:"git_backend,omitempty"`
+
+	// Path globs files must match to be analyzed, and globs leaving files
+	// out
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Hours counted against a sustainable pace: from after until before
+	// o'clock, and weekends unless set to false
+	UnhealthyHours struct {
+		After    *int  `json:"after,omitempty"`
+		Before   *int  `json:"before,omitempty"`
+		Weekends *bool `json:"weekends,omitempty"`
+	} `json:"unhealthy_hours"`
+
+	// Holidays shaded on the timeline, names by date, e.g.
+	// "2024-12-25": "Christmas Day"
+	Holidays map[string]string `json:"holidays,omitempty"`
+
+	// Repositories gitstat daemon keeps warm; interval is a Go duration
+	// such as "15m", and fetch defaults to true
+	Daemon struct {
+		Repos    []string `json:"repos,omitempty"`
+		Interval string   `json:"interval,omitempty"`
+		Fetch    *bool    `json:"fetch,omitempty"`
+	} `json:"daemon"`
+
+	// Publishing targets with their API tokens, by service
+	Publish struct {
+		Confluence *Confluence `json:"confluence,omitempty"`
+		Notion     *Notion     `json:"notion,omitempty"`
+	} `json:"publish"`
+
+	// GitHub API access enriching the pull requests with their reviews
+	GitHub *GitHub `json:"github,omitempty"`
+}
+
+// Path returns the location of the configuration file in the user config
+// directory (~/.config/gitstat/config.json on Linux)
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gitstat", "config.json"), nil
+}
+
+// Load returns the default configuration with the configuration file
+// applied. A missing file or config directory leaves the defaults. A GitHub
+// token in GitHubTokenEnv replaces the file's.
+func Load() (*Config, error) {
+	cfg := Default()
+	if token := os.Getenv(GitHubTokenEnv); token != "" {
+		cfg.GitHub = &GitHub{Token: token}
+	}
+
+	path, err := Path()
+	if err != nil {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file fileConfig
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	cfg.KeyBindings = file.Keys
+	cfg.Macros = file.Macros
+	cfg.Bookmarks = file.Bookmarks
+	cfg.Confluence = file.Publish.Confluence
+	cfg.Notion = file.Publish.Notion
+	if github := file.GitHub; github != nil {
+		if github.MaxPRs < 0 {
+			return nil, fmt.Errorf("%s: github max_prs %d is negative", path, github.MaxPRs)
+		}
+		if cfg.GitHub != nil {
+			github.Token = cfg.GitHub.Token
+		}
+		if github.Token != "" {
+			cfg.GitHub = github
+		}
+	}
+	cfg.ExcludeMerges = file.ExcludeMerges
+	cfg.FirstParent = file.FirstParent
+	if file.GitBackend != "" {
+		if !slices.Contains(git.Backends, file.GitBackend) {
+			return nil, fmt.Errorf("%s: git backend %q is not one of %s", path, file.GitBackend, strings.Join(git.Backends, ", "))
+		}
+		cfg.GitBackend = file.GitBackend
+	}
+	if file.ChurnHalfLifeDays < 0 {
+		return nil, fmt.Errorf("%s: churn half-life of %d days is negative", path, file.ChurnHalfLifeDays)
+	}
+	cfg.ChurnHalfLife = time.Duration(file.ChurnHalfLifeDays) * 24 * time.Hour
+	if file.CodeAgeSample < 0 {
+		return nil, fmt.Errorf("%s: code age sample %d is negative", path, file.CodeAgeSample)
+	}
+	cfg.CodeAgeSample = file.CodeAgeSample
+	for name, weight := range file.HealthWeights {
+		if !slices.Contains(stats.HealthComponents, name) {
+			return nil, fmt.Errorf("%s: health component %q is not one of %s", path, name, strings.Join(stats.HealthComponents, ", "))
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("%s: health weight %g of %s is negative", path, weight, name)
+		}
+		cfg.HealthWeights[name] = weight
+	}
+	if err := stats.CheckAliasRules(file.AuthorAliases); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	cfg.AuthorAliases = file.AuthorAliases
+	cfg.Handles = file.Handles
+	cfg.IncludeGlobs = file.Include
+	cfg.ExcludeGlobs = file.Exclude
+	for _, hour := range []struct {
+		value *int
+		field *int
+	}{{file.UnhealthyHours.After, &cfg.UnhealthyAfter}, {file.UnhealthyHours.Before, &cfg.UnhealthyBefore}} {
+		if hour.value == nil {
+			continue
+		}
+		if *hour.value < 0 || *hour.value > 23 {
+			return nil, fmt.Errorf("%s: unhealthy hour %d is not between 0 and 23", path, *hour.value)
+		}
+		*hour.field = *hour.value
+	}
+	if file.UnhealthyHours.Weekends != nil {
+		cfg.UnhealthyWeekends = *file.UnhealthyHours.Weekends
+	}
+	for date := range file.Holidays {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("%s: holiday %q is not a date like 2024-12-25", path, date)
+		}
+	}
+	cfg.Holidays = file.Holidays
+	cfg.DaemonRepos = file.Daemon.Repos
+	if file.Daemon.Interval != "" {
+		interval, err := time.ParseDuration(file.Daemon.Interval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%s: daemon interval %q is not a duration like 15m", path, file.Daemon.Interval)
+		}
+		cfg.DaemonInterval = interval
+	}
+	if file.Daemon.Fetch != nil {
+		cfg.DaemonFetch = *file.Daemon.Fetch
+	}
+	return cfg, nil
+}
@@ -0,0 +1,19 @@
+package config
+
+// GitHubTokenEnv is the environment variable a GitHub token can be given in
+// instead of the configuration file; it takes precedence over the file
+const GitHubTokenEnv = "GITSTAT_GITHUB_TOKEN"
+
+// GitHub is the access to the GitHub API fetching the reviews, reviewers and
+// labels of the pull requests found in merge commits
+type GitHub struct {
+	Token string `json:"token"` // personal access token that can read the repositories
+
+	// API base URL for GitHub Enterprise Server, e.g.
+	// https://github.example.com/api/v3; https://api.github.com by default
+	URL string `json:"url,omitempty"`
+
+	// Newest numbered pull requests fetched per scan, 300 by default; each
+	// takes two requests of the hourly rate limit
+	MaxPRs int `json:"max_prs,omitempty"`
+}
@@ -0,0 +1,25 @@
+package config
+
+// Confluence is the Confluence page a published report replaces
+type Confluence struct {
+	// Base URL of the site, e.g. https://acme.atlassian.net/wiki
+	URL string `json:"url"`
+
+	// ID of the page, the number in its URL
+	Page string `json:"page"`
+
+	// Account email for Confluence Cloud API tokens; empty for a Data
+	// Center personal access token, which is sent as a bearer token
+	User  string `json:"user,omitempty"`
+	Token string `json:"token"`
+}
+
+// Notion is the Notion database each published report is added to as a
+// page
+type Notion struct {
+	Token    string `json:"token"`    // secret of an integration the database is shared with
+	Database string `json:"database"` // database ID, from its URL
+
+	// Title property of the database, "Name" by default
+	TitleProperty string `json:"title_property,omitempty"`
+}
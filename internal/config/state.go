@@ -0,0 +1,145 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// SortState is the sort column and direction of a table view
+type SortState struct {
+	Column    int  `json:"column"`
+	Ascending bool `json:"ascending"`
+}
+
+// UIState is the working context of the main view. It is kept per set of
+// scanned repositories in the user config directory, so reopening the same
+// repositories restores it.
+type UIState struct {
+	View  string               `json:"view,omitempty"`
+	Sorts map[string]SortState `json:"sorts,omitempty"` // view name -> sort
+	Query string               `json:"query,omitempty"` // query bar filter
+
+	// Watched rows by kind ("files", "dirs", "authors"): paths or emails
+	Watchlist map[string][]string `json:"watchlist,omitempty"`
+
+	path string
+}
+
+// NewUIState returns an empty state that is not saved
+func NewUIState() *UIState {
+	return &UIState{
+		Sorts:     make(map[string]SortState),
+		Watchlist: make(map[string][]string),
+	}
+}
+
+// Watched reports whether item of the given kind is on the watchlist
+func (s *UIState) Watched(kind, item string) bool {
+	return slices.Contains(s.Watchlist[kind], item)
+}
+
+// ToggleWatched adds items to the watchlist, or removes them if all of them
+// are already on it, and reports whether they were added
+func (s *UIState) ToggleWatched(kind string, items []string) bool {
+	watched := true
+	for _, item := range items {
+		watched = watched && s.Watched(kind, item)
+	}
+	if watched {
+		s.Watchlist[kind] = slices.DeleteFunc(s.Watchlist[kind], func(item string) bool {
+			return slices.Contains(items, item)
+		})
+		if len(s.Watchlist[kind]) == 0 {
+			delete(s.Watchlist, kind)
+		}
+		return false
+	}
+	for _, item := range items {
+		if !s.Watched(kind, item) {
+			s.Watchlist[kind] = append(s.Watchlist[kind], item)
+		}
+	}
+	sort.Strings(s.Watchlist[kind])
+	return true
+}
+
+// LoadUIState loads the state saved for repoPaths. A missing or unreadable
+// state starts empty; without a config directory it is never saved.
+func LoadUIState(repoPaths []string) *UIState {
+	s := NewUIState()
+	s.path = repoSetFile("state", repoPaths, ".json")
+	if s.path == "" {
+		return s
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s
+	}
+	var saved UIState
+	if json.Unmarshal(data, &saved) != nil {
+		return s
+	}
+	s.View, s.Query = saved.View, saved.Query
+	for view, sort := range saved.Sorts {
+		s.Sorts[view] = sort
+	}
+	for kind, items := range saved.Watchlist {
+		s.Watchlist[kind] = items
+	}
+	return s
+}
+
+// TrendsPath returns the file keeping the scan snapshots of repoPaths (see
+// stats.Snapshot), or "" without a config directory
+func TrendsPath(repoPaths []string) string {
+	return repoSetFile("trends", repoPaths, ".jsonl")
+}
+
+// repoSetFile names a file in the kind subdirectory of the user config
+// directory for a set of repositories, in any order, or "" without a config
+// directory
+func repoSetFile(kind string, repoPaths []string, ext string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	abs := make([]string, 0, len(repoPaths))
+	for _, path := range repoPaths {
+		if p, err := filepath.Abs(path); err == nil {
+			path = p
+		}
+		abs = append(abs, path)
+	}
+	sort.Strings(abs)
+	h := sha256.New()
+	for _, path := range abs {
+		h.Write([]byte(path + "\n"))
+	}
+	return filepath.Join(dir, "gitstat", kind, hex.EncodeToString(h.Sum(nil)[:8])+ext)
+}
+
+// Save writes the state back to disk
+func (s *UIState) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	// Write through a temporary file so an interrupted save keeps the old state
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+)
+
+// linguistAttributes mark files GitHub leaves out of language statistics
+var linguistAttributes = []string{"linguist-generated", "linguist-vendored"}
+
+// attrChecker asks a long-running git check-attr process whether paths are
+// marked generated or vendored, caching the answer per path. Paths are
+// matched against the current .gitattributes, so files deleted long ago are
+// classified too.
+type attrChecker struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	cache  map[string]bool
+}
+
+func newAttrChecker(ctx context.Context, repoPath string) (*attrChecker, error) {
+	args := append([]string{"check-attr", "--stdin", "-z"}, linguistAttributes...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoPath
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &attrChecker{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		cache:  make(map[string]bool),
+	}, nil
+}
+
+// excluded reports whether path is marked linguist-generated or
+// linguist-vendored
+func (c *attrChecker) excluded(path string) (bool, error) {
+	if v, ok := c.cache[path]; ok {
+		return v, nil
+	}
+
+	if _, err := io.WriteString(c.stdin, path+"\x00"); err != nil {
+		return false, err
+	}
+
+	// One <path> NUL <attribute> NUL <info> NUL record per attribute
+	excluded := false
+	for range linguistAttributes {
+		for field := 0; field < 3; field++ {
+			value, err := c.stdout.ReadString(0)
+			if err != nil {
+				return false, err
+			}
+			if field == 2 {
+				switch value[:len(value)-1] {
+				case "set", "true":
+					excluded = true
+				}
+			}
+		}
+	}
+
+	c.cache[path] = excluded
+	return excluded, nil
+}
+
+// filter moves the changes to generated and vendored files out of
+// FileChanges into Generated
+func (c *attrChecker) filter(commit *Commit) error {
+	excluded := make([]bool, len(commit.FileChanges))
+	for i, fc := range commit.FileChanges {
+		var err error
+		if excluded[i], err = c.excluded(fc.FilePath); err != nil {
+			return err
+		}
+	}
+
+	kept := commit.FileChanges[:0]
+	for i, fc := range commit.FileChanges {
+		if excluded[i] {
+			commit.Generated = append(commit.Generated, fc)
+		} else {
+			kept = append(kept, fc)
+		}
+	}
+	commit.FileChanges = kept
+	return nil
+}
+
+func (c *attrChecker) close() error {
+	c.stdin.Close()
+	return c.cmd.Wait()
+}
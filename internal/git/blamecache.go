@@ -0,0 +1,175 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blameCacheVersion is bumped whenever the stored format changes; files
+// written by other versions are ignored
+const blameCacheVersion = 1
+
+// BlameLine is the cached blame result for one line
+type BlameLine struct {
+	Author string    `json:"a"`
+	Email  string    `json:"e"`
+	At     time.Time `json:"t"`
+}
+
+// BlameCache keeps blame results between scans, keyed by the blob hash of
+// the blamed file content (qualified by its path) and the line number. A file
+// whose content is unchanged since the last scan is not blamed again; editing
+// it changes the hash, which invalidates its entries. Blobs not looked up
+// during a scan are dropped on Save.
+type BlameCache struct {
+	path    string
+	entries map[string]map[int]BlameLine
+	used    map[string]bool
+	dirty   bool
+}
+
+type blameCacheFile struct {
+	Version int                             `json:"version"`
+	Blobs   map[string]map[string]BlameLine `json:"blobs"`
+}
+
+// OpenBlameCache loads the cache for repoPath from the user cache
+// directory. A missing or unreadable cache starts empty; if no cache
+// directory is available the cache works in memory only.
+func OpenBlameCache(repoPath string) *BlameCache {
+	c := &BlameCache{
+		entries: make(map[string]map[int]BlameLine),
+		used:    make(map[string]bool),
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return c
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		return c
+	}
+	sum := sha256.Sum256([]byte(abs))
+	c.path = filepath.Join(dir, "gitstat", "blame", hex.EncodeToString(sum[:8])+".json")
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	var file blameCacheFile
+	if json.Unmarshal(data, &file) != nil || file.Version != blameCacheVersion {
+		return c
+	}
+	for blob, lines := range file.Blobs {
+		entry := make(map[int]BlameLine, len(lines))
+		for lineStr, bl := range lines {
+			if n, err := strconv.Atoi(lineStr); err == nil {
+				entry[n] = bl
+			}
+		}
+		c.entries[blob] = entry
+	}
+	return c
+}
+
+// lookup returns the cached blame for lines of blob, or false unless every
+// line is cached
+func (c *BlameCache) lookup(blob string, lines []int) (map[int]BlameLine, bool) {
+	c.used[blob] = true
+	entry, ok := c.entries[blob]
+	if !ok {
+		return nil, false
+	}
+	for _, n := range lines {
+		if _, ok := entry[n]; !ok {
+			return nil, false
+		}
+	}
+	return entry, true
+}
+
+// store records the blame of one line of blob
+func (c *BlameCache) store(blob string, line int, bl BlameLine) {
+	entry, ok := c.entries[blob]
+	if !ok {
+		entry = make(map[int]BlameLine)
+		c.entries[blob] = entry
+	}
+	entry[line] = bl
+	c.used[blob] = true
+	c.dirty = true
+}
+
+// Save prunes blobs that were not looked up since the cache was opened and
+// writes the cache back to disk
+func (c *BlameCache) Save() error {
+	for blob := range c.entries {
+		if !c.used[blob] {
+			delete(c.entries, blob)
+			c.dirty = true
+		}
+	}
+	if c.path == "" || !c.dirty {
+		return nil
+	}
+
+	file := blameCacheFile{
+		Version: blameCacheVersion,
+		Blobs:   make(map[string]map[string]BlameLine, len(c.entries)),
+	}
+	for blob, entry := range c.entries {
+		lines := make(map[string]BlameLine, len(entry))
+		for n, bl := range entry {
+			lines[strconv.Itoa(n)] = bl
+		}
+		file.Blobs[blob] = lines
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	// Write through a temporary file so an interrupted save keeps the old cache
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// worktreeBlobs returns the blob hash of each file's current worktree
+// content, as git hash-object computes it
+func worktreeBlobs(ctx context.Context, repoPath string, files []string) (map[string]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "hash-object", "--stdin-paths")
+	cmd.Dir = repoPath
+	cmd.Stdin = strings.NewReader(strings.Join(files, "\n") + "\n")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make(map[string]string, len(files))
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for i := 0; scanner.Scan() && i < len(files); i++ {
+		blobs[files[i]] = strings.TrimSpace(scanner.Text())
+	}
+	return blobs, scanner.Err()
+}
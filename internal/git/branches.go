@@ -0,0 +1,182 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// BranchBackports holds backport statistics for a single release branch
+type BranchBackports struct {
+	Branch          string
+	Commits         int // commits on the branch that are not reachable from HEAD
+	CherryPicks     int // commits carrying a "(cherry picked from commit ...)" marker
+	PatchDuplicates int // commits whose patch-id matches a commit on HEAD
+	Backported      int // commits detected by either method
+}
+
+// ListBranches returns local and remote-tracking branches matching the
+// given glob patterns (e.g. "release/*"), without duplicates
+func (p *ExecParser) ListBranches(ctx context.Context, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"for-each-ref", "--format=%(refname:short)"}
+	for _, pattern := range patterns {
+		args = append(args, "refs/heads/"+pattern, "refs/remotes/*/"+pattern)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = p.RepoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var branches []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		branches = append(branches, line)
+	}
+	return branches, nil
+}
+
+// PatchIDs returns stable patch-ids for the commits selected by revs,
+// mapped patch-id -> commit hash
+func (p *ExecParser) PatchIDs(ctx context.Context, since, until time.Time, revs ...string) (map[string]string, error) {
+	byHash, err := p.CommitPatchIDs(ctx, since, until, revs...)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(byHash))
+	for hash, id := range byHash {
+		ids[id] = hash
+	}
+	return ids, nil
+}
+
+// CommitPatchIDs returns stable patch-ids for the non-merge commits
+// selected by revs, mapped commit hash -> patch-id. Commits without a diff
+// have no patch-id.
+func (p *ExecParser) CommitPatchIDs(ctx context.Context, since, until time.Time, revs ...string) (map[string]string, error) {
+	args := []string{"log", "-p", "--no-merges", "--no-color"}
+	args = append(args, dateArgs(since, until)...)
+	args = append(args, revs...)
+
+	logCmd := exec.CommandContext(ctx, "git", args...)
+	logCmd.Dir = p.RepoPath
+
+	idCmd := exec.CommandContext(ctx, "git", "patch-id", "--stable")
+	idCmd.Dir = p.RepoPath
+
+	pipe, err := logCmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	idCmd.Stdin = pipe
+
+	if err := logCmd.Start(); err != nil {
+		return nil, err
+	}
+
+	output, err := idCmd.Output()
+	if waitErr := logCmd.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 2 {
+			ids[parts[1]] = parts[0]
+		}
+	}
+	return ids, nil
+}
+
+// GetBackports reports, for each branch, how many commits were backported
+// from HEAD, either via cherry-pick -x markers or identical patch-ids
+func (p *ExecParser) GetBackports(ctx context.Context, since, until time.Time, branches []string) ([]*BranchBackports, error) {
+	if len(branches) == 0 {
+		return nil, nil
+	}
+
+	headIDs, err := p.PatchIDs(ctx, since, until, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*BranchBackports
+	for _, branch := range branches {
+		bp := &BranchBackports{Branch: branch}
+
+		branchIDs, err := p.PatchIDs(ctx, since, until, branch, "--not", "HEAD")
+		if err != nil {
+			return result, err
+		}
+		duplicates := make(map[string]bool)
+		for id, hash := range branchIDs {
+			if _, ok := headIDs[id]; ok {
+				duplicates[hash] = true
+			}
+		}
+		bp.PatchDuplicates = len(duplicates)
+
+		// Commits only on the branch, with their full message
+		args := []string{"log", "--no-merges", "--format=%H%x00%B%x1e"}
+		args = append(args, dateArgs(since, until)...)
+		args = append(args, branch, "--not", "HEAD")
+
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = p.RepoPath
+		output, err := cmd.Output()
+		if err != nil {
+			return result, err
+		}
+
+		for _, record := range strings.Split(string(output), "\x1e") {
+			record = strings.TrimSpace(record)
+			if record == "" {
+				continue
+			}
+			bp.Commits++
+
+			hash, message, _ := strings.Cut(record, "\x00")
+			cherryPick := cherryPickRegex.MatchString(message)
+			if cherryPick {
+				bp.CherryPicks++
+			}
+			if cherryPick || duplicates[hash] {
+				bp.Backported++
+			}
+		}
+
+		result = append(result, bp)
+	}
+
+	return result, nil
+}
+
+// dateArgs returns git log --since/--until arguments for non-zero times
+func dateArgs(since, until time.Time) []string {
+	var args []string
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		args = append(args, "--until="+until.Format(time.RFC3339))
+	}
+	return args
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Checkout describes which objects and files of a repository are local
+type Checkout struct {
+	// Sparse checkout: tracked files outside the sparse cone are not in
+	// the worktree
+	Sparse bool
+
+	// Partial clone: blobs, and possibly trees, were left out of the clone
+	// and are fetched on demand from the promisor remote
+	Partial bool
+}
+
+// GetCheckout reports whether the repository at path is a sparse checkout
+// or a partial clone
+func GetCheckout(path string) Checkout {
+	return Checkout{
+		Sparse:  configValue(path, "--bool", "core.sparseCheckout") == "true",
+		Partial: configValue(path, "extensions.partialClone") != "" || hasPromisor(path),
+	}
+}
+
+// hasPromisor reports whether a remote of the repository at path is a
+// promisor remote, which is how older clones record a partial clone
+func hasPromisor(path string) bool {
+	cmd := exec.Command("git", "config", "--bool", "--get-regexp", `^remote\..*\.promisor$`)
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasSuffix(line, " true") {
+			return true
+		}
+	}
+	return false
+}
+
+// configValue returns a git config value of the repository at path, or ""
+// if it is not set
+func configValue(path string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"config", "--get"}, args...)...)
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// noLazyFetch keeps git from downloading objects missing from a partial
+// clone, one request per object; commands needing them fail instead. Git
+// before 2.44 ignores it.
+func noLazyFetch(cmd *exec.Cmd) {
+	cmd.Env = append(os.Environ(), "GIT_NO_LAZY_FETCH=1")
+}
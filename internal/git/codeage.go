@@ -0,0 +1,122 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineAge is a run of lines of a file that were last changed at the same
+// time
+type LineAge struct {
+	File  string
+	At    time.Time // author time of the commit that last changed the lines
+	Lines int
+}
+
+// CodeAgeSample is the blame of a sample of the files at HEAD
+type CodeAgeSample struct {
+	Files   int // text files at HEAD
+	Sampled int // files blamed
+	Ages    []LineAge
+}
+
+// SampleCodeAge blames up to maxFiles text files of HEAD, spread evenly over
+// the sorted paths so every part of the tree is represented. Files that
+// fail to blame, e.g. for history missing from a partial clone, are left
+// out.
+func SampleCodeAge(ctx context.Context, repoPath string, maxFiles int) (*CodeAgeSample, error) {
+	// An empty pattern matches every line, so -l lists the text files that
+	// are not empty
+	cmd := exec.CommandContext(ctx, "git", "grep", "-I", "-l", "-z", "-e", "", "HEAD", "--")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		// git grep exits 1 when nothing matches
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return &CodeAgeSample{}, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if file := strings.TrimPrefix(entry, "HEAD:"); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	sample := &CodeAgeSample{Files: len(files)}
+	picked := files
+	if maxFiles > 0 && len(files) > maxFiles {
+		picked = make([]string, maxFiles)
+		for i := range picked {
+			picked[i] = files[i*len(files)/maxFiles]
+		}
+	}
+
+	for _, file := range picked {
+		ages, err := blameAges(ctx, repoPath, file)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		sample.Sampled++
+		sample.Ages = append(sample.Ages, ages...)
+	}
+	return sample, nil
+}
+
+// blameAges blames a file at HEAD with git blame --incremental, which names
+// each commit's author time only the first time the commit appears
+func blameAges(ctx context.Context, repoPath, file string) ([]LineAge, error) {
+	cmd := exec.CommandContext(ctx, "git", "blame", "--incremental", "HEAD", "--", file)
+	cmd.Dir = repoPath
+	noLazyFetch(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]time.Time)
+	byTime := make(map[time.Time]int)
+	var hash string
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case hash == "":
+			// Entry header: <hash> <orig line> <final line> <lines>
+			fields := strings.Fields(line)
+			if len(fields) == 4 {
+				hash = fields[0]
+				lines, _ = strconv.Atoi(fields[3])
+			}
+		case strings.HasPrefix(line, "author-time "):
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				times[hash] = time.Unix(ts, 0)
+			}
+		case strings.HasPrefix(line, "filename "):
+			// The filename ends the entry
+			byTime[times[hash]] += lines
+			hash = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ages := make([]LineAge, 0, len(byTime))
+	for at, n := range byTime {
+		ages = append(ages, LineAge{File: file, At: at, Lines: n})
+	}
+	return ages, nil
+}
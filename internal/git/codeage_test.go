@@ -0,0 +1,50 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/gittest"
+)
+
+func TestSampleCodeAge(t *testing.T) {
+	repo := gittest.New(t)
+	repo.At(time.Date(2023, time.February, 1, 9, 0, 0, 0, time.UTC))
+	repo.Write("a.go", gittest.Lines("a", 10))
+	repo.Write("logo.png", "\x89PNG\x00\x00")
+	repo.Commit(gittest.Alice, "Add a")
+	repo.At(time.Date(2024, time.August, 1, 9, 0, 0, 0, time.UTC))
+	repo.Write("a.go", gittest.Lines("a", 6)+"b\nb\n")
+	repo.Write("lib/b.go", gittest.Lines("b", 4))
+	repo.Commit(gittest.Bob, "Rewrite a, add b")
+
+	sample, err := SampleCodeAge(context.Background(), repo.Dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.Files != 2 || sample.Sampled != 2 {
+		t.Errorf("got %d of %d files sampled, want 2 of 2", sample.Sampled, sample.Files)
+	}
+	lines := make(map[string]int)
+	for _, a := range sample.Ages {
+		lines[a.File+" "+a.At.UTC().Format("2006-01")] += a.Lines
+	}
+	want := map[string]int{"a.go 2023-02": 6, "a.go 2024-08": 2, "lib/b.go 2024-08": 4}
+	for key, n := range want {
+		if lines[key] != n {
+			t.Errorf("%s: got %d lines, want %d", key, lines[key], n)
+		}
+	}
+	if len(lines) != len(want) {
+		t.Errorf("got %v, want %v", lines, want)
+	}
+
+	sample, err = SampleCodeAge(context.Background(), repo.Dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.Files != 2 || sample.Sampled != 1 {
+		t.Errorf("got %d of %d files sampled, want 1 of 2", sample.Sampled, sample.Files)
+	}
+}
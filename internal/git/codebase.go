@@ -0,0 +1,96 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/audi70r/gitstat/internal/complexity"
+)
+
+// HeaderCheck describes the license header expected in source files
+type HeaderCheck struct {
+	Pattern    *regexp.Regexp
+	Extensions []string // file extensions to check, e.g. ".go"
+	MaxLines   int      // only the first MaxLines lines are searched
+}
+
+// CodebaseScan holds the results of a walk over the tracked files
+type CodebaseScan struct {
+	Lines      int
+	Headers    map[string]bool            // checked file -> has license header
+	Complexity map[string]complexity.File // files a complexity analyzer handles
+	Sparse     int                        // tracked files outside a sparse checkout, not counted
+}
+
+// ScanCodebase walks the tracked files, counting lines, measuring the
+// complexity of the source files an analyzer handles and, when check is
+// not nil, recording which source files carry the license header. Files
+// outside a sparse checkout are not in the worktree; they are counted in
+// Sparse instead.
+func ScanCodebase(ctx context.Context, repoPath string, check *HeaderCheck) (*CodebaseScan, error) {
+	// -t tags each file, S marking those outside the sparse checkout
+	cmd := exec.CommandContext(ctx, "git", "ls-files", "-z", "-t")
+	cmd.Dir = repoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &CodebaseScan{Headers: make(map[string]bool), Complexity: make(map[string]complexity.File)}
+
+	var extensions map[string]bool
+	if check != nil {
+		extensions = make(map[string]bool, len(check.Extensions))
+		for _, ext := range check.Extensions {
+			extensions[strings.ToLower(ext)] = true
+		}
+	}
+
+	for _, entry := range strings.Split(string(output), "\x00") {
+		tag, file, ok := strings.Cut(entry, " ")
+		if !ok || file == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if tag == "S" {
+			scan.Sparse++
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(repoPath, file))
+		if err != nil {
+			continue // Skip files that can't be read, e.g. submodules
+		}
+		scan.Lines += bytes.Count(content, []byte{'\n'})
+
+		if measured, ok := complexity.Measure(file, content); ok {
+			scan.Complexity[file] = measured
+		}
+
+		if check != nil && extensions[strings.ToLower(filepath.Ext(file))] {
+			scan.Headers[file] = check.Pattern.Match(headLines(content, check.MaxLines))
+		}
+	}
+
+	return scan, nil
+}
+
+// headLines returns the first n lines of content
+func headLines(content []byte, n int) []byte {
+	end := 0
+	for i := 0; i < n && end < len(content); i++ {
+		next := bytes.IndexByte(content[end:], '\n')
+		if next < 0 {
+			return content
+		}
+		end += next + 1
+	}
+	return content[:end]
+}
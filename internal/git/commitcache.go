@@ -0,0 +1,245 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// commitCacheVersion is bumped whenever Commit or the stored format
+// changes; files written by other versions are ignored. Version 3 drops
+// caches a log cut short by a long body line may have left incomplete.
+const commitCacheVersion = 3
+
+// Periods ending longer than this before HEAD's last commit are parsed
+// without the cache, which would also parse every commit after them
+const cacheUntilSlack = 7 * 24 * time.Hour
+
+// CommitCache keeps the commits parsed from HEAD between scans, keyed by the
+// repository path and the HEAD they were parsed at. A rescan only runs git
+// log for the commits added since that HEAD and replays the rest, so
+// re-analyzing a large repository takes moments. A HEAD that no longer
+// contains the cached one (after a rebase or reset) parses everything
+// again. Changes to .gitattributes do not invalidate the cache.
+type CommitCache struct {
+	path  string
+	file  commitCacheFile
+	dirty bool
+}
+
+type commitCacheFile struct {
+	Version int
+	Tip     string    // HEAD the commits were parsed at
+	Since   time.Time // earliest committer date parsed; zero for all history
+	Commits []*Commit // newest first, as git log lists them
+
+	// Parser options the commits were parsed with
+	SkipGenerated bool
+	PatchIDs      bool
+	NoMerges      bool
+	FirstParent   bool
+}
+
+// OpenCommitCache loads the cache for repoPath from the user cache
+// directory. A missing or unreadable cache starts empty; if no cache
+// directory is available the cache works in memory only.
+func OpenCommitCache(repoPath string) *CommitCache {
+	c := &CommitCache{}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return c
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		return c
+	}
+	sum := sha256.Sum256([]byte(abs))
+	c.path = filepath.Join(dir, "gitstat", "commits", hex.EncodeToString(sum[:8])+".gob")
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	var file commitCacheFile
+	if gob.NewDecoder(bytes.NewReader(data)).Decode(&file) != nil || file.Version != commitCacheVersion {
+		return c
+	}
+	c.file = file
+	return c
+}
+
+// Save writes the cache back to disk if a scan changed it
+func (c *CommitCache) Save() error {
+	if c.path == "" || !c.dirty {
+		return nil
+	}
+	c.file.Version = commitCacheVersion
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c.file); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	// Write through a temporary file so an interrupted save keeps the old
+	// cache; the name is unique as gitstat daemon may save at the same time
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// parseCached scans HEAD through the cache: commits since the cached tip are
+// parsed and streamed first, then the cached ones within the period are
+// replayed with their labels refreshed. The cache holds every commit up to
+// HEAD; commits after until are skipped here as git log --until would.
+// Parsed commits are only stored once their number matches git rev-list,
+// so an incomplete parse fails the scan instead of losing history for good.
+func (p *ExecParser) parseCached(ctx context.Context, since, until time.Time,
+	onProgress func(ScanProgress), onCommit func(*Commit)) error {
+	tip, tipDate, err := p.head(ctx)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() && until.Before(tipDate.Add(-cacheUntilSlack)) {
+		return p.parse(ctx, since, until, []string{"HEAD"}, onProgress, onCommit)
+	}
+
+	// Commits with patch-ids serve scans without them
+	c := p.Cache
+	reuse := c.file.Tip != "" && c.file.SkipGenerated == p.SkipGenerated && (c.file.PatchIDs || !p.WithPatchIDs) &&
+		c.file.NoMerges == p.NoMerges && c.file.FirstParent == p.FirstParent && !since.Before(c.file.Since) && (c.file.Tip == tip || p.isAncestor(ctx, c.file.Tip, tip))
+	inPeriod := func(commit *Commit) bool {
+		return !commit.CommitDate.Before(since) && (until.IsZero() || !commit.CommitDate.After(until))
+	}
+	if !reuse {
+		var commits []*Commit
+		err := p.parse(ctx, since, time.Time{}, []string{tip}, onProgress, func(commit *Commit) {
+			commits = append(commits, commit)
+			if inPeriod(commit) {
+				onCommit(commit)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if err := p.verifyCount(ctx, since, []string{tip}, len(commits)); err != nil {
+			return err
+		}
+		c.file = commitCacheFile{Tip: tip, Since: since, Commits: commits,
+			SkipGenerated: p.SkipGenerated, PatchIDs: p.WithPatchIDs, NoMerges: p.NoMerges, FirstParent: p.FirstParent}
+		c.dirty = true
+		// An unsaved cache only makes the next scan slower
+		c.Save()
+		return nil
+	}
+
+	var fresh []*Commit
+	if c.file.Tip != tip {
+		// New commits are parsed like the cached ones
+		q := *p
+		q.WithPatchIDs = c.file.PatchIDs
+		err := q.parse(ctx, c.file.Since, time.Time{}, []string{tip, "^" + c.file.Tip}, onProgress, func(commit *Commit) {
+			fresh = append(fresh, commit)
+			if inPeriod(commit) {
+				onCommit(commit)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.verifyCount(ctx, c.file.Since, []string{tip, "^" + c.file.Tip}, len(fresh)); err != nil {
+			return err
+		}
+	}
+
+	// Notes change without new commits
+	labels, _ := ReadLabels(ctx, p.RepoPath)
+	count := len(fresh)
+	for _, commit := range c.file.Commits {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !inPeriod(commit) {
+			continue
+		}
+		commit.Labels = labels[commit.Hash]
+		onCommit(commit)
+		count++
+		if onProgress != nil {
+			onProgress(ScanProgress{CommitsParsed: count, CurrentHash: commit.ShortHash})
+		}
+	}
+	if onProgress != nil {
+		onProgress(ScanProgress{CommitsParsed: count, Done: true})
+	}
+
+	if len(fresh) > 0 {
+		c.file.Tip = tip
+		c.file.Commits = append(fresh, c.file.Commits...)
+		c.dirty = true
+		c.Save()
+	}
+	return nil
+}
+
+// head returns the hash and committer date of HEAD
+func (p *ExecParser) head(ctx context.Context) (string, time.Time, error) {
+	cmd := exec.CommandContext(ctx, "git", "log", "-1", "--format=%H %cI", "HEAD")
+	cmd.Dir = p.RepoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	hash, date, _ := strings.Cut(strings.TrimSpace(string(output)), " ")
+	at, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("HEAD date %q: %w", date, err)
+	}
+	return hash, at, nil
+}
+
+// isAncestor reports whether commit is reachable from tip
+func (p *ExecParser) isAncestor(ctx context.Context, commit, tip string) bool {
+	cmd := exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", commit, tip)
+	cmd.Dir = p.RepoPath
+	return cmd.Run() == nil
+}
+
+// verifyCount checks that parsing revs since the given time yielded every
+// commit git lists for them
+func (p *ExecParser) verifyCount(ctx context.Context, since time.Time, revs []string, parsed int) error {
+	want, err := p.countCommits(ctx, since, time.Time{}, revs)
+	if err != nil {
+		return fmt.Errorf("counting commits: %w", err)
+	}
+	if parsed != want {
+		return fmt.Errorf("parsed %d of %d commits; the commit cache was left unchanged", parsed, want)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DebtMarker is a TODO/FIXME/HACK style comment in the current worktree
+type DebtMarker struct {
+	File        string
+	Line        int
+	Kind        string // the matched keyword, e.g. "TODO"
+	Text        string // trimmed source line
+	Author      string
+	AuthorEmail string
+	At          time.Time // when the line was last changed
+}
+
+// GetDebtMarkers finds the given keywords in tracked text files and
+// attributes each occurrence to the author who last changed the line. With a
+// non-nil cache, files whose content is unchanged since an earlier scan are
+// attributed from the cache instead of being blamed again.
+func GetDebtMarkers(ctx context.Context, repoPath string, keywords []string, cache *BlameCache) ([]*DebtMarker, error) {
+	if len(keywords) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"grep", "-n", "-I", "-w", "-F", "--no-color"}
+	quoted := make([]string, len(keywords))
+	for i, k := range keywords {
+		args = append(args, "-e", k)
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	kindRegex := regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+
+	// git grep exits 1 when nothing matches
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Group matches by file so each file is blamed once
+	byFile := make(map[string][]*DebtMarker)
+	var files []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		file, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		lineStr, text, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		line, err := strconv.Atoi(lineStr)
+		if err != nil {
+			continue
+		}
+
+		if _, exists := byFile[file]; !exists {
+			files = append(files, file)
+		}
+		byFile[file] = append(byFile[file], &DebtMarker{
+			File: file,
+			Line: line,
+			Kind: kindRegex.FindString(text),
+			Text: strings.TrimSpace(text),
+		})
+	}
+
+	var blobs map[string]string
+	if cache != nil {
+		// Without blob hashes every file is blamed, as without a cache
+		blobs, _ = worktreeBlobs(ctx, repoPath, files)
+	}
+
+	var markers []*DebtMarker
+	for _, file := range files {
+		fileMarkers := byFile[file]
+		markers = append(markers, fileMarkers...)
+
+		key := ""
+		if blob := blobs[file]; blob != "" {
+			key = blob + " " + file
+			lines := make([]int, len(fileMarkers))
+			for i, m := range fileMarkers {
+				lines[i] = m.Line
+			}
+			if cached, ok := cache.lookup(key, lines); ok {
+				for _, m := range fileMarkers {
+					bl := cached[m.Line]
+					m.Author, m.AuthorEmail, m.At = bl.Author, bl.Email, bl.At
+				}
+				continue
+			}
+		}
+
+		if err := blameMarkers(ctx, repoPath, file, fileMarkers); err != nil {
+			if ctx.Err() != nil {
+				return markers, ctx.Err()
+			}
+			// Keep unattributed markers, e.g. for files with unusual paths
+			// or history missing from a partial clone
+			continue
+		}
+		if key == "" {
+			continue
+		}
+		for _, m := range fileMarkers {
+			// Uncommitted lines are attributed once committed, so they
+			// must be blamed again even if the content stays the same
+			if m.AuthorEmail != "" && m.AuthorEmail != "not.committed.yet" {
+				cache.store(key, m.Line, BlameLine{Author: m.Author, Email: m.AuthorEmail, At: m.At})
+			}
+		}
+	}
+
+	return markers, nil
+}
+
+// blameMarkers fills author and date for the marker lines of a single file.
+// In a partial clone, blame fails rather than fetching missing history
+// objects one by one.
+func blameMarkers(ctx context.Context, repoPath, file string, markers []*DebtMarker) error {
+	args := []string{"blame", "--line-porcelain"}
+	for _, m := range markers {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", m.Line, m.Line))
+	}
+	args = append(args, "--", file)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoPath
+	noLazyFetch(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+
+	byLine := make(map[int]*DebtMarker, len(markers))
+	for _, m := range markers {
+		byLine[m.Line] = m
+	}
+
+	var current *DebtMarker
+	inEntry := false
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			// Source line ends the entry
+			current = nil
+			inEntry = false
+		case !inEntry:
+			// Entry header: <hash> <orig line> <final line> [<group size>]
+			inEntry = true
+			fields := strings.Fields(line)
+			if len(fields) >= 3 {
+				if n, err := strconv.Atoi(fields[2]); err == nil {
+					current = byLine[n]
+				}
+			}
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "author "):
+			current.Author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			current.AuthorEmail = strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+		case strings.HasPrefix(line, "author-time "):
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				current.At = time.Unix(ts, 0)
+			}
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ChangedFiles returns the files changed since the merge base of base and
+// HEAD, committed or not, relative to the repository root: the files of a
+// pending change to review before merging it into base
+func ChangedFiles(ctx context.Context, repoPath, base string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "-z", "--merge-base", base, "--")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git diff --merge-base %s: %s", base, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,447 @@
+package git
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GoGitParser reads the history with go-git, so no git binary is needed.
+// It lists the same commits as ExecParser, but is slower on large
+// histories and has no patch-ids: WithPatchIDs and Cache are ignored.
+type GoGitParser struct {
+	RepoPath string
+	ParseOptions
+
+	onlyMerges bool // see ParseMerges
+}
+
+// EstimateCommitCount walks the commits Parse lists without diffing them
+func (p *GoGitParser) EstimateCommitCount(ctx context.Context, since, until time.Time) (int, error) {
+	repo, err := p.open()
+	if err != nil {
+		return -1, err
+	}
+	tips, err := p.revisions(repo)
+	if err != nil {
+		return -1, err
+	}
+	count := 0
+	err = p.walk(ctx, repo, tips, since, until, func(*object.Commit) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// Parse walks the history and streams commits via callback, newest first
+func (p *GoGitParser) Parse(ctx context.Context, since, until time.Time,
+	onProgress func(ScanProgress), onCommit func(*Commit)) error {
+	repo, err := p.open()
+	if err != nil {
+		return err
+	}
+	tips, err := p.revisions(repo)
+	if err != nil {
+		return err
+	}
+
+	// Unreadable notes leave commits unlabeled
+	labels, _ := readNotesLabels(repo)
+
+	var attrs gitattributes.Matcher
+	if p.SkipGenerated {
+		// Without a worktree every file is kept
+		attrs, _ = worktreeAttributes(repo)
+	}
+
+	commitCount := 0
+	err = p.walk(ctx, repo, tips, since, until, func(oc *object.Commit) error {
+		c, err := p.commit(ctx, oc)
+		if err != nil {
+			return err
+		}
+		if attrs != nil {
+			filterGenerated(attrs, c)
+		}
+		separateMergeChanges(c)
+		c.Labels = labels[c.Hash]
+		onCommit(c)
+
+		commitCount++
+		if onProgress != nil {
+			onProgress(ScanProgress{CommitsParsed: commitCount, CurrentHash: c.ShortHash})
+		}
+		return nil
+	})
+	if onProgress != nil {
+		onProgress(ScanProgress{CommitsParsed: commitCount, Done: true})
+	}
+	return err
+}
+
+// ParseMerges walks the history listing only merge commits
+func (p *GoGitParser) ParseMerges(ctx context.Context, since, until time.Time, onCommit func(*Commit)) error {
+	q := *p
+	q.NoMerges, q.onlyMerges = false, true
+	return q.Parse(ctx, since, until, nil, onCommit)
+}
+
+// FirstParentCommits follows the first parents from HEAD
+func (p *GoGitParser) FirstParentCommits(ctx context.Context, since, until time.Time) (map[string]bool, error) {
+	repo, err := p.open()
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.ResolveRevision(plumbing.Revision(plumbing.HEAD))
+	if err != nil {
+		return nil, err
+	}
+
+	q := GoGitParser{ParseOptions: ParseOptions{NoMerges: true, FirstParent: true}}
+	hashes := make(map[string]bool)
+	err = q.walk(ctx, repo, []plumbing.Hash{*head}, since, until, func(c *object.Commit) error {
+		hashes[c.Hash.String()] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// open opens the repository containing RepoPath
+func (p *GoGitParser) open() (*gogit.Repository, error) {
+	return gogit.PlainOpenWithOptions(p.RepoPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+}
+
+// revisions resolves Refs, or HEAD without them, to the commits the walk
+// starts from. Globs are matched against local and remote-tracking
+// branches as ListBranches does.
+func (p *GoGitParser) revisions(repo *gogit.Repository) ([]plumbing.Hash, error) {
+	if len(p.Refs) == 0 {
+		head, err := repo.ResolveRevision(plumbing.Revision(plumbing.HEAD))
+		if err != nil {
+			return nil, err
+		}
+		return []plumbing.Hash{*head}, nil
+	}
+
+	var tips []plumbing.Hash
+	for _, ref := range p.Refs {
+		if !strings.ContainsAny(ref, "*?[") {
+			hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ref, err)
+			}
+			tips = append(tips, *hash)
+			continue
+		}
+
+		refs, err := repo.References()
+		if err != nil {
+			return nil, err
+		}
+		err = refs.ForEach(func(r *plumbing.Reference) error {
+			if r.Type() != plumbing.HashReference || !branchMatches(r.Name(), ref) {
+				return nil
+			}
+			tips = append(tips, r.Hash())
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(tips) == 0 {
+		return nil, fmt.Errorf("no branches match %s", strings.Join(p.Refs, ", "))
+	}
+	return tips, nil
+}
+
+// branchMatches reports whether a local or remote-tracking branch matches
+// the glob pattern
+func branchMatches(name plumbing.ReferenceName, pattern string) bool {
+	var branch string
+	switch {
+	case name.IsBranch():
+		branch = name.Short()
+	case name.IsRemote():
+		// refs/remotes/<remote>/<branch>
+		_, branch, _ = strings.Cut(strings.TrimPrefix(name.String(), "refs/remotes/"), "/")
+		if branch == "HEAD" {
+			return false
+		}
+	default:
+		return false
+	}
+	ok, _ := path.Match(pattern, branch)
+	return ok
+}
+
+// walk visits the commits reachable from tips in committer date order,
+// newest first like git log, each once. NoMerges, FirstParent and the
+// ParseMerges filter apply as they do to git log.
+func (p *GoGitParser) walk(ctx context.Context, repo *gogit.Repository, tips []plumbing.Hash,
+	since, until time.Time, visit func(*object.Commit) error) error {
+	seen := make(map[plumbing.Hash]bool)
+	queue := &commitQueue{}
+	push := func(hash plumbing.Hash) error {
+		if seen[hash] {
+			return nil
+		}
+		seen[hash] = true
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		heap.Push(queue, c)
+		return nil
+	}
+	for _, tip := range tips {
+		if err := push(tip); err != nil {
+			return err
+		}
+	}
+
+	for queue.Len() > 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c := heap.Pop(queue).(*object.Commit)
+		// The queue is ordered by date, so every commit left is older
+		if !since.IsZero() && c.Committer.When.Before(since) {
+			break
+		}
+
+		parents := c.ParentHashes
+		if p.FirstParent && len(parents) > 1 {
+			parents = parents[:1]
+		}
+		for _, parent := range parents {
+			err := push(parent)
+			if err == plumbing.ErrObjectNotFound {
+				continue // cut off by a shallow clone
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		isMerge := c.NumParents() >= 2
+		if (p.NoMerges && isMerge) || (p.onlyMerges && !isMerge) {
+			continue
+		}
+		if !until.IsZero() && c.Committer.When.After(until) {
+			continue
+		}
+		if err := visit(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitQueue orders commits newest first by committer date
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int { return len(q) }
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any)   { *q = append(*q, x.(*object.Commit)) }
+func (q *commitQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// commit converts a go-git commit, diffing it against its first parent as
+// git log --diff-merges=first-parent does
+func (p *GoGitParser) commit(ctx context.Context, oc *object.Commit) (*Commit, error) {
+	hash := oc.Hash.String()
+	c := &Commit{
+		Hash:        hash,
+		ShortHash:   hash[:7],
+		Author:      Author{Name: oc.Author.Name, Email: oc.Author.Email},
+		AuthorDate:  oc.Author.When,
+		Committer:   Author{Name: oc.Committer.Name, Email: oc.Committer.Email},
+		CommitDate:  oc.Committer.When,
+		ParentCount: oc.NumParents(),
+		IsMerge:     oc.NumParents() >= 2,
+	}
+	c.Subject, c.Body, _ = strings.Cut(oc.Message, "\n")
+	c.Subject = strings.TrimSpace(c.Subject)
+	if c.IsMerge {
+		if matches := prNumberRegex.FindStringSubmatch(c.Subject); len(matches) >= 2 {
+			c.PRNumber, _ = strconv.Atoi(matches[1])
+		}
+		if matches := mergeBranchRegex.FindStringSubmatch(c.Subject); len(matches) >= 2 {
+			c.MergeBranch = matches[1]
+		}
+	}
+	finishMessage(c)
+
+	tree, err := oc.Tree()
+	if err != nil {
+		return nil, err
+	}
+	// A root commit is diffed against the empty tree
+	var parentTree *object.Tree
+	if oc.NumParents() > 0 {
+		parent, err := oc.Parent(0)
+		if err == nil {
+			if parentTree, err = parent.Tree(); err != nil {
+				return nil, err
+			}
+		} else if err != plumbing.ErrObjectNotFound {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, fp := range patch.FilePatches() {
+		c.FileChanges = append(c.FileChanges, fileChange(fp))
+	}
+	return c, nil
+}
+
+// fileChange counts the lines of a file patch as git log --numstat does
+func fileChange(fp fdiff.FilePatch) FileChange {
+	var fc FileChange
+	from, to := fp.Files()
+	switch {
+	case from == nil:
+		fc.FilePath, fc.Status = to.Path(), FileCreated
+	case to == nil:
+		fc.FilePath, fc.Status = from.Path(), FileDeleted
+	case from.Path() != to.Path():
+		fc.FilePath, fc.OldPath, fc.Status = to.Path(), from.Path(), FileRenamed
+	default:
+		fc.FilePath = to.Path()
+	}
+
+	if fp.IsBinary() {
+		fc.IsBinary = true
+		return fc
+	}
+	for _, chunk := range fp.Chunks() {
+		s := chunk.Content()
+		if s == "" {
+			continue
+		}
+		// A last line without newline counts too
+		lines := strings.Count(s, "\n")
+		if !strings.HasSuffix(s, "\n") {
+			lines++
+		}
+		switch chunk.Type() {
+		case fdiff.Add:
+			fc.Additions += lines
+		case fdiff.Delete:
+			fc.Deletions += lines
+		}
+	}
+	return fc
+}
+
+// worktreeAttributes loads the .gitattributes of the worktree; like git
+// check-attr, paths are matched against the current attributes
+func worktreeAttributes(repo *gogit.Repository) (gitattributes.Matcher, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := gitattributes.ReadPatterns(wt.Filesystem, nil)
+	if err != nil {
+		return nil, err
+	}
+	return gitattributes.NewMatcher(patterns), nil
+}
+
+// filterGenerated moves the changes to files marked generated or vendored
+// out of FileChanges into Generated, as attrChecker.filter does
+func filterGenerated(attrs gitattributes.Matcher, commit *Commit) {
+	kept := commit.FileChanges[:0]
+	for _, fc := range commit.FileChanges {
+		if generatedPath(attrs, fc.FilePath) {
+			commit.Generated = append(commit.Generated, fc)
+		} else {
+			kept = append(kept, fc)
+		}
+	}
+	commit.FileChanges = kept
+}
+
+func generatedPath(attrs gitattributes.Matcher, name string) bool {
+	results, _ := attrs.Match(strings.Split(name, "/"), linguistAttributes)
+	for _, attr := range results {
+		if attr.IsSet() || attr.Value() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// readNotesLabels reads the gitstat notes from the notes tree, mapped commit
+// hash -> labels like ReadLabels
+func readNotesLabels(repo *gogit.Repository) (map[string][]string, error) {
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/notes/"+NotesRef), true)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := notes.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string][]string)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		// Notes of many commits are fanned out as ab/cdef...
+		commit := strings.ReplaceAll(f.Name, "/", "")
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		note, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if l := ParseLabels(string(note)); len(l) > 0 {
+			labels[commit] = l
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
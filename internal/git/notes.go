@@ -0,0 +1,159 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NotesRef is the git notes namespace holding commit labels
+// (refs/notes/gitstat), so they never mix with other notes
+const NotesRef = "gitstat"
+
+// ParseLabels splits a note into labels: one per line or comma-separated,
+// lowercased, ignoring blank lines and lines starting with '#'
+func ParseLabels(note string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(note, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, label := range strings.Split(line, ",") {
+			label = strings.ToLower(strings.TrimSpace(label))
+			if label != "" && !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+// ReadLabels returns the labels noted on commits, mapped commit hash ->
+// labels. A repository without gitstat notes has none.
+func ReadLabels(ctx context.Context, repoPath string) (map[string][]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "notes", "--ref="+NotesRef, "list")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// note blob -> annotated commits
+	commits := make(map[string][]string)
+	var blobs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		blob, commit, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if _, exists := commits[blob]; !exists {
+			blobs = append(blobs, blob)
+		}
+		commits[blob] = append(commits[blob], commit)
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	cmd.Dir = repoPath
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+	output, err = cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each object is "<hash> <type> <size>\n<content>\n"
+	labels := make(map[string][]string)
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for {
+		header, err := reader.ReadString('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue // "<hash> missing"
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cat-file header %q", header)
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, err
+		}
+		noteLabels := ParseLabels(string(content[:size]))
+		for _, commit := range commits[fields[0]] {
+			labels[commit] = noteLabels
+		}
+	}
+	return labels, nil
+}
+
+// AddLabels adds labels to the note of a commit, keeping the labels it
+// already has, and returns the resulting labels
+func AddLabels(ctx context.Context, repoPath, commit string, add []string) ([]string, error) {
+	return editLabels(ctx, repoPath, commit, func(labels map[string]bool) {
+		for _, label := range add {
+			labels[strings.ToLower(strings.TrimSpace(label))] = true
+		}
+	})
+}
+
+// RemoveLabels removes labels from the note of a commit, deleting the note
+// when none remain, and returns the remaining labels
+func RemoveLabels(ctx context.Context, repoPath, commit string, remove []string) ([]string, error) {
+	return editLabels(ctx, repoPath, commit, func(labels map[string]bool) {
+		for _, label := range remove {
+			delete(labels, strings.ToLower(strings.TrimSpace(label)))
+		}
+	})
+}
+
+// editLabels rewrites a commit's note after edit changed its label set
+func editLabels(ctx context.Context, repoPath, commit string, edit func(map[string]bool)) ([]string, error) {
+	labels := make(map[string]bool)
+	show := exec.CommandContext(ctx, "git", "notes", "--ref="+NotesRef, "show", commit)
+	show.Dir = repoPath
+	if output, err := show.Output(); err == nil {
+		// A commit without a note fails; it simply starts empty
+		for _, label := range ParseLabels(string(output)) {
+			labels[label] = true
+		}
+	}
+
+	edit(labels)
+	delete(labels, "")
+
+	result := make([]string, 0, len(labels))
+	for label := range labels {
+		result = append(result, label)
+	}
+	sort.Strings(result)
+
+	var cmd *exec.Cmd
+	if len(result) == 0 {
+		cmd = exec.CommandContext(ctx, "git", "notes", "--ref="+NotesRef, "remove", "--ignore-missing", commit)
+	} else {
+		cmd = exec.CommandContext(ctx, "git", "notes", "--ref="+NotesRef, "add", "-f",
+			"-m", strings.Join(result, "\n"), commit)
+	}
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git notes: %s", strings.TrimSpace(string(output)))
+	}
+	return result, nil
+}
@@ -3,7 +3,11 @@ package git
 import (
 	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,26 +19,118 @@ var (
 	prNumberRegex = regexp.MustCompile(`[Mm]erge pull request #(\d+)`)
 	// Match "Merge branch 'feature'" or "Merge branch 'feature' into 'main'"
 	mergeBranchRegex = regexp.MustCompile(`[Mm]erge (?:pull request #\d+ from |branch '?)([^'"\s]+)`)
+	// Match "(cherry picked from commit abc123)" added by git cherry-pick -x
+	cherryPickRegex = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
 )
 
+// Commit markers of the log format: control bytes, which no subject or body
+// line consists of, unlike a word a message could quote
 const (
-	commitStart = "COMMIT_START"
-	commitEnd   = "COMMIT_END"
+	commitStart = "\x1e"
+	commitEnd   = "\x1f"
 )
 
-// Parser handles git log parsing
-type Parser struct {
+// maxLogLine is the longest log line parsed, e.g. a body pasted on one line
+const maxLogLine = 64 * 1024 * 1024
+
+// Parser lists the commits of a repository. Backends differ in how they
+// read the history: ExecParser runs the git binary, GoGitParser reads the
+// repository in Go, so gitstat also works where git is not installed.
+type Parser interface {
+	// EstimateCommitCount returns an estimate of commits in the date range
+	EstimateCommitCount(ctx context.Context, since, until time.Time) (int, error)
+
+	// Parse streams the commits in the date range via callback, newest
+	// first
+	Parse(ctx context.Context, since, until time.Time,
+		onProgress func(ScanProgress), onCommit func(*Commit)) error
+
+	// ParseMerges lists only the merge commits, which a NoMerges scan
+	// leaves out, so pull requests are still counted (see
+	// Aggregator.ProcessMerge)
+	ParseMerges(ctx context.Context, since, until time.Time, onCommit func(*Commit)) error
+
+	// FirstParentCommits returns the hashes of non-merge commits made
+	// directly on the first-parent chain of HEAD, i.e. not brought in by a
+	// merge
+	FirstParentCommits(ctx context.Context, since, until time.Time) (map[string]bool, error)
+}
+
+// Parser backends, selected with Config.GitBackend
+const (
+	BackendExec  = "exec"
+	BackendGoGit = "go-git"
+)
+
+// Backends lists the parser backends
+var Backends = []string{BackendExec, BackendGoGit}
+
+// ParseOptions select which commits a Parser lists and what it reports
+// about them
+type ParseOptions struct {
+	// Move changes to files marked linguist-generated or linguist-vendored
+	// in .gitattributes out of Commit.FileChanges, as GitHub's language
+	// statistics do
+	SkipGenerated bool
+
+	// Refs to scan together instead of HEAD, e.g. "main" and "release/*".
+	// Globs are matched against local and remote-tracking branches.
+	// Commits reachable from several refs are reported once.
+	Refs []string
+
+	// Fill Commit.PatchID, so copies of a change on other refs (e.g.
+	// cherry-picks to a release branch) can be recognized
+	WithPatchIDs bool
+
+	// Leave merge commits out (git log --no-merges); ParseMerges lists
+	// them separately for the pull request statistics
+	NoMerges bool
+
+	// Follow only the first parent of merges (git log --first-parent), so
+	// the commits of merged branches are left out
+	FirstParent bool
+
+	// Commits parsed by earlier scans of HEAD; a rescan only parses the
+	// commits added since. Scans of Refs bypass it.
+	Cache *CommitCache
+}
+
+// NewParser creates a parser for the repository at repoPath using the named
+// backend; any name but BackendGoGit runs the git binary
+func NewParser(backend, repoPath string, opts ParseOptions) Parser {
+	if backend == BackendGoGit {
+		return &GoGitParser{RepoPath: repoPath, ParseOptions: opts}
+	}
+	return &ExecParser{RepoPath: repoPath, ParseOptions: opts}
+}
+
+// ExecParser parses the output of git log
+type ExecParser struct {
 	RepoPath string
+	ParseOptions
+
+	onlyMerges bool // git log --merges, see ParseMerges
 }
 
-// NewParser creates a new git parser for the given repository path
-func NewParser(repoPath string) *Parser {
-	return &Parser{RepoPath: repoPath}
+// NewExecParser creates a parser running git in the given repository, for
+// the operations only the git binary offers, such as ListBranches
+func NewExecParser(repoPath string) *ExecParser {
+	return &ExecParser{RepoPath: repoPath}
+}
+
+// EstimateCommitCount runs git rev-list --count over the commits Parse lists
+func (p *ExecParser) EstimateCommitCount(ctx context.Context, since, until time.Time) (int, error) {
+	revs, err := p.revisions(ctx)
+	if err != nil {
+		return -1, err
+	}
+	return p.countCommits(ctx, since, until, revs)
 }
 
-// EstimateCommitCount returns an estimate of commits in the date range
-func (p *Parser) EstimateCommitCount(ctx context.Context, since, until time.Time) (int, error) {
-	args := []string{"rev-list", "--count", "HEAD"}
+// countCommits counts the commits git log lists for revs in the period
+func (p *ExecParser) countCommits(ctx context.Context, since, until time.Time, revs []string) (int, error) {
+	args := append([]string{"rev-list", "--count"}, p.traversalArgs()...)
+	args = append(args, revs...)
 
 	if !since.IsZero() {
 		args = append(args, "--since="+since.Format(time.RFC3339))
@@ -59,18 +155,115 @@ func (p *Parser) EstimateCommitCount(ctx context.Context, since, until time.Time
 	return count, nil
 }
 
-// Parse executes git log and streams commits via callback
-func (p *Parser) Parse(ctx context.Context, since, until time.Time,
+// revisions resolves Refs to the revisions passed to git log
+func (p *ExecParser) revisions(ctx context.Context) ([]string, error) {
+	if len(p.Refs) == 0 {
+		return []string{"HEAD"}, nil
+	}
+
+	var revs []string
+	for _, ref := range p.Refs {
+		if !strings.ContainsAny(ref, "*?[") {
+			revs = append(revs, ref)
+			continue
+		}
+		branches, err := p.ListBranches(ctx, []string{ref})
+		if err != nil {
+			return nil, err
+		}
+		revs = append(revs, branches...)
+	}
+	if len(revs) == 0 {
+		return nil, fmt.Errorf("no branches match %s", strings.Join(p.Refs, ", "))
+	}
+	return revs, nil
+}
+
+// FirstParentCommits lists the first-parent chain with git rev-list
+func (p *ExecParser) FirstParentCommits(ctx context.Context, since, until time.Time) (map[string]bool, error) {
+	args := []string{"rev-list", "--first-parent", "--no-merges", "HEAD"}
+	args = append(args, dateArgs(since, until)...)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = p.RepoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]bool)
+	for _, hash := range strings.Fields(string(output)) {
+		hashes[hash] = true
+	}
+	return hashes, nil
+}
+
+// Parse executes git log and streams commits via callback. With a Cache,
+// a scan of HEAD reuses the commits parsed by earlier scans.
+func (p *ExecParser) Parse(ctx context.Context, since, until time.Time,
+	onProgress func(ScanProgress), onCommit func(*Commit)) error {
+	if p.Cache != nil && len(p.Refs) == 0 {
+		return p.parseCached(ctx, since, until, onProgress, onCommit)
+	}
+	revs, err := p.revisions(ctx)
+	if err != nil {
+		return err
+	}
+	return p.parse(ctx, since, until, revs, onProgress, onCommit)
+}
+
+// ParseMerges runs git log --merges
+func (p *ExecParser) ParseMerges(ctx context.Context, since, until time.Time, onCommit func(*Commit)) error {
+	q := *p
+	q.NoMerges, q.onlyMerges = false, true
+	q.WithPatchIDs = false // merges have none
+	revs, err := q.revisions(ctx)
+	if err != nil {
+		return err
+	}
+	return q.parse(ctx, since, until, revs, nil, onCommit)
+}
+
+// traversalArgs returns the git log options selecting which commits are
+// listed
+func (p *ExecParser) traversalArgs() []string {
+	var args []string
+	if p.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if p.onlyMerges {
+		args = append(args, "--merges")
+	}
+	if p.FirstParent {
+		args = append(args, "--first-parent")
+	}
+	return args
+}
+
+// parse runs git log over revs, streaming the commits via callback
+func (p *ExecParser) parse(ctx context.Context, since, until time.Time, revs []string,
 	onProgress func(ScanProgress), onCommit func(*Commit)) error {
 
+	// %cn/%ce/%cI = committer, which differs from the author after a rebase
 	// %P = parent hashes (space-separated), used to detect merge commits
-	format := "COMMIT_START%n%H%n%h%n%an%n%ae%n%aI%n%P%n%s%nCOMMIT_END"
+	// %b = body, which may span any number of lines up to the end marker
+	// %x1e/%x1f = commitStart/commitEnd
+	format := "%x1e%n%H%n%h%n%an%n%ae%n%aI%n%cn%n%ce%n%cI%n%P%n%s%n%b%n%x1f"
 
 	args := []string{
 		"log",
 		"--format=" + format,
 		"--numstat",
+		"--summary", // create/delete mode lines for file lifecycle tracking
+		// Report renames as "old => new" even where diff.renames is off, so
+		// a renamed file keeps its history
+		"--find-renames",
+		// Diff merges against their first parent: the changes the merged
+		// branch brought in, kept apart in Commit.MergeChanges
+		"--diff-merges=first-parent",
 	}
+	args = append(args, p.traversalArgs()...)
 
 	if !since.IsZero() {
 		args = append(args, "--since="+since.Format(time.RFC3339))
@@ -79,6 +272,17 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 		args = append(args, "--until="+until.Format(time.RFC3339))
 	}
 
+	args = append(args, revs...)
+
+	var patchIDs map[string]string
+	if p.WithPatchIDs {
+		// Without patch-ids, copies of a change are counted separately
+		patchIDs, _ = p.CommitPatchIDs(ctx, since, until, revs...)
+	}
+
+	// Unreadable notes leave commits unlabeled
+	labels, _ := ReadLabels(ctx, p.RepoPath)
+
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = p.RepoPath
 
@@ -91,7 +295,29 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 		return err
 	}
 
+	var attrs *attrChecker
+	if p.SkipGenerated {
+		// Without the attribute lookup every file is kept
+		attrs, _ = newAttrChecker(ctx, p.RepoPath)
+		defer func() {
+			if attrs != nil {
+				attrs.close()
+			}
+		}()
+	}
+	emit := func(c *Commit) {
+		if attrs != nil && attrs.filter(c) != nil {
+			attrs.close()
+			attrs = nil
+		}
+		separateMergeChanges(c)
+		c.PatchID = patchIDs[c.Hash]
+		c.Labels = labels[c.Hash]
+		onCommit(c)
+	}
+
 	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLine)
 	var current *Commit
 	lineNum := 0
 	commitCount := 0
@@ -105,7 +331,7 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 		case line == commitStart:
 			// If we have a pending commit, emit it
 			if current != nil {
-				onCommit(current)
+				emit(current)
 				commitCount++
 				if onProgress != nil {
 					onProgress(ScanProgress{
@@ -120,6 +346,9 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 			seenNumstatContent = false
 
 		case line == commitEnd:
+			if current != nil {
+				finishMessage(current)
+			}
 			inNumstat = true
 			seenNumstatContent = false
 
@@ -133,8 +362,10 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 				// Only finalize if we've seen content and this is the separator
 				if seenNumstatContent {
 					// This empty line ends the numstat section
-					// But don't emit yet - wait for COMMIT_START
+					// But don't emit yet - wait for the next commit
 				}
+			} else if path, status, ok := parseSummary(line); ok {
+				applyFileStatus(current, path, status)
 			} else {
 				fc := parseNumstat(line)
 				if fc != nil {
@@ -145,9 +376,17 @@ func (p *Parser) Parse(ctx context.Context, since, until time.Time,
 		}
 	}
 
+	// A line too long to read would end the log early and leave git
+	// blocked on a full pipe; the last commit may be cut off, so fail
+	if err := scanner.Err(); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return fmt.Errorf("reading git log: %w", err)
+	}
+
 	// Handle last commit
 	if current != nil {
-		onCommit(current)
+		emit(current)
 		commitCount++
 		if onProgress != nil {
 			onProgress(ScanProgress{
@@ -181,10 +420,17 @@ func parseCommitLine(c *Commit, lineNum int, line string) {
 	case 4:
 		c.AuthorDate, _ = time.Parse(time.RFC3339, line)
 	case 5:
+		c.Committer.Name = line
+	case 6:
+		c.Committer.Email = line
+	case 7:
+		c.CommitDate, _ = time.Parse(time.RFC3339, line)
+	case 8:
 		// Parent hashes - merge commits have 2+ parents
 		parents := strings.Fields(line)
+		c.ParentCount = len(parents)
 		c.IsMerge = len(parents) >= 2
-	case 6:
+	case 9:
 		c.Subject = line
 		// Extract PR number and branch from merge commit message
 		if c.IsMerge {
@@ -195,6 +441,17 @@ func parseCommitLine(c *Commit, lineNum int, line string) {
 				c.MergeBranch = matches[1]
 			}
 		}
+	default:
+		// Body lines
+		c.Body += line + "\n"
+	}
+}
+
+// finishMessage trims the collected body and extracts body markers
+func finishMessage(c *Commit) {
+	c.Body = strings.TrimSpace(c.Body)
+	if matches := cherryPickRegex.FindStringSubmatch(c.Body); len(matches) >= 2 {
+		c.CherryPickOf = matches[1]
 	}
 }
 
@@ -205,6 +462,11 @@ func parseNumstat(line string) *FileChange {
 	}
 
 	fc := &FileChange{FilePath: parts[2]}
+	if oldPath, newPath, ok := parseRename(parts[2]); ok {
+		fc.FilePath = newPath
+		fc.OldPath = oldPath
+		fc.Status = FileRenamed
+	}
 
 	if parts[0] == "-" {
 		fc.IsBinary = true
@@ -216,47 +478,148 @@ func parseNumstat(line string) *FileChange {
 	return fc
 }
 
-// IsGitRepo checks if the path is a valid git repository
+// parseRename expands numstat rename paths like "old => new" or
+// "dir/{old => new}/file" into the old and new paths
+func parseRename(path string) (string, string, bool) {
+	open := strings.Index(path, "{")
+	end := strings.LastIndex(path, "}")
+	if open >= 0 && end > open {
+		inner := path[open+1 : end]
+		oldPart, newPart, ok := strings.Cut(inner, " => ")
+		if !ok {
+			return "", "", false
+		}
+		prefix, suffix := path[:open], path[end+1:]
+		return cleanRenamePath(prefix + oldPart + suffix), cleanRenamePath(prefix + newPart + suffix), true
+	}
+
+	oldPath, newPath, ok := strings.Cut(path, " => ")
+	return oldPath, newPath, ok
+}
+
+// cleanRenamePath removes the doubled slash left by an empty brace side
+func cleanRenamePath(path string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(path, "//", "/"), "/")
+}
+
+// parseSummary parses --summary lines like " create mode 100644 path/to/file"
+func parseSummary(line string) (string, FileStatus, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(trimmed) == len(line) {
+		return "", FileModified, false // summary lines are always indented
+	}
+
+	var status FileStatus
+	switch {
+	case strings.HasPrefix(trimmed, "create mode "):
+		status = FileCreated
+	case strings.HasPrefix(trimmed, "delete mode "):
+		status = FileDeleted
+	default:
+		return "", FileModified, false
+	}
+
+	// Fields: "create", "mode", "<mode>", "<path...>"
+	parts := strings.SplitN(trimmed, " ", 4)
+	if len(parts) < 4 {
+		return "", FileModified, false
+	}
+	return parts[3], status, true
+}
+
+// separateMergeChanges moves a merge's diff out of FileChanges, so the
+// branch's changes are not counted a second time as work of the merger
+func separateMergeChanges(c *Commit) {
+	if c.IsMerge {
+		c.MergeChanges = c.FileChanges
+		c.FileChanges = nil
+	}
+}
+
+// applyFileStatus marks the matching file change with its lifecycle status
+func applyFileStatus(c *Commit, path string, status FileStatus) {
+	for i := range c.FileChanges {
+		if c.FileChanges[i].FilePath == path {
+			c.FileChanges[i].Status = status
+			return
+		}
+	}
+}
+
+// IsGitRepo checks if the path is a valid git repository. Without a git
+// binary the repository is opened with go-git instead.
 func IsGitRepo(path string) bool {
 	cmd := exec.Command("git", "rev-parse", "--git-dir")
 	cmd.Dir = path
-	return cmd.Run() == nil
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		_, err = (&GoGitParser{RepoPath: path}).open()
+	}
+	return err == nil
 }
 
-// GetCodebaseSize returns total lines of code in the repository
-func GetCodebaseSize(repoPath string) (int, error) {
-	// Get list of tracked files and count lines
-	cmd := exec.Command("git", "ls-files")
-	cmd.Dir = repoPath
+// HasGitDir reports whether path is the top of a worktree, i.e. holds a
+// .git directory or file. Unlike IsGitRepo it runs no git process, so it is
+// cheap enough to check many directories.
+func HasGitDir(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
 
+// IsShallow reports whether the repository at path is a shallow clone, i.e.
+// has a shallow file in its git directory, so its history is cut off
+func IsShallow(path string) bool {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "shallow")
+	cmd.Dir = path
 	output, err := cmd.Output()
 	if err != nil {
-		return 0, err
+		return false
+	}
+	shallow := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(shallow) {
+		shallow = filepath.Join(path, shallow)
 	}
+	_, err = os.Stat(shallow)
+	return err == nil
+}
 
-	files := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(files) == 0 || (len(files) == 1 && files[0] == "") {
-		return 0, nil
+// Unshallow fetches the full history of a shallow clone
+func Unshallow(ctx context.Context, path string) error {
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--unshallow")
+	cmd.Dir = path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git fetch --unshallow: %s", strings.TrimSpace(string(output)))
 	}
+	return nil
+}
 
-	totalLines := 0
-	for _, file := range files {
-		if file == "" {
-			continue
-		}
-		// Count lines in file
-		wcCmd := exec.Command("wc", "-l", file)
-		wcCmd.Dir = repoPath
-		wcOutput, err := wcCmd.Output()
-		if err != nil {
-			continue // Skip files that can't be read
-		}
-		parts := strings.Fields(string(wcOutput))
-		if len(parts) > 0 {
-			lines, _ := strconv.Atoi(parts[0])
-			totalLines += lines
-		}
+// Fetch updates the remote-tracking branches of every remote, leaving the
+// worktree and local branches alone
+func Fetch(ctx context.Context, path string) error {
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--all", "--quiet")
+	cmd.Dir = path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git fetch: %s", strings.TrimSpace(string(output)))
 	}
+	return nil
+}
 
-	return totalLines, nil
+// RemoteURL returns the URL of the named remote, e.g. "origin"
+func RemoteURL(ctx context.Context, path, remote string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", remote)
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git remote get-url %s: %v", remote, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetCodebaseSize returns total lines of code in the repository
+func GetCodebaseSize(ctx context.Context, repoPath string) (int, error) {
+	scan, err := ScanCodebase(ctx, repoPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	return scan.Lines, nil
 }
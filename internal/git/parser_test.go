@@ -0,0 +1,175 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/gittest"
+)
+
+// formatCommits renders parsed commits for golden files, leaving out the
+// hashes so the files read as a history
+func formatCommits(commits []*Commit) string {
+	var sb strings.Builder
+	for _, c := range commits {
+		fmt.Fprintf(&sb, "%s\n", c.Subject)
+		fmt.Fprintf(&sb, "  author    %s <%s> %s\n", c.Author.Name, c.Author.Email, c.AuthorDate.UTC().Format(time.RFC3339))
+		if c.Committer != c.Author {
+			fmt.Fprintf(&sb, "  committer %s <%s>\n", c.Committer.Name, c.Committer.Email)
+		}
+		fmt.Fprintf(&sb, "  parents   %d\n", c.ParentCount)
+		if c.Body != "" {
+			fmt.Fprintf(&sb, "  body      %q\n", c.Body)
+		}
+		if c.IsMerge {
+			fmt.Fprintf(&sb, "  merge     pr=%d branch=%q\n", c.PRNumber, c.MergeBranch)
+		}
+		writeChanges(&sb, "change", c.FileChanges)
+		writeChanges(&sb, "merged", c.MergeChanges)
+		writeChanges(&sb, "generated", c.Generated)
+	}
+	return sb.String()
+}
+
+func writeChanges(sb *strings.Builder, label string, changes []FileChange) {
+	statuses := []string{"M", "A", "D", "R"}
+	for _, fc := range changes {
+		path := fc.FilePath
+		if fc.OldPath != "" {
+			path = fc.OldPath + " -> " + path
+		}
+		if fc.IsBinary {
+			fmt.Fprintf(sb, "  %-9s %s %s binary\n", label, statuses[fc.Status], path)
+			continue
+		}
+		fmt.Fprintf(sb, "  %-9s %s %s +%d -%d\n", label, statuses[fc.Status], path, fc.Additions, fc.Deletions)
+	}
+}
+
+func parseAll(t *testing.T, p Parser) []*Commit {
+	t.Helper()
+	var commits []*Commit
+	err := p.Parse(context.Background(), time.Time{}, time.Time{}, nil, func(c *Commit) {
+		commits = append(commits, c)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commits
+}
+
+// Both backends must parse the fixture into the same commits
+func TestParseProject(t *testing.T) {
+	repo := gittest.Project(t)
+
+	for _, backend := range Backends {
+		t.Run(backend, func(t *testing.T) {
+			parser := NewParser(backend, repo.Dir, ParseOptions{SkipGenerated: true})
+			gittest.Golden(t, "project", formatCommits(parseAll(t, parser)))
+		})
+	}
+}
+
+func TestParseProjectNoMerges(t *testing.T) {
+	repo := gittest.Project(t)
+
+	for _, backend := range Backends {
+		t.Run(backend, func(t *testing.T) {
+			parser := NewParser(backend, repo.Dir, ParseOptions{NoMerges: true, FirstParent: true})
+			commits := parseAll(t, parser)
+			err := parser.ParseMerges(context.Background(), time.Time{}, time.Time{}, func(c *Commit) {
+				commits = append(commits, c)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gittest.Golden(t, "project-first-parent", formatCommits(commits))
+		})
+	}
+}
+
+func TestEstimateCommitCount(t *testing.T) {
+	repo := gittest.Project(t)
+
+	for _, backend := range Backends {
+		t.Run(backend, func(t *testing.T) {
+			count, err := NewParser(backend, repo.Dir, ParseOptions{}).EstimateCommitCount(context.Background(), time.Time{}, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 10 {
+				t.Errorf("got %d commits, want 10", count)
+			}
+		})
+	}
+}
+
+func TestListTags(t *testing.T) {
+	repo := gittest.Project(t)
+
+	tags, err := ListTags(context.Background(), repo.Dir, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gittest.Start.Add(10 * gittest.Step)
+	if len(tags) != 1 || tags[0].Name != "v1.0.0" || !tags[0].Date.Equal(want) {
+		t.Errorf("got %v, want v1.0.0 at %s", tags, want)
+	}
+}
+
+// Bodies of any size and content stay inside their commit
+func TestParseLongBody(t *testing.T) {
+	repo := gittest.New(t)
+	repo.Write("a.txt", "a\n")
+	repo.Commit(gittest.Alice, "first")
+	repo.Write("a.txt", "b\n")
+	repo.Commit(gittest.Alice, "second\n\n"+strings.Repeat("x", 70*1024)+"\nCOMMIT_END\nCOMMIT_START")
+	repo.Write("a.txt", "c\n")
+	repo.Commit(gittest.Alice, "third")
+
+	for _, backend := range Backends {
+		t.Run(backend, func(t *testing.T) {
+			commits := parseAll(t, NewParser(backend, repo.Dir, ParseOptions{}))
+			var subjects []string
+			for _, c := range commits {
+				subjects = append(subjects, c.Subject)
+			}
+			if got := strings.Join(subjects, " "); got != "third second first" {
+				t.Fatalf("got commits %s, want third second first", got)
+			}
+			if body := commits[1].Body; !strings.HasSuffix(body, "COMMIT_END\nCOMMIT_START") || len(commits[1].FileChanges) != 1 {
+				t.Errorf("second commit has a %d byte body ending %q and %d changes", len(body), body[max(0, len(body)-24):], len(commits[1].FileChanges))
+			}
+		})
+	}
+}
+
+// A rescan through the cache parses the new commits only and stores them
+func TestParseCached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	repo := gittest.Project(t)
+
+	subjects := func() []string {
+		parser := NewExecParser(repo.Dir)
+		parser.Cache = OpenCommitCache(repo.Dir)
+		var subjects []string
+		for _, c := range parseAll(t, parser) {
+			subjects = append(subjects, c.Subject)
+		}
+		return subjects
+	}
+	if got := subjects(); len(got) != 10 {
+		t.Fatalf("first scan: got %d commits, want 10", len(got))
+	}
+	repo.Write("NEWS.md", "news\n")
+	repo.Commit(gittest.Bob, "Add the news")
+	if got := subjects(); len(got) != 11 || got[0] != "Add the news" {
+		t.Fatalf("rescan: got %q, want the new commit first of 11", got)
+	}
+	if cache := OpenCommitCache(repo.Dir); len(cache.file.Commits) != 11 {
+		t.Errorf("cache holds %d commits, want 11", len(cache.file.Commits))
+	}
+}
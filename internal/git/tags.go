@@ -0,0 +1,45 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Tag is a tag of the repository, usually marking a release
+type Tag struct {
+	Name string
+	Date time.Time // tagger date of annotated tags, committer date of the tagged commit otherwise
+}
+
+// ListTags returns the tags of the repository dated within since and until,
+// oldest first; zero times leave the range open
+func ListTags(ctx context.Context, repoPath string, since, until time.Time) ([]Tag, error) {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref",
+		"--format=%(refname:short)%09%(creatordate:iso-strict)", "refs/tags")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, date, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			continue
+		}
+		if (!since.IsZero() && at.Before(since)) || (!until.IsZero() && at.After(until)) {
+			continue
+		}
+		tags = append(tags, Tag{Name: name, Date: at})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Date.Before(tags[j].Date) })
+	return tags, nil
+}
@@ -4,15 +4,24 @@ import "time"
 
 // Commit represents a single parsed git commit
 type Commit struct {
-	Hash        string
-	ShortHash   string
-	Author      Author
-	AuthorDate  time.Time
-	Subject     string
-	FileChanges []FileChange
-	IsMerge     bool   // True if this is a merge commit
-	PRNumber    int    // PR number if extracted from merge message
-	MergeBranch string // Branch that was merged
+	Hash         string
+	ShortHash    string
+	Author       Author
+	AuthorDate   time.Time
+	Committer    Author    // differs from the author after a rebase, cherry-pick or web UI merge
+	CommitDate   time.Time // committer date
+	Subject      string
+	Body         string // message body after the subject, trimmed
+	CherryPickOf string // source hash from "(cherry picked from commit ...)"
+	FileChanges  []FileChange
+	MergeChanges []FileChange // merges only: diff against the first parent
+	Generated    []FileChange // changes to generated or vendored files, see ParseOptions.SkipGenerated
+	PatchID      string       // stable patch-id, see ParseOptions.WithPatchIDs; empty for merges
+	Labels       []string     // from the commit's gitstat note, see ReadLabels
+	IsMerge      bool         // True if this is a merge commit
+	ParentCount  int          // number of parents, 0 for root commits
+	PRNumber     int          // PR number if extracted from merge message
+	MergeBranch  string       // Branch that was merged
 }
 
 // Author represents commit author info
@@ -27,8 +36,20 @@ type FileChange struct {
 	Deletions int
 	FilePath  string
 	IsBinary  bool
+	Status    FileStatus // from --summary create/delete mode lines, or a rename
+	OldPath   string     // previous path for renamed files
 }
 
+// FileStatus describes what a commit did to a file
+type FileStatus int
+
+const (
+	FileModified FileStatus = iota
+	FileCreated
+	FileDeleted
+	FileRenamed
+)
+
 // ScanProgress reports parsing progress
 type ScanProgress struct {
 	CommitsParsed int
@@ -0,0 +1,187 @@
+// Package github fetches what the history does not record about pull
+// requests from the GitHub REST API: when they were opened, their reviews
+// and reviewers, and their labels
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+const (
+	defaultAPI = "https://api.github.com"
+	apiVersion = "2022-11-28"
+
+	// DefaultMaxPRs limits the pull requests fetched per scan when the
+	// configuration sets no limit
+	DefaultMaxPRs = 300
+)
+
+// ErrNotFound is returned for a pull request the repository does not have,
+// e.g. a number taken from a merge message of another repository
+var ErrNotFound = errors.New("not found")
+
+// Client reads pull requests with a token
+type Client struct {
+	api   string
+	host  string // web host of the repositories, github.com for the public API
+	token string
+	http  *http.Client
+}
+
+// NewClient creates a client for the API configured in cfg
+func NewClient(cfg *config.GitHub) *Client {
+	api := strings.TrimSuffix(cfg.URL, "/")
+	if api == "" {
+		api = defaultAPI
+	}
+	host := "github.com"
+	if u, err := url.Parse(api); err == nil && api != defaultAPI {
+		host = u.Hostname()
+	}
+	return &Client{api: api, host: host, token: cfg.Token, http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Repo returns the "owner/name" of a remote URL on the client's host, in
+// any of the forms git accepts: https://github.com/owner/name.git,
+// git@github.com:owner/name.git or ssh://git@github.com/owner/name. False
+// for remotes elsewhere.
+func (c *Client) Repo(remote string) (string, bool) {
+	var host, path string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if at, rest, ok := strings.Cut(remote, "@"); ok && !strings.Contains(at, "/") {
+		// scp-like syntax, user@host:path
+		host, path, _ = strings.Cut(rest, ":")
+	}
+	if !strings.EqualFold(host, c.host) {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return parts[0] + "/" + parts[1], true
+}
+
+// pullRequest is the part of a pull request the review data is taken from
+type pullRequest struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	User           struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// review is a review submitted on a pull request
+type review struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string     `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED or PENDING
+	SubmittedAt *time.Time `json:"submitted_at"`
+}
+
+// PullRequest fetches the review data of a pull request of repo, and the
+// hash of its merge commit. A pull request not merged on GitHub has none
+// and returns nil. Reviews by the author, such as replies to comments, and
+// pending reviews are left out.
+func (c *Client) PullRequest(ctx context.Context, repo string, number int) (*stats.PRReview, string, error) {
+	var pr pullRequest
+	path := fmt.Sprintf("/repos/%s/pulls/%d", repo, number)
+	if err := c.get(ctx, path, &pr); err != nil {
+		return nil, "", fmt.Errorf("%s#%d: %w", repo, number, err)
+	}
+	if pr.MergedAt == nil {
+		return nil, "", nil
+	}
+
+	// A pull request with more than 100 reviews is counted by its first 100
+	var reviews []review
+	if err := c.get(ctx, path+"/reviews?per_page=100", &reviews); err != nil {
+		return nil, "", fmt.Errorf("%s#%d reviews: %w", repo, number, err)
+	}
+
+	result := &stats.PRReview{
+		Repo:      repo,
+		Author:    pr.User.Login,
+		CreatedAt: pr.CreatedAt,
+		MergedAt:  *pr.MergedAt,
+	}
+	for _, label := range pr.Labels {
+		result.Labels = append(result.Labels, label.Name)
+	}
+	seen := make(map[string]bool)
+	for _, r := range reviews {
+		if r.SubmittedAt == nil || r.State == "PENDING" || r.User.Login == pr.User.Login {
+			continue
+		}
+		result.Reviews++
+		if r.State == "APPROVED" {
+			result.Approvals++
+		}
+		if result.FirstReviewAt.IsZero() || r.SubmittedAt.Before(result.FirstReviewAt) {
+			result.FirstReviewAt = *r.SubmittedAt
+		}
+		if !seen[r.User.Login] {
+			seen[r.User.Login] = true
+			result.Reviewers = append(result.Reviewers, r.User.Login)
+		}
+	}
+	return result, pr.MergeCommitSHA, nil
+}
+
+// get requests an API path, decoding the JSON response into result. Failed
+// requests report GitHub's error message, and when the rate limit is spent,
+// when it resets.
+func (c *Client) get(ctx context.Context, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.api+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode/100 != 2:
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			var reset int64
+			fmt.Sscan(resp.Header.Get("X-RateLimit-Reset"), &reset)
+			return fmt.Errorf("rate limit exceeded until %s", time.Unix(reset, 0).Format("15:04"))
+		}
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+)
+
+func TestRepo(t *testing.T) {
+	client := NewClient(&config.GitHub{})
+	for remote, want := range map[string]string{
+		"https://github.com/audi70r/gitstat.git":   "audi70r/gitstat",
+		"https://github.com/audi70r/gitstat":       "audi70r/gitstat",
+		"git@github.com:audi70r/gitstat.git":       "audi70r/gitstat",
+		"ssh://git@github.com:22/audi70r/gitstat":  "audi70r/gitstat",
+		"https://gitlab.com/audi70r/gitstat.git":   "",
+		"git@github.com:audi70r/gitstat/extra.git": "",
+		"/srv/git/gitstat":                         "",
+	} {
+		got, ok := client.Repo(remote)
+		if got != want || ok != (want != "") {
+			t.Errorf("Repo(%q) = %q, %v; want %q", remote, got, ok, want)
+		}
+	}
+
+	enterprise := NewClient(&config.GitHub{URL: "https://git.example.com/api/v3"})
+	if got, _ := enterprise.Repo("git@git.example.com:team/app.git"); got != "team/app" {
+		t.Errorf("enterprise Repo = %q, want team/app", got)
+	}
+}
+
+func TestPullRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"created_at": "2024-03-01T09:00:00Z", "merged_at": "2024-03-02T09:00:00Z",
+			"merge_commit_sha": "abc", "user": {"login": "ann"}, "labels": [{"name": "bug"}]}`))
+	})
+	mux.HandleFunc("/repos/o/r/pulls/7/reviews", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"user": {"login": "bob"}, "state": "COMMENTED", "submitted_at": "2024-03-01T15:00:00Z"},
+			{"user": {"login": "ann"}, "state": "COMMENTED", "submitted_at": "2024-03-01T10:00:00Z"},
+			{"user": {"login": "cid"}, "state": "PENDING"},
+			{"user": {"login": "cid"}, "state": "APPROVED", "submitted_at": "2024-03-01T12:00:00Z"},
+			{"user": {"login": "bob"}, "state": "APPROVED", "submitted_at": "2024-03-01T16:00:00Z"}
+		]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(&config.GitHub{URL: server.URL, Token: "secret"})
+	review, hash, err := client.PullRequest(context.Background(), "o/r", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "abc" || review.Author != "ann" || review.Reviews != 3 || review.Approvals != 2 {
+		t.Errorf("got hash %q, author %q, %d reviews, %d approvals", hash, review.Author, review.Reviews, review.Approvals)
+	}
+	if !slices.Equal(review.Reviewers, []string{"bob", "cid"}) || !slices.Equal(review.Labels, []string{"bug"}) {
+		t.Errorf("got reviewers %q, labels %q", review.Reviewers, review.Labels)
+	}
+	if wait, _ := review.TimeToFirstReview(); wait != 3*time.Hour || review.TimeToMerge() != 24*time.Hour {
+		t.Errorf("got first review after %v, merge after %v", wait, review.TimeToMerge())
+	}
+
+	if _, _, err := client.PullRequest(context.Background(), "o/r", 8); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing PR: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,53 @@
+package gittest
+
+import "testing"
+
+// Project builds a small history covering what the parsers and the
+// statistics have to handle:
+//
+//   - three authors, one commit by a committer other than its author
+//   - a feature branch merged with a pull request merge commit
+//   - a rename with an edit, a deletion and a binary file
+//   - a generated file marked in .gitattributes
+//   - messages with bodies, trailers, ticket references and a fixup
+//   - a tag on the last commit
+func Project(t testing.TB) *Repo {
+	t.Helper()
+	r := New(t)
+
+	r.Write("README.md", Lines("readme", 3))
+	r.Write("cmd/app/main.go", Lines("main", 10))
+	r.Commit(Alice, "Initial commit")
+
+	r.Write("cmd/app/main.go", Lines("main", 12))
+	r.Write("internal/lib/lib.go", Lines("lib", 20))
+	r.Commit(Bob, "feat(lib): add the library\n\nRefs JIRA-101\n\nSigned-off-by: Bob Builder <bob@example.com>")
+
+	r.Branch("feature")
+	r.Write("internal/lib/lib_test.go", Lines("test", 8))
+	r.Commit(Alice, "Add library tests")
+	r.Write("internal/lib/lib_test.go", Lines("test", 9))
+	r.Commit(Alice, "fixup! Add library tests")
+
+	r.Checkout("main")
+	r.Write("README.md", Lines("readme", 5))
+	r.Commit(Carol, "docs: explain the build (#12)")
+	r.Merge(Bob, "feature", "Merge pull request #7 from alice/feature")
+
+	r.Write(".gitattributes", "gen/* linguist-generated\n")
+	r.Write("gen/api.go", Lines("generated", 30))
+	r.CommitAs(Carol, Bob, "Generate the API client")
+
+	r.Rename("internal/lib/lib.go", "internal/core/core.go")
+	r.Write("internal/core/core.go", Lines("lib", 21))
+	r.Commit(Bob, "refactor: move lib to core")
+
+	r.Write("assets/logo.png", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x01")
+	r.Commit(Alice, "WIP logo")
+
+	r.Remove("README.md")
+	r.Commit(Carol, "Remove the README")
+	r.Tag("v1.0.0")
+
+	return r
+}
@@ -0,0 +1,185 @@
+// Package gittest builds small git repositories for tests: commits by
+// several authors at fixed times, branches and merges, renames, deletions
+// and binary files. The histories are deterministic, so the statistics
+// computed from them can be compared with golden files.
+package gittest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Person is the author or committer of a commit
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Authors of the fixture histories
+var (
+	Alice = Person{Name: "Alice Example", Email: "alice@example.com"}
+	Bob   = Person{Name: "Bob Builder", Email: "bob@example.com"}
+	Carol = Person{Name: "Carol Coder", Email: "carol@example.com"}
+)
+
+// Start is the time of the first commit of a repository; every commit
+// advances the clock by Step
+var (
+	Start = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	Step  = time.Hour
+)
+
+// Repo is a git repository under construction in a temporary directory.
+// Every method fails the test on an error.
+type Repo struct {
+	Dir string
+
+	t     testing.TB
+	clock time.Time
+}
+
+// New creates an empty repository on branch main, skipping the test when
+// git is not installed. The user's and the system's git configuration are
+// ignored for the rest of the test, by the code under test too, so their
+// settings don't change the results.
+func New(t testing.TB) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	r := &Repo{Dir: t.TempDir(), t: t, clock: Start}
+	r.Git("init", "--quiet", "--initial-branch=main")
+	r.Git("config", "commit.gpgsign", "false")
+	r.Git("config", "tag.gpgsign", "false")
+	return r
+}
+
+// Git runs a git command in the repository and returns its output,
+// trimmed
+func (r *Repo) Git(args ...string) string {
+	r.t.Helper()
+	return r.run(nil, args...)
+}
+
+func (r *Repo) run(env []string, args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// Write creates or overwrites a file of the worktree, creating its
+// directories
+func (r *Repo) Write(path, content string) {
+	r.t.Helper()
+	full := filepath.Join(r.Dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// Lines returns n numbered lines, prefixed with prefix, as file content
+func Lines(prefix string, n int) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		sb.WriteString(prefix)
+		sb.WriteString(" ")
+		sb.WriteString(strings.Repeat("x", i%7))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Remove deletes a file from the worktree and the index
+func (r *Repo) Remove(path string) {
+	r.t.Helper()
+	r.Git("rm", "--quiet", path)
+}
+
+// Rename moves a file with git mv
+func (r *Repo) Rename(from, to string) {
+	r.t.Helper()
+	full := filepath.Join(r.Dir, filepath.FromSlash(to))
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		r.t.Fatal(err)
+	}
+	r.Git("mv", from, to)
+}
+
+// At sets the time of the next commit; later ones follow it by Step
+func (r *Repo) At(t time.Time) {
+	r.clock = t
+}
+
+// Commit stages every change of the worktree and commits it by author at
+// the repository's clock, returning the commit's hash. The message may
+// carry a body after a blank line.
+func (r *Repo) Commit(author Person, message string) string {
+	r.t.Helper()
+	return r.CommitAs(author, author, message)
+}
+
+// CommitAs commits like Commit with a committer other than the author, as
+// after a rebase or cherry-pick
+func (r *Repo) CommitAs(author, committer Person, message string) string {
+	r.t.Helper()
+	r.Git("add", "--all")
+	r.run(r.identity(author, committer), "commit", "--quiet", "--allow-empty", "--no-verify", "-m", message)
+	return r.Git("rev-parse", "HEAD")
+}
+
+// Branch creates a branch at HEAD and checks it out
+func (r *Repo) Branch(name string) {
+	r.t.Helper()
+	r.Git("checkout", "--quiet", "-b", name)
+}
+
+// Checkout switches to an existing branch
+func (r *Repo) Checkout(name string) {
+	r.t.Helper()
+	r.Git("checkout", "--quiet", name)
+}
+
+// Merge merges branch into the current branch with a merge commit by
+// author, returning the merge's hash
+func (r *Repo) Merge(author Person, branch, message string) string {
+	r.t.Helper()
+	r.run(r.identity(author, author), "merge", "--quiet", "--no-ff", "--no-edit", "-m", message, branch)
+	return r.Git("rev-parse", "HEAD")
+}
+
+// Tag creates an annotated tag at HEAD, dated at the repository's clock
+func (r *Repo) Tag(name string) {
+	r.t.Helper()
+	r.run(r.identity(Alice, Alice), "tag", "-a", "-m", name, name)
+}
+
+// identity returns the environment dating a commit at the clock and
+// advances it
+func (r *Repo) identity(author, committer Person) []string {
+	date := r.clock.Format(time.RFC3339)
+	r.clock = r.clock.Add(Step)
+	return []string{
+		"GIT_AUTHOR_NAME=" + author.Name,
+		"GIT_AUTHOR_EMAIL=" + author.Email,
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_NAME=" + committer.Name,
+		"GIT_COMMITTER_EMAIL=" + committer.Email,
+		"GIT_COMMITTER_DATE=" + date,
+	}
+}
@@ -0,0 +1,35 @@
+package gittest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current output")
+
+// Golden compares got with testdata/<name>.golden of the package under
+// test. Run the tests with -update to write the file after an intended
+// change, then review the diff.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run the test with -update to create it", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s; run the test with -update and review the diff\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
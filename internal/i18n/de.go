@@ -0,0 +1,352 @@
+package i18n
+
+var german = &Locale{
+	Tag:          "de",
+	Name:         "Deutsch",
+	DateLayout:   "02.01.2006",
+	Decimal:      ",",
+	Group:        ".",
+	Months:       [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	Weekdays:     [7]string{"Mo", "Di", "Mi", "Do", "Fr", "Sa", "So"},
+	WeekdaysLong: [7]string{"Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag", "Sonntag"},
+
+	messages: map[string]string{
+		// Views
+		"Views":             "Ansichten",
+		"Leaderboard":       "Rangliste",
+		"Codebase":          "Codebasis",
+		"Timeline":          "Zeitverlauf",
+		"Work Hours":        "Arbeitszeiten",
+		"Top Files":         "Top-Dateien",
+		"Hotspots":          "Hotspots",
+		"Ownership":         "Zuständigkeit",
+		"Pull Requests":     "Pull-Requests",
+		"Authors":           "Autoren",
+		"Health":            "Zustand",
+		"Highlights":        "Meilensteine",
+		"Conventions":       "Konventionen",
+		"Commit Quality":    "Commit-Qualität",
+		"Architecture":      "Architektur",
+		"Commit Sizes":      "Commit-Größen",
+		"Backports":         "Backports",
+		"Issues":            "Issues",
+		"Debt Markers":      "Schuldmarker",
+		"Code Age":          "Code-Alter",
+		"Author Drill-down": "Autorendetails",
+		"Licenses":          "Lizenzen",
+		"Refactoring":       "Refactoring",
+		"Offboarding":       "Offboarding",
+		"Branching":         "Branching",
+		"Upstream":          "Upstream",
+		"Labels":            "Labels",
+		"Languages":         "Sprachen",
+		"Top Movers":        "Auf- und Absteiger",
+		"Trends":            "Trends",
+		"Query":             "Abfrage",
+		"Notifications":     "Meldungen",
+
+		// Key hints
+		"Focus":                  "Fokus",
+		"Navigate":               "Navigieren",
+		"Rescan":                 "Neu scannen",
+		"Quit":                   "Beenden",
+		"Sort":                   "Sortieren",
+		"Reverse":                "Umkehren",
+		"Select":                 "Markieren",
+		"Watch":                  "Beobachten",
+		"Exclude":                "Ausschließen",
+		"Export":                 "Exportieren",
+		"Open":                   "Öffnen",
+		"Merge":                  "Zusammenführen",
+		"Clear":                  "Leeren",
+		"Play/Pause":             "Abspielen/Pause",
+		"Export Chart":           "Diagramm exportieren",
+		"Compare Authors":        "Autoren vergleichen",
+		"Shorter Average":        "Kürzerer Schnitt",
+		"Longer Average":         "Längerer Schnitt",
+		"Full Screen":            "Vollbild",
+		"Scroll":                 "Blättern",
+		"Zoom":                   "Zoomen",
+		"Settings":               "Einstellungen",
+		"Timezone":               "Zeitzone",
+		"Rolling average (days)": "Gleitender Durchschnitt (Tage)",
+		"Churn half-life (days)": "Churn-Halbwertszeit (Tage)",
+		"The timezone and the churn half-life recompute the statistics from the commits of the last scan, without running git again. The rolling average only redraws the Timeline.": "Zeitzone und Churn-Halbwertszeit berechnen die Statistiken aus den Commits des letzten Scans neu, ohne git erneut auszuführen. Der gleitende Durchschnitt zeichnet nur die Zeitleiste neu.",
+		"Timezones are IANA names such as UTC or America/New_York, or Local.":                                                                                                        "Zeitzonen sind IANA-Namen wie UTC oder America/New_York, oder Local.",
+		"Unknown timezone: %s":                     "Unbekannte Zeitzone: %s",
+		"The rolling average needs at least 1 day": "Der gleitende Durchschnitt braucht mindestens 1 Tag",
+		"The churn half-life cannot be negative":   "Die Churn-Halbwertszeit darf nicht negativ sein",
+		"Recomputing statistics...":                "Statistiken werden neu berechnet...",
+		"Statistics not recomputed":                "Statistiken nicht neu berechnet",
+		"Statistics recomputed (%s)":               "Statistiken neu berechnet (%s)",
+		"Subdirectories":                           "Unterverzeichnisse",
+		"Parent":                                   "Übergeordnet",
+		"Earlier":                                  "Früher",
+		"Later":                                    "Später",
+		"Fewer Leavers":                            "Weniger Abgänge",
+		"More Leavers":                             "Mehr Abgänge",
+		"Toggle Matrix":                            "Matrix wechseln",
+		"Period":                                   "Zeitraum",
+		"Run":                                      "Ausführen",
+		"Menu":                                     "Menü",
+		"Toggle View":                              "Ansicht wechseln",
+		"Find":                                     "Suchen",
+		"Auto":                                     "Automatisch",
+		"Apply":                                    "Anwenden",
+		"Mailmap":                                  "Mailmap",
+		"Add repo":                                 "Repo hinzufügen",
+		"Remove":                                   "Entfernen",
+		"Scan":                                     "Scannen",
+		"Open folder":                              "Ordner öffnen",
+		"Close":                                    "Schließen",
+		"Go to":                                    "Gehe zu",
+		"Bookmarks":                                "Lesezeichen",
+		"Hidden":                                   "Versteckte",
+		"Numbers":                                  "Zahlen",
+		"Details":                                  "Details",
+		"Filter":                                   "Filtern",
+		"filter rows":                              "Zeilen filtern",
+		"Back":                                     "Zurück",
+		"Help":                                     "Hilfe",
+		"Global":                                   "Überall",
+
+		// Header and setup
+		"%s to %s":                                   "%s bis %s",
+		" - %d excluded":                             " - %d ausgeschlossen",
+		" - %d path globs":                           " - %d Pfadmuster",
+		"%s (%s) - %d commits by %d authors%s":       "%s (%s) - %d Commits von %d Autoren%s",
+		"GitStat - Git Repository Analyzer":          "GitStat - Analyse von Git-Repositorys",
+		"Select one or more repositories to analyze": "Wählen Sie ein oder mehrere Repositorys zur Analyse",
+		" Selected Repositories (%d) ":               " Ausgewählte Repositorys (%d) ",
+		"Date Range":                                 "Zeitraum",
+		"Since":                                      "Von",
+		"Until":                                      "Bis",
+		"Refs":                                       "Refs",
+		"Paths":                                      "Pfade",
+		"No merges":                                  "Ohne Merges",
+		"First parent":                               "Nur erster Elternteil",
+		"Add Repository":                             "Repository hinzufügen",
+		"Scan All":                                   "Alle scannen",
+		" (upstream)":                                " (Upstream)",
+		"Please add at least one repository":         "Bitte mindestens ein Repository hinzufügen",
+		"Not a git repo: %s":                         "Kein Git-Repository: %s",
+		"Invalid 'Since' date. Use YYYY-MM-DD":       "Ungültiges Von-Datum. Format JJJJ-MM-TT",
+		"Invalid 'Until' date. Use YYYY-MM-DD":       "Ungültiges Bis-Datum. Format JJJJ-MM-TT",
+		"'Until' must be after 'Since'":              "Das Bis-Datum muss nach dem Von-Datum liegen",
+		"Go up one directory":                        "Ein Verzeichnis nach oben",
+		"Directory":                                  "Verzeichnis",
+		"Directory with %d repositories":             "Verzeichnis mit %d Repositorys",
+		"%s to add this repo":                        "%s fügt dieses Repo hinzu",
+		"Merge Preview":                              "Vorschau der Zusammenführung",
+		"Author Details":                             "Autorendetails",
+		"Risk Details":                               "Risikodetails",
+		"Surviving Lines by Quarter Written":         "Erhaltene Zeilen nach Entstehungsquartal",
+		"Size Mix":                                   "Größenverteilung",
+		"Repository Conventions":                     "Konventionen des Repositorys",
+		"Pair Details":                               "Paardetails",
+		"Oldest Outstanding":                         "Älteste offene",
+		"File Details":                               "Dateidetails",
+		"Component Details":                          "Komponentendetails",
+		"Closed Issues":                              "Geschlossene Issues",
+		"Label Details":                              "Labeldetails",
+		"Language Details":                           "Sprachdetails",
+		"Missing Header":                             "Fehlender Header",
+		"Mover Details":                              "Details zur Veränderung",
+		"Directories at Risk":                        "Gefährdete Verzeichnisse",
+		"Directories":                                "Verzeichnisse",
+		"Ownership Details":                          "Eigentümerdetails",
+		"Summary":                                    "Übersicht",
+		"PR Details":                                 "PR-Details",
+		"Message Quality":                            "Nachrichtenqualität",
+		"By Author":                                  "Nach Autor",
+		"By Directory":                               "Nach Verzeichnis",
+		"Merge Authors":                              "Autoren zusammenführen",
+		"name or email fragment":                     "Teil von Name oder E-Mail",
+		"Daily Commits":                              "Commits pro Tag",
+		"Weekly Commits":                             "Commits pro Woche",
+		"Monthly Commits":                            "Commits pro Monat",
+		"Quarterly Commits":                          "Commits pro Quartal",
+		"shaded: days off":                           "schattiert: freie Tage",
+		"crunch":                                     "Crunch",
+		"spike":                                      "Spitze",
+		"dip":                                        "Einbruch",
+		"Not a directory: %s":                        "Kein Verzeichnis: %s",
+		"Select Repository":                          "Repository auswählen",
+		"(shallow clone)":                            "(flacher Klon)",
+		"(partial clone)":                            "(partieller Klon)",
+		"(sparse checkout)":                          "(Sparse-Checkout)",
+		"Fetch history":                              "Historie holen",
+		"Scan anyway":                                "Trotzdem scannen",
+		"Cancel":                                     "Abbrechen",
+		"Shallow clones: %s\n\nTheir history is cut off, so the statistics will be incomplete. Fetch the full history with git fetch --unshallow?": "Flache Klone: %s\n\nIhre Historie ist abgeschnitten, daher sind die Statistiken unvollständig. Vollständige Historie mit git fetch --unshallow holen?",
+		"Fetching full history...": "Vollständige Historie wird geholt...",
+
+		// Scan progress
+		"Scanning Repository":                             "Repository wird gescannt",
+		"[yellow]%d[-] / [yellow]%d[-] commits processed": "[yellow]%d[-] / [yellow]%d[-] Commits verarbeitet",
+		"[yellow]%d[-] commits processed":                 "[yellow]%d[-] Commits verarbeitet",
+		"Applying author merges...":                       "Autoren werden zusammengeführt...",
+		"No repositories selected":                        "Keine Repositorys ausgewählt",
+		"Not a git repository: %s":                        "Kein Git-Repository: %s",
+		"Starting scan... [gray](Esc to cancel)[-]":       "Scan startet... [gray](Esc bricht ab)[-]",
+		"Invalid license header pattern: %v":              "Ungültiges Muster für Lizenz-Header: %v",
+		"Scanning %s (%d/%d)...":                          "%s wird gescannt (%d/%d)...",
+		"[%s] Processing %s...":                           "[%s] Verarbeite %s...",
+		"Error in %s: %v":                                 "Fehler in %s: %v",
+		"Cannot record the scan: %v":                      "Scan kann nicht aufgezeichnet werden: %v",
+		"Detecting backports in %s (%d branches)...":      "Suche Backports in %s (%d Branches)...",
+		"Scanning previous period for %s...":              "Vorperiode von %s wird gescannt...",
+
+		// Notifications
+		"Merged %d author identities":            "%d Autorenidentitäten zusammengeführt",
+		"No chart to export":                     "Kein Diagramm zum Exportieren",
+		"Chart export failed":                    "Diagrammexport fehlgeschlagen",
+		"Wrote %s":                               "%s geschrieben",
+		"Codebase size incomplete":               "Codebasis-Größe unvollständig",
+		"Codebase size: %d lines (%s)":           "Codebasis-Größe: %d Zeilen (%s)",
+		", %d files checked for license headers": ", %d Dateien auf Lizenz-Header geprüft",
+		"Blame pass incomplete":                  "Blame-Durchlauf unvollständig",
+		"Blame pass: %d debt markers (%s)":       "Blame-Durchlauf: %d Schuldmarker (%s)",
+		"Code age sample incomplete":             "Code-Alter-Stichprobe unvollständig",
+		"Code age: %d of %d files blamed (%s)":   "Code-Alter: %d von %d Dateien per Blame untersucht (%s)",
+		"Sampling code age in %s...":             "Code-Alter in %s wird ermittelt...",
+		"GitHub reviews incomplete":              "GitHub-Reviews unvollständig",
+		"GitHub: reviews of %d pull requests from %s, %d newly asked (%s)":          "GitHub: Reviews von %d Pull Requests aus %s, %d neu abgefragt (%s)",
+		"Fetching pull request %d of %d from GitHub...":                             "Pull Request %d von %d wird von GitHub geladen...",
+		"Scan not recorded in the trends":                                           "Scan nicht in den Trends gespeichert",
+		", %d files outside the sparse checkout skipped":                            ", %d Dateien außerhalb des Sparse-Checkouts übersprungen",
+		", %d unattributed: history missing from the partial clone":                 ", %d nicht zugeordnet: Historie fehlt im partiellen Klon",
+		"No notifications yet. Background operations report here when they finish.": "Noch keine Meldungen. Hintergrundvorgänge melden sich hier, sobald sie fertig sind.",
+		"%d notifications": "%d Meldungen",
+		"Time":             "Zeit",
+		"Message":          "Meldung",
+
+		// Command palette and macros
+		"Commands":                         "Befehle",
+		"command or macro":                 "Befehl oder Makro",
+		"Macro":                            "Makro",
+		"Macro %s finished":                "Makro %s abgeschlossen",
+		"Report published to %s":           "Bericht in %s veröffentlicht",
+		"Macro %s stopped at step %d":      "Makro %s bei Schritt %d abgebrochen",
+		"the scan did not start":           "der Scan wurde nicht gestartet",
+		"nothing scanned yet":              "noch nichts gescannt",
+		"no report exported yet":           "noch kein Bericht exportiert",
+		"%s is not available in this view": "%s ist in dieser Ansicht nicht verfügbar",
+
+		// Batch actions
+		"files":                            "Dateien",
+		"directories":                      "Verzeichnisse",
+		"authors":                          "Autoren",
+		"%d %s added to the watchlist":     "%d %s zur Beobachtungsliste hinzugefügt",
+		"%d %s removed from the watchlist": "%d %s von der Beobachtungsliste entfernt",
+		"Exported %d %s to %s":             "%d %s nach %s exportiert",
+		"none of the files exist in the worktree": "keine der Dateien existiert im Arbeitsverzeichnis",
+		"Opened %d files in %s":                   "%d Dateien in %s geöffnet",
+
+		// Codebase
+		"calculating...":    "wird berechnet...",
+		"[cyan]%s[-] lines": "[cyan]%s[-] Zeilen",
+		"(approximate: %d files outside the sparse checkout)":                       "(ungefähr: %d Dateien außerhalb des Sparse-Checkouts)",
+		"%d files outside the sparse checkout not checked":                          "%d Dateien außerhalb des Sparse-Checkouts nicht geprüft",
+		"ages approximate: %d unattributed, history missing from the partial clone": "Alter ungefähr: %d nicht zugeordnet, Historie fehlt im partiellen Klon",
+
+		// Table columns
+		"Author":          "Autor",
+		"Commits":         "Commits",
+		"Churn":           "Churn",
+		"Bus Factor":      "Bus-Faktor",
+		"Component":       "Komponente",
+		"Score":           "Wert",
+		"Weight":          "Gewicht",
+		"Measured":        "Gemessen",
+		"PR Size":         "PR-Größe",
+		"Contributors":    "Mitwirkende",
+		"off":             "aus",
+		"Active Authors":  "Aktive Autoren",
+		"Hours":           "Stunden",
+		"Hrs/Wk":          "Std/Wo",
+		"Additions":       "Hinzugefügt",
+		"Deletions":       "Entfernt",
+		"Net":             "Netto",
+		"Files":           "Dateien",
+		"Activity":        "Aktivität",
+		"File":            "Datei",
+		"Changes":         "Änderungen",
+		"Touches":         "Berührungen",
+		"Complexity":      "Komplexität",
+		"+Lines":          "+Zeilen",
+		"-Lines":          "-Zeilen",
+		"Churn%":          "Churn%",
+		"Risk":            "Risiko",
+		"Trend":           "Trend",
+		"Merges":          "Merges",
+		"PRs":             "PRs",
+		"PR":              "PR",
+		"Branch":          "Branch",
+		"Merged By":       "Gemergt von",
+		"Size":            "Größe",
+		"Date":            "Datum",
+		"Milestone":       "Meilenstein",
+		"Markers":         "Marker",
+		"Oldest":          "Ältester",
+		"Median Age":      "Medianalter",
+		"Lines":           "Zeilen",
+		"Large%":          "Groß%",
+		"Lines p50/90/99": "Zeilen p50/90/99",
+		"Conventional":    "Konventionell",
+		"Tickets":         "Tickets",
+		"Body":            "Text",
+		"Length p50/90":   "Länge p50/90",
+		"Files p50/90/99": "Dateien p50/90/99",
+		"Trivial":         "Trivial",
+		"Small":           "Klein",
+		"Medium":          "Mittel",
+		"Large":           "Groß",
+		"Huge":            "Riesig",
+		"Refactor":        "Refactor",
+		"Share":           "Anteil",
+		"Label":           "Label",
+		"Last":            "Zuletzt",
+		"First":           "Zuerst",
+		"Upstreamed":      "Upstream",
+		"Fork-only":       "Nur Fork",
+		"Total":           "Gesamt",
+		"Upstreamed%":     "Upstream%",
+		"Inactive":        "Inaktiv",
+		"Dirs":            "Verz.",
+		"Top Share":       "Max. Anteil",
+		"Directory A":     "Verzeichnis A",
+		"Directory B":     "Verzeichnis B",
+		"Shared":          "Gemeinsam",
+		"Coupling":        "Kopplung",
+		"Boundary":        "Grenze",
+		"File A":          "Datei A",
+		"File B":          "Datei B",
+		"Kinds":           "Arten",
+		"Flag":            "Hinweis",
+		"Emoji%":          "Emoji%",
+		"Gitmoji":         "Gitmoji",
+		"Language":        "Sprache",
+		"Extension":       "Endung",
+		"Move":            "Bewegung",
+		"Kind":            "Art",
+		"Lost":            "Verloren",
+		"Remaining":       "Verbleibend",
+		"Owners":          "Besitzer",
+		"Rank":            "Rang",
+		"Prev":            "Vorher",
+		"Prev Churn":      "Churn vorher",
+		"Risers":          "Aufsteiger",
+		"Fallers":         "Absteiger",
+		"file":            "Datei",
+		"dir":             "Verz.",
+		"Adherence":       "Einhaltung",
+		"With Header":     "Mit Header",
+		"Missing":         "Fehlend",
+		"Compliance":      "Konformität",
+		"Cherry-picks":    "Cherry-Picks",
+		"Patch Dupes":     "Patch-Duplikate",
+		"Backported":      "Zurückportiert",
+		"Backport%":       "Backport%",
+	},
+}
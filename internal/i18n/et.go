@@ -0,0 +1,352 @@
+package i18n
+
+var estonian = &Locale{
+	Tag:          "et",
+	Name:         "Eesti",
+	DateLayout:   "02.01.2006",
+	Decimal:      ",",
+	Group:        "\u00a0", // no-break space
+	Months:       [12]string{"jaan", "veebr", "märts", "apr", "mai", "juuni", "juuli", "aug", "sept", "okt", "nov", "dets"},
+	Weekdays:     [7]string{"E", "T", "K", "N", "R", "L", "P"},
+	WeekdaysLong: [7]string{"esmaspäev", "teisipäev", "kolmapäev", "neljapäev", "reede", "laupäev", "pühapäev"},
+
+	messages: map[string]string{
+		// Views
+		"Views":             "Vaated",
+		"Leaderboard":       "Edetabel",
+		"Codebase":          "Koodibaas",
+		"Timeline":          "Ajajoon",
+		"Work Hours":        "Tööajad",
+		"Top Files":         "Enim muudetud",
+		"Hotspots":          "Kuumad kohad",
+		"Ownership":         "Omanikud",
+		"Pull Requests":     "Tõmbetaotlused",
+		"Authors":           "Autorid",
+		"Health":            "Tervis",
+		"Highlights":        "Verstapostid",
+		"Conventions":       "Tavad",
+		"Commit Quality":    "Commitide kvaliteet",
+		"Architecture":      "Arhitektuur",
+		"Commit Sizes":      "Commitide maht",
+		"Backports":         "Tagasiportimine",
+		"Issues":            "Piletid",
+		"Debt Markers":      "Võla märgid",
+		"Code Age":          "Koodi vanus",
+		"Author Drill-down": "Autori üksikasjad",
+		"Licenses":          "Litsentsid",
+		"Refactoring":       "Refaktooring",
+		"Offboarding":       "Lahkujad",
+		"Branching":         "Harud",
+		"Upstream":          "Ülemallikas",
+		"Labels":            "Sildid",
+		"Languages":         "Keeled",
+		"Top Movers":        "Tõusjad ja langejad",
+		"Trends":            "Trendid",
+		"Query":             "Päring",
+		"Notifications":     "Teated",
+
+		// Key hints
+		"Focus":                  "Fookus",
+		"Navigate":               "Liigu",
+		"Rescan":                 "Skanni uuesti",
+		"Quit":                   "Välju",
+		"Sort":                   "Sordi",
+		"Reverse":                "Pööra",
+		"Select":                 "Märgi",
+		"Watch":                  "Jälgi",
+		"Exclude":                "Välista",
+		"Export":                 "Ekspordi",
+		"Open":                   "Ava",
+		"Merge":                  "Ühenda",
+		"Clear":                  "Tühjenda",
+		"Play/Pause":             "Esita/Paus",
+		"Export Chart":           "Ekspordi diagramm",
+		"Compare Authors":        "Võrdle autoreid",
+		"Shorter Average":        "Lühem keskmine",
+		"Longer Average":         "Pikem keskmine",
+		"Full Screen":            "Täisekraan",
+		"Scroll":                 "Keri",
+		"Zoom":                   "Suumi",
+		"Settings":               "Seaded",
+		"Timezone":               "Ajavöönd",
+		"Rolling average (days)": "Libisev keskmine (päeva)",
+		"Churn half-life (days)": "Muutuste poolestusaeg (päeva)",
+		"The timezone and the churn half-life recompute the statistics from the commits of the last scan, without running git again. The rolling average only redraws the Timeline.": "Ajavöönd ja muutuste poolestusaeg arvutavad statistika viimase skannimise kommititest uuesti, ilma gitti uuesti käivitamata. Libisev keskmine joonistab uuesti ainult ajajoone.",
+		"Timezones are IANA names such as UTC or America/New_York, or Local.":                                                                                                        "Ajavööndid on IANA nimed nagu UTC või America/New_York, või Local.",
+		"Unknown timezone: %s":                     "Tundmatu ajavöönd: %s",
+		"The rolling average needs at least 1 day": "Libisev keskmine vajab vähemalt 1 päeva",
+		"The churn half-life cannot be negative":   "Muutuste poolestusaeg ei saa olla negatiivne",
+		"Recomputing statistics...":                "Statistika arvutatakse uuesti...",
+		"Statistics not recomputed":                "Statistikat ei arvutatud uuesti",
+		"Statistics recomputed (%s)":               "Statistika arvutati uuesti (%s)",
+		"Subdirectories":                           "Alamkataloogid",
+		"Parent":                                   "Ülemkataloog",
+		"Earlier":                                  "Varem",
+		"Later":                                    "Hiljem",
+		"Fewer Leavers":                            "Vähem lahkujaid",
+		"More Leavers":                             "Rohkem lahkujaid",
+		"Toggle Matrix":                            "Vaheta maatriksit",
+		"Period":                                   "Periood",
+		"Run":                                      "Käivita",
+		"Menu":                                     "Menüü",
+		"Toggle View":                              "Vaheta vaadet",
+		"Find":                                     "Otsi",
+		"Auto":                                     "Automaatne",
+		"Apply":                                    "Rakenda",
+		"Mailmap":                                  "Mailmap",
+		"Add repo":                                 "Lisa hoidla",
+		"Remove":                                   "Eemalda",
+		"Scan":                                     "Skanni",
+		"Open folder":                              "Ava kaust",
+		"Close":                                    "Sulge",
+		"Go to":                                    "Mine",
+		"Bookmarks":                                "Järjehoidjad",
+		"Hidden":                                   "Peidetud",
+		"Numbers":                                  "Arvud",
+		"Details":                                  "Üksikasjad",
+		"Filter":                                   "Filtreeri",
+		"filter rows":                              "filtreeri ridu",
+		"Back":                                     "Tagasi",
+		"Help":                                     "Abi",
+		"Global":                                   "Kõikjal",
+
+		// Header and setup
+		"%s to %s":                                   "%s kuni %s",
+		" - %d excluded":                             " - %d välistatud",
+		" - %d path globs":                           " - %d teemustrit",
+		"%s (%s) - %d commits by %d authors%s":       "%s (%s) - %d commiti, %d autorit%s",
+		"GitStat - Git Repository Analyzer":          "GitStat - Giti hoidlate analüüs",
+		"Select one or more repositories to analyze": "Vali analüüsiks üks või mitu hoidlat",
+		" Selected Repositories (%d) ":               " Valitud hoidlad (%d) ",
+		"Date Range":                                 "Ajavahemik",
+		"Since":                                      "Alates",
+		"Until":                                      "Kuni",
+		"Refs":                                       "Viited",
+		"Paths":                                      "Teed",
+		"No merges":                                  "Ilma mestimisteta",
+		"First parent":                               "Ainult esimene vanem",
+		"Add Repository":                             "Lisa hoidla",
+		"Scan All":                                   "Skanni kõik",
+		" (upstream)":                                " (ülemallikas)",
+		"Please add at least one repository":         "Lisa vähemalt üks hoidla",
+		"Not a git repo: %s":                         "Pole Giti hoidla: %s",
+		"Invalid 'Since' date. Use YYYY-MM-DD":       "Vigane alguskuupäev. Kasuta AAAA-KK-PP",
+		"Invalid 'Until' date. Use YYYY-MM-DD":       "Vigane lõppkuupäev. Kasuta AAAA-KK-PP",
+		"'Until' must be after 'Since'":              "Lõppkuupäev peab olema pärast alguskuupäeva",
+		"Go up one directory":                        "Üks kaust üles",
+		"Directory":                                  "Kaust",
+		"Directory with %d repositories":             "Kaust, milles on %d hoidlat",
+		"%s to add this repo":                        "%s lisab selle hoidla",
+		"Merge Preview":                              "Ühendamise eelvaade",
+		"Author Details":                             "Autori üksikasjad",
+		"Risk Details":                               "Riski üksikasjad",
+		"Surviving Lines by Quarter Written":         "Säilinud read kirjutamise kvartali järgi",
+		"Size Mix":                                   "Suuruste jaotus",
+		"Repository Conventions":                     "Hoidla tavad",
+		"Pair Details":                               "Paari üksikasjad",
+		"Oldest Outstanding":                         "Vanimad lahendamata",
+		"File Details":                               "Faili üksikasjad",
+		"Component Details":                          "Komponendi üksikasjad",
+		"Closed Issues":                              "Suletud probleemid",
+		"Label Details":                              "Sildi üksikasjad",
+		"Language Details":                           "Keele üksikasjad",
+		"Missing Header":                             "Päis puudub",
+		"Mover Details":                              "Muutuse üksikasjad",
+		"Directories at Risk":                        "Ohustatud kaustad",
+		"Directories":                                "Kaustad",
+		"Ownership Details":                          "Omandi üksikasjad",
+		"Summary":                                    "Kokkuvõte",
+		"PR Details":                                 "PR-i üksikasjad",
+		"Message Quality":                            "Sõnumite kvaliteet",
+		"By Author":                                  "Autori järgi",
+		"By Directory":                               "Kausta järgi",
+		"Merge Authors":                              "Ühenda autorid",
+		"name or email fragment":                     "nime või e-posti osa",
+		"Daily Commits":                              "Commitid päevas",
+		"Weekly Commits":                             "Commitid nädalas",
+		"Monthly Commits":                            "Commitid kuus",
+		"Quarterly Commits":                          "Commitid kvartalis",
+		"shaded: days off":                           "varjutatud: vabad päevad",
+		"crunch":                                     "ületunnid",
+		"spike":                                      "tipp",
+		"dip":                                        "langus",
+		"Not a directory: %s":                        "Pole kaust: %s",
+		"Select Repository":                          "Vali hoidla",
+		"(shallow clone)":                            "(madal kloon)",
+		"(partial clone)":                            "(osaline kloon)",
+		"(sparse checkout)":                          "(hõre väljavõte)",
+		"Fetch history":                              "Too ajalugu",
+		"Scan anyway":                                "Skanni ikkagi",
+		"Cancel":                                     "Loobu",
+		"Shallow clones: %s\n\nTheir history is cut off, so the statistics will be incomplete. Fetch the full history with git fetch --unshallow?": "Madalad kloonid: %s\n\nNende ajalugu on kärbitud, seega on statistika puudulik. Kas tuua kogu ajalugu käsuga git fetch --unshallow?",
+		"Fetching full history...": "Kogu ajaloo toomine...",
+
+		// Scan progress
+		"Scanning Repository":                             "Hoidla skannimine",
+		"[yellow]%d[-] / [yellow]%d[-] commits processed": "[yellow]%d[-] / [yellow]%d[-] commiti töödeldud",
+		"[yellow]%d[-] commits processed":                 "[yellow]%d[-] commiti töödeldud",
+		"Applying author merges...":                       "Autorite ühendamine...",
+		"No repositories selected":                        "Ühtegi hoidlat pole valitud",
+		"Not a git repository: %s":                        "Pole Giti hoidla: %s",
+		"Starting scan... [gray](Esc to cancel)[-]":       "Skannimine algab... [gray](Esc katkestab)[-]",
+		"Invalid license header pattern: %v":              "Vigane litsentsipäise muster: %v",
+		"Scanning %s (%d/%d)...":                          "Skannin %s (%d/%d)...",
+		"[%s] Processing %s...":                           "[%s] Töötlen %s...",
+		"Error in %s: %v":                                 "Viga hoidlas %s: %v",
+		"Cannot record the scan: %v":                      "Skannimist ei saa salvestada: %v",
+		"Detecting backports in %s (%d branches)...":      "Otsin tagasiporte hoidlas %s (%d haru)...",
+		"Scanning previous period for %s...":              "Skannin hoidla %s eelmist perioodi...",
+
+		// Notifications
+		"Merged %d author identities":            "Ühendati %d autori identiteeti",
+		"No chart to export":                     "Pole diagrammi, mida eksportida",
+		"Chart export failed":                    "Diagrammi eksport ebaõnnestus",
+		"Wrote %s":                               "Kirjutati %s",
+		"Codebase size incomplete":               "Koodibaasi maht on puudulik",
+		"Codebase size: %d lines (%s)":           "Koodibaasi maht: %d rida (%s)",
+		", %d files checked for license headers": ", %d faili litsentsipäis kontrollitud",
+		"Blame pass incomplete":                  "Blame-läbivaatus on puudulik",
+		"Blame pass: %d debt markers (%s)":       "Blame-läbivaatus: %d võla märki (%s)",
+		"Code age sample incomplete":             "Koodi vanuse valim on puudulik",
+		"Code age: %d of %d files blamed (%s)":   "Koodi vanus: %d faili %d-st läbi vaadatud (%s)",
+		"Sampling code age in %s...":             "Koodi vanuse valimi võtmine: %s...",
+		"GitHub reviews incomplete":              "GitHubi ülevaatused on puudulikud",
+		"GitHub: reviews of %d pull requests from %s, %d newly asked (%s)":          "GitHub: %d pull requesti ülevaatused allikast %s, %d uut päringut (%s)",
+		"Fetching pull request %d of %d from GitHub...":                             "Pull requesti %d/%d laadimine GitHubist...",
+		"Scan not recorded in the trends":                                           "Skannimist ei salvestatud trendidesse",
+		", %d files outside the sparse checkout skipped":                            ", %d faili väljaspool hõredat väljavõtet vahele jäetud",
+		", %d unattributed: history missing from the partial clone":                 ", %d omistamata: ajalugu puudub osalisest kloonist",
+		"No notifications yet. Background operations report here when they finish.": "Teateid veel pole. Taustatoimingud annavad siin lõpetamisest teada.",
+		"%d notifications": "%d teadet",
+		"Time":             "Aeg",
+		"Message":          "Teade",
+
+		// Command palette and macros
+		"Commands":                         "Käsud",
+		"command or macro":                 "käsk või makro",
+		"Macro":                            "Makro",
+		"Macro %s finished":                "Makro %s lõpetas",
+		"Report published to %s":           "Aruanne avaldatud: %s",
+		"Macro %s stopped at step %d":      "Makro %s peatus sammul %d",
+		"the scan did not start":           "skannimine ei alanud",
+		"nothing scanned yet":              "midagi pole veel skannitud",
+		"no report exported yet":           "aruannet pole veel eksporditud",
+		"%s is not available in this view": "%s pole selles vaates saadaval",
+
+		// Batch actions
+		"files":                            "faili",
+		"directories":                      "kausta",
+		"authors":                          "autorit",
+		"%d %s added to the watchlist":     "%d %s lisati jälgimisnimekirja",
+		"%d %s removed from the watchlist": "%d %s eemaldati jälgimisnimekirjast",
+		"Exported %d %s to %s":             "%d %s eksporditi faili %s",
+		"none of the files exist in the worktree": "ühtegi faili pole töökaustas",
+		"Opened %d files in %s":                   "%d faili avati redaktoris %s",
+
+		// Codebase
+		"calculating...":    "arvutan...",
+		"[cyan]%s[-] lines": "[cyan]%s[-] rida",
+		"(approximate: %d files outside the sparse checkout)":                       "(ligikaudne: %d faili väljaspool hõredat väljavõtet)",
+		"%d files outside the sparse checkout not checked":                          "%d faili väljaspool hõredat väljavõtet kontrollimata",
+		"ages approximate: %d unattributed, history missing from the partial clone": "vanused ligikaudsed: %d omistamata, ajalugu puudub osalisest kloonist",
+
+		// Table columns
+		"Author":          "Autor",
+		"Commits":         "Commitid",
+		"Churn":           "Muutused",
+		"Bus Factor":      "Bussifaktor",
+		"Component":       "Komponent",
+		"Score":           "Skoor",
+		"Weight":          "Kaal",
+		"Measured":        "Mõõdetud",
+		"PR Size":         "PR-i suurus",
+		"Contributors":    "Kaastöötajad",
+		"off":             "väljas",
+		"Active Authors":  "Aktiivsed autorid",
+		"Hours":           "Tunnid",
+		"Hrs/Wk":          "T/näd",
+		"Additions":       "Lisatud",
+		"Deletions":       "Kustutatud",
+		"Net":             "Neto",
+		"Files":           "Failid",
+		"Activity":        "Aktiivsus",
+		"File":            "Fail",
+		"Changes":         "Muudatused",
+		"Touches":         "Puuted",
+		"Complexity":      "Keerukus",
+		"+Lines":          "+Read",
+		"-Lines":          "-Read",
+		"Churn%":          "Muutlikkus%",
+		"Risk":            "Risk",
+		"Trend":           "Suund",
+		"Merges":          "Ühendamised",
+		"PRs":             "Taotlused",
+		"PR":              "Taotlus",
+		"Branch":          "Haru",
+		"Merged By":       "Ühendaja",
+		"Size":            "Maht",
+		"Date":            "Kuupäev",
+		"Milestone":       "Verstapost",
+		"Markers":         "Märgid",
+		"Oldest":          "Vanim",
+		"Median Age":      "Mediaanvanus",
+		"Lines":           "Read",
+		"Large%":          "Suur%",
+		"Lines p50/90/99": "Read p50/90/99",
+		"Conventional":    "Konventsionaalne",
+		"Tickets":         "Piletid",
+		"Body":            "Sisu",
+		"Length p50/90":   "Pikkus p50/90",
+		"Files p50/90/99": "Failid p50/90/99",
+		"Trivial":         "Tühine",
+		"Small":           "Väike",
+		"Medium":          "Keskmine",
+		"Large":           "Suur",
+		"Huge":            "Hiiglaslik",
+		"Refactor":        "Refaktor",
+		"Share":           "Osa",
+		"Label":           "Silt",
+		"Last":            "Viimane",
+		"First":           "Esimene",
+		"Upstreamed":      "Ülemallikas",
+		"Fork-only":       "Ainult harus",
+		"Total":           "Kokku",
+		"Upstreamed%":     "Ülemallikas%",
+		"Inactive":        "Eemal",
+		"Dirs":            "Kaustad",
+		"Top Share":       "Suurim osa",
+		"Directory A":     "Kaust A",
+		"Directory B":     "Kaust B",
+		"Shared":          "Ühised",
+		"Coupling":        "Sidusus",
+		"Boundary":        "Piir",
+		"File A":          "Fail A",
+		"File B":          "Fail B",
+		"Kinds":           "Liigid",
+		"Flag":            "Märge",
+		"Emoji%":          "Emoji%",
+		"Gitmoji":         "Gitmoji",
+		"Language":        "Keel",
+		"Extension":       "Laiend",
+		"Move":            "Liikumine",
+		"Kind":            "Liik",
+		"Lost":            "Kaotatud",
+		"Remaining":       "Alles",
+		"Owners":          "Omanikud",
+		"Rank":            "Koht",
+		"Prev":            "Eelmine",
+		"Prev Churn":      "Eelmine churn",
+		"Risers":          "Tõusjad",
+		"Fallers":         "Langejad",
+		"file":            "fail",
+		"dir":             "kaust",
+		"Adherence":       "Järgimine",
+		"With Header":     "Päisega",
+		"Missing":         "Puudub",
+		"Compliance":      "Vastavus",
+		"Cherry-picks":    "Cherry-pickid",
+		"Patch Dupes":     "Paiga koopiad",
+		"Backported":      "Tagasiporditud",
+		"Backport%":       "Tagasiport%",
+	},
+}
@@ -0,0 +1,169 @@
+// Package i18n translates user-facing strings and formats dates and numbers
+// for the selected locale.
+//
+// Messages are keyed by their English text, so a string missing from a
+// catalog falls back to English. Format strings are translated before their
+// arguments are substituted.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the message catalog and formatting conventions of a language
+type Locale struct {
+	Tag  string // language code, e.g. "de"
+	Name string // language name in the language itself
+
+	DateLayout   string // time.Format layout of a calendar date
+	Decimal      string // decimal separator
+	Group        string // thousands separator
+	Months       [12]string
+	Weekdays     [7]string // abbreviated, Monday first
+	WeekdaysLong [7]string // Monday first
+
+	messages map[string]string
+}
+
+var english = &Locale{
+	Tag:          "en",
+	Name:         "English",
+	DateLayout:   "2006-01-02",
+	Decimal:      ".",
+	Group:        ",",
+	Months:       [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:     [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+	WeekdaysLong: [7]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
+}
+
+// Available locales by tag
+var locales = map[string]*Locale{
+	"en": english,
+	"de": german,
+	"et": estonian,
+}
+
+var current = english
+
+// Tags returns the tags of the available locales, sorted
+func Tags() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Use selects the locale for tag, e.g. "de" or "de_DE.UTF-8". An empty tag
+// selects the locale of the environment. An unknown language selects
+// English and reports false, unless it came from the environment.
+func Use(tag string) bool {
+	detected := tag == ""
+	if detected {
+		tag = Detect()
+	}
+	locale, ok := locales[language(tag)]
+	if !ok {
+		current = english
+		return detected
+	}
+	current = locale
+	return true
+}
+
+// Detect returns the language of the environment's message locale, checking
+// LC_ALL, LC_MESSAGES and LANG in the order the C library does
+func Detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return language(value)
+		}
+	}
+	return ""
+}
+
+// language strips the territory, encoding and modifier of a POSIX locale
+// name: "de_DE.UTF-8@euro" -> "de"
+func language(tag string) string {
+	if i := strings.IndexAny(tag, "_-.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ToLower(tag)
+}
+
+// Current returns the selected locale
+func Current() *Locale {
+	return current
+}
+
+// T translates message and, given arguments, formats it like fmt.Sprintf
+func T(message string, args ...any) string {
+	if translated, ok := current.messages[message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Date formats the calendar date of t
+func Date(t time.Time) string {
+	return t.Format(current.DateLayout)
+}
+
+// Month returns the abbreviated name of m
+func Month(m time.Month) string {
+	return current.Months[m-1]
+}
+
+// Weekday returns the abbreviated name of the day, Monday being 0
+func Weekday(day int) string {
+	return current.Weekdays[day]
+}
+
+// WeekdayLong returns the full name of the day, Monday being 0
+func WeekdayLong(day int) string {
+	return current.WeekdaysLong[day]
+}
+
+// Float formats f with prec decimals and the locale's decimal separator
+func Float(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if current.Decimal != "." {
+		s = strings.Replace(s, ".", current.Decimal, 1)
+	}
+	return s
+}
+
+// Int formats n with the locale's thousands separator
+func Int(n int) string {
+	s := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	var sb strings.Builder
+	for i, digit := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			sb.WriteString(current.Group)
+		}
+		sb.WriteRune(digit)
+	}
+	return sign + sb.String()
+}
+
+// CSVSeparator returns the field separator spreadsheets of the locale
+// expect: a semicolon where the comma is the decimal separator
+func CSVSeparator() rune {
+	if current.Decimal == "," {
+		return ';'
+	}
+	return ','
+}
@@ -0,0 +1,258 @@
+package identity
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Score thresholds for Match results
+const (
+	SuggestThreshold   = 0.5 // worth showing as a possible duplicate
+	AutoMergeThreshold = 0.8 // confident enough to merge without review
+)
+
+// Identity is a commit author as recorded by git
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// Match describes how likely two identities belong to the same person
+type Match struct {
+	Score  float64 // 0 (unrelated) to 1 (same email)
+	Reason string
+}
+
+// NormalizeEmail lowercases an email and rewrites GitHub noreply addresses
+// ("12345+octocat@users.noreply.github.com") to "octocat@users.noreply.github.com"
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	if domain == "users.noreply.github.com" {
+		if _, login, ok := strings.Cut(local, "+"); ok {
+			local = login
+		}
+	}
+	return local + "@" + domain
+}
+
+// EmailUser returns the part of an email identifying the person: the GitHub
+// login for noreply addresses, otherwise the local part without a +tag
+func EmailUser(email string) string {
+	local, _, _ := strings.Cut(NormalizeEmail(email), "@")
+	local, _, _ = strings.Cut(local, "+")
+	return local
+}
+
+// NameTokens splits a name into lowercase words, reordering "Last, First"
+// to "First Last". Single-letter initials are kept.
+func NameTokens(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if last, first, ok := strings.Cut(name, ","); ok {
+		name = first + " " + last
+	}
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Levenshtein returns the edit distance between two strings
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Compare scores two identities, keeping the strongest rule that applies
+func Compare(a, b Identity) Match {
+	best := Match{}
+	consider := func(score float64, reason string) {
+		if score > best.Score {
+			best = Match{Score: score, Reason: reason}
+		}
+	}
+
+	emailA, emailB := NormalizeEmail(a.Email), NormalizeEmail(b.Email)
+	if emailA != "" && emailA == emailB {
+		consider(1, "same email")
+	}
+	userA, userB := EmailUser(a.Email), EmailUser(b.Email)
+	if len(userA) >= 3 && userA == userB && !genericUsers[userA] {
+		consider(0.9, "same email user")
+	}
+
+	tokensA, tokensB := NameTokens(a.Name), NameTokens(b.Name)
+	if len(tokensA) > 0 && len(tokensB) > 0 {
+		setA, setB := tokenSet(tokensA), tokenSet(tokensB)
+		switch {
+		case len(setA) > 1 && sameSet(setA, setB):
+			consider(0.9, "same name")
+		case min(len(setA), len(setB)) > 1 && (subset(setA, setB) || subset(setB, setA)):
+			consider(0.8, "name contains the other")
+		}
+
+		joinedA, joinedB := strings.Join(sortedKeys(setA), " "), strings.Join(sortedKeys(setB), " ")
+		if longest := max(len([]rune(joinedA)), len([]rune(joinedB))); longest >= 6 {
+			similarity := 1 - float64(Levenshtein(joinedA, joinedB))/float64(longest)
+			if similarity >= 0.85 {
+				consider(similarity*0.85, "similar name")
+			}
+		}
+
+		consider(max(nameUserScore(tokensA, userB), nameUserScore(tokensB, userA)), "email matches name")
+
+		if len(tokensA[0]) >= 3 && tokensA[0] == tokensB[0] {
+			consider(0.5, "same first name")
+		}
+	}
+
+	return best
+}
+
+// genericUsers are email users shared by unrelated people
+var genericUsers = map[string]bool{
+	"admin": true, "dev": true, "git": true, "info": true, "mail": true,
+	"noreply": true, "no-reply": true, "root": true, "user": true,
+}
+
+// nameUserScore scores an email user built from the name: "john.smith" or
+// "smithjohn" for John Smith score higher than initials such as "jsmith",
+// which many people share
+func nameUserScore(tokens []string, user string) float64 {
+	if len(tokens) < 2 || len(user) < 4 {
+		return 0
+	}
+	first, last := tokens[0], tokens[len(tokens)-1]
+	initial := string([]rune(first)[:1])
+	user = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, user)
+	switch user {
+	case first + last, last + first:
+		return 0.8
+	case initial + last, last + initial:
+		return 0.6
+	}
+	return 0
+}
+
+// Candidate is an identity that may duplicate another
+type Candidate struct {
+	Index int // position in the list passed to Similar
+	Match
+}
+
+// Similar returns the identities scoring at least threshold against target,
+// strongest first. The target itself is skipped by email.
+func Similar(target Identity, identities []Identity, threshold float64) []Candidate {
+	var result []Candidate
+	for i, id := range identities {
+		if id.Email == target.Email {
+			continue
+		}
+		if m := Compare(target, id); m.Score >= threshold {
+			result = append(result, Candidate{Index: i, Match: m})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result
+}
+
+// Clusters groups identities whose pairwise score reaches threshold,
+// transitively. Groups hold indexes in input order and singletons are
+// omitted.
+func Clusters(identities []Identity, threshold float64) [][]int {
+	parent := make([]int, len(identities))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range identities {
+		for j := i + 1; j < len(identities); j++ {
+			if Compare(identities[i], identities[j]).Score >= threshold {
+				if ri, rj := find(i), find(j); ri != rj {
+					parent[max(ri, rj)] = min(ri, rj)
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range identities {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+
+	var clusters [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+	return clusters
+}
+
+func tokenSet(tokens []string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	return set
+}
+
+func sameSet(a, b map[string]bool) bool {
+	return len(a) == len(b) && subset(a, b)
+}
+
+func subset(a, b map[string]bool) bool {
+	for t := range a {
+		if !b[t] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
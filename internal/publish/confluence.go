@@ -0,0 +1,89 @@
+package publish
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/audi70r/gitstat/internal/config"
+)
+
+// confluencePage is the part of a Confluence content object a page update
+// needs
+type confluencePage struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Version struct {
+		Number  int    `json:"number"`
+		Message string `json:"message,omitempty"`
+	} `json:"version"`
+	Body *confluenceBody `json:"body,omitempty"`
+}
+
+type confluenceBody struct {
+	Storage struct {
+		Value          string `json:"value"`
+		Representation string `json:"representation"`
+	} `json:"storage"`
+}
+
+// publishConfluence replaces the body of the page with the report as a new
+// version, keeping the page's title so links and the page tree stay intact
+func publishConfluence(ctx context.Context, target *config.Confluence, blocks []block) error {
+	endpoint := strings.TrimRight(target.URL, "/") + "/rest/api/content/" + url.PathEscape(target.Page)
+	header := http.Header{}
+	if target.User != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(target.User+":"+target.Token)))
+	} else {
+		header.Set("Authorization", "Bearer "+target.Token)
+	}
+
+	var page confluencePage
+	if err := send(ctx, http.MethodGet, endpoint+"?expand=version", header, nil, &page); err != nil {
+		return fmt.Errorf("confluence page %s: %w", target.Page, err)
+	}
+
+	update := confluencePage{ID: page.ID, Type: page.Type, Title: page.Title, Body: &confluenceBody{}}
+	update.Version.Number = page.Version.Number + 1
+	update.Version.Message = "Updated by gitstat"
+	update.Body.Storage.Value = storageFormat(blocks)
+	update.Body.Storage.Representation = "storage"
+	if err := send(ctx, http.MethodPut, endpoint, header, update, nil); err != nil {
+		return fmt.Errorf("confluence page %s: %w", target.Page, err)
+	}
+	return nil
+}
+
+// storageFormat renders blocks as Confluence storage format, the XHTML
+// Confluence keeps pages in
+func storageFormat(blocks []block) string {
+	var sb strings.Builder
+	for _, b := range blocks {
+		switch {
+		case b.rows != nil:
+			sb.WriteString("<table><tbody>")
+			for i, row := range b.rows {
+				cell := "td"
+				if i == 0 {
+					cell = "th"
+				}
+				sb.WriteString("<tr>")
+				for _, c := range row {
+					fmt.Fprintf(&sb, "<%s>%s</%s>", cell, html.EscapeString(c), cell)
+				}
+				sb.WriteString("</tr>")
+			}
+			sb.WriteString("</tbody></table>")
+		case b.heading > 0:
+			fmt.Fprintf(&sb, "<h%d>%s</h%d>", b.heading, html.EscapeString(b.text), b.heading)
+		default:
+			fmt.Fprintf(&sb, "<p>%s</p>", html.EscapeString(b.text))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/audi70r/gitstat/internal/config"
+)
+
+const (
+	notionAPI     = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+
+	// Notion's limits: blocks per request and characters per text
+	notionMaxBlocks = 100
+	notionMaxText   = 2000
+)
+
+// notionBlock is a Notion block object; the content is keyed by its type
+type notionBlock map[string]any
+
+// publishNotion adds the report to the database as a new page, so the
+// database collects every published report. The report's own top heading
+// becomes the page title.
+func publishNotion(ctx context.Context, target *config.Notion, title string, blocks []block) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+target.Token)
+	header.Set("Notion-Version", notionVersion)
+
+	if len(blocks) > 0 && blocks[0].heading == 1 {
+		blocks = blocks[1:]
+	}
+	var children []notionBlock
+	for _, b := range blocks {
+		children = append(children, notionBlocks(b)...)
+	}
+
+	titleProperty := target.TitleProperty
+	if titleProperty == "" {
+		titleProperty = "Name"
+	}
+	first := children[:min(len(children), notionMaxBlocks)]
+	page := map[string]any{
+		"parent": map[string]string{"database_id": target.Database},
+		"properties": map[string]any{
+			titleProperty: map[string]any{"title": richText(title)},
+		},
+		"children": first,
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := send(ctx, http.MethodPost, notionAPI+"/pages", header, page, &created); err != nil {
+		return fmt.Errorf("notion database %s: %w", target.Database, err)
+	}
+
+	// Blocks beyond the limit of a request are appended in batches
+	for start := len(first); start < len(children); start += notionMaxBlocks {
+		batch := children[start:min(len(children), start+notionMaxBlocks)]
+		err := send(ctx, http.MethodPatch, notionAPI+"/blocks/"+created.ID+"/children", header,
+			map[string]any{"children": batch}, nil)
+		if err != nil {
+			return fmt.Errorf("notion page %s: %w", created.ID, err)
+		}
+	}
+	return nil
+}
+
+// notionBlocks converts a block of the report. Tables longer than a request
+// allows are split, repeating the header.
+func notionBlocks(b block) []notionBlock {
+	switch {
+	case b.rows != nil:
+		header, body := b.rows[0], b.rows[1:]
+		var tables []notionBlock
+		for start := 0; start == 0 || start < len(body); start += notionMaxBlocks - 1 {
+			rows := []notionBlock{tableRow(header, len(header))}
+			for _, row := range body[start:min(len(body), start+notionMaxBlocks-1)] {
+				rows = append(rows, tableRow(row, len(header)))
+			}
+			tables = append(tables, notionBlock{
+				"object": "block",
+				"type":   "table",
+				"table": map[string]any{
+					"table_width":       len(header),
+					"has_column_header": true,
+					"children":          rows,
+				},
+			})
+		}
+		return tables
+	case b.heading > 0:
+		kind := fmt.Sprintf("heading_%d", b.heading)
+		return []notionBlock{{"object": "block", "type": kind, kind: map[string]any{"rich_text": richText(b.text)}}}
+	}
+	return []notionBlock{{"object": "block", "type": "paragraph", "paragraph": map[string]any{"rich_text": richText(b.text)}}}
+}
+
+// tableRow converts the cells of a table row, padded or cut to the table's
+// width as Notion requires
+func tableRow(cells []string, width int) notionBlock {
+	texts := make([]any, width)
+	for i := range texts {
+		texts[i] = []any{}
+		if i < len(cells) {
+			texts[i] = richText(cells[i])
+		}
+	}
+	return notionBlock{"object": "block", "type": "table_row", "table_row": map[string]any{"cells": texts}}
+}
+
+// richText converts plain text, split into the longest pieces Notion accepts
+func richText(text string) []any {
+	runes := []rune(text)
+	parts := []any{}
+	for start := 0; start < len(runes); start += notionMaxText {
+		parts = append(parts, map[string]any{
+			"type": "text",
+			"text": map[string]string{"content": string(runes[start:min(len(runes), start+notionMaxText)])},
+		})
+	}
+	return parts
+}
@@ -0,0 +1,166 @@
+// Package publish sends the Markdown report to the wikis and databases
+// stakeholders read, replacing a Confluence page or adding a Notion page
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+)
+
+// Targets are the services a report can be published to
+var Targets = []string{"confluence", "notion"}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Publish sends a Markdown report with the given title to a target set up
+// in the configuration
+func Publish(ctx context.Context, cfg *config.Config, target, title, markdown string) error {
+	if err := Check(cfg, target); err != nil {
+		return err
+	}
+	blocks := parseMarkdown(markdown)
+	if target == "confluence" {
+		return publishConfluence(ctx, cfg.Confluence, blocks)
+	}
+	return publishNotion(ctx, cfg.Notion, title, blocks)
+}
+
+// Check reports whether target is known and set up in the configuration,
+// so callers can fail before a long scan
+func Check(cfg *config.Config, target string) error {
+	switch target {
+	case "confluence":
+		if cfg.Confluence == nil || cfg.Confluence.URL == "" || cfg.Confluence.Page == "" {
+			return fmt.Errorf("confluence needs url, page and token under publish in the configuration file")
+		}
+	case "notion":
+		if cfg.Notion == nil || cfg.Notion.Database == "" {
+			return fmt.Errorf("notion needs token and database under publish in the configuration file")
+		}
+	default:
+		return fmt.Errorf("unknown publishing target %q, supported: %s", target, strings.Join(Targets, ", "))
+	}
+	return nil
+}
+
+// block is a part of the report: a heading, a paragraph or a table
+type block struct {
+	heading int // level of a heading, 0 otherwise
+	text    string
+	rows    [][]string // rows of a table, the header first
+}
+
+// parseMarkdown splits a report into blocks. It reads the Markdown the
+// reports are written in: headings, paragraphs and pipe tables.
+func parseMarkdown(markdown string) []block {
+	var blocks []block
+	inTable := false
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			inTable = false
+		case strings.HasPrefix(line, "|"):
+			cells := tableCells(line)
+			if isDelimiterRow(cells) {
+				continue
+			}
+			if !inTable {
+				blocks = append(blocks, block{})
+				inTable = true
+			}
+			last := &blocks[len(blocks)-1]
+			last.rows = append(last.rows, cells)
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			blocks = append(blocks, block{heading: min(level, 3), text: strings.TrimSpace(line[level:])})
+		default:
+			blocks = append(blocks, block{text: line})
+		}
+	}
+	return blocks
+}
+
+// tableCells splits a table row at the pipes that are not escaped
+func tableCells(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isDelimiterRow reports whether cells are the row separating a table's
+// header from its body, like |---|--:|
+func isDelimiterRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.Trim(c, "-:") != "" || !strings.Contains(c, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// send makes a JSON API request, decoding the response into result unless
+// it is nil. Failed requests report the service's error message.
+func send(ctx context.Context, method, url string, header http.Header, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,784 @@
+// Package scan runs the scans of repositories for every frontend: it parses
+// the history, aggregates the statistics and runs the worktree passes,
+// handing progress and results to a Presenter
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/complexity"
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/github"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Presenter shows the progress and the results of scans. The controller
+// calls it from the scanning goroutine; a UI applies the calls on its own.
+// The worktree results arrive after Results; a presenter applying them
+// later drops them once ctx, the scan's context, is done.
+type Presenter interface {
+	// Progress reports the commits parsed so far against the estimated
+	// total, 0 if unknown
+	Progress(parsed, total int)
+	// Status reports what the scan is doing, or a repository's error
+	Status(message string)
+	// Results hands over the statistics of a completed history scan
+	Results(ctx context.Context, result *Result)
+	// Codebase hands over the line count and license headers of the
+	// worktrees
+	Codebase(ctx context.Context, scan *CodebaseResult)
+	// DebtMarkers hands over the blamed debt markers
+	DebtMarkers(ctx context.Context, scan *DebtResult)
+	// CodeAge hands over the blame sample of the Code Age view
+	CodeAge(ctx context.Context, scan *CodeAgeResult)
+	// GitHub hands over the review data of the pull requests
+	GitHub(ctx context.Context, scan *GitHubResult)
+}
+
+// Result is the outcome of a history scan
+type Result struct {
+	Repos    []string
+	Stats    *stats.Repository
+	Trends   []stats.Snapshot // recorded scans of Repos, oldest first
+	TrendErr error            // recording or loading the trends failed
+}
+
+// CodebaseResult is the outcome of the worktree pass counting lines
+type CodebaseResult struct {
+	Lines          int
+	Sparse         int             // files outside a sparse checkout, not counted
+	LicenseHeaders map[string]bool // nil without a license header pattern
+	Complexity     map[string]complexity.File
+	Elapsed        time.Duration
+	Err            error // a repository failed; the counts are incomplete
+}
+
+// Apply stores the counts in the statistics
+func (c *CodebaseResult) Apply(repo *stats.Repository) {
+	repo.CodebaseSize = c.Lines
+	repo.SparseFiles = c.Sparse
+	repo.LicenseHeaders = c.LicenseHeaders
+	repo.Complexity = c.Complexity
+}
+
+// DebtResult is the outcome of the blame pass over the debt markers
+type DebtResult struct {
+	Markers []*git.DebtMarker
+	Partial bool // a repository is a partial clone, so blame may miss history
+	Elapsed time.Duration
+	Err     error // a repository failed; the markers are incomplete
+}
+
+// Apply stores the markers in the statistics
+func (d *DebtResult) Apply(repo *stats.Repository) {
+	repo.SetDebtMarkers(d.Markers)
+	repo.PartialClone = d.Partial
+}
+
+// CodeAgeResult is the outcome of the blame pass sampling the age of the
+// code
+type CodeAgeResult struct {
+	Sample  *git.CodeAgeSample
+	Elapsed time.Duration
+	Err     error // a repository failed; the sample is incomplete
+}
+
+// Apply stores the sample in the statistics
+func (c *CodeAgeResult) Apply(repo *stats.Repository) {
+	repo.SetCodeAge(c.Sample, time.Now())
+}
+
+// GitHubResult is the outcome of the pass fetching the review data of the
+// numbered pull requests from GitHub
+type GitHubResult struct {
+	Reviews map[string]*stats.PRReview // by merge commit hash
+	Repos   []string                   // GitHub repositories asked, "owner/name"
+	Fetched int                        // pull requests asked in this scan, the others known from earlier ones
+	Elapsed time.Duration
+	Err     error // a request failed; the remaining pull requests were not fetched
+}
+
+// Apply stores the review data in the statistics
+func (g *GitHubResult) Apply(repo *stats.Repository) {
+	repo.SetPRReviews(g.Reviews)
+}
+
+// Controller runs scans with the settings of a configuration. Start runs
+// one in the background for interactive frontends, cancelling the previous
+// one; Scan runs one to completion.
+type Controller struct {
+	config    *config.Config
+	presenter Presenter
+
+	// Trends records every scan and loads the recorded ones for Result
+	Trends bool
+	// Blame runs the blame passes over the debt markers and, when
+	// configured, the code age sample after the history
+	Blame bool
+	// GitHub fetches the review data of the pull requests after the
+	// history, when a token is configured; off for one-shot commands,
+	// which would otherwise wait for hundreds of requests
+	GitHub bool
+	// ComparePrevious scans the preceding equal-length period too, when
+	// configured and a start date is set; only the comparisons of the
+	// interactive views use it, so one-shot commands skip the second parse
+	ComparePrevious bool
+	// KeepCommits keeps the commits of the last completed scan in memory
+	// for Reaggregate
+	KeepCommits bool
+
+	// Cancelled on Close; every scan runs in a child context so git
+	// subprocesses are killed when the frontend exits or a scan is aborted
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mu         sync.Mutex
+	scanCancel context.CancelFunc
+	scans      sync.WaitGroup
+	kept       *keptScan // guarded by mu
+
+	// GitHub data of the pull requests asked so far by merge commit hash,
+	// nil for those GitHub has none of, so rescans only ask about new
+	// merges; guarded by mu
+	reviews map[string]*stats.PRReview
+}
+
+// NewController creates a controller scanning with cfg and reporting to
+// presenter; cancelling ctx aborts its scans
+func NewController(ctx context.Context, cfg *config.Config, presenter Presenter) *Controller {
+	c := &Controller{config: cfg, presenter: presenter}
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	return c
+}
+
+// Repositories returns the configured repositories in scan order, the
+// upstream first so fork commits can be matched against it. It fails when
+// none is selected or one is not a git repository.
+func (c *Controller) Repositories() ([]string, error) {
+	repos := c.config.RepoPaths
+	if len(repos) == 0 && c.config.RepoPath != "" {
+		repos = []string{c.config.RepoPath}
+	}
+
+	if len(repos) == 0 {
+		return nil, errors.New(i18n.T("No repositories selected"))
+	}
+
+	for _, path := range repos {
+		if !git.IsGitRepo(path) {
+			return nil, errors.New(i18n.T("Not a git repository: %s", path))
+		}
+	}
+
+	for i, path := range repos {
+		if i > 0 && path == c.config.UpstreamRepo {
+			ordered := append([]string{path}, repos[:i]...)
+			return append(ordered, repos[i+1:]...), nil
+		}
+	}
+	return repos, nil
+}
+
+// Start cancels any running scan and scans repos in the background
+func (c *Controller) Start(repos []string) {
+	c.mu.Lock()
+	if c.scanCancel != nil {
+		c.scanCancel()
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.scanCancel = cancel
+	c.mu.Unlock()
+
+	c.scans.Add(1)
+	go func() {
+		defer c.scans.Done()
+		defer c.finish(ctx)
+		c.Scan(ctx, repos)
+	}()
+}
+
+// Cancel aborts the running scan, reporting whether there was one
+func (c *Controller) Cancel() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scanCancel == nil {
+		return false
+	}
+	c.scanCancel()
+	c.scanCancel = nil
+	return true
+}
+
+// finish releases the scan context unless a newer scan replaced it
+func (c *Controller) finish(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.scanCancel != nil && ctx.Err() == nil {
+		c.scanCancel()
+		c.scanCancel = nil
+	}
+}
+
+// Close cancels running scans and waits up to timeout for them to exit
+func (c *Controller) Close(timeout time.Duration) {
+	c.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.scans.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// Scan aggregates the history of repos and hands the statistics to the
+// presenter, then runs the worktree passes. A repository failing does not
+// stop the others; the errors are returned together. An aborted scan
+// presents nothing and returns ctx's error.
+func (c *Controller) Scan(ctx context.Context, repos []string) error {
+	cfg := c.config
+	p := c.presenter
+
+	// Estimate total commits across all repos
+	totalEstimate := 0
+	for _, repoPath := range repos {
+		parser := git.NewParser(cfg.GitBackend, repoPath, git.ParseOptions{
+			Refs:        cfg.ScanRefs,
+			NoMerges:    cfg.ExcludeMerges,
+			FirstParent: cfg.FirstParent,
+		})
+		estimate, _ := parser.EstimateCommitCount(ctx, cfg.Since, cfg.Until)
+		if estimate > 0 {
+			totalEstimate += estimate
+		}
+	}
+	p.Progress(0, totalEstimate)
+
+	// Create aggregator with combined path info
+	dateRange := stats.DateRange{
+		Since: cfg.Since,
+		Until: cfg.Until,
+	}
+
+	combinedPath := repos[0]
+	if len(repos) > 1 {
+		combinedPath = fmt.Sprintf("%d repositories", len(repos))
+	}
+	aggregator := c.newAggregator(combinedPath, dateRange, repos)
+
+	// Record the parsed commits as they arrive, so they can be replayed
+	// even if the scan does not finish
+	var rec *recorder
+	if cfg.RecordPath != "" {
+		var err error
+		rec, err = openRecord(cfg.RecordPath, RecordLine{
+			Repos:       repos,
+			Since:       cfg.Since,
+			Until:       cfg.Until,
+			Refs:        cfg.ScanRefs,
+			NoMerges:    cfg.ExcludeMerges,
+			FirstParent: cfg.FirstParent,
+		})
+		if err != nil {
+			p.Status(i18n.T("Cannot record the scan: %v", err))
+		}
+	}
+
+	// Keep the commits to aggregate them again with other settings
+	var commits *commitLog
+	if c.KeepCommits {
+		commits = &commitLog{}
+	}
+
+	// Scan each repository
+	totalCommits := 0
+	firstParentCommits := 0
+	var firstParent map[string]bool
+	var backportResults []*git.BranchBackports
+	var tags []git.Tag
+	var scanErrs []error
+
+	// License header check, only when a pattern is configured
+	var headerCheck *git.HeaderCheck
+	if cfg.LicenseHeader != "" {
+		pattern, err := regexp.Compile(cfg.LicenseHeader)
+		if err != nil {
+			p.Status(i18n.T("Invalid license header pattern: %v", err))
+		} else {
+			headerCheck = &git.HeaderCheck{
+				Pattern:    pattern,
+				Extensions: cfg.LicenseExtensions,
+				MaxLines:   cfg.LicenseHeaderLines,
+			}
+		}
+	}
+
+	for i, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+
+		p.Status(i18n.T("Scanning %s (%d/%d)...", repoName, i+1, len(repos)))
+
+		opts := git.ParseOptions{
+			SkipGenerated: cfg.SkipGenerated,
+			Refs:          cfg.ScanRefs,
+			WithPatchIDs:  c.deduplicate(repos),
+			NoMerges:      cfg.ExcludeMerges,
+			FirstParent:   cfg.FirstParent,
+		}
+		if cfg.CacheCommits {
+			opts.Cache = git.OpenCommitCache(repoPath)
+		}
+		parser := git.NewParser(cfg.GitBackend, repoPath, opts)
+
+		// Parse commits from this repo
+		fork := i > 0 && c.upstream(repos)
+		err := parser.Parse(ctx, cfg.Since, cfg.Until,
+			func(progress git.ScanProgress) {
+				p.Progress(totalCommits+progress.CommitsParsed, totalEstimate)
+				if progress.CurrentHash != "" {
+					p.Status(i18n.T("[%s] Processing %s...", repoName, progress.CurrentHash))
+				}
+			},
+			func(commit *git.Commit) {
+				if fork {
+					rec.record(RecordFork, repoPath, commit)
+					commits.add(RecordFork, repoPath, commit)
+					aggregator.ProcessForkCommit(commit)
+				} else {
+					rec.record(RecordCommit, repoPath, commit)
+					commits.add(RecordCommit, repoPath, commit)
+					aggregator.ProcessCommit(commit)
+				}
+			},
+		)
+
+		if err == nil && opts.NoMerges {
+			// Merges still count as pull requests
+			err = parser.ParseMerges(ctx, cfg.Since, cfg.Until, func(commit *git.Commit) {
+				rec.record(RecordMerge, repoPath, commit)
+				commits.add(RecordMerge, repoPath, commit)
+				aggregator.ProcessMerge(commit)
+			})
+		}
+
+		if err != nil {
+			p.Status(i18n.T("Error in %s: %v", repoName, err))
+			scanErrs = append(scanErrs, fmt.Errorf("%s: %w", repoName, err))
+			// Continue with other repos
+		}
+
+		// Update total commits processed
+		totalCommits = aggregator.CommitCount()
+
+		// Find commits made directly on the trunk
+		if hashes, err := parser.FirstParentCommits(ctx, cfg.Since, cfg.Until); err == nil {
+			firstParentCommits += len(hashes)
+			if firstParent == nil {
+				firstParent = make(map[string]bool)
+			}
+			for hash := range hashes {
+				firstParent[hash] = true
+			}
+		}
+
+		// Detect backports on release branches
+		backports := c.scanBackports(ctx, repoPath, repoName, len(repos) > 1)
+		backportResults = append(backportResults, backports...)
+
+		// Tags around the period annotate crunches on the timeline
+		tags = append(tags, c.scanTags(ctx, repoPath, repoName, len(repos) > 1)...)
+	}
+
+	if err := rec.Close(); err != nil {
+		p.Status(i18n.T("Cannot record the scan: %v", err))
+	}
+
+	// An aborted scan leaves partial data behind; keep the previous results
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Finalize statistics
+	repoStats := aggregator.Finalize()
+	repoStats.FirstParentCommits = firstParentCommits
+	repoStats.FirstParent = firstParent
+	repoStats.Backports = backportResults
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Date.Before(tags[j].Date) })
+	repoStats.Tags = tags
+
+	kept := &keptScan{
+		repos:              repos,
+		combinedPath:       combinedPath,
+		dateRange:          dateRange,
+		commits:            commits,
+		firstParentCommits: firstParentCommits,
+		firstParent:        firstParent,
+		backports:          backportResults,
+		tags:               tags,
+	}
+
+	// Scan the preceding equal-length period for comparisons
+	if c.ComparePrevious && cfg.ComparePrevious && !cfg.Since.IsZero() {
+		if c.KeepCommits {
+			kept.previous = &commitLog{}
+		}
+		var previous *stats.Repository
+		kept.previousRange, previous = c.scanPreviousPeriod(ctx, repos, combinedPath, kept.previous)
+		repoStats.SetPrevious(previous)
+	}
+
+	// Re-apply directory merges made in the Ownership view
+	repoStats.ApplyDirMerges(cfg.DirMerges)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if c.KeepCommits {
+		c.mu.Lock()
+		c.kept = kept
+		c.mu.Unlock()
+	}
+
+	result := &Result{Repos: repos, Stats: repoStats}
+	if c.Trends {
+		// Add this scan to the history charted by the Trends view
+		result.Trends, result.TrendErr = c.recordTrend(repos, repoStats)
+	}
+	p.Results(ctx, result)
+
+	// The worktree passes only feed a few views; they finish in the
+	// background while the history statistics are already on screen
+	p.Codebase(ctx, c.scanCodebases(ctx, repos, headerCheck))
+	if c.Blame && len(cfg.DebtMarkers) > 0 {
+		p.DebtMarkers(ctx, c.scanDebtMarkers(ctx, repos))
+	}
+	if c.Blame && cfg.CodeAgeSample > 0 {
+		p.CodeAge(ctx, c.scanCodeAge(ctx, repos))
+	}
+	if c.GitHub && cfg.GitHub != nil {
+		if result := c.fetchPullRequests(ctx, repos, repoStats.GetPRList("date", false, 0)); result != nil {
+			p.GitHub(ctx, result)
+		}
+	}
+	return errors.Join(scanErrs...)
+}
+
+// newAggregator creates an aggregator with the configured collectors and
+// filters
+func (c *Controller) newAggregator(path string, dateRange stats.DateRange, repos []string) *stats.Aggregator {
+	cfg := c.config
+	aggregator := stats.NewAggregator(path, dateRange, cfg.Timezone)
+	aggregator.Disable(cfg.DisabledCollectors...)
+	aggregator.SetExcludeMechanical(cfg.ExcludeMechanical)
+	aggregator.SetExclusions(cfg.ExcludePaths, cfg.ExcludeAuthors)
+	aggregator.SetAuthorAliases(cfg.AuthorAliases)
+	aggregator.SetPathFilter(cfg.IncludeGlobs, cfg.ExcludeGlobs)
+	aggregator.SetHistoryFilter(cfg.ScanRefs, cfg.ExcludeMerges, cfg.FirstParent)
+	aggregator.SetDeduplicate(c.deduplicate(repos))
+	aggregator.SetChurnHalfLife(cfg.ChurnHalfLife)
+	return aggregator
+}
+
+// recordTrend appends a snapshot of the new statistics to the trends file of
+// repos, unless disabled, and returns every recorded snapshot
+func (c *Controller) recordTrend(repos []string, repoStats *stats.Repository) ([]stats.Snapshot, error) {
+	path := config.TrendsPath(repos)
+	if path == "" {
+		return nil, nil
+	}
+	if c.config.RecordTrends {
+		if err := stats.AppendTrend(path, repoStats.Snapshot(time.Now())); err != nil {
+			return nil, err
+		}
+	}
+	return stats.LoadTrends(path)
+}
+
+// scanCodebases counts the lines in the worktrees, measures the complexity
+// of the source files and checks license headers
+func (c *Controller) scanCodebases(ctx context.Context, repos []string, headerCheck *git.HeaderCheck) *CodebaseResult {
+	start := time.Now()
+	result := &CodebaseResult{Complexity: make(map[string]complexity.File)}
+	if headerCheck != nil {
+		result.LicenseHeaders = make(map[string]bool)
+	}
+
+	for _, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+		scan, err := git.ScanCodebase(ctx, repoPath, headerCheck)
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %v", repoName, err)
+			continue
+		}
+		result.Lines += scan.Lines
+		result.Sparse += scan.Sparse
+		for file, ok := range scan.Headers {
+			if len(repos) > 1 {
+				file = filepath.Join(repoName, file)
+			}
+			result.LicenseHeaders[file] = ok
+		}
+		// Keyed like the file statistics, which combine the repositories
+		for file, measured := range scan.Complexity {
+			if measured.Total >= result.Complexity[file].Total {
+				result.Complexity[file] = measured
+			}
+		}
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// scanDebtMarkers blames the lines carrying tech-debt markers
+func (c *Controller) scanDebtMarkers(ctx context.Context, repos []string) *DebtResult {
+	start := time.Now()
+	result := &DebtResult{}
+	for _, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+		result.Partial = result.Partial || git.GetCheckout(repoPath).Partial
+		var cache *git.BlameCache
+		if c.config.BlameCache {
+			cache = git.OpenBlameCache(repoPath)
+		}
+		markers, err := git.GetDebtMarkers(ctx, repoPath, c.config.DebtMarkers, cache)
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %v", repoName, err)
+		} else if cache != nil {
+			cache.Save()
+		}
+		if len(repos) > 1 {
+			for _, m := range markers {
+				m.File = filepath.Join(repoName, m.File)
+			}
+		}
+		result.Markers = append(result.Markers, markers...)
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// scanCodeAge blames a sample of the files of every repository, the sample
+// size split between them
+func (c *Controller) scanCodeAge(ctx context.Context, repos []string) *CodeAgeResult {
+	start := time.Now()
+	result := &CodeAgeResult{Sample: &git.CodeAgeSample{}}
+	perRepo := max(c.config.CodeAgeSample/len(repos), 1)
+	for _, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+		c.presenter.Status(i18n.T("Sampling code age in %s...", repoName))
+		sample, err := git.SampleCodeAge(ctx, repoPath, perRepo)
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %v", repoName, err)
+			continue
+		}
+		if len(repos) > 1 {
+			for i := range sample.Ages {
+				sample.Ages[i].File = filepath.Join(repoName, sample.Ages[i].File)
+			}
+		}
+		result.Sample.Files += sample.Files
+		result.Sample.Sampled += sample.Sampled
+		result.Sample.Ages = append(result.Sample.Ages, sample.Ages...)
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// fetchPullRequests fetches the review data of the newest numbered pull
+// requests from the GitHub repositories among the remotes of repos; nil
+// when no repository is on GitHub. With several repositories a number is
+// asked of each in turn until the merge commit matches. Pull requests asked
+// in an earlier scan are not asked again.
+func (c *Controller) fetchPullRequests(ctx context.Context, repos []string, prs []*stats.PRInfo) *GitHubResult {
+	start := time.Now()
+	client := github.NewClient(c.config.GitHub)
+	result := &GitHubResult{Reviews: make(map[string]*stats.PRReview)}
+	for _, repoPath := range repos {
+		remote, err := git.RemoteURL(ctx, repoPath, "origin")
+		if err != nil {
+			continue
+		}
+		if name, ok := client.Repo(remote); ok && !slices.Contains(result.Repos, name) {
+			result.Repos = append(result.Repos, name)
+		}
+	}
+	if len(result.Repos) == 0 {
+		return nil
+	}
+
+	limit := c.config.GitHub.MaxPRs
+	if limit == 0 {
+		limit = github.DefaultMaxPRs
+	}
+	var numbered []*stats.PRInfo
+	for _, pr := range prs {
+		if pr.PRNumber > 0 && len(numbered) < limit {
+			numbered = append(numbered, pr)
+		}
+	}
+	c.mu.Lock()
+	if c.reviews == nil {
+		c.reviews = make(map[string]*stats.PRReview)
+	}
+	var missing []*stats.PRInfo
+	for _, pr := range numbered {
+		if review, ok := c.reviews[pr.Hash]; !ok {
+			missing = append(missing, pr)
+		} else if review != nil {
+			result.Reviews[pr.Hash] = review
+		}
+	}
+	c.mu.Unlock()
+
+	for i, pr := range missing {
+		c.presenter.Status(i18n.T("Fetching pull request %d of %d from GitHub...", i+1, len(missing)))
+		review, err := c.fetchPullRequest(ctx, client, result.Repos, pr)
+		if err != nil {
+			result.Err = err
+			break
+		}
+		result.Fetched++
+		if review != nil {
+			result.Reviews[pr.Hash] = review
+		}
+		c.mu.Lock()
+		c.reviews[pr.Hash] = review
+		c.mu.Unlock()
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// fetchPullRequest asks the GitHub repositories in turn about a pull
+// request; nil when none has it merged as pr
+func (c *Controller) fetchPullRequest(ctx context.Context, client *github.Client, repos []string, pr *stats.PRInfo) (*stats.PRReview, error) {
+	for _, name := range repos {
+		review, hash, err := client.PullRequest(ctx, name, pr.PRNumber)
+		if errors.Is(err, github.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if review != nil && (len(repos) == 1 || hash == pr.Hash) {
+			return review, nil
+		}
+	}
+	return nil, nil
+}
+
+// deduplicate reports whether commits found in several scanned refs or
+// repositories are matched up and counted once
+func (c *Controller) deduplicate(repos []string) bool {
+	if c.upstream(repos) {
+		return true
+	}
+	return c.config.DeduplicateCommits && (len(repos) > 1 || len(c.config.ScanRefs) > 0)
+}
+
+// upstream reports whether repos are a fork scan: the configured upstream
+// repository, ordered first, followed by its forks
+func (c *Controller) upstream(repos []string) bool {
+	return len(repos) > 1 && c.config.UpstreamRepo != "" && repos[0] == c.config.UpstreamRepo
+}
+
+// scanBackports collects backport statistics for the configured release
+// branches. Branch listing and patch-ids need the git binary whatever the
+// configured backend.
+func (c *Controller) scanBackports(ctx context.Context, repoPath, repoName string, prefix bool) []*git.BranchBackports {
+	parser := git.NewExecParser(repoPath)
+	branches, err := parser.ListBranches(ctx, c.config.BackportBranches)
+	if err != nil || len(branches) == 0 {
+		return nil
+	}
+
+	c.presenter.Status(i18n.T("Detecting backports in %s (%d branches)...", repoName, len(branches)))
+
+	backports, _ := parser.GetBackports(ctx, c.config.Since, c.config.Until, branches)
+	if prefix {
+		for _, bp := range backports {
+			bp.Branch = repoName + ":" + bp.Branch
+		}
+	}
+	return backports
+}
+
+// scanTags lists the tags dated within the period, widened by
+// stats.CrunchTagWindow; several repositories prefix them with the
+// repository name
+func (c *Controller) scanTags(ctx context.Context, repoPath, repoName string, prefix bool) []git.Tag {
+	since, until := c.config.Since, c.config.Until
+	if !since.IsZero() {
+		since = since.Add(-stats.CrunchTagWindow)
+	}
+	if !until.IsZero() {
+		until = until.Add(stats.CrunchTagWindow)
+	}
+	tags, _ := git.ListTags(ctx, repoPath, since, until)
+	if prefix {
+		for i := range tags {
+			tags[i].Name = repoName + ":" + tags[i].Name
+		}
+	}
+	return tags
+}
+
+// scanPreviousPeriod aggregates the period of the same length that ends
+// where the selected range starts
+func (c *Controller) scanPreviousPeriod(ctx context.Context, repos []string, combinedPath string, commits *commitLog) (stats.DateRange, *stats.Repository) {
+	cfg := c.config
+	// An open range runs until now
+	until := cfg.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	length := until.Sub(cfg.Since)
+	dateRange := stats.DateRange{
+		Since: cfg.Since.Add(-length),
+		Until: cfg.Since,
+	}
+	aggregator := c.newAggregator(combinedPath, dateRange, repos)
+
+	for _, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+
+		c.presenter.Status(i18n.T("Scanning previous period for %s...", repoName))
+
+		parser := git.NewParser(cfg.GitBackend, repoPath, git.ParseOptions{
+			SkipGenerated: cfg.SkipGenerated,
+			Refs:          cfg.ScanRefs,
+			WithPatchIDs:  c.deduplicate(repos),
+			NoMerges:      cfg.ExcludeMerges,
+			FirstParent:   cfg.FirstParent,
+		})
+		err := parser.Parse(ctx, dateRange.Since, dateRange.Until, nil,
+			func(commit *git.Commit) {
+				commits.add(RecordCommit, repoPath, commit)
+				aggregator.ProcessCommit(commit)
+			},
+		)
+		if err == nil && cfg.ExcludeMerges {
+			err = parser.ParseMerges(ctx, dateRange.Since, dateRange.Until, func(commit *git.Commit) {
+				commits.add(RecordMerge, repoPath, commit)
+				aggregator.ProcessMerge(commit)
+			})
+		}
+		if err != nil {
+			c.presenter.Status(i18n.T("Error in %s: %v", repoName, err))
+		}
+	}
+
+	return dateRange, aggregator.Finalize()
+}
@@ -0,0 +1,120 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/gittest"
+)
+
+// githubServer answers every pull request as merged with one review,
+// counting the requests
+func githubServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"created_at": "2024-01-01T09:00:00Z", "merged_at": "2024-01-01T15:00:00Z", "user": {"login": "alice"}}`)
+	})
+	mux.HandleFunc("/repos/o/r/pulls/{number}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `[{"user": {"login": "bob"}, "state": "APPROVED", "submitted_at": "2024-01-01T10:00:00Z"}]`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// scanConfig scans the fixture project with a GitHub token, leaving the
+// user's caches alone
+func scanConfig(repo *gittest.Repo, api string) *config.Config {
+	cfg := config.Default()
+	cfg.RepoPath = repo.Dir
+	cfg.CacheCommits = false
+	cfg.ComparePrevious = false
+	cfg.GitHub = &config.GitHub{Token: "secret", URL: api}
+	return cfg
+}
+
+// The one-shot commands scan with a controller as created, which must not
+// ask GitHub even with a token configured
+func TestScanWithoutGitHub(t *testing.T) {
+	server, requests := githubServer(t)
+	repo := gittest.Project(t)
+	repo.Git("remote", "add", "origin", server.URL+"/o/r.git")
+
+	headless := &Headless{}
+	c := NewController(context.Background(), scanConfig(repo, server.URL), headless)
+	if err := c.Scan(context.Background(), []string{repo.Dir}); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("scan made %d GitHub requests, want none", n)
+	}
+	if s := headless.Result.Stats.PRStats; s.TotalPRs == 0 || s.Reviewed != 0 {
+		t.Errorf("got %d numbered PRs, %d reviewed; want some, none reviewed", s.TotalPRs, s.Reviewed)
+	}
+}
+
+// Only the interface scans the preceding period for its comparisons
+func TestScanPreviousPeriod(t *testing.T) {
+	repo := gittest.Project(t)
+	cfg := scanConfig(repo, "")
+	cfg.ComparePrevious = true
+	cfg.Since = gittest.Start.AddDate(0, 0, -1)
+	cfg.Until = gittest.Start.Add(30 * 24 * time.Hour)
+
+	for _, compare := range []bool{false, true} {
+		headless := &Headless{}
+		c := NewController(context.Background(), cfg, headless)
+		c.ComparePrevious = compare
+		if err := c.Scan(context.Background(), []string{repo.Dir}); err != nil {
+			t.Fatal(err)
+		}
+		if got := headless.Result.Stats.Previous != nil; got != compare {
+			t.Errorf("ComparePrevious %v: scanned the previous period: %v", compare, got)
+		}
+	}
+
+	// An open range is compared with as long a period before it
+	cfg.Until = time.Time{}
+	headless := &Headless{}
+	c := NewController(context.Background(), cfg, headless)
+	c.ComparePrevious = true
+	if err := c.Scan(context.Background(), []string{repo.Dir}); err != nil {
+		t.Fatal(err)
+	}
+	previous := headless.Result.Stats.Previous.DateRange
+	if !previous.Until.Equal(cfg.Since) || !previous.Since.Before(cfg.Since.Add(-365*24*time.Hour)) {
+		t.Errorf("open range since %s: previous period %s", cfg.Since, previous)
+	}
+}
+
+// Rescans reuse the reviews fetched before
+func TestScanWithGitHub(t *testing.T) {
+	server, requests := githubServer(t)
+	repo := gittest.Project(t)
+	repo.Git("remote", "add", "origin", server.URL+"/o/r.git")
+
+	headless := &Headless{}
+	c := NewController(context.Background(), scanConfig(repo, server.URL), headless)
+	c.GitHub = true
+	for scan := 1; scan <= 2; scan++ {
+		if err := c.Scan(context.Background(), []string{repo.Dir}); err != nil {
+			t.Fatal(err)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("scan %d: %d GitHub requests in all, want 2", scan, n)
+		}
+		s := headless.Result.Stats.PRStats
+		if s.Reviewed != 1 || s.TopReviewers(1)[0] != "bob" {
+			t.Errorf("scan %d: %d PRs reviewed, reviewers %v; want 1 by bob", scan, s.Reviewed, s.ReviewsBy)
+		}
+	}
+}
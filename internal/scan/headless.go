@@ -0,0 +1,41 @@
+package scan
+
+import "context"
+
+// Headless is the presenter of frontends without a UI: it ignores the
+// progress, keeps the result and applies the worktree passes to it as they
+// arrive, so the statistics are complete when Scan returns
+type Headless struct {
+	Result *Result
+}
+
+// Progress implements Presenter
+func (h *Headless) Progress(parsed, total int) {}
+
+// Status implements Presenter
+func (h *Headless) Status(message string) {}
+
+// Results implements Presenter
+func (h *Headless) Results(ctx context.Context, result *Result) {
+	h.Result = result
+}
+
+// Codebase implements Presenter
+func (h *Headless) Codebase(ctx context.Context, scan *CodebaseResult) {
+	scan.Apply(h.Result.Stats)
+}
+
+// DebtMarkers implements Presenter
+func (h *Headless) DebtMarkers(ctx context.Context, scan *DebtResult) {
+	scan.Apply(h.Result.Stats)
+}
+
+// CodeAge implements Presenter
+func (h *Headless) CodeAge(ctx context.Context, scan *CodeAgeResult) {
+	scan.Apply(h.Result.Stats)
+}
+
+// GitHub implements Presenter
+func (h *Headless) GitHub(ctx context.Context, scan *GitHubResult) {
+	scan.Apply(h.Result.Stats)
+}
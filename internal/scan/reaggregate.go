@@ -0,0 +1,111 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// ErrNothingKept is returned by Reaggregate when no completed scan kept its
+// commits, so only a rescan can apply new settings
+var ErrNothingKept = errors.New("no scan kept its commits")
+
+// commitLog keeps the commits of a scan in memory, in the order they were
+// aggregated. It is safe for concurrent use; a nil log keeps nothing.
+type commitLog struct {
+	mu    sync.Mutex
+	lines []RecordLine
+}
+
+func (l *commitLog) add(kind, repo string, commit *git.Commit) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, RecordLine{Kind: kind, Repo: repo, Commit: commit})
+}
+
+// aggregate processes the kept commits with a
+func (l *commitLog) aggregate(ctx context.Context, a *stats.Aggregator) error {
+	for _, line := range l.lines {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		processLine(a, line)
+	}
+	return nil
+}
+
+// processLine hands a commit, fork or merge line to the aggregator,
+// reporting false for other kinds
+func processLine(a *stats.Aggregator, line RecordLine) bool {
+	switch line.Kind {
+	case RecordCommit:
+		a.ProcessCommit(line.Commit)
+	case RecordFork:
+		a.ProcessForkCommit(line.Commit)
+	case RecordMerge:
+		a.ProcessMerge(line.Commit)
+	default:
+		return false
+	}
+	return true
+}
+
+// keptScan is a completed scan whose commits were kept by
+// Controller.KeepCommits, with what the history pass found besides them
+type keptScan struct {
+	repos        []string
+	combinedPath string
+	dateRange    stats.DateRange
+	commits      *commitLog
+
+	// Preceding equal-length period, nil when not compared
+	previousRange stats.DateRange
+	previous      *commitLog
+
+	firstParentCommits int
+	firstParent        map[string]bool
+	backports          []*git.BranchBackports
+	tags               []git.Tag // sorted by date
+}
+
+// Reaggregate aggregates the commits kept from the last completed scan
+// again, without running git, so a change to the settings applied after
+// parsing (timezone, churn half-life, exclusions, collectors) takes effect
+// without a rescan. Trunk commits, backports, tags and the previous period
+// carry over; the worktree passes and merges made in the UI are left to the
+// caller. It returns ErrNothingKept if no scan kept its commits.
+func (c *Controller) Reaggregate(ctx context.Context) (*stats.Repository, error) {
+	c.mu.Lock()
+	kept := c.kept
+	c.mu.Unlock()
+	if kept == nil {
+		return nil, ErrNothingKept
+	}
+
+	aggregator := c.newAggregator(kept.combinedPath, kept.dateRange, kept.repos)
+	if err := kept.commits.aggregate(ctx, aggregator); err != nil {
+		return nil, err
+	}
+	repoStats := aggregator.Finalize()
+	repoStats.FirstParentCommits = kept.firstParentCommits
+	repoStats.FirstParent = kept.firstParent
+	repoStats.Backports = kept.backports
+	repoStats.Tags = kept.tags
+
+	if kept.previous != nil {
+		previous := c.newAggregator(kept.combinedPath, kept.previousRange, kept.repos)
+		if err := kept.previous.aggregate(ctx, previous); err != nil {
+			return nil, err
+		}
+		repoStats.SetPrevious(previous.Finalize())
+	}
+
+	repoStats.ApplyDirMerges(c.config.DirMerges)
+	return repoStats, nil
+}
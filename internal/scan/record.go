@@ -0,0 +1,164 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Kinds of the lines of a scan record
+const (
+	RecordScan   = "scan"   // first line: what was scanned
+	RecordCommit = "commit" // a commit of the history
+	RecordFork   = "fork"   // a commit read from a fork of the upstream
+	RecordMerge  = "merge"  // a merge parsed for the pull requests only
+)
+
+// RecordLine is one line of a scan record, the NDJSON file a scan writes
+// its parsed commits to as they arrive when Config.RecordPath is set
+type RecordLine struct {
+	Kind string `json:"kind"`
+
+	// Scan lines only
+	Repos       []string  `json:"repos,omitempty"`
+	Since       time.Time `json:"since,omitzero"`
+	Until       time.Time `json:"until,omitzero"`
+	Refs        []string  `json:"refs,omitempty"`
+	NoMerges    bool      `json:"no_merges,omitempty"`
+	FirstParent bool      `json:"first_parent,omitempty"`
+
+	// Commit, fork and merge lines
+	Repo   string      `json:"repo,omitempty"`
+	Commit *git.Commit `json:"commit,omitempty"`
+}
+
+// recorder writes a scan record. Every line goes straight to the file, so
+// what was parsed before a crash or an interrupt is on disk. A nil recorder
+// records nothing.
+type recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+	err  error
+}
+
+// openRecord creates the record at path, replacing an earlier one, and
+// writes its scan line
+func openRecord(path string, scan RecordLine) (*recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &recorder{file: file, enc: json.NewEncoder(file)}
+	scan.Kind = RecordScan
+	if err := r.enc.Encode(scan); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// record appends a commit of repo. It is safe for concurrent use, as
+// parsers may call back from several goroutines; after a write fails the
+// rest is dropped and Close reports the error.
+func (r *recorder) record(kind, repo string, commit *git.Commit) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(RecordLine{Kind: kind, Repo: repo, Commit: commit})
+}
+
+// Close closes the file, returning the first write error
+func (r *recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	err := r.file.Close()
+	if r.err != nil {
+		return r.err
+	}
+	return err
+}
+
+// Replay aggregates the scan record at path as the scan that wrote it did,
+// without running git, with the collectors and filters of the controller's
+// configuration. A record cut off by an interrupted scan replays the
+// commits it holds. The record only keeps the parsed history: trunk
+// commits, backports, tags, the previous period and the worktree passes
+// are left out.
+func (c *Controller) Replay(ctx context.Context, path string) (*stats.Repository, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var aggregator *stats.Aggregator
+	reader := bufio.NewReader(file)
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Without a newline the last line was cut off mid-write
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		var line RecordLine
+		if err := json.Unmarshal(data, &line); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		if aggregator == nil && line.Kind != RecordScan {
+			return nil, fmt.Errorf("%s: not a gitstat scan record", path)
+		}
+		if line.Kind != RecordScan && line.Commit == nil {
+			return nil, fmt.Errorf("%s:%d: %s without a commit", path, n, line.Kind)
+		}
+
+		if line.Kind == RecordScan {
+			if aggregator != nil {
+				return nil, fmt.Errorf("%s:%d: second scan in one record", path, n)
+			}
+			if len(line.Repos) == 0 {
+				return nil, fmt.Errorf("%s:%d: scan without repositories", path, n)
+			}
+			combinedPath := line.Repos[0]
+			if len(line.Repos) > 1 {
+				combinedPath = fmt.Sprintf("%d repositories", len(line.Repos))
+			}
+			aggregator = c.newAggregator(combinedPath, stats.DateRange{Since: line.Since, Until: line.Until}, line.Repos)
+			aggregator.SetHistoryFilter(line.Refs, line.NoMerges, line.FirstParent)
+		} else if !processLine(aggregator, line) {
+			return nil, fmt.Errorf("%s:%d: unknown line kind %q", path, n, line.Kind)
+		}
+	}
+	if aggregator == nil {
+		return nil, fmt.Errorf("%s: empty scan record", path)
+	}
+
+	repoStats := aggregator.Finalize()
+	repoStats.ApplyDirMerges(c.config.DirMerges)
+	return repoStats, nil
+}
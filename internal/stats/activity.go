@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Periods the timeline sums the commits over, see GetTimeline
+const (
+	PeriodDay     = "day"     // "2024-01-15"
+	PeriodWeek    = "week"    // ISO week, "2024-W03"
+	PeriodMonth   = "month"   // "2024-01"
+	PeriodQuarter = "quarter" // "2024-Q1"
+)
+
+// TimelinePeriods lists the periods from the shortest
+var TimelinePeriods = []string{PeriodDay, PeriodWeek, PeriodMonth, PeriodQuarter}
+
+// isoWeek labels the week of t in the timeline, e.g. "2024-W03"; the
+// weekly maps are keyed by WeekKey
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// periodLabel returns the label of the period containing day; unknown
+// periods are days
+func periodLabel(period string, day time.Time) string {
+	switch period {
+	case PeriodWeek:
+		return isoWeek(day)
+	case PeriodMonth:
+		return day.Format("2006-01")
+	case PeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", day.Year(), (int(day.Month())+2)/3)
+	}
+	return day.Format("2006-01-02")
+}
+
+// WeekKey returns the Monday of t's week, the key of the weekly activity
+// maps (AuthorStats.Weekly, FileStats.Weekly)
+func WeekKey(t time.Time) string {
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+// ActivityWeeks returns the keys of every week from the first to the last
+// commit, including weeks without commits
+func (r *Repository) ActivityWeeks() []string {
+	if len(r.DailyActivity) == 0 {
+		return nil
+	}
+	dates := make([]string, 0, len(r.DailyActivity))
+	for d := range r.DailyActivity {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+
+	first, _ := time.Parse("2006-01-02", dates[0])
+	last, _ := time.Parse("2006-01-02", dates[len(dates)-1])
+	var weeks []string
+	for w, _ := time.Parse("2006-01-02", WeekKey(first)); !w.After(last); w = w.AddDate(0, 0, 7) {
+		weeks = append(weeks, w.Format("2006-01-02"))
+	}
+	return weeks
+}
+
+// WeeklySeries sums weekly activity into at most buckets consecutive
+// buckets spanning weeks, oldest first
+func WeeklySeries(weekly map[string]int, weeks []string, buckets int) []int {
+	if len(weeks) == 0 || buckets <= 0 {
+		return nil
+	}
+	buckets = min(buckets, len(weeks))
+	series := make([]int, buckets)
+	for i, week := range weeks {
+		series[i*buckets/len(weeks)] += weekly[week]
+	}
+	return series
+}
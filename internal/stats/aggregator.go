@@ -1,41 +1,344 @@
 package stats
 
 import (
+	"cmp"
+	"math"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/audi70r/gitstat/internal/git"
 )
 
-// Aggregator processes commits and builds statistics
+// Aggregator processes commits and builds statistics. Author totals are
+// kept by the aggregator itself; everything else comes from collectors.
+// Its methods are safe for concurrent use, but the statistics depend on the
+// order of the commits; parsers running in parallel, e.g. one per
+// repository or branch, feed it through a Feed each.
 type Aggregator struct {
-	repo     *Repository
-	timezone *time.Location
+	mu         sync.Mutex // guards repo and collector state
+	repo       *Repository
+	timezone   *time.Location
+	collectors []Collector
+
+	// Skip commits classified by IsMechanicalCommit, see SetExcludeMechanical
+	excludeMechanical bool
+
+	// Paths and author emails left out of the statistics, see SetExclusions
+	excludedPaths   []string
+	pathFilter      pathFilter
+	excludedAuthors map[string]bool
+
+	// Author emails mapped onto other identities, see SetAuthorAliases
+	aliases []aliasRule
+
+	// Hashes and patch-ids of processed commits, nil unless deduplicating
+	// (see SetDeduplicate)
+	seenHashes  map[string]bool
+	seenPatches map[string]bool
+
+	// Churn decays by half every halfLife before decayRef, see
+	// SetChurnHalfLife
+	halfLife time.Duration
+	decayRef time.Time
+
+	// Open feeds in processing order, see Feed; feedMu is taken before mu
+	feedMu sync.Mutex
+	feeds  []*Feed
 }
 
-// NewAggregator creates a new statistics aggregator
+// NewAggregator creates a new statistics aggregator with all built-in collectors
 func NewAggregator(repoPath string, dateRange DateRange, tz *time.Location) *Aggregator {
 	if tz == nil {
 		tz = time.Local
 	}
 	return &Aggregator{
-		repo:     NewRepository(repoPath, dateRange),
-		timezone: tz,
+		repo:       NewRepository(repoPath, dateRange),
+		timezone:   tz,
+		collectors: defaultCollectors(),
 	}
 }
 
-// ProcessCommit adds a commit's data to the statistics
+// Disable removes the named collectors; their statistics stay empty
+func (a *Aggregator) Disable(names ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	disabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		disabled[name] = true
+	}
+	kept := a.collectors[:0]
+	for _, c := range a.collectors {
+		if !disabled[c.Name()] {
+			kept = append(kept, c)
+		}
+	}
+	a.collectors = kept
+}
+
+// AddCollector registers an additional collector, run after the existing ones
+func (a *Aggregator) AddCollector(c Collector) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.collectors = append(a.collectors, c)
+}
+
+// SetExcludeMechanical leaves version bumps, lockfile updates and changelog
+// edits out of all statistics. They are still summarized in
+// Repository.Mechanical.
+func (a *Aggregator) SetExcludeMechanical(exclude bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.excludeMechanical = exclude
+	a.repo.Mechanical.Excluded = exclude
+	a.repo.Filters.NoMechanical = exclude
+}
+
+// SetExclusions leaves commits by the given author emails and changes to
+// the given files or directories out of all statistics. A commit that only
+// touched excluded paths is skipped as a whole.
+func (a *Aggregator) SetExclusions(paths, authors []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.excludedPaths = paths
+	a.excludedAuthors = make(map[string]bool, len(authors))
+	for _, email := range authors {
+		a.excludedAuthors[email] = true
+	}
+	a.repo.Filters.ExcludedPaths = paths
+	a.repo.Filters.ExcludedAuthors = authors
+}
+
+// SetAuthorAliases counts the commits of authors matching a rule under
+// the rule's identity. Email rules apply as each commit is processed, so
+// exclusions and every collector see the canonical email; username rules
+// merge the matching authors in Finalize, as ApplyAuthorMerges does. Rules
+// with an invalid pattern (see CheckAliasRules) are skipped.
+func (a *Aggregator) SetAuthorAliases(rules []AliasRule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.aliases = nil
+	for _, rule := range rules {
+		if compiled, err := compileAliasRules([]AliasRule{rule}); err == nil {
+			a.aliases = append(a.aliases, compiled...)
+		}
+	}
+}
+
+// SetPathFilter restricts all statistics to files matching one of the
+// include globs, or to every file when there are none, leaving out files
+// matching an exclude glob (see MatchGlob). A commit without remaining
+// changes is skipped as a whole, like with SetExclusions.
+func (a *Aggregator) SetPathFilter(include, exclude []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pathFilter = pathFilter{include: include, exclude: exclude}
+	a.repo.Filters.Include = include
+	a.repo.Filters.Exclude = exclude
+}
+
+// SetHistoryFilter records how the history was read, which the parser
+// applies: the refs scanned instead of HEAD and whether merges or the
+// commits of merged branches were left out
+func (a *Aggregator) SetHistoryFilter(refs []string, noMerges, firstParent bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.repo.Filters.Refs = refs
+	a.repo.Filters.NoMerges = noMerges
+	a.repo.Filters.FirstParent = firstParent
+}
+
+// SetChurnHalfLife weights each commit's churn and touch of a file by its
+// age, halving it every halfLife before the end of the range (now when the
+// range is open), so FileStats.DecayedChanges and DecayedTouches and the
+// hotspot risk favor recent changes. 0 weights every commit alike.
+func (a *Aggregator) SetChurnHalfLife(halfLife time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.halfLife = halfLife
+	a.decayRef = a.repo.DateRange.Until
+	if a.decayRef.IsZero() {
+		a.decayRef = time.Now()
+	}
+	a.repo.ChurnHalfLife = halfLife
+}
+
+// decay returns the weight of a commit authored at t
+func (a *Aggregator) decay(t time.Time) float64 {
+	if a.halfLife <= 0 {
+		return 1
+	}
+	age := max(a.decayRef.Sub(t), 0)
+	return math.Exp2(-float64(age) / float64(a.halfLife))
+}
+
+// excludePaths returns c without changes to excluded or filtered paths, or
+// nil if nothing else remains
+func (a *Aggregator) excludePaths(c *git.Commit) *git.Commit {
+	if len(a.excludedPaths) == 0 && a.pathFilter.empty() {
+		return c
+	}
+	var kept []git.FileChange
+	for _, fc := range c.FileChanges {
+		if !pathExcluded(fc.FilePath, a.excludedPaths) && a.pathFilter.keeps(fc.FilePath) {
+			kept = append(kept, fc)
+		}
+	}
+	if len(kept) == len(c.FileChanges) {
+		return c
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	filtered := *c
+	filtered.FileChanges = kept
+	return &filtered
+}
+
+// pathExcluded reports whether path is one of excluded or lies in one of
+// them; "." stands for the files at the repository root
+func pathExcluded(path string, excluded []string) bool {
+	for _, p := range excluded {
+		if path == p || strings.HasPrefix(path, p+"/") || (p == "." && !strings.Contains(path, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDeduplicate counts a commit found in several scanned repositories or
+// refs once: by hash for shared history, as in a fork and its upstream, and
+// by Commit.PatchID for copies such as cherry-picks or rebased commits. The
+// first copy processed is kept, so the upstream repository should be
+// scanned first.
+func (a *Aggregator) SetDeduplicate(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if enabled {
+		a.seenHashes = make(map[string]bool)
+		a.seenPatches = make(map[string]bool)
+	} else {
+		a.seenHashes, a.seenPatches = nil, nil
+	}
+}
+
+// isDuplicate reports whether c or a copy of it was processed before
+func (a *Aggregator) isDuplicate(c *git.Commit) bool {
+	if a.seenHashes[c.Hash] || (c.PatchID != "" && a.seenPatches[c.PatchID]) {
+		return true
+	}
+	a.seenHashes[c.Hash] = true
+	if c.PatchID != "" {
+		a.seenPatches[c.PatchID] = true
+	}
+	return false
+}
+
+// ProcessCommit adds a commit's data to the statistics. Commits come in
+// git log order, newest first, one repository after the other. It is safe
+// for concurrent use: message parsing and classification run in the
+// calling goroutine, only the updates to the shared statistics are
+// serialized. Concurrent calls may be applied in either order; Feed keeps
+// the order of parallel parsers.
 func (a *Aggregator) ProcessCommit(c *git.Commit) {
+	a.processCommit(c, false)
+}
+
+// ProcessForkCommit adds a commit read from a fork whose upstream
+// repository was processed before it, additionally counting it as
+// upstreamed or fork-only for its author (see Repository.Fork). It requires
+// deduplication to be enabled.
+func (a *Aggregator) ProcessForkCommit(c *git.Commit) {
+	a.processCommit(c, true)
+}
+
+// ProcessMerge adds a merge commit to the pull request statistics only,
+// for scans that leave merges out of everything else (see
+// git.Parser.ParseMerges)
+func (a *Aggregator) ProcessMerge(c *git.Commit) {
+	if !c.IsMerge {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	c = aliasEmail(a.aliases, c)
+	if a.excludedAuthors[c.Author.Email] {
+		return
+	}
+	localTime := c.AuthorDate.In(a.timezone)
+	cc := &CommitContext{
+		Commit:    c,
+		LocalTime: localTime,
+		DateKey:   localTime.Format("2006-01-02"),
+		MonthKey:  localTime.Format("2006-01"),
+		WeekKey:   WeekKey(localTime),
+	}
+	for _, collector := range a.collectors {
+		if _, ok := collector.(prCollector); ok {
+			collector.Collect(a.repo, cc)
+		}
+	}
+}
+
+func (a *Aggregator) processCommit(c *git.Commit, fork bool) {
+	a.mu.Lock()
+	c = aliasEmail(a.aliases, c)
+	excludedAuthor := a.excludedAuthors[c.Author.Email]
+	if !excludedAuthor {
+		c = a.excludePaths(c)
+	}
+	a.mu.Unlock()
+	if excludedAuthor || c == nil {
+		return
+	}
+
+	localTime := c.AuthorDate.In(a.timezone)
+	cc := &CommitContext{
+		Commit:    c,
+		LocalTime: localTime,
+		DateKey:   localTime.Format("2006-01-02"),
+		MonthKey:  localTime.Format("2006-01"),
+		WeekKey:   WeekKey(localTime),
+		Issues:    ParseClosedIssues(c.Subject + "\n" + c.Body),
+		Refactor:  IsRefactorCommit(c),
+		Decay:     a.decay(c.AuthorDate),
+	}
+	mechanical := IsMechanicalCommit(c)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	duplicate := a.seenHashes != nil && a.isDuplicate(c)
+	if fork && !c.IsMerge {
+		a.repo.recordForkCommit(c, duplicate)
+	}
+	if duplicate {
+		a.repo.DuplicateCommits++
+		return
+	}
+
+	if mechanical {
+		a.repo.Mechanical.record(c)
+		if a.excludeMechanical {
+			return
+		}
+	}
+
 	a.repo.TotalCommits++
+	cc.Author = a.collectAuthor(cc)
 
-	// Process merge commits for PR stats
-	if c.IsMerge {
-		a.processMergeCommit(c)
+	for _, collector := range a.collectors {
+		collector.Collect(a.repo, cc)
 	}
+}
 
-	// Author stats
+// collectAuthor updates the author's commit and line totals
+func (a *Aggregator) collectAuthor(cc *CommitContext) *AuthorStats {
+	c := cc.Commit
 	authorKey := c.Author.Email
 	author, ok := a.repo.Authors[authorKey]
 	if !ok {
@@ -45,6 +348,8 @@ func (a *Aggregator) ProcessCommit(c *git.Commit) {
 	}
 
 	author.Commits++
+	author.Weekly[cc.WeekKey]++
+	author.Daily[cc.DateKey]++
 	if author.FirstCommit.IsZero() || c.AuthorDate.Before(author.FirstCommit) {
 		author.FirstCommit = c.AuthorDate
 	}
@@ -52,19 +357,6 @@ func (a *Aggregator) ProcessCommit(c *git.Commit) {
 		author.LastCommit = c.AuthorDate
 	}
 
-	// Daily activity
-	dateKey := c.AuthorDate.In(a.timezone).Format("2006-01-02")
-	a.repo.DailyActivity[dateKey]++
-
-	// Hourly matrix (weekday x hour)
-	localTime := c.AuthorDate.In(a.timezone)
-	weekday := int(localTime.Weekday())
-	// Convert Sunday=0 to Monday=0 format
-	weekday = (weekday + 6) % 7
-	hour := localTime.Hour()
-	a.repo.HourlyMatrix[weekday][hour]++
-
-	// Process file changes
 	for _, fc := range c.FileChanges {
 		if fc.IsBinary {
 			continue
@@ -73,66 +365,75 @@ func (a *Aggregator) ProcessCommit(c *git.Commit) {
 		author.Additions += fc.Additions
 		author.Deletions += fc.Deletions
 		author.FilesTouched[fc.FilePath]++
+		cc.Lines += fc.Additions + fc.Deletions
 
 		a.repo.TotalAdditions += fc.Additions
 		a.repo.TotalDeletions += fc.Deletions
+	}
 
-		// File stats
-		fileStat, ok := a.repo.FileStats[fc.FilePath]
-		if !ok {
-			fileStat = NewFileStats(fc.FilePath)
-			a.repo.FileStats[fc.FilePath] = fileStat
-		}
+	return author
+}
 
-		fileStat.Additions += fc.Additions
-		fileStat.Deletions += fc.Deletions
-		fileStat.TotalChanges += fc.Additions + fc.Deletions
-		fileStat.TouchCount++
-		fileStat.Authors[c.Author.Email]++
+// Finalize calculates derived statistics after all commits are processed
+func (a *Aggregator) Finalize() *Repository {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 
-		// Directory stats
-		dir := getTopDir(fc.FilePath)
-		dirStat, ok := a.repo.DirStats[dir]
-		if !ok {
-			dirStat = NewDirStats(dir)
-			a.repo.DirStats[dir] = dirStat
+	for _, collector := range a.collectors {
+		if f, ok := collector.(Finalizer); ok {
+			f.Finalize(a.repo)
 		}
+	}
+	a.repo.ApplyAuthorMerges(usernameMerges(a.aliases, a.repo.Authors))
 
-		dirStat.TotalChanges += fc.Additions + fc.Deletions
-		dirStat.TouchCount++
+	return a.repo
+}
 
-		dirAuthor, ok := dirStat.Authors[c.Author.Email]
-		if !ok {
-			dirAuthor = &DirAuthorStats{
-				Name:  c.Author.Name,
-				Email: c.Author.Email,
-			}
-			dirStat.Authors[c.Author.Email] = dirAuthor
-		}
-		dirAuthor.Commits++
-		dirAuthor.Changes += fc.Additions + fc.Deletions
+// countResurrections counts creates that follow a delete of the same path.
+// git log emits newest commits first, so events are put in date order first.
+func countResurrections(events []FileEvent) int {
+	if len(events) < 2 {
+		return 0
 	}
-}
 
-// Finalize calculates derived statistics after all commits are processed
-func (a *Aggregator) Finalize() *Repository {
-	// Calculate directory ownership shares
-	for _, dir := range a.repo.DirStats {
-		if dir.TotalChanges > 0 {
-			for _, author := range dir.Authors {
-				author.Share = float64(author.Changes) / float64(dir.TotalChanges) * 100
-			}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+
+	count := 0
+	deleted := false
+	for _, e := range events {
+		if e.Deleted {
+			deleted = true
+		} else if deleted {
+			count++
+			deleted = false
 		}
 	}
+	return count
+}
 
-	return a.repo
+// monthEndDays is the window at the end of each month counted as month-end
+const monthEndDays = 5
+
+// daysInMonth returns the number of days in t's month
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
 }
 
-// GetResult returns the current repository statistics
+// GetResult returns the current repository statistics. Its fields must not
+// be read while other goroutines are still feeding commits.
 func (a *Aggregator) GetResult() *Repository {
 	return a.repo
 }
 
+// CommitCount returns the number of commits processed so far
+func (a *Aggregator) CommitCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.repo.TotalCommits
+}
+
 func getTopDir(path string) string {
 	// Clean the path
 	path = filepath.Clean(path)
@@ -149,32 +450,35 @@ func getTopDir(path string) string {
 
 // GetLeaderboard returns authors sorted by the given criteria
 func (r *Repository) GetLeaderboard(sortBy string, ascending bool) []*AuthorStats {
+	l := r.sortedAuthors(sortSpec("authors", sortBy, ascending), sortBy, ascending)
+	return append([]*AuthorStats(nil), l.items...)
+}
+
+// sortAuthors sorts authors by the given criteria, ties broken by email
+func (r *Repository) sortAuthors(sortBy string, ascending bool) []*AuthorStats {
 	authors := make([]*AuthorStats, 0, len(r.Authors))
 	for _, a := range r.Authors {
 		authors = append(authors, a)
 	}
 
 	sort.Slice(authors, func(i, j int) bool {
-		var cmp bool
+		var c int
 		switch sortBy {
 		case "name":
-			cmp = authors[i].Name < authors[j].Name
+			c = strings.Compare(authors[i].Name, authors[j].Name)
 		case "commits":
-			cmp = authors[i].Commits < authors[j].Commits
+			c = cmp.Compare(authors[i].Commits, authors[j].Commits)
 		case "additions":
-			cmp = authors[i].Additions < authors[j].Additions
+			c = cmp.Compare(authors[i].Additions, authors[j].Additions)
 		case "deletions":
-			cmp = authors[i].Deletions < authors[j].Deletions
+			c = cmp.Compare(authors[i].Deletions, authors[j].Deletions)
 		case "net":
-			cmp = (authors[i].Additions - authors[i].Deletions) <
-				(authors[j].Additions - authors[j].Deletions)
+			c = cmp.Compare(authors[i].Additions-authors[i].Deletions,
+				authors[j].Additions-authors[j].Deletions)
 		default:
-			cmp = authors[i].Commits < authors[j].Commits
+			c = cmp.Compare(authors[i].Commits, authors[j].Commits)
 		}
-		if ascending {
-			return cmp
-		}
-		return !cmp
+		return orderedLess(c, ascending, authors[i].Email, authors[j].Email)
 	})
 
 	return authors
@@ -182,50 +486,53 @@ func (r *Repository) GetLeaderboard(sortBy string, ascending bool) []*AuthorStat
 
 // GetTopFiles returns files sorted by the given criteria
 func (r *Repository) GetTopFiles(sortBy string, ascending bool, limit int) []*FileStats {
+	l := r.sortedFiles(sortSpec("files", sortBy, ascending), sortBy, ascending)
+	files := l.items
+	if limit > 0 && limit < len(files) {
+		files = files[:limit]
+	}
+	return append([]*FileStats(nil), files...)
+}
+
+// sortFiles sorts files by the given criteria, ties broken by path
+func (r *Repository) sortFiles(sortBy string, ascending bool) []*FileStats {
 	files := make([]*FileStats, 0, len(r.FileStats))
 	for _, f := range r.FileStats {
 		files = append(files, f)
 	}
 
 	sort.Slice(files, func(i, j int) bool {
-		var cmp bool
+		var c int
 		switch sortBy {
 		case "path":
-			cmp = files[i].Path < files[j].Path
+			c = strings.Compare(files[i].Path, files[j].Path)
 		case "changes":
-			cmp = files[i].TotalChanges < files[j].TotalChanges
+			c = cmp.Compare(files[i].TotalChanges, files[j].TotalChanges)
 		case "touches":
-			cmp = files[i].TouchCount < files[j].TouchCount
+			c = cmp.Compare(files[i].TouchCount, files[j].TouchCount)
 		case "authors":
-			cmp = len(files[i].Authors) < len(files[j].Authors)
+			c = cmp.Compare(len(files[i].Authors), len(files[j].Authors))
 		default:
-			cmp = files[i].TotalChanges < files[j].TotalChanges
-		}
-		if ascending {
-			return cmp
+			c = cmp.Compare(files[i].TotalChanges, files[j].TotalChanges)
 		}
-		return !cmp
+		return orderedLess(c, ascending, files[i].Path, files[j].Path)
 	})
 
-	if limit > 0 && limit < len(files) {
-		return files[:limit]
-	}
 	return files
 }
 
-// GetHotspots returns files with high churn and multiple authors
+// GetHotspots returns files with high churn and multiple authors. Once the
+// complexity of the files is measured, it scales their risk: complex files
+// that change often rank above simple ones changing as often.
 func (r *Repository) GetHotspots(limit int) []*HotspotFile {
 	hotspots := make([]*HotspotFile, 0)
 
-	// Find max values for normalization
-	var maxChanges, maxTouches int
+	// Find max values for normalization; churn and touches are weighted by
+	// age when a half-life is set
+	var maxChanges, maxTouches float64
 	for _, f := range r.FileStats {
-		if f.TotalChanges > maxChanges {
-			maxChanges = f.TotalChanges
-		}
-		if f.TouchCount > maxTouches {
-			maxTouches = f.TouchCount
-		}
+		maxChanges = max(maxChanges, f.DecayedChanges)
+		maxTouches = max(maxTouches, f.DecayedTouches)
 	}
 
 	if maxChanges == 0 {
@@ -234,6 +541,7 @@ func (r *Repository) GetHotspots(limit int) []*HotspotFile {
 	if maxTouches == 0 {
 		maxTouches = 1
 	}
+	maxComplexity := r.maxComplexity()
 
 	for _, f := range r.FileStats {
 		authorCount := len(f.Authors)
@@ -241,64 +549,59 @@ func (r *Repository) GetHotspots(limit int) []*HotspotFile {
 			continue // Skip single-author files
 		}
 
-		churnScore := float64(f.TotalChanges) / float64(maxChanges)
-		touchScore := float64(f.TouchCount) / float64(maxTouches)
+		churnScore := f.DecayedChanges / maxChanges
+		touchScore := f.DecayedTouches / maxTouches
 		authorScore := float64(authorCount) / float64(r.TotalAuthors)
 
-		// Combined risk score: churn * frequency * author diversity
+		// Combined risk score: churn * frequency * author diversity,
+		// scaled by complexity
 		riskScore := (churnScore*0.4 + touchScore*0.3 + authorScore*0.3) * 100
+		riskScore = min(100, riskScore*r.complexityFactor(f.Path, maxComplexity))
 
+		c, measured := r.Complexity[f.Path]
 		hotspots = append(hotspots, &HotspotFile{
-			Path:        f.Path,
-			ChurnScore:  churnScore * 100,
-			AuthorCount: authorCount,
-			RiskScore:   riskScore,
-			Changes:     f.TotalChanges,
-			TouchCount:  f.TouchCount,
+			Path:          f.Path,
+			ChurnScore:    churnScore * 100,
+			AuthorCount:   authorCount,
+			Complexity:    c.Total,
+			HasComplexity: measured,
+			RiskScore:     riskScore,
+			Changes:       f.TotalChanges,
+			TouchCount:    f.TouchCount,
 		})
 	}
 
 	// Sort by risk score descending
 	sort.Slice(hotspots, func(i, j int) bool {
-		return hotspots[i].RiskScore > hotspots[j].RiskScore
+		if hotspots[i].RiskScore != hotspots[j].RiskScore {
+			return hotspots[i].RiskScore > hotspots[j].RiskScore
+		}
+		return hotspots[i].Path < hotspots[j].Path
 	})
 
 	if limit > 0 && limit < len(hotspots) {
-		return hotspots[:limit]
+		hotspots = hotspots[:limit]
 	}
+
+	r.computeRiskTrends(hotspots)
 	return hotspots
 }
 
-// GetTimeline returns daily commit data with rolling average
-func (r *Repository) GetTimeline(windowDays int) *TimelineData {
+// GetTimeline returns the commits per period (PeriodDay, PeriodWeek,
+// PeriodMonth or PeriodQuarter) from the first to the last day with
+// commits, with a rolling average over window periods. Periods without
+// commits are included; the first and last may be partly outside the
+// range.
+func (r *Repository) GetTimeline(period string, window int) *TimelineData {
 	if len(r.DailyActivity) == 0 {
 		return &TimelineData{}
 	}
-
-	// Get sorted dates
-	dates := make([]string, 0, len(r.DailyActivity))
-	for d := range r.DailyActivity {
-		dates = append(dates, d)
-	}
-	sort.Strings(dates)
-
-	// Find date range
-	startDate, _ := time.Parse("2006-01-02", dates[0])
-	endDate, _ := time.Parse("2006-01-02", dates[len(dates)-1])
-
-	// Fill in all dates in range
-	var labels []string
-	var values []int
-	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		dateStr := d.Format("2006-01-02")
-		labels = append(labels, dateStr)
-		values = append(values, r.DailyActivity[dateStr])
-	}
+	labels, values := r.periodSeries(r.DailyActivity, period)
 
 	// Calculate rolling average
 	rollingAvg := make([]float64, len(values))
 	for i := range values {
-		start := i - windowDays + 1
+		start := i - window + 1
 		if start < 0 {
 			start = 0
 		}
@@ -310,13 +613,88 @@ func (r *Repository) GetTimeline(windowDays int) *TimelineData {
 	}
 
 	return &TimelineData{
-		Period:     "day",
+		Period:     period,
 		Labels:     labels,
 		Values:     values,
 		RollingAvg: rollingAvg,
 	}
 }
 
+// GetAuthorTimeline returns an author's commits per period, aligned to the
+// repository's timeline so authors can be compared; nil for an unknown
+// email. There is no rolling average.
+func (r *Repository) GetAuthorTimeline(email, period string) *TimelineData {
+	author, ok := r.Authors[email]
+	if !ok || len(r.DailyActivity) == 0 {
+		return nil
+	}
+	labels, values := r.periodSeries(author.Daily, period)
+	return &TimelineData{Period: period, Labels: labels, Values: values}
+}
+
+// periodSeries sums daily counts into the periods from the first to the
+// last day with commits in the repository
+func (r *Repository) periodSeries(daily map[string]int, period string) ([]string, []int) {
+	// Fill in all periods in range
+	startDate, endDate := r.activityBounds()
+	var labels []string
+	var values []int
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		label := periodLabel(period, d)
+		if len(labels) == 0 || labels[len(labels)-1] != label {
+			labels = append(labels, label)
+			values = append(values, 0)
+		}
+		values[len(values)-1] += daily[d.Format("2006-01-02")]
+	}
+	return labels, values
+}
+
+// GetDirTimeline returns daily commit and churn data for a directory,
+// aligned to the repository-wide activity range so directories can be compared
+func (r *Repository) GetDirTimeline(path string) *DirTimelineData {
+	data := &DirTimelineData{Path: path}
+
+	dir := r.GetDir(path)
+	if dir == nil || len(r.DailyActivity) == 0 {
+		return data
+	}
+
+	startDate, endDate := r.activityBounds()
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dateStr := d.Format("2006-01-02")
+		commits := dir.DailyCommits[dateStr]
+
+		data.Labels = append(data.Labels, dateStr)
+		data.Commits = append(data.Commits, commits)
+		data.Churn = append(data.Churn, dir.DailyChurn[dateStr])
+
+		if commits > 0 {
+			data.ActiveDays++
+			data.LastActive = dateStr
+		}
+	}
+
+	return data
+}
+
+// activityBounds returns the first and last day with recorded commits
+func (r *Repository) activityBounds() (time.Time, time.Time) {
+	var first, last string
+	for d := range r.DailyActivity {
+		if first == "" || d < first {
+			first = d
+		}
+		if last == "" || d > last {
+			last = d
+		}
+	}
+
+	startDate, _ := time.Parse("2006-01-02", first)
+	endDate, _ := time.Parse("2006-01-02", last)
+	return startDate, endDate
+}
+
 // GetHeatmap returns hourly commit distribution data
 func (r *Repository) GetHeatmap(tz *time.Location) *HeatmapData {
 	var maxValue int
@@ -335,31 +713,61 @@ func (r *Repository) GetHeatmap(tz *time.Location) *HeatmapData {
 	}
 }
 
+// GetCalendarHeatmaps returns the month x day-of-month and month x weekday
+// matrices as row slices, with the share of commits landing at month-end
+func (r *Repository) GetCalendarHeatmaps() *CalendarHeatmaps {
+	c := &CalendarHeatmaps{
+		MonthDay:     make([][]int, 12),
+		MonthWeekday: make([][]int, 12),
+	}
+	total := 0
+	for m := 0; m < 12; m++ {
+		c.MonthDay[m] = r.MonthDay[m][:]
+		c.MonthWeekday[m] = r.MonthWeekday[m][:]
+		for _, v := range r.MonthDay[m] {
+			total += v
+		}
+	}
+	if total > 0 {
+		c.MonthEndPercent = float64(r.MonthEnd) / float64(total) * 100
+	}
+	return c
+}
+
 // GetOwnership returns directories with author ownership data
 func (r *Repository) GetOwnership(sortBy string, ascending bool) []*DirStats {
+	l := r.sortedDirs(sortSpec("dirs", sortBy, ascending), sortBy, ascending)
+	return append([]*DirStats(nil), l.items...)
+}
+
+// sortDirs sorts the top-level directories by the given criteria
+func (r *Repository) sortDirs(sortBy string, ascending bool) []*DirStats {
 	dirs := make([]*DirStats, 0, len(r.DirStats))
 	for _, d := range r.DirStats {
 		dirs = append(dirs, d)
 	}
+	return sortDirList(dirs, sortBy, ascending)
+}
 
+// sortDirList sorts directories by the given criteria, ties broken by path
+func sortDirList(dirs []*DirStats, sortBy string, ascending bool) []*DirStats {
 	sort.Slice(dirs, func(i, j int) bool {
-		var cmp bool
+		var c int
 		switch sortBy {
 		case "path":
-			cmp = dirs[i].Path < dirs[j].Path
+			c = strings.Compare(dirs[i].Path, dirs[j].Path)
 		case "changes":
-			cmp = dirs[i].TotalChanges < dirs[j].TotalChanges
+			c = cmp.Compare(dirs[i].TotalChanges, dirs[j].TotalChanges)
 		case "touches":
-			cmp = dirs[i].TouchCount < dirs[j].TouchCount
+			c = cmp.Compare(dirs[i].TouchCount, dirs[j].TouchCount)
 		case "authors":
-			cmp = len(dirs[i].Authors) < len(dirs[j].Authors)
+			c = cmp.Compare(len(dirs[i].Authors), len(dirs[j].Authors))
+		case "turnover":
+			c = cmp.Compare(turnoverShift(dirs[i]), turnoverShift(dirs[j]))
 		default:
-			cmp = dirs[i].TotalChanges < dirs[j].TotalChanges
-		}
-		if ascending {
-			return cmp
+			c = cmp.Compare(dirs[i].TotalChanges, dirs[j].TotalChanges)
 		}
-		return !cmp
+		return orderedLess(c, ascending, dirs[i].Path, dirs[j].Path)
 	})
 
 	return dirs
@@ -375,14 +783,64 @@ func (r *Repository) GetCodebaseStats() *CodebaseStats {
 		refactoredPct = float64(totalChanges) / float64(r.CodebaseSize) * 100
 	}
 
+	// File lifecycle counts
+	var added, deleted, resurrected int
+	for _, f := range r.FileStats {
+		added += f.Created
+		deleted += f.Deleted
+		if f.Resurrections > 0 {
+			resurrected++
+		}
+	}
+
+	// Surviving vs churned additions
+	var surviving, churned int
+	for _, author := range r.Authors {
+		surviving += author.SurvivingLines
+		churned += author.ChurnedLines
+	}
+	var productivePct float64
+	if surviving+churned > 0 {
+		productivePct = float64(surviving) / float64(surviving+churned) * 100
+	}
+
 	return &CodebaseStats{
 		TotalAdditions:    r.TotalAdditions,
 		TotalDeletions:    r.TotalDeletions,
 		TotalChanges:      totalChanges,
+		FilesAdded:        added,
 		FilesModified:     len(r.FileStats),
+		FilesDeleted:      deleted,
+		FilesResurrected:  resurrected,
 		CodebaseSize:      r.CodebaseSize,
 		RefactoredPercent: refactoredPct,
+		SurvivingLines:    surviving,
+		ChurnedLines:      churned,
+		ProductivePercent: productivePct,
+	}
+}
+
+// GetResurrectedFiles returns files that were deleted and re-created,
+// most resurrected first
+func (r *Repository) GetResurrectedFiles(limit int) []*FileStats {
+	files := make([]*FileStats, 0)
+	for _, f := range r.FileStats {
+		if f.Resurrections > 0 {
+			files = append(files, f)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Resurrections != files[j].Resurrections {
+			return files[i].Resurrections > files[j].Resurrections
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	if limit > 0 && limit < len(files) {
+		return files[:limit]
 	}
+	return files
 }
 
 // ApplyAuthorMerges merges authors based on the provided mapping
@@ -400,6 +858,16 @@ func (r *Repository) ApplyAuthorMerges(merges map[string]string) {
 		}
 	}
 
+	// Earlier merges into an identity that is now an alias follow it
+	for _, m := range r.IdentityMerges {
+		if primaryEmail, ok := merges[m.PrimaryEmail]; ok && primaryEmail != m.PrimaryEmail {
+			if primary, exists := r.Authors[primaryEmail]; exists {
+				m.PrimaryEmail = primaryEmail
+				m.PrimaryName = primary.Name
+			}
+		}
+	}
+
 	// Merge author stats
 	for aliasEmail, primaryEmail := range merges {
 		if aliasEmail == primaryEmail {
@@ -417,12 +885,25 @@ func (r *Repository) ApplyAuthorMerges(merges map[string]string) {
 		primary.Commits += alias.Commits
 		primary.Additions += alias.Additions
 		primary.Deletions += alias.Deletions
+		primary.SurvivingLines += alias.SurvivingLines
+		primary.ChurnedLines += alias.ChurnedLines
+		primary.RefactorCommits += alias.RefactorCommits
+		primary.CommitTimes = append(primary.CommitTimes, alias.CommitTimes...)
+		for week, commits := range alias.Weekly {
+			primary.Weekly[week] += commits
+		}
+		for day, commits := range alias.Daily {
+			primary.Daily[day] += commits
+		}
 
 		// Merge files touched
 		for file, count := range alias.FilesTouched {
 			primary.FilesTouched[file] += count
 		}
 
+		// Merge message conventions
+		primary.Messages.Merge(alias.Messages)
+
 		// Update date range
 		if alias.FirstCommit.Before(primary.FirstCommit) {
 			primary.FirstCommit = alias.FirstCommit
@@ -431,11 +912,55 @@ func (r *Repository) ApplyAuthorMerges(merges map[string]string) {
 			primary.LastCommit = alias.LastCommit
 		}
 
+		r.IdentityMerges = append(r.IdentityMerges, &IdentityMerge{
+			PrimaryName:  primary.Name,
+			PrimaryEmail: primaryEmail,
+			AliasName:    alias.Name,
+			AliasEmail:   aliasEmail,
+		})
+
 		// Remove alias from authors map
 		delete(r.Authors, aliasEmail)
 		r.TotalAuthors--
 	}
 
+	// Update commit size ownership
+	for i := range r.CommitSizes {
+		if primaryEmail, ok := merges[r.CommitSizes[i].Email]; ok {
+			r.CommitSizes[i].Email = primaryEmail
+		}
+	}
+
+	// Update debt marker authors
+	for _, m := range r.DebtMarkers {
+		if primaryEmail, ok := merges[m.AuthorEmail]; ok {
+			m.AuthorEmail = primaryEmail
+			if primary, exists := r.Authors[primaryEmail]; exists {
+				m.Author = primary.Name
+			}
+		}
+	}
+
+	// Update issue-closing commit authors
+	for _, ic := range r.IssueCloses {
+		if primaryEmail, ok := merges[ic.AuthorEmail]; ok {
+			ic.AuthorEmail = primaryEmail
+			if primary, exists := r.Authors[primaryEmail]; exists {
+				ic.Author = primary.Name
+			}
+		}
+	}
+
+	// Update cherry-pick authors
+	for _, cp := range r.CherryPicks {
+		if primaryEmail, ok := merges[cp.AuthorEmail]; ok {
+			cp.AuthorEmail = primaryEmail
+			if primary, exists := r.Authors[primaryEmail]; exists {
+				cp.Author = primary.Name
+			}
+		}
+	}
+
 	// Update file stats authors
 	for _, fileStat := range r.FileStats {
 		for aliasEmail, primaryEmail := range merges {
@@ -446,58 +971,48 @@ func (r *Repository) ApplyAuthorMerges(merges map[string]string) {
 				fileStat.Authors[primaryEmail] += count
 				delete(fileStat.Authors, aliasEmail)
 			}
+			for _, month := range fileStat.Monthly {
+				if count, exists := month.Authors[aliasEmail]; exists {
+					month.Authors[primaryEmail] += count
+					delete(month.Authors, aliasEmail)
+				}
+			}
 		}
 	}
 
 	// Update directory stats authors
 	for _, dirStat := range r.DirStats {
-		for aliasEmail, primaryEmail := range merges {
-			if aliasEmail == primaryEmail {
-				continue
-			}
-
-			alias, aliasExists := dirStat.Authors[aliasEmail]
-			primary, primaryExists := dirStat.Authors[primaryEmail]
-
-			if !aliasExists {
-				continue
-			}
-
-			if !primaryExists {
-				// Rename alias to primary
-				dirStat.Authors[primaryEmail] = alias
-				alias.Email = primaryEmail
-			} else {
-				// Merge into primary
-				primary.Commits += alias.Commits
-				primary.Changes += alias.Changes
-			}
+		dirStat.mergeAuthors(merges)
+	}
+	r.DirTree.walk(func(dir *DirStats) { dir.mergeAuthors(merges) })
 
-			delete(dirStat.Authors, aliasEmail)
-		}
+	r.mergeForkContributions(merges)
+	r.mergeLabelAuthors(merges)
+	r.mergeDayAuthors(merges)
+	r.mergeLanguageAuthors(merges)
 
-		// Recalculate shares
-		if dirStat.TotalChanges > 0 {
-			for _, author := range dirStat.Authors {
-				author.Share = float64(author.Changes) / float64(dirStat.TotalChanges) * 100
-			}
-		}
+	// Keep the comparison period consistent with the merged identities
+	if r.Previous != nil {
+		r.Previous.ApplyAuthorMerges(merges)
+		r.computeTurnover()
 	}
+
+	r.invalidateSorted()
 }
 
 // processMergeCommit processes a merge commit for PR statistics
-func (a *Aggregator) processMergeCommit(c *git.Commit) {
-	prStats := a.repo.PRStats
+func (r *Repository) processMergeCommit(cc *CommitContext) {
+	c := cc.Commit
+	prStats := r.PRStats
 	prStats.TotalMerges++
 
 	// Track daily merges
-	dateKey := c.AuthorDate.In(a.timezone).Format("2006-01-02")
-	prStats.DailyMerges[dateKey]++
+	prStats.DailyMerges[cc.DateKey]++
 
 	// Calculate totals for this merge
 	additions := 0
 	deletions := 0
-	for _, fc := range c.FileChanges {
+	for _, fc := range c.MergeChanges {
 		if !fc.IsBinary {
 			additions += fc.Additions
 			deletions += fc.Deletions
@@ -527,6 +1042,7 @@ func (a *Aggregator) processMergeCommit(c *git.Commit) {
 	}
 
 	prInfo := &PRInfo{
+		Hash:          c.Hash,
 		PRNumber:      c.PRNumber,
 		MergedBy:      c.Author.Name,
 		MergedByEmail: c.Author.Email,
@@ -535,11 +1051,42 @@ func (a *Aggregator) processMergeCommit(c *git.Commit) {
 		Subject:       c.Subject,
 		Additions:     additions,
 		Deletions:     deletions,
-		FilesCount:    len(c.FileChanges),
+		FilesCount:    len(c.MergeChanges),
+		Files:         c.MergeChanges,
 	}
 	prStats.PRList = append(prStats.PRList, prInfo)
 }
 
+// TopDirs returns the top-level directories a pull request touched, by
+// lines changed
+func (p *PRInfo) TopDirs() []PRDirChange {
+	byDir := make(map[string]*PRDirChange)
+	for _, fc := range p.Files {
+		dir := getTopDir(fc.FilePath)
+		d, ok := byDir[dir]
+		if !ok {
+			d = &PRDirChange{Path: dir}
+			byDir[dir] = d
+		}
+		d.Files++
+		if !fc.IsBinary {
+			d.Changes += fc.Additions + fc.Deletions
+		}
+	}
+
+	dirs := make([]PRDirChange, 0, len(byDir))
+	for _, d := range byDir {
+		dirs = append(dirs, *d)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if dirs[i].Changes != dirs[j].Changes {
+			return dirs[i].Changes > dirs[j].Changes
+		}
+		return dirs[i].Path < dirs[j].Path
+	})
+	return dirs
+}
+
 // GetPRLeaderboard returns authors sorted by merge count
 func (r *Repository) GetPRLeaderboard(sortBy string, ascending bool) []*PRAuthorStats {
 	authors := make([]*PRAuthorStats, 0, len(r.PRStats.MergesByAuthor))
@@ -548,21 +1095,18 @@ func (r *Repository) GetPRLeaderboard(sortBy string, ascending bool) []*PRAuthor
 	}
 
 	sort.Slice(authors, func(i, j int) bool {
-		var cmp bool
+		var c int
 		switch sortBy {
 		case "name":
-			cmp = authors[i].Name < authors[j].Name
+			c = strings.Compare(authors[i].Name, authors[j].Name)
 		case "merges":
-			cmp = authors[i].MergeCount < authors[j].MergeCount
+			c = cmp.Compare(authors[i].MergeCount, authors[j].MergeCount)
 		case "changes":
-			cmp = authors[i].TotalChanges < authors[j].TotalChanges
+			c = cmp.Compare(authors[i].TotalChanges, authors[j].TotalChanges)
 		default:
-			cmp = authors[i].MergeCount < authors[j].MergeCount
+			c = cmp.Compare(authors[i].MergeCount, authors[j].MergeCount)
 		}
-		if ascending {
-			return cmp
-		}
-		return !cmp
+		return orderedLess(c, ascending, authors[i].Email, authors[j].Email)
 	})
 
 	return authors
@@ -570,29 +1114,45 @@ func (r *Repository) GetPRLeaderboard(sortBy string, ascending bool) []*PRAuthor
 
 // GetPRList returns PRs sorted by date or size
 func (r *Repository) GetPRList(sortBy string, ascending bool, limit int) []*PRInfo {
+	l := r.sortedPRs(sortSpec("prs", sortBy, ascending), sortBy, ascending)
+	prs := l.items
+	if limit > 0 && limit < len(prs) {
+		prs = prs[:limit]
+	}
+	return append([]*PRInfo(nil), prs...)
+}
+
+// sortPRs sorts PRs by the given criteria, ties broken by merge commit hash
+func (r *Repository) sortPRs(sortBy string, ascending bool) []*PRInfo {
 	prs := make([]*PRInfo, len(r.PRStats.PRList))
 	copy(prs, r.PRStats.PRList)
 
 	sort.Slice(prs, func(i, j int) bool {
-		var cmp bool
+		var c int
 		switch sortBy {
 		case "date":
-			cmp = prs[i].MergedAt.Before(prs[j].MergedAt)
+			c = prs[i].MergedAt.Compare(prs[j].MergedAt)
 		case "size":
-			cmp = (prs[i].Additions + prs[i].Deletions) < (prs[j].Additions + prs[j].Deletions)
+			c = cmp.Compare(prs[i].Additions+prs[i].Deletions, prs[j].Additions+prs[j].Deletions)
 		case "files":
-			cmp = prs[i].FilesCount < prs[j].FilesCount
+			c = cmp.Compare(prs[i].FilesCount, prs[j].FilesCount)
 		default:
-			cmp = prs[i].MergedAt.Before(prs[j].MergedAt)
-		}
-		if ascending {
-			return cmp
+			c = prs[i].MergedAt.Compare(prs[j].MergedAt)
 		}
-		return !cmp
+		return orderedLess(c, ascending, prs[i].Hash, prs[j].Hash)
 	})
 
-	if limit > 0 && limit < len(prs) {
-		return prs[:limit]
-	}
 	return prs
 }
+
+// orderedLess applies the sort direction to a comparison result; ties are
+// broken by key in ascending order so listings are stable across calls
+func orderedLess(c int, ascending bool, keyI, keyJ string) bool {
+	if c == 0 {
+		return keyI < keyJ
+	}
+	if ascending {
+		return c < 0
+	}
+	return c > 0
+}
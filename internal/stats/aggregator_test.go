@@ -0,0 +1,604 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/complexity"
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/gittest"
+)
+
+// aggregate parses the repository with opts and returns the finalized
+// statistics of its whole history
+func aggregate(t *testing.T, dir string, opts git.ParseOptions) *Repository {
+	t.Helper()
+	a := NewAggregator(dir, DateRange{}, time.UTC)
+	parser := git.NewExecParser(dir)
+	parser.ParseOptions = opts
+	if err := parser.Parse(context.Background(), time.Time{}, time.Time{}, nil, a.ProcessCommit); err != nil {
+		t.Fatal(err)
+	}
+	if opts.NoMerges {
+		if err := parser.ParseMerges(context.Background(), time.Time{}, time.Time{}, a.ProcessMerge); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return a.Finalize()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatRepository renders the statistics the views build on for golden
+// files, in a stable order
+func formatRepository(r *Repository) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "commits %d, authors %d, +%d -%d, roots %d, parents %d\n",
+		r.TotalCommits, r.TotalAuthors, r.TotalAdditions, r.TotalDeletions, r.RootCommits, r.TotalParents)
+	fmt.Fprintf(&sb, "bus factor %d\n", r.BusFactor())
+
+	sb.WriteString("\nauthors\n")
+	for _, email := range sortedKeys(r.Authors) {
+		a := r.Authors[email]
+		fmt.Fprintf(&sb, "  %s <%s> commits %d +%d -%d files %d refactor %d first %s last %s\n",
+			a.Name, email, a.Commits, a.Additions, a.Deletions, len(a.FilesTouched), a.RefactorCommits,
+			a.FirstCommit.UTC().Format(time.RFC3339), a.LastCommit.UTC().Format(time.RFC3339))
+		m := a.Messages
+		fmt.Fprintf(&sb, "    messages %d conventional %d tickets %d body %d fixup %d wip %d\n",
+			m.Commits, m.Conventional, m.TicketRefs, m.WithBody, m.Fixups, m.WIP)
+	}
+
+	sb.WriteString("\nfiles\n")
+	for _, path := range sortedKeys(r.FileStats) {
+		f := r.FileStats[path]
+		fmt.Fprintf(&sb, "  %s changes %d (+%d -%d) touches %d created %d deleted %d renames %d",
+			path, f.TotalChanges, f.Additions, f.Deletions, f.TouchCount, f.Created, f.Deleted, f.Renames)
+		if f.RenamedFrom != "" {
+			fmt.Fprintf(&sb, " from %s", f.RenamedFrom)
+		}
+		sb.WriteString("\n    authors")
+		for _, email := range sortedKeys(f.Authors) {
+			fmt.Fprintf(&sb, " %s=%d", email, f.Authors[email])
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\ndirs\n")
+	for _, path := range sortedKeys(r.DirStats) {
+		d := r.DirStats[path]
+		fmt.Fprintf(&sb, "  %s changes %d touches %d\n    authors", path, d.TotalChanges, d.TouchCount)
+		for _, email := range sortedKeys(d.Authors) {
+			da := d.Authors[email]
+			fmt.Fprintf(&sb, " %s=%d(%.1f%%)", email, da.Changes, da.Share)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\ngenerated\n")
+	for _, path := range sortedKeys(r.Generated) {
+		fmt.Fprintf(&sb, "  %s %d\n", path, r.Generated[path])
+	}
+
+	sb.WriteString("\npull requests\n")
+	fmt.Fprintf(&sb, "  merges %d prs %d\n", r.PRStats.TotalMerges, r.PRStats.TotalPRs)
+	for _, email := range sortedKeys(r.PRStats.MergesByAuthor) {
+		pa := r.PRStats.MergesByAuthor[email]
+		fmt.Fprintf(&sb, "  %s merges %d changes %d prs %v\n", email, pa.MergeCount, pa.TotalChanges, pa.PRNumbers)
+	}
+
+	sb.WriteString("\ndaily\n")
+	for _, day := range sortedKeys(r.DailyActivity) {
+		fmt.Fprintf(&sb, "  %s %d\n", day, r.DailyActivity[day])
+	}
+	return sb.String()
+}
+
+func TestAggregateProject(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true})
+	gittest.Golden(t, "project", formatRepository(r))
+}
+
+// Merges parsed separately, as with Config.ExcludeMerges, still count as
+// pull requests
+func TestAggregateProjectNoMerges(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true, NoMerges: true})
+	gittest.Golden(t, "project-no-merges", formatRepository(r))
+}
+
+func TestSimulateDeparture(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true})
+
+	impact := r.SimulateDeparture(1)
+	if len(impact.Leaving) != 1 {
+		t.Fatalf("got %d leaving authors, want 1", len(impact.Leaving))
+	}
+	for _, risk := range impact.Risks {
+		if risk.Remaining >= KnowledgeShare {
+			t.Errorf("%s: remaining share %.1f%% counts as knowing it", risk.Path, risk.Remaining)
+		}
+	}
+	if all := r.SimulateDeparture(len(r.Authors)); all.LostFiles != all.Files {
+		t.Errorf("everyone leaving loses %d of %d files", all.LostFiles, all.Files)
+	}
+}
+
+// Old churn fades from the hotspot risk with a half-life
+func TestChurnHalfLife(t *testing.T) {
+	repo := gittest.New(t)
+	repo.At(time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC))
+	repo.Write("old.go", gittest.Lines("old", 200))
+	repo.Commit(gittest.Alice, "Add old")
+	repo.Write("old.go", gittest.Lines("older", 200))
+	repo.Commit(gittest.Bob, "Rewrite old")
+	repo.At(time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC))
+	repo.Write("new.go", gittest.Lines("new", 20))
+	repo.Commit(gittest.Alice, "Add new")
+	repo.Write("new.go", gittest.Lines("newer", 20))
+	repo.Commit(gittest.Bob, "Rewrite new")
+
+	hotspots := func(halfLife time.Duration) []*HotspotFile {
+		a := NewAggregator(repo.Dir, DateRange{Until: time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)}, time.UTC)
+		a.SetChurnHalfLife(halfLife)
+		if err := git.NewExecParser(repo.Dir).Parse(context.Background(), time.Time{}, time.Time{}, nil, a.ProcessCommit); err != nil {
+			t.Fatal(err)
+		}
+		r := a.Finalize()
+		if f := r.FileStats["old.go"]; halfLife == 0 && (f.DecayedChanges != float64(f.TotalChanges) || f.DecayedTouches != float64(f.TouchCount)) {
+			t.Errorf("without a half-life old.go weighs %.1f changes and %.1f touches, want %d and %d",
+				f.DecayedChanges, f.DecayedTouches, f.TotalChanges, f.TouchCount)
+		}
+		return r.GetHotspots(0)
+	}
+
+	if got := hotspots(0); len(got) != 2 || got[0].Path != "old.go" {
+		t.Errorf("without a half-life got %v first, want old.go", got[0].Path)
+	}
+	got := hotspots(30 * 24 * time.Hour)
+	if len(got) != 2 || got[0].Path != "new.go" {
+		t.Fatalf("with a half-life got %v first, want new.go", got[0].Path)
+	}
+	if got[0].Changes != 60 || got[1].Changes != 600 {
+		t.Errorf("got %d and %d changes, want the raw counts 60 and 600", got[0].Changes, got[1].Changes)
+	}
+}
+
+func TestCodeOwners(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true})
+
+	handles := map[string]string{gittest.Alice.Email: "alice", gittest.Bob.Email: "@bob"}
+	gittest.Golden(t, "codeowners", CodeOwnersFile(r.CodeOwners(20, 2), handles, "Draft"))
+}
+
+func TestHealth(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true})
+
+	// Pull request sizes are left out with a weight of 0
+	weights := map[string]float64{HealthBusFactor: 2, HealthHotspots: 1, HealthChurn: 1, HealthContributors: 1}
+	gittest.Golden(t, "health", r.Health(weights).String())
+}
+
+func TestMarkdownReportFilters(t *testing.T) {
+	repo := gittest.Project(t)
+	a := NewAggregator("project", DateRange{}, time.UTC)
+	a.SetPathFilter([]string{"internal/**", "cmd/**"}, nil)
+	a.SetExclusions(nil, []string{gittest.Carol.Email})
+	a.SetHistoryFilter(nil, false, true)
+	parser := git.NewExecParser(repo.Dir)
+	parser.ParseOptions = git.ParseOptions{SkipGenerated: true, FirstParent: true}
+	if err := parser.Parse(context.Background(), time.Time{}, time.Time{}, nil, a.ProcessCommit); err != nil {
+		t.Fatal(err)
+	}
+	r := a.Finalize()
+	gittest.Golden(t, "report-filtered", r.MarkdownReport(3, 3))
+	if got := r.ReportHeader()[0]; got != "project, all history" {
+		t.Errorf("report header %q, want the open range as all history", got)
+	}
+}
+
+func TestAuthorAliases(t *testing.T) {
+	repo := gittest.New(t)
+	for i, author := range []gittest.Person{
+		gittest.Alice,
+		{Name: "Alice Example", Email: "alice@oldcorp.com"},
+		{Name: "Alice Example", Email: "12345+alice@users.noreply.github.com"},
+		gittest.Bob,
+		{Name: "bob", Email: "bob@users.noreply.github.com"},
+		{Name: "Builder", Email: "bob@home.example"},
+		{Name: "Carol Coder", Email: "CAROL@OLDCORP.COM"},
+	} {
+		repo.Write("file.go", gittest.Lines(fmt.Sprint("v", i), 5))
+		repo.Commit(author, fmt.Sprint("Change ", i))
+	}
+
+	a := NewAggregator(repo.Dir, DateRange{}, time.UTC)
+	a.SetAuthorAliases([]AliasRule{
+		{Match: `^(.+)@oldcorp\.com$`, Email: "$1@example.com"},
+		{Match: `^(?:\d+\+)?(.+)@users\.noreply\.github\.com$`, Username: "$1"},
+	})
+	if err := git.NewExecParser(repo.Dir).Parse(context.Background(), time.Time{}, time.Time{}, nil, a.ProcessCommit); err != nil {
+		t.Fatal(err)
+	}
+	r := a.Finalize()
+
+	commits := make(map[string]int)
+	for email, author := range r.Authors {
+		commits[email] = author.Commits
+	}
+	// Bob's noreply email stays apart: bob@example.com and bob@home.example
+	// both match the username
+	want := map[string]int{
+		"alice@example.com":            3,
+		"bob@example.com":              1,
+		"bob@users.noreply.github.com": 1,
+		"bob@home.example":             1,
+		"CAROL@example.com":            1,
+	}
+	if fmt.Sprint(commits) != fmt.Sprint(want) {
+		t.Errorf("got commits %v, want %v", commits, want)
+	}
+	if got := r.Mailmap(); len(got) != 1 || !strings.Contains(got[0], "12345+alice@users.noreply.github.com") {
+		t.Errorf("got mailmap %q, want the noreply alias of Alice", got)
+	}
+}
+
+func TestHotspotComplexity(t *testing.T) {
+	repo := gittest.Project(t)
+	r := aggregate(t, repo.Dir, git.ParseOptions{SkipGenerated: true})
+
+	risk := make(map[string]float64)
+	for _, h := range r.GetHotspots(0) {
+		risk[h.Path] = h.RiskScore
+	}
+	if got := r.GetHotspots(0)[0].Path; got != "README.md" {
+		t.Fatalf("got %s first before measuring complexity, want README.md", got)
+	}
+
+	// main.go is the most complex file; README.md has no analyzer
+	r.Complexity = map[string]complexity.File{
+		"cmd/app/main.go":     {Functions: 2, Total: 8, Max: 6},
+		"internal/lib/lib.go": {Functions: 1, Total: 2, Max: 2},
+	}
+	hotspots := r.GetHotspots(0)
+	if hotspots[0].Path != "cmd/app/main.go" || !hotspots[0].HasComplexity || hotspots[0].Complexity != 8 {
+		t.Fatalf("got %s first with complexity %d, want cmd/app/main.go with 8", hotspots[0].Path, hotspots[0].Complexity)
+	}
+	if got, want := hotspots[0].RiskScore, min(100, risk["cmd/app/main.go"]*1.5); math.Abs(got-want) > 1e-9 {
+		t.Errorf("got main.go risk %.2f, want %.2f", got, want)
+	}
+	if hotspots[1].Path != "README.md" || hotspots[1].RiskScore != risk["README.md"] || hotspots[1].HasComplexity {
+		t.Errorf("got %s with risk %.2f, want README.md unchanged at %.2f", hotspots[1].Path, hotspots[1].RiskScore, risk["README.md"])
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) // a Monday
+	for day := 0; day < 70; day++ {
+		r.DailyActivity[start.AddDate(0, 0, day).Format("2006-01-02")] = 4
+	}
+	r.DailyActivity["2024-02-27"] = 15 // Tuesday of the 9th week
+	r.DailyActivity["2024-03-01"] = 0  // Friday of the 9th week
+	r.Days["2024-02-27"] = &DayActivity{Authors: map[string]int{"bob@example.com": 5, "alice@example.com": 8}}
+	r.Days["2024-02-27"].record(DayCommit{Hash: "small", Email: "alice@example.com", Lines: 3})
+	r.Days["2024-02-27"].record(DayCommit{Hash: "large", Email: "alice@example.com", Lines: 300})
+
+	anomalies := r.GetAnomalies()
+	var got []string
+	for _, a := range anomalies {
+		got = append(got, fmt.Sprintf("%s %v %.1f", a.Date, a.Spike, a.ZScore))
+	}
+	want := []string{"2024-02-27 true 11.0", "2024-03-01 false -4.0"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got anomalies %q, want %q", got, want)
+	}
+	spike := anomalies[0]
+	if len(spike.Authors) != 2 || spike.Authors[0].Email != "alice@example.com" || spike.Authors[0].Commits != 10 {
+		t.Errorf("got authors %+v, want alice first with 10 commits", spike.Authors)
+	}
+	if len(spike.Top) != 2 || spike.Top[0].Hash != "large" {
+		t.Errorf("got commits %+v, want the largest first", spike.Top)
+	}
+}
+
+func TestDirTree(t *testing.T) {
+	repo := gittest.New(t)
+	repo.Write("internal/stats/a.go", gittest.Lines("a", 4))
+	repo.Write("internal/ui/views/b.go", gittest.Lines("b", 2))
+	repo.Commit(gittest.Alice, "Add a and b")
+	repo.Write("internal/ui/views/b.go", gittest.Lines("c", 2))
+	repo.Write("README.md", gittest.Lines("d", 1))
+	repo.Commit(gittest.Bob, "Change b")
+
+	r := aggregate(t, repo.Dir, git.ParseOptions{})
+
+	var got []string
+	for _, dir := range r.GetSubdirs("path", true, "internal") {
+		got = append(got, fmt.Sprintf("%s %d %d", dir.Path, dir.TotalChanges, len(dir.Authors)))
+	}
+	want := []string{"internal/stats 4 1", "internal/ui 6 2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got subdirectories %q, want %q", got, want)
+	}
+
+	views := r.GetDir("internal/ui/views")
+	if views == nil || views.TouchCount != 2 || views.Authors[gittest.Bob.Email].Changes != 4 {
+		t.Errorf("got internal/ui/views %+v, want Bob owning 4 of 6 lines", views)
+	}
+	if r.HasSubdirs("internal/stats") || !r.HasSubdirs("internal/ui") {
+		t.Error("internal/ui has subdirectories and internal/stats has none")
+	}
+	if top := r.GetDir("internal"); top != r.DirStats["internal"] {
+		t.Error("GetDir did not return the top-level directory")
+	}
+}
+
+func TestTimelinePeriods(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	r.DailyActivity["2024-03-31"] = 1 // Sunday of ISO week 13, Q1
+	r.DailyActivity["2024-04-01"] = 2 // Monday of week 14, Q2
+	r.DailyActivity["2024-04-16"] = 4
+
+	for _, tc := range []struct {
+		period string
+		want   string
+	}{
+		{PeriodDay, "17 2024-03-31 2024-04-16"},
+		{PeriodWeek, "[2024-W13 2024-W14 2024-W15 2024-W16] [1 2 0 4]"},
+		{PeriodMonth, "[2024-03 2024-04] [1 6]"},
+		{PeriodQuarter, "[2024-Q1 2024-Q2] [1 6]"},
+	} {
+		timeline := r.GetTimeline(tc.period, 2)
+		got := fmt.Sprint(timeline.Labels, " ", timeline.Values)
+		if tc.period == PeriodDay {
+			got = fmt.Sprint(len(timeline.Labels), " ", timeline.Labels[0], " ", timeline.Labels[len(timeline.Labels)-1])
+		}
+		if got != tc.want || timeline.Period != tc.period {
+			t.Errorf("%s: got %s, want %s", tc.period, got, tc.want)
+		}
+	}
+
+	weeks := r.GetTimeline(PeriodWeek, 2)
+	if weeks.RollingAvg[1] != 1.5 || weeks.Forecast(4) != nil {
+		t.Errorf("got rolling average %v and a forecast of weeks, want 1.5 and none", weeks.RollingAvg)
+	}
+
+	r.Authors["a@example.com"] = &AuthorStats{Email: "a@example.com", Daily: map[string]int{"2024-04-01": 2}}
+	author := r.GetAuthorTimeline("a@example.com", PeriodWeek)
+	if got := fmt.Sprint(author.Labels, " ", author.Values); got != "[2024-W13 2024-W14 2024-W15 2024-W16] [0 2 0 0]" {
+		t.Errorf("got author timeline %s, want the weeks of the repository", got)
+	}
+	if r.GetAuthorTimeline("nobody@example.com", PeriodWeek) != nil {
+		t.Error("got a timeline for an unknown author")
+	}
+}
+
+func TestMilestones(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	author := NewAuthorStats("Alice", "alice@example.com")
+	author.Daily = map[string]int{"2023-01-10": 50, "2023-06-01": 210, "2024-02-01": 10}
+	r.Authors[author.Email] = author
+	for _, dir := range []struct{ path, first string }{{"src", "2023-01-10"}, {"docs", "2023-06-01"}, {".", "2023-06-01"}} {
+		r.DirStats[dir.path] = NewDirStats(dir.path)
+		r.DirStats[dir.path].Authors[author.Email] = &DirAuthorStats{Name: "Alice", Email: author.Email, FirstDay: dir.first}
+	}
+
+	var got []string
+	for _, m := range r.GetMilestones() {
+		got = append(got, m.Date+" "+m.Title())
+	}
+	want := []string{
+		"2024-01-10 1 year in the repository",
+		"2023-06-01 100th commit",
+		"2023-06-01 250th commit",
+		"2023-06-01 First change to docs/",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got milestones %q, want %q", got, want)
+	}
+}
+
+func TestSetPRReviews(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	for _, hash := range []string{"a1", "b2", "c3", "d4"} {
+		r.PRStats.PRList = append(r.PRStats.PRList, &PRInfo{Hash: hash})
+	}
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	r.SetPRReviews(map[string]*PRReview{
+		"a1": {CreatedAt: opened, MergedAt: opened.Add(48 * time.Hour), FirstReviewAt: opened.Add(2 * time.Hour),
+			Reviews: 3, Reviewers: []string{"bob", "carol"}},
+		"b2": {CreatedAt: opened, MergedAt: opened.Add(4 * time.Hour), FirstReviewAt: opened.Add(6 * time.Hour),
+			Reviews: 1, Reviewers: []string{"carol"}},
+		"c3": {CreatedAt: opened, MergedAt: opened.Add(time.Hour)},
+		"zz": {CreatedAt: opened, MergedAt: opened.Add(time.Hour), Reviewers: []string{"dave"}},
+	})
+
+	s := r.PRStats
+	if s.Reviewed != 3 || s.Unreviewed != 1 || s.Reviews != 4 {
+		t.Errorf("reviewed %d, unreviewed %d, reviews %d; want 3, 1, 4", s.Reviewed, s.Unreviewed, s.Reviews)
+	}
+	if s.MedianTimeToMerge != 4*time.Hour || s.MedianFirstReview != 2*time.Hour {
+		t.Errorf("median time to merge %v, to first review %v; want 4h, 2h", s.MedianTimeToMerge, s.MedianFirstReview)
+	}
+	if got := s.TopReviewers(5); !slices.Equal(got, []string{"carol", "bob"}) {
+		t.Errorf("top reviewers %q, want carol, bob", got)
+	}
+	if s.PRList[3].Review != nil {
+		t.Error("PR without GitHub data got a review")
+	}
+}
+
+// parseAll returns the commits of a repository's whole history, newest
+// first
+func parseAll(t *testing.T, dir string) []*git.Commit {
+	t.Helper()
+	var commits []*git.Commit
+	err := git.NewExecParser(dir).Parse(context.Background(), time.Time{}, time.Time{}, nil, func(c *git.Commit) {
+		commits = append(commits, c)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commits
+}
+
+// formatFeedOrder renders the order-dependent statistics of an upstream
+// and its fork: those of formatRepository, the fork split and the files'
+// delete/re-create cycles
+func formatFeedOrder(r *Repository) string {
+	var sb strings.Builder
+	sb.WriteString(formatRepository(r))
+	fmt.Fprintf(&sb, "duplicates %d\n", r.DuplicateCommits)
+	for _, f := range r.GetForkContributions() {
+		fmt.Fprintf(&sb, "fork %s upstreamed %d only %d\n", f.Email, f.Upstreamed, f.ForkOnly)
+	}
+	for _, path := range sortedKeys(r.FileStats) {
+		fmt.Fprintf(&sb, "%s resurrections %d\n", path, r.FileStats[path].Resurrections)
+	}
+	return sb.String()
+}
+
+// Feeds filled from parallel goroutines give the statistics of processing
+// the repositories one after the other; run with -race
+func TestFeedOrder(t *testing.T) {
+	upstream := gittest.Project(t)
+	fork := gittest.Project(t)
+	fork.Write("README.md", gittest.Lines("readme", 4))
+	fork.Commit(gittest.Alice, "Restore the README")
+
+	newAggregator := func() *Aggregator {
+		a := NewAggregator("project", DateRange{}, time.UTC)
+		a.SetDeduplicate(true)
+		return a
+	}
+
+	sequential := newAggregator()
+	for _, c := range parseAll(t, upstream.Dir) {
+		sequential.ProcessCommit(c)
+	}
+	for _, c := range parseAll(t, fork.Dir) {
+		sequential.ProcessForkCommit(c)
+	}
+	want := formatFeedOrder(sequential.Finalize())
+
+	parallel := newAggregator()
+	upstreamFeed, forkFeed := parallel.Feed(), parallel.Feed()
+	upstreamCommits, forkCommits := parseAll(t, upstream.Dir), parseAll(t, fork.Dir)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer upstreamFeed.Close()
+		for _, c := range upstreamCommits {
+			upstreamFeed.ProcessCommit(c)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		defer forkFeed.Close()
+		for _, c := range forkCommits {
+			forkFeed.ProcessForkCommit(c)
+		}
+	}()
+	wg.Wait()
+	r := parallel.Finalize()
+
+	if r.DuplicateCommits == 0 || r.FileStats["README.md"].Resurrections != 1 {
+		t.Fatalf("got %d duplicates, README.md resurrected %d times; want the fork's shared history deduplicated and 1",
+			r.DuplicateCommits, r.FileStats["README.md"].Resurrections)
+	}
+	if got := formatFeedOrder(r); got != want {
+		t.Errorf("parallel feeds differ from sequential processing:\n%s\nwant:\n%s", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("a commit fed to a closed feed was accepted")
+		}
+	}()
+	forkFeed.ProcessCommit(forkCommits[0])
+}
+
+func TestIssueStats(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	monday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	for _, day := range []time.Time{monday, monday.AddDate(0, 0, 15)} {
+		r.DailyActivity[day.Format("2006-01-02")]++
+	}
+	r.IssueCloses = []*IssueClose{
+		{Hash: "a", Author: "Alice", AuthorEmail: "alice@example.com", At: monday, Issues: []string{"#1"}},
+		{Hash: "b", Author: "Bob", AuthorEmail: "bob@example.com", At: monday.AddDate(0, 0, 15), Issues: []string{"#1"}},
+	}
+
+	s := r.GetIssueStats()
+	var authors []string
+	for _, a := range s.ByAuthor {
+		authors = append(authors, fmt.Sprintf("%s %d/%d", a.Name, a.Issues, a.Commits))
+	}
+	if want := []string{"Alice 1/1", "Bob 0/1"}; !slices.Equal(authors, want) {
+		t.Errorf("got authors %q, want %q", authors, want)
+	}
+	if want := []string{"2024-03-04", "2024-03-11", "2024-03-18"}; !slices.Equal(s.Weeks, want) || !slices.Equal(s.ByWeek, []int{1, 0, 0}) {
+		t.Errorf("got weeks %q with %v closes, want %q with 1, 0, 0", s.Weeks, s.ByWeek, want)
+	}
+}
+
+// Adherence counts the commits following both conventions at once
+func TestMessageAdherence(t *testing.T) {
+	r := NewRepository("test", DateRange{})
+	subjects := map[string][]string{
+		"alice@example.com": {"✨ add the parser", "✨ add tests", "Fehler behoben", "Modul umbenannt"},
+		"bob@example.com":   {"✨ add docs", "✨ fix the build", "✨ update deps", "✨ add logo"},
+	}
+	for email, list := range subjects {
+		a := NewAuthorStats(email, email)
+		a.Messages = NewMessageStats()
+		for _, subject := range list {
+			a.Messages.Add(subject, "")
+		}
+		r.Authors[email] = a
+	}
+
+	conv := r.GetMessageConventions()
+	if !conv.UsesEmoji || conv.Language != LangEnglish {
+		t.Fatalf("got emoji %v, language %s; want emoji and English", conv.UsesEmoji, conv.Language)
+	}
+	for _, report := range conv.AuthorReports {
+		want := map[string]float64{"alice@example.com": 50, "bob@example.com": 100}[report.Email]
+		if report.Adherence != want {
+			t.Errorf("%s: adherence %.1f%%, want %.0f%%", report.Email, report.Adherence, want)
+		}
+	}
+}
+
+// Work estimates share the week keys of the author's weekly commits
+func TestEstimateHoursWeeks(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	times := []time.Time{monday, monday.Add(time.Hour), monday.AddDate(0, 0, 9)}
+	w := EstimateHours(times, 2*time.Hour, 30*time.Minute)
+	if want := []string{WeekKey(monday), WeekKey(times[2])}; !slices.Equal(w.Weeks(), want) {
+		t.Errorf("got weeks %q, want %q", w.Weeks(), want)
+	}
+	if w.Weekly["2024-03-04"] != 1.5 || w.Commits["2024-03-11"] != 1 {
+		t.Errorf("got hours %v, commits %v", w.Weekly, w.Commits)
+	}
+}
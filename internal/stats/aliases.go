@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// AliasRule maps the author emails matching a pattern onto one identity,
+// applied to every commit as it is aggregated, so the same person committing
+// under several emails counts once without merging them by hand
+type AliasRule struct {
+	// Regular expression matched against author emails, ignoring case,
+	// e.g. `^(.+)@oldcorp\.com$`
+	Match string `json:"match"`
+
+	// Email the commits are counted under, expanded with the groups of
+	// Match as in regexp.Regexp.Expand, e.g. "$1@newcorp.com"
+	Email string `json:"email,omitempty"`
+
+	// Username, expanded likewise, of the author the commits are merged
+	// with: the one other author whose email before the @ or whose name
+	// is the username, ignoring case. Matches with no such author, or
+	// several, stay apart. E.g. `^(?:\d+\+)?(.+)@users\.noreply\.github\.com$`
+	// with "$1".
+	Username string `json:"username,omitempty"`
+}
+
+// aliasRule is an AliasRule with its pattern compiled
+type aliasRule struct {
+	AliasRule
+	re *regexp.Regexp
+}
+
+// CheckAliasRules reports the first rule with an invalid pattern, or
+// without exactly one of Email and Username
+func CheckAliasRules(rules []AliasRule) error {
+	_, err := compileAliasRules(rules)
+	return err
+}
+
+func compileAliasRules(rules []AliasRule) ([]aliasRule, error) {
+	compiled := make([]aliasRule, 0, len(rules))
+	for _, rule := range rules {
+		if (rule.Email == "") == (rule.Username == "") {
+			return nil, fmt.Errorf("author alias %q needs either an email or a username", rule.Match)
+		}
+		re, err := regexp.Compile("(?i)" + rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("author alias %q: %v", rule.Match, err)
+		}
+		compiled = append(compiled, aliasRule{AliasRule: rule, re: re})
+	}
+	return compiled, nil
+}
+
+// expand returns the rule's template expanded for email, or "" when the
+// rule does not match it
+func (r aliasRule) expand(template, email string) string {
+	match := r.re.FindStringSubmatchIndex(email)
+	if match == nil {
+		return ""
+	}
+	return string(r.re.ExpandString(nil, template, email, match))
+}
+
+// aliasEmail returns c with its author email replaced by the first email
+// rule matching it, or c itself when none does. The commit is copied, as
+// the parser may share it, e.g. with the commit cache.
+func aliasEmail(rules []aliasRule, c *git.Commit) *git.Commit {
+	for _, rule := range rules {
+		if rule.Email == "" {
+			continue
+		}
+		email := rule.expand(rule.Email, c.Author.Email)
+		if email == "" {
+			continue
+		}
+		if email == c.Author.Email {
+			return c
+		}
+		aliased := *c
+		aliased.Author.Email = email
+		return &aliased
+	}
+	return c
+}
+
+// usernameMerges returns the merges of the username rules over authors, in
+// the email -> primary email form of ApplyAuthorMerges
+func usernameMerges(rules []aliasRule, authors map[string]*AuthorStats) map[string]string {
+	usernames := make(map[string]string) // alias email -> username
+	for email := range authors {
+		for _, rule := range rules {
+			if rule.Username == "" {
+				continue
+			}
+			if username := rule.expand(rule.Username, email); username != "" {
+				usernames[email] = strings.ToLower(username)
+				break
+			}
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	// Authors by username, leaving out the aliases themselves
+	candidates := make(map[string][]string)
+	for email, author := range authors {
+		if _, alias := usernames[email]; alias {
+			continue
+		}
+		local, _, _ := strings.Cut(strings.ToLower(email), "@")
+		candidates[local] = append(candidates[local], email)
+		if name := strings.ToLower(author.Name); name != local {
+			candidates[name] = append(candidates[name], email)
+		}
+	}
+
+	merges := make(map[string]string)
+	for email, username := range usernames {
+		if primaries := candidates[username]; len(primaries) == 1 {
+			merges[email] = primaries[0]
+			merges[primaries[0]] = primaries[0]
+		}
+	}
+	return merges
+}
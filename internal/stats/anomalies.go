@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Anomaly detection: each day is compared with the same weekday of the
+// anomalyWeeks weeks before it, so quiet weekends are not dips. A day is a
+// spike when its z-score reaches spikeZScore with at least
+// anomalyMinCommits commits, and a dip when it falls to dipZScore on a
+// weekday that usually has anomalyMinCommits. The standard deviation is
+// taken as at least 1, so a single commit after weeks without any is no
+// spike.
+const (
+	anomalyWeeks      = 8
+	anomalyMinWeeks   = 4
+	spikeZScore       = 3.0
+	dipZScore         = -2.5
+	anomalyMinCommits = 3
+)
+
+// Commits and authors kept per day for the anomalies
+const (
+	dayTopCommits = 3
+	dayTopAuthors = 3
+)
+
+// DayActivity is what happened on one day, kept to explain anomalous days
+type DayActivity struct {
+	Authors map[string]int // commits per author email
+	Top     []DayCommit    // largest commits, most lines first
+}
+
+// DayCommit is one of the largest commits of a day
+type DayCommit struct {
+	Hash    string
+	Subject string
+	Email   string
+	Lines   int
+}
+
+// record adds a commit to the day
+func (d *DayActivity) record(c DayCommit) {
+	d.Authors[c.Email]++
+	i := sort.Search(len(d.Top), func(i int) bool { return d.Top[i].Lines < c.Lines })
+	if i >= dayTopCommits {
+		return
+	}
+	d.Top = slices.Insert(d.Top, i, c)
+	if len(d.Top) > dayTopCommits {
+		d.Top = d.Top[:dayTopCommits]
+	}
+}
+
+// Anomaly is a day whose commits stand out from the same weekday in the
+// weeks before it
+type Anomaly struct {
+	Date     string // "2024-01-15"
+	Commits  int
+	Baseline float64 // mean commits of the same weekday before
+	ZScore   float64
+	Spike    bool // above the baseline; false for a dip
+	Authors  []AuthorCommits
+	Top      []DayCommit
+}
+
+// AuthorCommits is an author's commits on an anomalous day
+type AuthorCommits struct {
+	Name    string
+	Email   string
+	Commits int
+}
+
+// GetAnomalies returns the spikes and dips in the daily commits, oldest
+// first, each with the day's most active authors and largest commits
+func (r *Repository) GetAnomalies() []*Anomaly {
+	timeline := r.GetTimeline(PeriodDay, 1)
+	values := timeline.Values
+
+	var anomalies []*Anomaly
+	for i, commits := range values {
+		var history []float64
+		for w := 1; w <= anomalyWeeks && i-7*w >= 0; w++ {
+			history = append(history, float64(values[i-7*w]))
+		}
+		if len(history) < anomalyMinWeeks {
+			continue
+		}
+		mean, sd := meanStdDev(history)
+		z := (float64(commits) - mean) / max(sd, 1)
+
+		spike := z >= spikeZScore && commits >= anomalyMinCommits
+		dip := z <= dipZScore && mean >= anomalyMinCommits
+		if !spike && !dip {
+			continue
+		}
+		anomalies = append(anomalies, r.anomaly(timeline.Labels[i], commits, mean, z, spike))
+	}
+	return anomalies
+}
+
+func (r *Repository) anomaly(date string, commits int, mean, z float64, spike bool) *Anomaly {
+	a := &Anomaly{Date: date, Commits: commits, Baseline: mean, ZScore: z, Spike: spike}
+	day, ok := r.Days[date]
+	if !ok {
+		return a
+	}
+	a.Top = day.Top
+	for email, n := range day.Authors {
+		name := email
+		if author, ok := r.Authors[email]; ok {
+			name = author.Name
+		}
+		a.Authors = append(a.Authors, AuthorCommits{Name: name, Email: email, Commits: n})
+	}
+	slices.SortFunc(a.Authors, func(x, y AuthorCommits) int {
+		return cmp.Or(cmp.Compare(y.Commits, x.Commits), cmp.Compare(x.Email, y.Email))
+	})
+	if len(a.Authors) > dayTopAuthors {
+		a.Authors = a.Authors[:dayTopAuthors]
+	}
+	return a
+}
+
+// Weekday returns the anomaly's day of the week
+func (a *Anomaly) Weekday() time.Weekday {
+	t, _ := time.Parse("2006-01-02", a.Date)
+	return t.Weekday()
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// mergeDayAuthors moves the daily commits of merged identities to their
+// primary
+func (r *Repository) mergeDayAuthors(merges map[string]string) {
+	for _, day := range r.Days {
+		for aliasEmail, primaryEmail := range merges {
+			if count, ok := day.Authors[aliasEmail]; ok && aliasEmail != primaryEmail {
+				day.Authors[primaryEmail] += count
+				delete(day.Authors, aliasEmail)
+			}
+		}
+		for i := range day.Top {
+			if primaryEmail, ok := merges[day.Top[i].Email]; ok {
+				day.Top[i].Email = primaryEmail
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// AuthorDetail is everything known about one author, for the drill-down
+// from the Leaderboard
+type AuthorDetail struct {
+	Author *AuthorStats
+
+	// Commits per bucket of days from the first to the last commit of the
+	// repository, oldest first
+	Timeline []int
+
+	ActiveDays    int // days with a commit
+	LongestStreak int // most consecutive days with a commit
+	Days          int // days from the first to the last commit of the repository
+
+	// Lines changed per non-merge commit
+	AverageSize float64
+	Sizes       SizePercentiles
+
+	Files  []*AuthorFile    // most touched first
+	Dirs   []*MergeDirShare // most changes first
+	PRs    *PRAuthorStats   // merges performed, nil if none
+	Merges []*PRInfo        // merges performed, newest first
+}
+
+// AuthorFile is a file an author touched
+type AuthorFile struct {
+	Path    string
+	Touches int     // commits of the author touching the file
+	Share   float64 // percentage of the file's commits
+}
+
+// GetAuthorDetail returns the detail of the author with email, with up to
+// limit files, directories and merges and a timeline of buckets cells, or
+// nil if there is no such author
+func (r *Repository) GetAuthorDetail(email string, limit, buckets int) *AuthorDetail {
+	author, ok := r.Authors[email]
+	if !ok {
+		return nil
+	}
+	detail := &AuthorDetail{Author: author, ActiveDays: len(author.Daily)}
+
+	// The daily keys sum like the weekly ones
+	days := r.activityDays()
+	detail.Days = len(days)
+	detail.Timeline = WeeklySeries(author.Daily, days, buckets)
+	streak := 0
+	for _, day := range days {
+		if author.Daily[day] > 0 {
+			streak++
+			detail.LongestStreak = max(detail.LongestStreak, streak)
+		} else {
+			streak = 0
+		}
+	}
+
+	var sizes []int
+	total := 0
+	for _, s := range r.CommitSizes {
+		if s.Email == email {
+			sizes = append(sizes, s.Lines)
+			total += s.Lines
+		}
+	}
+	if len(sizes) > 0 {
+		detail.AverageSize = float64(total) / float64(len(sizes))
+	}
+	detail.Sizes = sizePercentiles(sizes)
+
+	for path, touches := range author.FilesTouched {
+		file := &AuthorFile{Path: path, Touches: touches}
+		if f, ok := r.FileStats[path]; ok && f.TouchCount > 0 {
+			file.Share = safePercent(f.Authors[email], f.TouchCount)
+		}
+		detail.Files = append(detail.Files, file)
+	}
+	sort.Slice(detail.Files, func(i, j int) bool {
+		if detail.Files[i].Touches != detail.Files[j].Touches {
+			return detail.Files[i].Touches > detail.Files[j].Touches
+		}
+		return detail.Files[i].Path < detail.Files[j].Path
+	})
+
+	for path, dir := range r.DirStats {
+		if a, ok := dir.Authors[email]; ok {
+			detail.Dirs = append(detail.Dirs, &MergeDirShare{Path: path, Commits: a.Commits, Changes: a.Changes, Share: a.Share})
+		}
+	}
+	sort.Slice(detail.Dirs, func(i, j int) bool {
+		if detail.Dirs[i].Changes != detail.Dirs[j].Changes {
+			return detail.Dirs[i].Changes > detail.Dirs[j].Changes
+		}
+		return detail.Dirs[i].Path < detail.Dirs[j].Path
+	})
+
+	if r.PRStats != nil {
+		detail.PRs = r.PRStats.MergesByAuthor[email]
+		for _, pr := range r.PRStats.PRList {
+			if pr.MergedByEmail == email {
+				detail.Merges = append(detail.Merges, pr)
+			}
+		}
+		sort.SliceStable(detail.Merges, func(i, j int) bool {
+			return detail.Merges[i].MergedAt.After(detail.Merges[j].MergedAt)
+		})
+	}
+
+	if limit > 0 {
+		detail.Files = detail.Files[:min(limit, len(detail.Files))]
+		detail.Dirs = detail.Dirs[:min(limit, len(detail.Dirs))]
+		detail.Merges = detail.Merges[:min(limit, len(detail.Merges))]
+	}
+	return detail
+}
+
+// activityDays returns the keys of every day from the first to the last
+// commit, including days without commits
+func (r *Repository) activityDays() []string {
+	if len(r.DailyActivity) == 0 {
+		return nil
+	}
+	first, last := "", ""
+	for d := range r.DailyActivity {
+		if first == "" || d < first {
+			first = d
+		}
+		if d > last {
+			last = d
+		}
+	}
+
+	start, _ := time.Parse("2006-01-02", first)
+	end, _ := time.Parse("2006-01-02", last)
+	var days []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, d.Format("2006-01-02"))
+	}
+	return days
+}
@@ -0,0 +1,128 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// Merge ratio below which history is considered effectively linear
+const linearMergeRatio = 0.05
+
+// BranchingStats holds commit graph metrics describing how work is integrated
+type BranchingStats struct {
+	Commits       int
+	Merges        int
+	RootCommits   int
+	OctopusMerges int
+
+	MergeRatio      float64 // merges / commits
+	AvgParents      float64 // mean parent count per commit
+	CommitsPerMerge float64 // non-merge commits per merge
+
+	// Integration frequency
+	MergesPerWeek     float64
+	MedianPerWeek     float64 // median of MergesByWeek, empty weeks included
+	AvgDaysBetween    float64 // mean gap between days with merges
+	LongestMergeGap   int     // longest gap in days between merges
+	ActiveMergeWeeks  int     // weeks with at least one merge
+	Weeks             []string
+	MergesByWeek      []int
+	DirectCommits     int     // non-merge commits made directly on the trunk
+	DirectShare       float64 // DirectCommits / non-merge commits, in percent
+	BranchCommitShare float64 // non-merge commits that arrived through merges, in percent
+}
+
+// Style classifies the branching workflow from the commit graph
+func (b *BranchingStats) Style() string {
+	switch {
+	case b.Commits == 0:
+		return "No commits"
+	case b.MergeRatio < linearMergeRatio:
+		return "Trunk-based (linear history)"
+	case b.MergesPerWeek >= 5 && b.CommitsPerMerge <= 5:
+		return "Short-lived branches"
+	case b.AvgDaysBetween > 7 || b.CommitsPerMerge > 20:
+		return "Long-lived branches"
+	default:
+		return "Feature branches"
+	}
+}
+
+// GetBranchingStats computes merge ratio, average parents and branch
+// integration frequency from the scanned commit graph
+func (r *Repository) GetBranchingStats() *BranchingStats {
+	b := &BranchingStats{
+		Commits:       r.TotalCommits,
+		Merges:        r.PRStats.TotalMerges,
+		RootCommits:   r.RootCommits,
+		OctopusMerges: r.OctopusMerges,
+		DirectCommits: r.FirstParentCommits,
+	}
+	if b.Commits == 0 {
+		return b
+	}
+
+	nonMerge := b.Commits - b.Merges
+	b.MergeRatio = float64(b.Merges) / float64(b.Commits)
+	b.AvgParents = float64(r.TotalParents) / float64(b.Commits)
+	if b.Merges > 0 {
+		b.CommitsPerMerge = float64(nonMerge) / float64(b.Merges)
+	}
+	if nonMerge > 0 && b.DirectCommits > 0 {
+		b.DirectShare = float64(b.DirectCommits) / float64(nonMerge) * 100
+		b.BranchCommitShare = 100 - b.DirectShare
+	}
+
+	// Merges per calendar week over the active range, empty weeks included
+	startDate, endDate := r.activityBounds()
+	byWeek := make(map[string]int)
+	var mergeDays []string
+	for day, count := range r.PRStats.DailyMerges {
+		t, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		byWeek[WeekKey(t)] += count
+		mergeDays = append(mergeDays, day)
+	}
+	firstWeek, _ := time.Parse("2006-01-02", WeekKey(startDate))
+	for week := firstWeek; !week.After(endDate); week = week.AddDate(0, 0, 7) {
+		key := week.Format("2006-01-02")
+		b.Weeks = append(b.Weeks, key)
+		b.MergesByWeek = append(b.MergesByWeek, byWeek[key])
+		if byWeek[key] > 0 {
+			b.ActiveMergeWeeks++
+		}
+	}
+	if len(b.Weeks) > 0 {
+		b.MergesPerWeek = float64(b.Merges) / float64(len(b.Weeks))
+
+		weekly := make([]int, len(b.MergesByWeek))
+		copy(weekly, b.MergesByWeek)
+		sort.Ints(weekly)
+		mid := len(weekly) / 2
+		if len(weekly)%2 == 1 {
+			b.MedianPerWeek = float64(weekly[mid])
+		} else {
+			b.MedianPerWeek = float64(weekly[mid-1]+weekly[mid]) / 2
+		}
+	}
+
+	// Gaps between consecutive days with merges
+	sort.Strings(mergeDays)
+	totalGap := 0
+	for i := 1; i < len(mergeDays); i++ {
+		prev, _ := time.Parse("2006-01-02", mergeDays[i-1])
+		cur, _ := time.Parse("2006-01-02", mergeDays[i])
+		gap := int(cur.Sub(prev).Hours() / 24)
+		totalGap += gap
+		if gap > b.LongestMergeGap {
+			b.LongestMergeGap = gap
+		}
+	}
+	if len(mergeDays) > 1 {
+		b.AvgDaysBetween = float64(totalGap) / float64(len(mergeDays)-1)
+	}
+
+	return b
+}
@@ -0,0 +1,199 @@
+package stats
+
+import "sort"
+
+// KnowledgeShare is the share of a file's commits, or of a directory's
+// churn, an author needs to count as knowing it; the Ownership view's bus
+// factor estimate uses the same bar
+const KnowledgeShare = 10.0
+
+// KnowledgeLoss is a file or top-level directory that no remaining author
+// knows once the leaving authors are gone
+type KnowledgeLoss struct {
+	Path      string
+	IsDir     bool
+	Churn     int      // lines changed
+	Lost      float64  // share held by the leaving authors, 0-100
+	Remaining float64  // largest share of a remaining author, below KnowledgeShare
+	Owners    []string // leaving authors who knew it, largest share first
+}
+
+// DepartureImpact is the outcome of the top contributors leaving: the files
+// and directories somebody knew before but nobody knows after
+type DepartureImpact struct {
+	Leaving   []*AuthorStats   // most lines changed first
+	Files     int              // files somebody knows
+	LostFiles int              // of Files, those only the leaving authors know
+	Dirs      int              // directories somebody knows
+	LostDirs  int              // of Dirs, those only the leaving authors know
+	Churn     int              // lines changed in Files
+	LostChurn int              // lines changed in the lost files
+	Risks     []*KnowledgeLoss // lost files and directories, most churn first
+}
+
+// FilePercent returns the share of known files that become unowned
+func (d *DepartureImpact) FilePercent() float64 {
+	return safePercent(d.LostFiles, d.Files)
+}
+
+// DirPercent returns the share of known directories that become unowned
+func (d *DepartureImpact) DirPercent() float64 {
+	return safePercent(d.LostDirs, d.Dirs)
+}
+
+// ChurnPercent returns the share of the known files' churn that becomes
+// unowned, weighting the loss by how actively the files change
+func (d *DepartureImpact) ChurnPercent() float64 {
+	return safePercent(d.LostChurn, d.Churn)
+}
+
+func safePercent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// TopContributors returns up to n authors by lines changed, the order the
+// bus factor counts them in
+func (r *Repository) TopContributors(n int) []*AuthorStats {
+	authors := make([]*AuthorStats, 0, len(r.Authors))
+	for _, a := range r.Authors {
+		authors = append(authors, a)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		ci := authors[i].Additions + authors[i].Deletions
+		cj := authors[j].Additions + authors[j].Deletions
+		if ci != cj {
+			return ci > cj
+		}
+		return authors[i].Email < authors[j].Email
+	})
+	if n < len(authors) {
+		authors = authors[:max(n, 0)]
+	}
+	return authors
+}
+
+// SimulateDeparture works out which files and top-level directories become
+// unowned if the top n contributors leave. A file's knowledge is its share
+// of commits, a directory's its share of churn; files deleted by the end of
+// the range are left out.
+func (r *Repository) SimulateDeparture(n int) *DepartureImpact {
+	impact := &DepartureImpact{Leaving: r.TopContributors(n)}
+	leaving := make(map[string]*AuthorStats, len(impact.Leaving))
+	for _, a := range impact.Leaving {
+		leaving[a.Email] = a
+	}
+
+	for path, f := range r.FileStats {
+		if f.IsDeleted() {
+			continue
+		}
+		total := 0
+		for _, commits := range f.Authors {
+			total += commits
+		}
+		shares := make(map[string]float64, len(f.Authors))
+		for email, commits := range f.Authors {
+			shares[email] = safePercent(commits, total)
+		}
+
+		loss := knowledgeLoss(shares, leaving)
+		if loss == nil {
+			continue
+		}
+		impact.Files++
+		impact.Churn += f.TotalChanges
+		if loss.Owners == nil {
+			continue
+		}
+		loss.Path, loss.Churn = path, f.TotalChanges
+		impact.LostFiles++
+		impact.LostChurn += f.TotalChanges
+		impact.Risks = append(impact.Risks, loss)
+	}
+
+	for path, d := range r.DirStats {
+		shares := make(map[string]float64, len(d.Authors))
+		for email, da := range d.Authors {
+			shares[email] = da.Share
+		}
+
+		loss := knowledgeLoss(shares, leaving)
+		if loss == nil {
+			continue
+		}
+		impact.Dirs++
+		if loss.Owners == nil {
+			continue
+		}
+		loss.Path, loss.IsDir, loss.Churn = path, true, d.TotalChanges
+		impact.LostDirs++
+		impact.Risks = append(impact.Risks, loss)
+	}
+
+	sort.Slice(impact.Risks, func(i, j int) bool {
+		a, b := impact.Risks[i], impact.Risks[j]
+		if a.Churn != b.Churn {
+			return a.Churn > b.Churn
+		}
+		if a.Lost != b.Lost {
+			return a.Lost > b.Lost
+		}
+		return a.Path < b.Path
+	})
+	return impact
+}
+
+// knowledgeLoss compares the shares of a path's authors with the leaving
+// authors. It returns nil if nobody knows the path, a loss without owners
+// if a remaining author still knows it, and the leaving owners otherwise.
+func knowledgeLoss(shares map[string]float64, leaving map[string]*AuthorStats) *KnowledgeLoss {
+	loss := &KnowledgeLoss{}
+	known := false
+	var owners []string
+	for email, share := range shares {
+		if share < KnowledgeShare {
+			if _, ok := leaving[email]; ok {
+				loss.Lost += share
+			}
+			continue
+		}
+		known = true
+		if _, ok := leaving[email]; !ok {
+			return &KnowledgeLoss{}
+		}
+		loss.Lost += share
+		owners = append(owners, email)
+	}
+	if !known {
+		return nil
+	}
+
+	for email, share := range shares {
+		if _, ok := leaving[email]; !ok {
+			loss.Remaining = max(loss.Remaining, share)
+		}
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if shares[owners[i]] != shares[owners[j]] {
+			return shares[owners[i]] > shares[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+	for _, email := range owners {
+		loss.Owners = append(loss.Owners, leaving[email].Name)
+	}
+	return loss
+}
+
+// DepartureCurve returns the share of known files that become unowned as
+// the top 1 to n contributors leave, one entry per departure
+func (r *Repository) DepartureCurve(n int) []float64 {
+	curve := make([]float64, 0, n)
+	for i := 1; i <= n && i <= len(r.Authors); i++ {
+		curve = append(curve, r.SimulateDeparture(i).FilePercent())
+	}
+	return curve
+}
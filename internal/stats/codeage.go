@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// CodeAge is how much of the current code was written in each quarter,
+// estimated from the blame of a sample of the files at HEAD
+type CodeAge struct {
+	Files        int           // text files at HEAD
+	Sampled      int           // files blamed
+	Lines        int           // lines blamed with a known date
+	Quarters     []string      // "2024-Q1", oldest first, without gaps
+	QuarterLines []int         // surviving lines written per quarter
+	MedianAge    time.Duration // half the lines are older
+	Dirs         []*DirCodeAge // most lines first
+}
+
+// DirCodeAge is the age of the surviving lines of a top-level directory
+type DirCodeAge struct {
+	Path      string
+	Lines     int
+	Quarters  []int // lines per CodeAge.Quarters
+	MedianAge time.Duration
+	Oldest    time.Time
+}
+
+// SetCodeAge attaches the blame sample taken after the scan completed,
+// with the ages measured at now
+func (r *Repository) SetCodeAge(sample *git.CodeAgeSample, now time.Time) {
+	if sample == nil {
+		r.CodeAge = nil
+		return
+	}
+	age := &CodeAge{Files: sample.Files, Sampled: sample.Sampled}
+
+	// Lines with an unknown date, e.g. from a partial clone, are left out
+	var ages []git.LineAge
+	var first, last time.Time
+	for _, a := range sample.Ages {
+		if a.At.IsZero() || a.Lines <= 0 {
+			continue
+		}
+		ages = append(ages, a)
+		age.Lines += a.Lines
+		if first.IsZero() || a.At.Before(first) {
+			first = a.At
+		}
+		if a.At.After(last) {
+			last = a.At
+		}
+	}
+	r.CodeAge = age
+	if len(ages) == 0 {
+		return
+	}
+
+	firstQuarter := quarterIndex(first)
+	for q := firstQuarter; q <= quarterIndex(last); q++ {
+		age.Quarters = append(age.Quarters, fmt.Sprintf("%d-Q%d", q/4, q%4+1))
+	}
+	age.QuarterLines = make([]int, len(age.Quarters))
+
+	dirs := make(map[string]*DirCodeAge)
+	dirAges := make(map[string][]git.LineAge)
+	for _, a := range ages {
+		q := quarterIndex(a.At) - firstQuarter
+		age.QuarterLines[q] += a.Lines
+
+		path := getTopDir(a.File)
+		dir := dirs[path]
+		if dir == nil {
+			dir = &DirCodeAge{Path: path, Quarters: make([]int, len(age.Quarters)), Oldest: a.At}
+			dirs[path] = dir
+		}
+		dir.Lines += a.Lines
+		dir.Quarters[q] += a.Lines
+		if a.At.Before(dir.Oldest) {
+			dir.Oldest = a.At
+		}
+		dirAges[path] = append(dirAges[path], a)
+	}
+
+	age.MedianAge = medianLineAge(ages, now)
+	for path, dir := range dirs {
+		dir.MedianAge = medianLineAge(dirAges[path], now)
+		age.Dirs = append(age.Dirs, dir)
+	}
+	sort.Slice(age.Dirs, func(i, j int) bool {
+		if age.Dirs[i].Lines != age.Dirs[j].Lines {
+			return age.Dirs[i].Lines > age.Dirs[j].Lines
+		}
+		return age.Dirs[i].Path < age.Dirs[j].Path
+	})
+}
+
+// quarterIndex numbers the quarters of the calendar, in UTC
+func quarterIndex(t time.Time) int {
+	t = t.UTC()
+	return t.Year()*4 + (int(t.Month())-1)/3
+}
+
+// medianLineAge returns the age at now of the median line, sorting ages
+func medianLineAge(ages []git.LineAge, now time.Time) time.Duration {
+	sort.Slice(ages, func(i, j int) bool { return ages[i].At.After(ages[j].At) })
+	total := 0
+	for _, a := range ages {
+		total += a.Lines
+	}
+	seen := 0
+	for _, a := range ages {
+		seen += a.Lines
+		if seen*2 >= total {
+			return now.Sub(a.At)
+		}
+	}
+	return 0
+}
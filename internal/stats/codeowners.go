@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CodeOwnersRule is a rule of a draft CODEOWNERS file: a path pattern and
+// the authors owning it
+type CodeOwnersRule struct {
+	Pattern string            // "*" for the root files, "/dir/" for a top-level directory
+	Dir     string            // directory the ownership was measured on
+	Owners  []*DirAuthorStats // largest share first; none when nobody reaches the threshold
+	Changes int
+}
+
+// CodeOwners derives CODEOWNERS rules from the directory ownership: every
+// top-level directory still in the tree is owned by up to maxOwners
+// authors holding at least minShare percent of its churn. The root files
+// become the "*" rule, which comes first so the directory rules override
+// it. Directories merged into a component share its owners.
+func (r *Repository) CodeOwners(minShare float64, maxOwners int) []*CodeOwnersRule {
+	// Directories with a file not deleted by the end of the range
+	live := make(map[string]bool)
+	for path, f := range r.FileStats {
+		if !f.IsDeleted() {
+			live[getTopDir(path)] = true
+		}
+	}
+
+	var rules []*CodeOwnersRule
+	for _, dir := range r.DirStats {
+		var owners []*DirAuthorStats
+		for _, a := range dir.Authors {
+			if a.Share >= minShare {
+				owners = append(owners, a)
+			}
+		}
+		sort.Slice(owners, func(i, j int) bool {
+			if owners[i].Share != owners[j].Share {
+				return owners[i].Share > owners[j].Share
+			}
+			return owners[i].Email < owners[j].Email
+		})
+		if maxOwners > 0 && len(owners) > maxOwners {
+			owners = owners[:maxOwners]
+		}
+
+		for _, path := range append([]string{dir.Path}, dir.Merged...) {
+			if !live[path] {
+				continue
+			}
+			pattern := "/" + path + "/"
+			if path == "." {
+				pattern = "*"
+			}
+			rules = append(rules, &CodeOwnersRule{Pattern: pattern, Dir: dir.Path, Owners: owners, Changes: dir.TotalChanges})
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if (rules[i].Pattern == "*") != (rules[j].Pattern == "*") {
+			return rules[i].Pattern == "*"
+		}
+		return rules[i].Pattern < rules[j].Pattern
+	})
+	return rules
+}
+
+// CodeOwnersFile renders rules as a CODEOWNERS file. Owners are named by
+// their handle in handles, keyed by email, or else by their email, which
+// GitHub and GitLab accept for members of the repository. Rules without an
+// owner are left commented out.
+func CodeOwnersFile(rules []*CodeOwnersRule, handles map[string]string, header string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(header, "\n") {
+		sb.WriteString(strings.TrimSpace("# " + line))
+		sb.WriteString("\n")
+	}
+
+	width := 0
+	for _, rule := range rules {
+		width = max(width, len(rule.Pattern))
+	}
+
+	var unmapped []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		sb.WriteString("\n")
+		if len(rule.Owners) == 0 {
+			sb.WriteString(fmt.Sprintf("# No author holds the minimum share of %d lines changed\n", rule.Changes))
+			sb.WriteString(fmt.Sprintf("# %s\n", rule.Pattern))
+			continue
+		}
+
+		shares := make([]string, len(rule.Owners))
+		names := make([]string, len(rule.Owners))
+		for i, o := range rule.Owners {
+			shares[i] = fmt.Sprintf("%s %.0f%%", o.Name, o.Share)
+			names[i] = codeOwner(o.Email, handles)
+			if handles[o.Email] == "" && !seen[o.Email] {
+				seen[o.Email] = true
+				unmapped = append(unmapped, o.Email)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("# %s of %d lines changed\n", strings.Join(shares, ", "), rule.Changes))
+		sb.WriteString(fmt.Sprintf("%-*s %s\n", width, rule.Pattern, strings.Join(names, " ")))
+	}
+
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		sb.WriteString("\n# No handle configured for: ")
+		sb.WriteString(strings.Join(unmapped, ", "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// codeOwner returns the CODEOWNERS name of an author: the configured
+// handle, prefixed with @ when missing, or the email
+func codeOwner(email string, handles map[string]string) string {
+	handle := handles[email]
+	if handle == "" {
+		return email
+	}
+	if !strings.HasPrefix(handle, "@") {
+		handle = "@" + handle
+	}
+	return handle
+}
@@ -0,0 +1,359 @@
+package stats
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// CommitContext carries a commit together with values derived once and
+// shared by all collectors
+type CommitContext struct {
+	Commit    *git.Commit
+	Author    *AuthorStats // already counted by the aggregator
+	LocalTime time.Time    // author date in the aggregator's timezone
+	DateKey   string       // "2024-01-15"
+	MonthKey  string       // "2024-01"
+	WeekKey   string       // Monday of the week, "2024-01-15"
+	Lines     int          // non-binary lines changed
+	Issues    []string     // issues closed by the message
+	Refactor  bool         // see IsRefactorCommit
+	Decay     float64      // weight of the commit's churn, see Aggregator.SetChurnHalfLife
+}
+
+// Collector turns commits into one group of statistics. Collectors run in
+// registration order while the aggregator holds its lock, so they may update
+// the repository without synchronization of their own.
+type Collector interface {
+	Name() string
+	Collect(repo *Repository, cc *CommitContext)
+}
+
+// Finalizer is implemented by collectors that derive statistics once all
+// commits have been collected
+type Finalizer interface {
+	Finalize(repo *Repository)
+}
+
+// defaultCollectors returns fresh instances of the built-in collectors
+func defaultCollectors() []Collector {
+	return []Collector{
+		graphCollector{},
+		prCollector{},
+		messageCollector{},
+		cherryPickCollector{},
+		timelineCollector{},
+		heatmapCollector{},
+		fileCollector{},
+		&survivalCollector{edits: make(map[string][]lineEdit)},
+		ownershipCollector{},
+		couplingCollector{},
+		fileCouplingCollector{},
+		refactorCollector{},
+		issueCollector{},
+		sizeCollector{},
+		strategyCollector{},
+		labelCollector{},
+		languageCollector{},
+		hoursCollector{},
+	}
+}
+
+// CollectorNames returns the names of the built-in collectors, which can be
+// listed in Config.DisabledCollectors
+func CollectorNames() []string {
+	var names []string
+	for _, c := range defaultCollectors() {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+// graphCollector records the shape of the commit graph
+type graphCollector struct{}
+
+func (graphCollector) Name() string { return "graph" }
+
+func (graphCollector) Collect(repo *Repository, cc *CommitContext) {
+	repo.TotalParents += cc.Commit.ParentCount
+	if cc.Commit.ParentCount == 0 {
+		repo.RootCommits++
+	} else if cc.Commit.ParentCount > 2 {
+		repo.OctopusMerges++
+	}
+}
+
+// prCollector records merge commits as pull requests
+type prCollector struct{}
+
+func (prCollector) Name() string { return "pullrequests" }
+
+func (prCollector) Collect(repo *Repository, cc *CommitContext) {
+	if cc.Commit.IsMerge {
+		repo.processMergeCommit(cc)
+	}
+}
+
+// messageCollector classifies commit message conventions per author
+type messageCollector struct{}
+
+func (messageCollector) Name() string { return "messages" }
+
+func (messageCollector) Collect(repo *Repository, cc *CommitContext) {
+	// Merge subjects are generated by git, so only authored messages count
+	if !cc.Commit.IsMerge {
+		cc.Author.Messages.Add(cc.Commit.Subject, cc.Commit.Body)
+	}
+}
+
+// cherryPickCollector records commits created with git cherry-pick -x
+type cherryPickCollector struct{}
+
+func (cherryPickCollector) Name() string { return "cherrypicks" }
+
+func (cherryPickCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	if c.CherryPickOf == "" {
+		return
+	}
+	repo.CherryPicks = append(repo.CherryPicks, &CherryPickInfo{
+		Hash:        c.ShortHash,
+		SourceHash:  c.CherryPickOf,
+		Author:      c.Author.Name,
+		AuthorEmail: c.Author.Email,
+		At:          c.AuthorDate,
+		Subject:     c.Subject,
+	})
+}
+
+// timelineCollector counts commits per day, keeping the authors and the
+// largest commits of each day
+type timelineCollector struct{}
+
+func (timelineCollector) Name() string { return "timeline" }
+
+func (timelineCollector) Collect(repo *Repository, cc *CommitContext) {
+	repo.DailyActivity[cc.DateKey]++
+
+	day, ok := repo.Days[cc.DateKey]
+	if !ok {
+		day = &DayActivity{Authors: make(map[string]int)}
+		repo.Days[cc.DateKey] = day
+	}
+	c := cc.Commit
+	day.record(DayCommit{Hash: c.ShortHash, Subject: c.Subject, Email: c.Author.Email, Lines: cc.Lines})
+}
+
+// heatmapCollector fills the weekday x hour and calendar matrices
+type heatmapCollector struct{}
+
+func (heatmapCollector) Name() string { return "heatmap" }
+
+func (heatmapCollector) Collect(repo *Repository, cc *CommitContext) {
+	localTime := cc.LocalTime
+	// Convert Sunday=0 to Monday=0 format
+	weekday := (int(localTime.Weekday()) + 6) % 7
+	repo.HourlyMatrix[weekday][localTime.Hour()]++
+
+	// Calendar matrices for month-end and seasonal patterns
+	monthIdx := int(localTime.Month()) - 1
+	repo.MonthDay[monthIdx][localTime.Day()-1]++
+	repo.MonthWeekday[monthIdx][weekday]++
+	if daysInMonth(localTime)-localTime.Day() < monthEndDays {
+		repo.MonthEnd++
+	}
+}
+
+// fileCollector tracks per-file churn, monthly activity and lifecycle
+type fileCollector struct{}
+
+func (fileCollector) Name() string { return "files" }
+
+func (fileCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	for _, fc := range c.FileChanges {
+		if fc.IsBinary {
+			continue
+		}
+
+		// Commits come newest first, so a file's history under its former
+		// paths follows the rename
+		path := fc.FilePath
+		if renamed, ok := repo.renames[path]; ok {
+			path = renamed
+		}
+		fileStat, ok := repo.FileStats[path]
+		if !ok {
+			fileStat = NewFileStats(path)
+			repo.FileStats[path] = fileStat
+		}
+
+		fileStat.Additions += fc.Additions
+		fileStat.Deletions += fc.Deletions
+		fileStat.TotalChanges += fc.Additions + fc.Deletions
+		fileStat.TouchCount++
+		fileStat.DecayedChanges += float64(fc.Additions+fc.Deletions) * cc.Decay
+		fileStat.DecayedTouches += cc.Decay
+		fileStat.Authors[c.Author.Email]++
+
+		month, ok := fileStat.Monthly[cc.MonthKey]
+		if !ok {
+			month = &FileMonth{Authors: make(map[string]int)}
+			fileStat.Monthly[cc.MonthKey] = month
+		}
+		month.Changes += fc.Additions + fc.Deletions
+		month.Touches++
+		month.Authors[c.Author.Email]++
+		fileStat.Weekly[cc.WeekKey] += fc.Additions + fc.Deletions
+
+		switch fc.Status {
+		case git.FileCreated:
+			fileStat.Created++
+			fileStat.Lifecycle = append(fileStat.Lifecycle, FileEvent{At: c.AuthorDate})
+		case git.FileDeleted:
+			fileStat.Deleted++
+			fileStat.Lifecycle = append(fileStat.Lifecycle, FileEvent{At: c.AuthorDate, Deleted: true})
+		case git.FileRenamed:
+			if fc.OldPath == "" || fc.OldPath == path {
+				break
+			}
+			fileStat.Renames++
+			if c.AuthorDate.After(fileStat.LastRenamed) {
+				fileStat.LastRenamed = c.AuthorDate
+				fileStat.RenamedFrom = fc.OldPath
+			}
+			repo.renames[fc.OldPath] = path
+		}
+	}
+
+	// A merge's generated changes were already counted on its branch
+	if !c.IsMerge {
+		for _, fc := range c.Generated {
+			repo.Generated[fc.FilePath] += fc.Additions + fc.Deletions
+		}
+	}
+}
+
+// Finalize reconciles delete/re-create cycles
+func (fileCollector) Finalize(repo *Repository) {
+	for _, file := range repo.FileStats {
+		file.Resurrections = countResurrections(file.Lifecycle)
+	}
+}
+
+// ownershipCollector tracks churn per directory and author, both for the
+// top-level directories and the full directory tree
+type ownershipCollector struct{}
+
+func (ownershipCollector) Name() string { return "ownership" }
+
+func (ownershipCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	touched := make(map[string]bool)
+	touchedTree := make(map[string]bool)
+	for _, fc := range c.FileChanges {
+		if fc.IsBinary {
+			continue
+		}
+
+		dir := getTopDir(fc.FilePath)
+		dirStat, ok := repo.DirStats[dir]
+		if !ok {
+			dirStat = NewDirStats(dir)
+			repo.DirStats[dir] = dirStat
+		}
+		dirStat.record(cc, fc.Additions+fc.Deletions, !touched[dir])
+		touched[dir] = true
+
+		for _, node := range repo.dirTreePath(fc.FilePath) {
+			node.record(cc, fc.Additions+fc.Deletions, !touchedTree[node.Path])
+			touchedTree[node.Path] = true
+		}
+	}
+}
+
+// Finalize calculates directory ownership shares
+func (ownershipCollector) Finalize(repo *Repository) {
+	for _, dir := range repo.DirStats {
+		dir.computeShares()
+	}
+	repo.DirTree.walk(func(dir *DirStats) { dir.computeShares() })
+}
+
+// couplingCollector counts directories changing in the same commit
+type couplingCollector struct{}
+
+func (couplingCollector) Name() string { return "coupling" }
+
+func (couplingCollector) Collect(repo *Repository, cc *CommitContext) {
+	dirs := make(map[string]bool)
+	for _, fc := range cc.Commit.FileChanges {
+		if !fc.IsBinary {
+			dirs[filepath.Dir(fc.FilePath)] = true
+		}
+	}
+	repo.recordDirCoupling(dirs)
+}
+
+// refactorCollector counts refactoring commits per author and directory
+type refactorCollector struct{}
+
+func (refactorCollector) Name() string { return "refactoring" }
+
+func (refactorCollector) Collect(repo *Repository, cc *CommitContext) {
+	if !cc.Refactor {
+		return
+	}
+	cc.Author.RefactorCommits++
+
+	counted := make(map[string]bool)
+	for _, fc := range cc.Commit.FileChanges {
+		dir := getTopDir(fc.FilePath)
+		if fc.IsBinary || counted[dir] {
+			continue
+		}
+		counted[dir] = true
+		// Directory stats only exist while the ownership collector runs
+		if dirStat, ok := repo.DirStats[dir]; ok {
+			dirStat.RefactorCommits++
+		}
+	}
+}
+
+// issueCollector records commits closing issues via keywords
+type issueCollector struct{}
+
+func (issueCollector) Name() string { return "issues" }
+
+func (issueCollector) Collect(repo *Repository, cc *CommitContext) {
+	if len(cc.Issues) == 0 {
+		return
+	}
+	c := cc.Commit
+	repo.IssueCloses = append(repo.IssueCloses, &IssueClose{
+		Hash:        c.ShortHash,
+		Author:      c.Author.Name,
+		AuthorEmail: c.Author.Email,
+		At:          cc.LocalTime,
+		Subject:     c.Subject,
+		Issues:      cc.Issues,
+	})
+}
+
+// sizeCollector records the size of every non-merge commit
+type sizeCollector struct{}
+
+func (sizeCollector) Name() string { return "sizes" }
+
+func (sizeCollector) Collect(repo *Repository, cc *CommitContext) {
+	if cc.Commit.IsMerge {
+		return
+	}
+	repo.CommitSizes = append(repo.CommitSizes, CommitSize{
+		At:    cc.LocalTime,
+		Email: cc.Commit.Author.Email,
+		Lines: cc.Lines,
+		Files: len(cc.Commit.FileChanges),
+	})
+}
@@ -0,0 +1,63 @@
+package stats
+
+import "sort"
+
+// PeriodComparison holds deltas against the preceding equal-length period
+type PeriodComparison struct {
+	Commits         int
+	PrevCommits     int
+	Churn           int // additions + deletions
+	PrevChurn       int
+	Authors         int
+	PrevAuthors     int
+	NewContributors []string // names of authors with no commits in the previous period
+}
+
+// CommitsDelta returns the relative change in commits, in percent
+func (c *PeriodComparison) CommitsDelta() float64 {
+	return percentChange(c.PrevCommits, c.Commits)
+}
+
+// ChurnDelta returns the relative change in churn, in percent
+func (c *PeriodComparison) ChurnDelta() float64 {
+	return percentChange(c.PrevChurn, c.Churn)
+}
+
+// GetPeriodComparison compares the selected range with the previous
+// period, or returns nil if the previous period was not scanned
+func (r *Repository) GetPeriodComparison() *PeriodComparison {
+	if r.Previous == nil {
+		return nil
+	}
+	prev := r.Previous
+
+	comparison := &PeriodComparison{
+		Commits:     r.TotalCommits,
+		PrevCommits: prev.TotalCommits,
+		Churn:       r.TotalAdditions + r.TotalDeletions,
+		PrevChurn:   prev.TotalAdditions + prev.TotalDeletions,
+		Authors:     len(r.Authors),
+		PrevAuthors: len(prev.Authors),
+	}
+
+	for email, a := range r.Authors {
+		if _, ok := prev.Authors[email]; !ok {
+			comparison.NewContributors = append(comparison.NewContributors, a.Name)
+		}
+	}
+	sort.Strings(comparison.NewContributors)
+
+	return comparison
+}
+
+// percentChange returns the change from prev to cur in percent; growth from
+// zero is reported as 100%
+func percentChange(prev, cur int) float64 {
+	if prev == 0 {
+		if cur == 0 {
+			return 0
+		}
+		return 100
+	}
+	return float64(cur-prev) / float64(prev) * 100
+}
@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// Crunch detection: a day is a crunch day when it has at least
+// crunchFactor times the average of the crunchBaselineDays before the
+// spike, and at least crunchMinCommits commits. A crunch is a run of at
+// least crunchMinDays such days, interrupted by no more than one quieter
+// day (e.g. a Sunday).
+const (
+	crunchFactor       = 2.0
+	crunchBaselineDays = 28
+	crunchMinCommits   = 3
+	crunchMinDays      = 3
+)
+
+// CrunchTagWindow is how far before and after a crunch tags are reported
+// with it, to correlate crunches with releases
+const CrunchTagWindow = 14 * 24 * time.Hour
+
+// Crunch is a sustained spike of commits well above the rolling average
+type Crunch struct {
+	Start    string // first crunch day, "2024-01-15"
+	End      string // last crunch day
+	Days     int    // calendar days from Start to End
+	Commits  int    // commits from Start to End
+	Peak     int    // commits on the busiest day
+	Baseline float64
+	Tags     []git.Tag // tags within CrunchTagWindow of the crunch, oldest first
+}
+
+// Intensity returns the crunch's commits per day relative to the baseline
+func (c *Crunch) Intensity() float64 {
+	if c.Baseline == 0 || c.Days == 0 {
+		return 0
+	}
+	return float64(c.Commits) / float64(c.Days) / c.Baseline
+}
+
+// GetCrunches finds the crunch periods in the daily commits, oldest first,
+// each with the tags around it
+func (r *Repository) GetCrunches() []*Crunch {
+	timeline := r.GetTimeline(PeriodDay, 1)
+	values := timeline.Values
+
+	var crunches []*Crunch
+	for i := 0; i < len(values); i++ {
+		baseline := crunchBaseline(values, i)
+		if !isCrunchDay(values[i], baseline) {
+			continue
+		}
+
+		// Extend the run against the baseline before it, so the spike does
+		// not raise its own bar
+		end, days := i, 1
+		for j := i + 1; j < len(values) && j-end <= 2; j++ {
+			if isCrunchDay(values[j], baseline) {
+				end = j
+				days++
+			}
+		}
+		if days >= crunchMinDays {
+			c := &Crunch{
+				Start:    timeline.Labels[i],
+				End:      timeline.Labels[end],
+				Days:     end - i + 1,
+				Baseline: baseline,
+			}
+			for _, v := range values[i : end+1] {
+				c.Commits += v
+				c.Peak = max(c.Peak, v)
+			}
+			c.Tags = r.tagsAround(c.Start, c.End)
+			crunches = append(crunches, c)
+		}
+		i = end
+	}
+	return crunches
+}
+
+// crunchBaseline averages the days before day i; the first days of the
+// history have no baseline
+func crunchBaseline(values []int, i int) float64 {
+	start := max(0, i-crunchBaselineDays)
+	if i-start < crunchMinDays {
+		return 0
+	}
+	sum := 0
+	for _, v := range values[start:i] {
+		sum += v
+	}
+	return float64(sum) / float64(i-start)
+}
+
+func isCrunchDay(commits int, baseline float64) bool {
+	return baseline > 0 && commits >= crunchMinCommits && float64(commits) >= crunchFactor*baseline
+}
+
+// tagsAround returns the tags dated within CrunchTagWindow of the days; the
+// days' timezone is negligible against the window
+func (r *Repository) tagsAround(start, end string) []git.Tag {
+	from, _ := time.Parse("2006-01-02", start)
+	to, _ := time.Parse("2006-01-02", end)
+	from = from.Add(-CrunchTagWindow)
+	to = to.AddDate(0, 0, 1).Add(CrunchTagWindow)
+
+	var tags []git.Tag
+	for _, tag := range r.Tags {
+		if !tag.Date.Before(from) && tag.Date.Before(to) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
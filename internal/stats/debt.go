@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// DebtSummary aggregates tech-debt markers found in the worktree
+type DebtSummary struct {
+	Total  int
+	ByKind map[string]int
+	Dirs   []*DirDebt
+	Oldest []*git.DebtMarker // oldest first
+}
+
+// DirDebt holds marker counts for a top-level directory
+type DirDebt struct {
+	Path   string
+	Total  int
+	ByKind map[string]int
+	Oldest *git.DebtMarker
+}
+
+// GetDebtSummary returns marker counts per top-level directory, most markers
+// first, and up to limit of the oldest outstanding markers
+func (r *Repository) GetDebtSummary(limit int) *DebtSummary {
+	summary := &DebtSummary{
+		Total:  len(r.DebtMarkers),
+		ByKind: make(map[string]int),
+	}
+
+	dirs := make(map[string]*DirDebt)
+	for _, m := range r.DebtMarkers {
+		summary.ByKind[m.Kind]++
+
+		path := getTopDir(m.File)
+		dir, ok := dirs[path]
+		if !ok {
+			dir = &DirDebt{Path: path, ByKind: make(map[string]int)}
+			dirs[path] = dir
+		}
+		dir.Total++
+		dir.ByKind[m.Kind]++
+		if dir.Oldest == nil || markerBefore(m, dir.Oldest) {
+			dir.Oldest = m
+		}
+	}
+
+	for _, dir := range dirs {
+		summary.Dirs = append(summary.Dirs, dir)
+	}
+	sort.Slice(summary.Dirs, func(i, j int) bool {
+		if summary.Dirs[i].Total != summary.Dirs[j].Total {
+			return summary.Dirs[i].Total > summary.Dirs[j].Total
+		}
+		return summary.Dirs[i].Path < summary.Dirs[j].Path
+	})
+
+	summary.Oldest = make([]*git.DebtMarker, len(r.DebtMarkers))
+	copy(summary.Oldest, r.DebtMarkers)
+	sort.SliceStable(summary.Oldest, func(i, j int) bool {
+		return markerBefore(summary.Oldest[i], summary.Oldest[j])
+	})
+	if limit > 0 && limit < len(summary.Oldest) {
+		summary.Oldest = summary.Oldest[:limit]
+	}
+
+	return summary
+}
+
+// markerBefore orders markers by age; unattributed markers sort last
+func markerBefore(a, b *git.DebtMarker) bool {
+	if a.At.IsZero() != b.At.IsZero() {
+		return !a.At.IsZero()
+	}
+	if !a.At.Equal(b.At) {
+		return a.At.Before(b.At)
+	}
+	if a.File != b.File {
+		return a.File < b.File
+	}
+	return a.Line < b.Line
+}
+
+// SetDebtMarkers attaches markers found after the scan completed, crediting
+// them to the primary identity of authors merged in the meantime
+func (r *Repository) SetDebtMarkers(markers []*git.DebtMarker) {
+	for _, m := range markers {
+		for _, merge := range r.IdentityMerges {
+			if m.AuthorEmail == merge.AliasEmail {
+				m.Author, m.AuthorEmail = merge.PrimaryName, merge.PrimaryEmail
+			}
+		}
+	}
+	r.DebtMarkers = markers
+}
+
+// UnattributedMarkers counts the markers blame could not attribute, e.g.
+// because their history is missing from a partial clone
+func UnattributedMarkers(markers []*git.DebtMarker) int {
+	n := 0
+	for _, m := range markers {
+		if m.AuthorEmail == "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxCouplingDirs skips commits touching more directories than this when
+// counting co-changes; mass edits (reformatting, license headers) would
+// otherwise couple everything with everything
+const maxCouplingDirs = 50
+
+// DirPair identifies two directories, ordered so that A < B
+type DirPair struct {
+	A string
+	B string
+}
+
+// DirCoupling represents how often two directories change together
+type DirCoupling struct {
+	DirA          string
+	DirB          string
+	SharedCommits int
+	CommitsA      int
+	CommitsB      int
+	Score         float64 // shared / min(commitsA, commitsB), 0-100
+	CrossBoundary bool    // directories don't share a parent
+}
+
+// recordDirCoupling counts per-directory commits and co-changing pairs
+func (r *Repository) recordDirCoupling(dirs map[string]bool) {
+	for dir := range dirs {
+		r.DirCommits[dir]++
+	}
+
+	if len(dirs) < 2 || len(dirs) > maxCouplingDirs {
+		return
+	}
+
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	for i := 0; i < len(sorted); i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			r.DirPairs[DirPair{A: sorted[i], B: sorted[j]}]++
+		}
+	}
+}
+
+// GetDirCoupling returns directory pairs that changed together in at least
+// minShared commits, strongest coupling first
+func (r *Repository) GetDirCoupling(minShared int, limit int) []*DirCoupling {
+	result := make([]*DirCoupling, 0)
+
+	for pair, shared := range r.DirPairs {
+		if shared < minShared {
+			continue
+		}
+
+		commitsA := r.DirCommits[pair.A]
+		commitsB := r.DirCommits[pair.B]
+		minCommits := commitsA
+		if commitsB < minCommits {
+			minCommits = commitsB
+		}
+		if minCommits == 0 {
+			continue
+		}
+
+		result = append(result, &DirCoupling{
+			DirA:          pair.A,
+			DirB:          pair.B,
+			SharedCommits: shared,
+			CommitsA:      commitsA,
+			CommitsB:      commitsB,
+			Score:         float64(shared) / float64(minCommits) * 100,
+			CrossBoundary: !shareParent(pair.A, pair.B),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].SharedCommits != result[j].SharedCommits {
+			return result[i].SharedCommits > result[j].SharedCommits
+		}
+		if result[i].DirA != result[j].DirA {
+			return result[i].DirA < result[j].DirA
+		}
+		return result[i].DirB < result[j].DirB
+	})
+
+	if limit > 0 && limit < len(result) {
+		return result[:limit]
+	}
+	return result
+}
+
+// shareParent reports whether two directories are siblings or one contains
+// the other, i.e. they sit inside the same architectural component
+func shareParent(a, b string) bool {
+	if a == "." || b == "." {
+		return true
+	}
+	if strings.HasPrefix(a+"/", b+"/") || strings.HasPrefix(b+"/", a+"/") {
+		return true
+	}
+	return filepath.Dir(a) == filepath.Dir(b) && filepath.Dir(a) != "."
+}
@@ -0,0 +1,76 @@
+package stats
+
+import "sort"
+
+// ApplyDirMerges combines directories into logical components.
+// merges maps directory -> primary directory; primaries map to themselves.
+// Commits touching several merged directories are counted once per directory.
+func (r *Repository) ApplyDirMerges(merges map[string]string) {
+	if len(merges) == 0 {
+		return
+	}
+
+	touched := make(map[string]bool)
+	for aliasPath, primaryPath := range merges {
+		if aliasPath == primaryPath {
+			continue
+		}
+		alias, ok := r.DirStats[aliasPath]
+		if !ok {
+			continue
+		}
+
+		primary, ok := r.DirStats[primaryPath]
+		if !ok {
+			primary = NewDirStats(primaryPath)
+			r.DirStats[primaryPath] = primary
+		}
+
+		primary.TotalChanges += alias.TotalChanges
+		primary.TouchCount += alias.TouchCount
+		primary.RefactorCommits += alias.RefactorCommits
+		for date, count := range alias.DailyCommits {
+			primary.DailyCommits[date] += count
+		}
+		for date, churn := range alias.DailyChurn {
+			primary.DailyChurn[date] += churn
+		}
+		for email, a := range alias.Authors {
+			existing, ok := primary.Authors[email]
+			if !ok {
+				existing = &DirAuthorStats{Name: a.Name, Email: a.Email, Monthly: make(map[string]int)}
+				primary.Authors[email] = existing
+			}
+			existing.Commits += a.Commits
+			existing.Changes += a.Changes
+			for month, changes := range a.Monthly {
+				existing.Monthly[month] += changes
+			}
+			existing.FirstDay = earlierDay(existing.FirstDay, a.FirstDay)
+		}
+
+		primary.Merged = append(primary.Merged, alias.Path)
+		primary.Merged = append(primary.Merged, alias.Merged...)
+		sort.Strings(primary.Merged)
+
+		delete(r.DirStats, aliasPath)
+		touched[primaryPath] = true
+	}
+
+	// Recalculate ownership shares of the combined directories
+	for path := range touched {
+		dir := r.DirStats[path]
+		for _, a := range dir.Authors {
+			a.Share = 0
+			if dir.TotalChanges > 0 {
+				a.Share = float64(a.Changes) / float64(dir.TotalChanges) * 100
+			}
+		}
+	}
+
+	if r.Previous != nil {
+		r.Previous.ApplyDirMerges(merges)
+	}
+	r.computeTurnover()
+	r.invalidateSorted()
+}
@@ -0,0 +1,171 @@
+package stats
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// record counts a file change of lines in the directory by the commit's
+// author, and the commit itself when it is the first change it makes there
+func (d *DirStats) record(cc *CommitContext, lines int, firstTouch bool) {
+	c := cc.Commit
+	d.TotalChanges += lines
+	d.TouchCount++
+	d.DailyChurn[cc.DateKey] += lines
+	if firstTouch {
+		d.DailyCommits[cc.DateKey]++
+	}
+
+	author, ok := d.Authors[c.Author.Email]
+	if !ok {
+		author = &DirAuthorStats{
+			Name:    c.Author.Name,
+			Email:   c.Author.Email,
+			Monthly: make(map[string]int),
+		}
+		d.Authors[c.Author.Email] = author
+	}
+	author.Commits++
+	author.Changes += lines
+	author.Monthly[cc.MonthKey] += lines
+	author.FirstDay = earlierDay(author.FirstDay, cc.DateKey)
+}
+
+// earlierDay returns the earlier of two "2024-01-15" days, either of which
+// may be unset
+func earlierDay(a, b string) string {
+	if a == "" || (b != "" && b < a) {
+		return b
+	}
+	return a
+}
+
+// computeShares sets each author's share of the directory's changes
+func (d *DirStats) computeShares() {
+	if d.TotalChanges == 0 {
+		return
+	}
+	for _, author := range d.Authors {
+		author.Share = float64(author.Changes) / float64(d.TotalChanges) * 100
+	}
+}
+
+// mergeAuthors moves the changes of merged identities to their primary and
+// recalculates the shares
+func (d *DirStats) mergeAuthors(merges map[string]string) {
+	for aliasEmail, primaryEmail := range merges {
+		if aliasEmail == primaryEmail {
+			continue
+		}
+
+		alias, aliasExists := d.Authors[aliasEmail]
+		primary, primaryExists := d.Authors[primaryEmail]
+
+		if !aliasExists {
+			continue
+		}
+
+		if !primaryExists {
+			// Rename alias to primary
+			d.Authors[primaryEmail] = alias
+			alias.Email = primaryEmail
+		} else {
+			// Merge into primary
+			primary.Commits += alias.Commits
+			primary.Changes += alias.Changes
+			for month, changes := range alias.Monthly {
+				primary.Monthly[month] += changes
+			}
+			primary.FirstDay = earlierDay(primary.FirstDay, alias.FirstDay)
+		}
+
+		delete(d.Authors, aliasEmail)
+	}
+	d.computeShares()
+}
+
+// walk calls fn for every directory below d, parents before their children
+func (d *DirStats) walk(fn func(*DirStats)) {
+	for _, sub := range d.Subdirs {
+		fn(sub)
+		sub.walk(fn)
+	}
+}
+
+// dirTreePath returns the tree nodes of every directory containing the file
+// at path, outermost first, creating the missing ones. Root files have none.
+func (r *Repository) dirTreePath(path string) []*DirStats {
+	parts := strings.Split(filepath.Clean(path), string(filepath.Separator))
+	parts = parts[:len(parts)-1]
+
+	nodes := make([]*DirStats, 0, len(parts))
+	node := r.DirTree
+	for i := range parts {
+		dirPath := strings.Join(parts[:i+1], "/")
+		sub, ok := node.Subdirs[dirPath]
+		if !ok {
+			if node.Subdirs == nil {
+				node.Subdirs = make(map[string]*DirStats)
+			}
+			sub = NewDirStats(dirPath)
+			node.Subdirs[dirPath] = sub
+		}
+		nodes = append(nodes, sub)
+		node = sub
+	}
+	return nodes
+}
+
+// treeDir returns the tree node of the directory at path, nil if no change
+// was made below it
+func (r *Repository) treeDir(path string) *DirStats {
+	if path == "." || path == "" {
+		return r.DirTree
+	}
+	node := r.DirTree
+	parts := strings.Split(path, "/")
+	for i := range parts {
+		if node = node.Subdirs[strings.Join(parts[:i+1], "/")]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// GetDir returns the directory at path: a top-level directory or component
+// as in GetOwnership, otherwise a directory of any depth from the tree. It
+// returns nil if no change was made in it.
+func (r *Repository) GetDir(path string) *DirStats {
+	if dir, ok := r.DirStats[path]; ok {
+		return dir
+	}
+	if path == "." {
+		return nil
+	}
+	return r.treeDir(path)
+}
+
+// GetSubdirs returns the direct subdirectories of the directories at paths,
+// several for a merged component, sorted as in GetOwnership. With no paths
+// it returns the top-level directories of the tree.
+func (r *Repository) GetSubdirs(sortBy string, ascending bool, paths ...string) []*DirStats {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var dirs []*DirStats
+	for _, path := range paths {
+		if node := r.treeDir(path); node != nil {
+			for _, sub := range node.Subdirs {
+				dirs = append(dirs, sub)
+			}
+		}
+	}
+	return sortDirList(dirs, sortBy, ascending)
+}
+
+// HasSubdirs reports whether changes were made in subdirectories of the
+// directory at path
+func (r *Repository) HasSubdirs(path string) bool {
+	node := r.treeDir(path)
+	return node != nil && len(node.Subdirs) > 0
+}
@@ -0,0 +1,277 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// Export is the JSON document written by gitstat --no-tui: the statistics
+// of the views, with stable field names for scripts and CI pipelines
+type Export struct {
+	Repository   string          `json:"repository"`
+	Since        time.Time       `json:"since"`
+	Until        time.Time       `json:"until"`
+	Filters      Filters         `json:"filters"`
+	Summary      ExportSummary   `json:"summary"`
+	Leaderboard  []ExportAuthor  `json:"leaderboard"`
+	Files        []ExportFile    `json:"files"`
+	Directories  []ExportDir     `json:"directories"`
+	Hotspots     []ExportHotspot `json:"hotspots"`
+	Heatmap      ExportHeatmap   `json:"heatmap"`
+	Daily        []ExportDay     `json:"daily"`
+	PullRequests ExportPRSummary `json:"pull_requests"`
+}
+
+// ExportSummary holds the repository totals
+type ExportSummary struct {
+	Commits           int     `json:"commits"`
+	Authors           int     `json:"authors"`
+	Additions         int     `json:"additions"`
+	Deletions         int     `json:"deletions"`
+	FilesAdded        int     `json:"files_added"`
+	FilesModified     int     `json:"files_modified"`
+	FilesDeleted      int     `json:"files_deleted"`
+	CodebaseLines     int     `json:"codebase_lines"`
+	RefactoredPercent float64 `json:"refactored_percent"`
+	SurvivingLines    int     `json:"surviving_lines"`
+	ChurnedLines      int     `json:"churned_lines"`
+	DuplicateCommits  int     `json:"duplicate_commits"`
+}
+
+// ExportAuthor is a row of the leaderboard
+type ExportAuthor struct {
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Commits     int       `json:"commits"`
+	Additions   int       `json:"additions"`
+	Deletions   int       `json:"deletions"`
+	Files       int       `json:"files"`
+	FirstCommit time.Time `json:"first_commit"`
+	LastCommit  time.Time `json:"last_commit"`
+}
+
+// ExportFile is a row of the top files
+type ExportFile struct {
+	Path      string `json:"path"`
+	Changes   int    `json:"changes"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Touches   int    `json:"touches"`
+	Authors   int    `json:"authors"`
+}
+
+// ExportDir is a top-level directory with its owners, largest share first
+type ExportDir struct {
+	Path    string            `json:"path"`
+	Changes int               `json:"changes"`
+	Touches int               `json:"touches"`
+	Owners  []ExportDirAuthor `json:"owners"`
+}
+
+// ExportDirAuthor is an author's share of a directory
+type ExportDirAuthor struct {
+	Name    string  `json:"name"`
+	Email   string  `json:"email"`
+	Commits int     `json:"commits"`
+	Changes int     `json:"changes"`
+	Share   float64 `json:"share"`
+}
+
+// ExportHotspot is a file with risk signals
+type ExportHotspot struct {
+	Path       string  `json:"path"`
+	RiskScore  float64 `json:"risk_score"`
+	ChurnScore float64 `json:"churn_score"`
+	Authors    int     `json:"authors"`
+	Changes    int     `json:"changes"`
+	Touches    int     `json:"touches"`
+	Complexity *int    `json:"complexity,omitempty"` // cyclomatic, when measured
+}
+
+// ExportHeatmap holds commits per weekday (Monday first) and hour
+type ExportHeatmap struct {
+	Timezone string     `json:"timezone"`
+	Hours    [7][24]int `json:"hours"`
+}
+
+// ExportDay holds the commits of a day
+type ExportDay struct {
+	Date    string `json:"date"`
+	Commits int    `json:"commits"`
+}
+
+// ExportPRSummary holds the merge statistics
+type ExportPRSummary struct {
+	Merges   int                 `json:"merges"`
+	Numbered int                 `json:"numbered"` // merges naming a PR number
+	Mergers  []ExportMerger      `json:"mergers"`
+	List     []ExportPullRequest `json:"list"`
+
+	// Review latency over the PRs with GitHub data, left out without it
+	Reviewed                 int     `json:"reviewed,omitempty"`
+	Unreviewed               int     `json:"unreviewed,omitempty"`
+	MedianHoursToMerge       float64 `json:"median_hours_to_merge,omitempty"`
+	MedianHoursToFirstReview float64 `json:"median_hours_to_first_review,omitempty"`
+}
+
+// ExportMerger is an author of merges
+type ExportMerger struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Merges  int    `json:"merges"`
+	Changes int    `json:"changes"`
+}
+
+// ExportPullRequest is a merge commit
+type ExportPullRequest struct {
+	Hash      string    `json:"hash"`
+	Number    int       `json:"number,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Subject   string    `json:"subject"`
+	MergedBy  string    `json:"merged_by"`
+	MergedAt  time.Time `json:"merged_at"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+	Files     int       `json:"files"`
+
+	Review *ExportReview `json:"review,omitempty"` // GitHub data, when fetched
+}
+
+// ExportReview is the GitHub data of a pull request
+type ExportReview struct {
+	Repo      string    `json:"repo"`
+	Author    string    `json:"author"`
+	OpenedAt  time.Time `json:"opened_at"`
+	Reviews   int       `json:"reviews"`
+	Approvals int       `json:"approvals"`
+	Reviewers []string  `json:"reviewers,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
+
+	HoursToMerge       float64  `json:"hours_to_merge"`
+	HoursToFirstReview *float64 `json:"hours_to_first_review,omitempty"` // nil without reviews
+}
+
+// GetExport collects the statistics of the views for a JSON export. Lists
+// are complete and in the views' default order; tz is the heatmap's zone.
+func (r *Repository) GetExport(tz *time.Location) *Export {
+	cb := r.GetCodebaseStats()
+	e := &Export{
+		Repository: r.Path,
+		Since:      r.DateRange.Since,
+		Until:      r.DateRange.Until,
+		Filters:    r.Filters,
+		Summary: ExportSummary{
+			Commits:           r.TotalCommits,
+			Authors:           r.TotalAuthors,
+			Additions:         cb.TotalAdditions,
+			Deletions:         cb.TotalDeletions,
+			FilesAdded:        cb.FilesAdded,
+			FilesModified:     cb.FilesModified,
+			FilesDeleted:      cb.FilesDeleted,
+			CodebaseLines:     cb.CodebaseSize,
+			RefactoredPercent: cb.RefactoredPercent,
+			SurvivingLines:    cb.SurvivingLines,
+			ChurnedLines:      cb.ChurnedLines,
+			DuplicateCommits:  r.DuplicateCommits,
+		},
+		Leaderboard: []ExportAuthor{},
+		Files:       []ExportFile{},
+		Directories: []ExportDir{},
+		Hotspots:    []ExportHotspot{},
+		Heatmap:     ExportHeatmap{Timezone: tz.String(), Hours: r.HourlyMatrix},
+		Daily:       []ExportDay{},
+		PullRequests: ExportPRSummary{
+			Mergers: []ExportMerger{},
+			List:    []ExportPullRequest{},
+		},
+	}
+
+	for _, a := range r.GetLeaderboard("commits", false) {
+		e.Leaderboard = append(e.Leaderboard, ExportAuthor{
+			Name: a.Name, Email: a.Email, Commits: a.Commits,
+			Additions: a.Additions, Deletions: a.Deletions, Files: len(a.FilesTouched),
+			FirstCommit: a.FirstCommit, LastCommit: a.LastCommit,
+		})
+	}
+	for _, f := range r.GetTopFiles("changes", false, 0) {
+		e.Files = append(e.Files, ExportFile{
+			Path: f.Path, Changes: f.TotalChanges, Additions: f.Additions, Deletions: f.Deletions,
+			Touches: f.TouchCount, Authors: len(f.Authors),
+		})
+	}
+	for _, d := range r.GetOwnership("changes", false) {
+		dir := ExportDir{Path: d.Path, Changes: d.TotalChanges, Touches: d.TouchCount, Owners: []ExportDirAuthor{}}
+		for _, a := range d.Authors {
+			dir.Owners = append(dir.Owners, ExportDirAuthor{
+				Name: a.Name, Email: a.Email, Commits: a.Commits, Changes: a.Changes, Share: a.Share,
+			})
+		}
+		sort.Slice(dir.Owners, func(i, j int) bool {
+			if dir.Owners[i].Changes != dir.Owners[j].Changes {
+				return dir.Owners[i].Changes > dir.Owners[j].Changes
+			}
+			return dir.Owners[i].Email < dir.Owners[j].Email
+		})
+		e.Directories = append(e.Directories, dir)
+	}
+	for _, h := range r.GetHotspots(0) {
+		hotspot := ExportHotspot{
+			Path: h.Path, RiskScore: h.RiskScore, ChurnScore: h.ChurnScore,
+			Authors: h.AuthorCount, Changes: h.Changes, Touches: h.TouchCount,
+		}
+		if h.HasComplexity {
+			hotspot.Complexity = &h.Complexity
+		}
+		e.Hotspots = append(e.Hotspots, hotspot)
+	}
+
+	days := make([]string, 0, len(r.DailyActivity))
+	for day := range r.DailyActivity {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	for _, day := range days {
+		e.Daily = append(e.Daily, ExportDay{Date: day, Commits: r.DailyActivity[day]})
+	}
+
+	if r.PRStats != nil {
+		e.PullRequests.Merges = r.PRStats.TotalMerges
+		e.PullRequests.Numbered = r.PRStats.TotalPRs
+		e.PullRequests.Reviewed = r.PRStats.Reviewed
+		e.PullRequests.Unreviewed = r.PRStats.Unreviewed
+		e.PullRequests.MedianHoursToMerge = r.PRStats.MedianTimeToMerge.Hours()
+		e.PullRequests.MedianHoursToFirstReview = r.PRStats.MedianFirstReview.Hours()
+		for _, a := range r.GetPRLeaderboard("merges", false) {
+			e.PullRequests.Mergers = append(e.PullRequests.Mergers, ExportMerger{
+				Name: a.Name, Email: a.Email, Merges: a.MergeCount, Changes: a.TotalChanges,
+			})
+		}
+		for _, pr := range r.GetPRList("date", false, 0) {
+			e.PullRequests.List = append(e.PullRequests.List, ExportPullRequest{
+				Hash: pr.Hash, Number: pr.PRNumber, Branch: pr.Branch, Subject: pr.Subject,
+				MergedBy: pr.MergedBy, MergedAt: pr.MergedAt,
+				Additions: pr.Additions, Deletions: pr.Deletions, Files: pr.FilesCount,
+				Review: exportReview(pr.Review),
+			})
+		}
+	}
+	return e
+}
+
+// exportReview converts the GitHub data of a pull request; nil without
+func exportReview(review *PRReview) *ExportReview {
+	if review == nil {
+		return nil
+	}
+	e := &ExportReview{
+		Repo: review.Repo, Author: review.Author, OpenedAt: review.CreatedAt,
+		Reviews: review.Reviews, Approvals: review.Approvals,
+		Reviewers: review.Reviewers, Labels: review.Labels,
+		HoursToMerge: review.TimeToMerge().Hours(),
+	}
+	if wait, ok := review.TimeToFirstReview(); ok {
+		hours := wait.Hours()
+		e.HoursToFirstReview = &hours
+	}
+	return e
+}
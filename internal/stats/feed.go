@@ -0,0 +1,85 @@
+package stats
+
+import "github.com/audi70r/gitstat/internal/git"
+
+// Feed is one parser's stream of commits into an Aggregator, for parsers
+// running in parallel. The statistics depend on the order of the commits:
+// renames are followed newest first, the first copy of a duplicate is kept
+// and an upstream must precede its forks. Feeds are therefore processed in
+// the order they were opened, as if their parsers had run one after the
+// other: the first open feed directly, later ones buffered until every
+// feed before them is closed.
+type Feed struct {
+	a       *Aggregator
+	pending []func()
+	closed  bool
+}
+
+// Feed opens the next stream of commits. Every feed must be closed before
+// Finalize.
+func (a *Aggregator) Feed() *Feed {
+	a.feedMu.Lock()
+	defer a.feedMu.Unlock()
+	f := &Feed{a: a}
+	a.feeds = append(a.feeds, f)
+	return f
+}
+
+// ProcessCommit adds a commit like Aggregator.ProcessCommit, in the feed's
+// turn
+func (f *Feed) ProcessCommit(c *git.Commit) {
+	f.process(func() { f.a.ProcessCommit(c) })
+}
+
+// ProcessForkCommit adds a fork's commit like
+// Aggregator.ProcessForkCommit, in the feed's turn
+func (f *Feed) ProcessForkCommit(c *git.Commit) {
+	f.process(func() { f.a.ProcessForkCommit(c) })
+}
+
+// ProcessMerge adds a merge like Aggregator.ProcessMerge, in the feed's
+// turn
+func (f *Feed) ProcessMerge(c *git.Commit) {
+	f.process(func() { f.a.ProcessMerge(c) })
+}
+
+// process runs a step now when the feed is the first open one, or queues
+// it. Steps run under feedMu, so a feed whose turn comes while its queue
+// is being drained cannot overtake it. A closed feed takes no more
+// commits, which would otherwise be lost.
+func (f *Feed) process(step func()) {
+	a := f.a
+	a.feedMu.Lock()
+	defer a.feedMu.Unlock()
+	if f.closed {
+		panic("stats: commit fed to a closed Feed")
+	}
+	if len(a.feeds) > 0 && a.feeds[0] == f {
+		step()
+		return
+	}
+	f.pending = append(f.pending, step)
+}
+
+// Close ends the feed. When it was the first open one, the feeds queued
+// behind it are processed in turn.
+func (f *Feed) Close() {
+	a := f.a
+	a.feedMu.Lock()
+	defer a.feedMu.Unlock()
+	if f.closed {
+		return
+	}
+	f.closed = true
+	for len(a.feeds) > 0 && a.feeds[0].closed {
+		a.feeds = a.feeds[1:]
+		if len(a.feeds) == 0 {
+			break
+		}
+		next := a.feeds[0]
+		for _, step := range next.pending {
+			step()
+		}
+		next.pending = nil
+	}
+}
@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// maxCouplingFiles skips commits touching more files than this when
+// counting file co-changes, like maxCouplingDirs for directories; the pairs
+// grow with the square of the files
+const maxCouplingFiles = 30
+
+// FilePair identifies two files, ordered so that A < B
+type FilePair struct {
+	A string
+	B string
+}
+
+// FileCoupling represents how often two files change together
+type FileCoupling struct {
+	FileA         string
+	FileB         string
+	SharedCommits int
+	CommitsA      int
+	CommitsB      int
+	Score         float64 // shared / min(commitsA, commitsB), 0-100
+	CrossBoundary bool    // the files' directories don't share a parent
+	KindA         string  // see ClassifyFile
+	KindB         string
+}
+
+// Suspicious reports whether the coupling hints at a hidden dependency:
+// files in unrelated directories, or code tied to a configuration file,
+// changing together. Code and its tests or docs changing together is
+// expected.
+func (c *FileCoupling) Suspicious() bool {
+	if c.CrossBoundary {
+		return true
+	}
+	return (c.KindA == KindCode && c.KindB == KindConfig) || (c.KindA == KindConfig && c.KindB == KindCode)
+}
+
+// fileCouplingCollector counts files changing in the same commit
+type fileCouplingCollector struct{}
+
+func (fileCouplingCollector) Name() string { return "filecoupling" }
+
+func (fileCouplingCollector) Collect(repo *Repository, cc *CommitContext) {
+	files := make(map[string]bool)
+	for _, fc := range cc.Commit.FileChanges {
+		if fc.IsBinary {
+			continue
+		}
+		// Pairs are kept under the current path, as the file statistics are
+		path := fc.FilePath
+		if renamed, ok := repo.renames[path]; ok {
+			path = renamed
+		}
+		files[path] = true
+	}
+	if len(files) < 2 || len(files) > maxCouplingFiles {
+		return
+	}
+
+	sorted := make([]string, 0, len(files))
+	for file := range files {
+		sorted = append(sorted, file)
+	}
+	sort.Strings(sorted)
+
+	for i := 0; i < len(sorted); i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			repo.FilePairs[FilePair{A: sorted[i], B: sorted[j]}]++
+		}
+	}
+}
+
+// GetCoupledFiles returns file pairs that changed together in at least
+// minShared commits, strongest coupling first
+func (r *Repository) GetCoupledFiles(minShared int, limit int) []*FileCoupling {
+	result := make([]*FileCoupling, 0)
+
+	for pair, shared := range r.FilePairs {
+		if shared < minShared {
+			continue
+		}
+
+		var commitsA, commitsB int
+		if f, ok := r.FileStats[pair.A]; ok {
+			commitsA = f.TouchCount
+		}
+		if f, ok := r.FileStats[pair.B]; ok {
+			commitsB = f.TouchCount
+		}
+		minCommits := min(commitsA, commitsB)
+		if minCommits == 0 {
+			continue
+		}
+
+		result = append(result, &FileCoupling{
+			FileA:         pair.A,
+			FileB:         pair.B,
+			SharedCommits: shared,
+			CommitsA:      commitsA,
+			CommitsB:      commitsB,
+			Score:         min(100, float64(shared)/float64(minCommits)*100),
+			CrossBoundary: !shareParent(filepath.Dir(pair.A), filepath.Dir(pair.B)),
+			KindA:         ClassifyFile(pair.A),
+			KindB:         ClassifyFile(pair.B),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].SharedCommits != result[j].SharedCommits {
+			return result[i].SharedCommits > result[j].SharedCommits
+		}
+		if result[i].FileA != result[j].FileA {
+			return result[i].FileA < result[j].FileA
+		}
+		return result[i].FileB < result[j].FileB
+	})
+
+	if limit > 0 && limit < len(result) {
+		return result[:limit]
+	}
+	return result
+}
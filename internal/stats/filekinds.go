@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Kinds of files an author's work is broken down by, in display order
+const (
+	KindCode   = "code"
+	KindTests  = "tests"
+	KindDocs   = "docs"
+	KindConfig = "config"
+	KindOther  = "other"
+)
+
+// FileKinds lists the kinds ClassifyFile returns
+var FileKinds = []string{KindCode, KindTests, KindDocs, KindConfig, KindOther}
+
+// codeExtensions are source file extensions
+var codeExtensions = map[string]bool{
+	".go": true, ".js": true, ".jsx": true, ".mjs": true, ".cjs": true,
+	".ts": true, ".tsx": true, ".py": true, ".java": true, ".kt": true,
+	".kts": true, ".c": true, ".h": true, ".cc": true, ".cpp": true,
+	".hpp": true, ".cs": true, ".rs": true, ".rb": true, ".php": true,
+	".swift": true, ".scala": true, ".m": true, ".mm": true, ".dart": true,
+	".ex": true, ".exs": true, ".erl": true, ".hs": true, ".clj": true,
+	".lua": true, ".pl": true, ".r": true, ".sh": true, ".bash": true,
+	".ps1": true, ".sql": true, ".vue": true, ".svelte": true, ".html": true,
+	".css": true, ".scss": true, ".sass": true, ".less": true, ".zig": true,
+	".proto": true, ".graphql": true,
+}
+
+// docExtensions are prose and documentation formats
+var docExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".rst": true, ".adoc": true,
+	".txt": true, ".tex": true,
+}
+
+// configExtensions are configuration and data formats
+var configExtensions = map[string]bool{
+	".json": true, ".yaml": true, ".yml": true, ".toml": true, ".ini": true,
+	".cfg": true, ".conf": true, ".xml": true, ".properties": true,
+	".env": true, ".lock": true, ".mod": true, ".sum": true, ".tf": true,
+	".gradle": true,
+}
+
+// configNames are configuration files without a telling extension
+var configNames = map[string]bool{
+	"makefile": true, "dockerfile": true, "gemfile": true, "rakefile": true,
+	"procfile": true, "jenkinsfile": true, "vagrantfile": true,
+	"codeowners": true, ".gitignore": true, ".gitattributes": true,
+	".editorconfig": true, ".dockerignore": true, ".npmrc": true,
+}
+
+// docNames are documentation files without an extension
+var docNames = map[string]bool{
+	"readme": true, "license": true, "copying": true, "authors": true,
+	"contributors": true, "changelog": true, "notice": true,
+}
+
+// ClassifyFile returns the kind of a file by its path: sources and data
+// under test directories or named like tests of the common frameworks are
+// tests, files under docs directories are docs, the rest goes by extension
+// or well-known name
+func ClassifyFile(name string) string {
+	base := path.Base(name)
+	ext := strings.ToLower(path.Ext(base))
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	lower := strings.ToLower(stem)
+
+	var testDir, docDir bool
+	for _, dir := range strings.Split(strings.ToLower(path.Dir(name)), "/") {
+		switch dir {
+		case "test", "tests", "__tests__", "spec", "specs", "testdata", "e2e":
+			testDir = true
+		case "doc", "docs", "documentation":
+			docDir = true
+		}
+	}
+
+	if codeExtensions[ext] || configExtensions[ext] {
+		// foo_test.go, foo.test.ts, foo.spec.js, test_foo.py, FooTest.java
+		if testDir || strings.HasSuffix(lower, "_test") || strings.HasSuffix(lower, ".test") ||
+			strings.HasSuffix(lower, ".spec") || strings.HasPrefix(lower, "test_") ||
+			strings.HasSuffix(stem, "Test") || strings.HasSuffix(stem, "Tests") {
+			return KindTests
+		}
+	}
+
+	switch {
+	case codeExtensions[ext]:
+		return KindCode
+	case docExtensions[ext], docNames[lower] && ext == "", docDir:
+		return KindDocs
+	case configExtensions[ext], configNames[strings.ToLower(base)]:
+		return KindConfig
+	}
+	return KindOther
+}
+
+// FileKindShare is the part of an author's work on one kind of file
+type FileKindShare struct {
+	Kind    string
+	Files   int
+	Touches int
+	Percent float64 // of all touches
+}
+
+// FileKinds breaks the files the author touched down by kind, the kind
+// touched most first; kinds the author never touched are left out
+func (a *AuthorStats) FileKinds() []FileKindShare {
+	byKind := make(map[string]*FileKindShare)
+	total := 0
+	for file, touches := range a.FilesTouched {
+		kind := ClassifyFile(file)
+		share := byKind[kind]
+		if share == nil {
+			share = &FileKindShare{Kind: kind}
+			byKind[kind] = share
+		}
+		share.Files++
+		share.Touches += touches
+		total += touches
+	}
+
+	var shares []FileKindShare
+	for _, kind := range FileKinds {
+		if share := byKind[kind]; share != nil {
+			share.Percent = float64(share.Touches) / float64(total) * 100
+			shares = append(shares, *share)
+		}
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Touches > shares[j].Touches
+	})
+	return shares
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filters records what a scan was restricted to, so reports and exports
+// can state it next to their numbers
+type Filters struct {
+	Refs            []string `json:"refs,omitempty"`             // scanned instead of HEAD
+	Include         []string `json:"include,omitempty"`          // path globs files must match
+	Exclude         []string `json:"exclude,omitempty"`          // path globs of files left out
+	ExcludedPaths   []string `json:"excluded_paths,omitempty"`   // files and directories left out
+	ExcludedAuthors []string `json:"excluded_authors,omitempty"` // emails left out
+	NoMerges        bool     `json:"no_merges,omitempty"`
+	FirstParent     bool     `json:"first_parent,omitempty"`
+	NoMechanical    bool     `json:"no_mechanical,omitempty"` // lockfile, version and changelog commits left out
+}
+
+// Describe returns a line per active filter, none when the scan covered
+// the whole history of the period
+func (f Filters) Describe() []string {
+	var lines []string
+	if len(f.Refs) > 0 {
+		lines = append(lines, "refs "+strings.Join(f.Refs, ", "))
+	}
+	if len(f.Include) > 0 {
+		lines = append(lines, "only paths matching "+strings.Join(f.Include, ", "))
+	}
+	if len(f.Exclude) > 0 {
+		lines = append(lines, "without paths matching "+strings.Join(f.Exclude, ", "))
+	}
+	if len(f.ExcludedPaths) > 0 {
+		lines = append(lines, "without "+strings.Join(f.ExcludedPaths, ", "))
+	}
+	if len(f.ExcludedAuthors) > 0 {
+		lines = append(lines, "without commits by "+strings.Join(f.ExcludedAuthors, ", "))
+	}
+	switch {
+	case f.FirstParent && f.NoMerges:
+		lines = append(lines, "first parent only, without merge commits")
+	case f.FirstParent:
+		lines = append(lines, "first parent only")
+	case f.NoMerges:
+		lines = append(lines, "without merge commits")
+	}
+	if f.NoMechanical {
+		lines = append(lines, "without mechanical commits")
+	}
+	return lines
+}
+
+// ReportHeader returns the lines heading a report or export: the
+// repository and period, then the active filters
+func (r *Repository) ReportHeader() []string {
+	header := []string{fmt.Sprintf("%s, %s", r.Path, r.DateRange)}
+	for _, line := range r.Filters.Describe() {
+		header = append(header, "Filter: "+line)
+	}
+	return header
+}
@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"math"
+	"time"
+)
+
+// minForecastDays is the shortest history a forecast is fitted on
+const minForecastDays = 28
+
+// Forecast holds a projected commit range per week, fitted on the daily series
+type Forecast struct {
+	Weeks      []ForecastWeek
+	SlopePerWk float64 // trend change in commits/day per week
+	FitDays    int     // days of history the model was fitted on
+}
+
+// ForecastWeek is the projected commit count for one future week
+type ForecastWeek struct {
+	Start    string // first day "2006-01-02"
+	Expected float64
+	Low      float64 // ~80% interval, never below zero
+	High     float64
+}
+
+// Forecast fits a linear trend plus day-of-week seasonality to the daily
+// series and projects the given number of weeks past the last day. It
+// returns nil when there is less than four weeks of history or the series
+// is not daily.
+func (t *TimelineData) Forecast(weeks int) *Forecast {
+	n := len(t.Values)
+	if n < minForecastDays || weeks <= 0 || t.Period != PeriodDay {
+		return nil
+	}
+
+	lastDate, err := time.Parse("2006-01-02", t.Labels[n-1])
+	if err != nil {
+		return nil
+	}
+
+	// Least squares trend over the day index
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range t.Values {
+		x, y := float64(i), float64(v)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	fn := float64(n)
+	slope := 0.0
+	if denom := fn*sumXX - sumX*sumX; denom != 0 {
+		slope = (fn*sumXY - sumX*sumY) / denom
+	}
+	intercept := (sumY - slope*sumX) / fn
+
+	// Mean residual per weekday captures weekly seasonality
+	var seasonal [7]float64
+	var seasonalCount [7]int
+	firstDate := lastDate.AddDate(0, 0, -(n - 1))
+	for i, v := range t.Values {
+		wd := int(firstDate.AddDate(0, 0, i).Weekday())
+		seasonal[wd] += float64(v) - (intercept + slope*float64(i))
+		seasonalCount[wd]++
+	}
+	for wd := range seasonal {
+		if seasonalCount[wd] > 0 {
+			seasonal[wd] /= float64(seasonalCount[wd])
+		}
+	}
+
+	// Residual spread after trend and seasonality
+	var sse float64
+	for i, v := range t.Values {
+		wd := int(firstDate.AddDate(0, 0, i).Weekday())
+		fit := intercept + slope*float64(i) + seasonal[wd]
+		sse += (float64(v) - fit) * (float64(v) - fit)
+	}
+	sd := math.Sqrt(sse / fn)
+
+	forecast := &Forecast{
+		SlopePerWk: slope * 7,
+		FitDays:    n,
+	}
+
+	for w := 0; w < weeks; w++ {
+		start := lastDate.AddDate(0, 0, w*7+1)
+		expected := 0.0
+		for d := 0; d < 7; d++ {
+			i := n + w*7 + d
+			wd := int(start.AddDate(0, 0, d).Weekday())
+			expected += math.Max(0, intercept+slope*float64(i)+seasonal[wd])
+		}
+
+		// Daily errors are treated as independent; 1.28 sd covers ~80%
+		margin := 1.28 * sd * math.Sqrt(7)
+		forecast.Weeks = append(forecast.Weeks, ForecastWeek{
+			Start:    start.Format("2006-01-02"),
+			Expected: expected,
+			Low:      math.Max(0, expected-margin),
+			High:     expected + margin,
+		})
+	}
+
+	return forecast
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// ForkContribution splits an author's non-merge commits in a fork into
+// those that also reached the upstream repository, by hash or patch-id, and
+// those that exist only in the fork
+type ForkContribution struct {
+	Name       string
+	Email      string
+	Upstreamed int
+	ForkOnly   int
+}
+
+// Total returns all of the author's fork commits
+func (f *ForkContribution) Total() int {
+	return f.Upstreamed + f.ForkOnly
+}
+
+// UpstreamedPercent returns the share of the author's fork commits that
+// reached upstream
+func (f *ForkContribution) UpstreamedPercent() float64 {
+	if f.Total() == 0 {
+		return 0
+	}
+	return float64(f.Upstreamed) / float64(f.Total()) * 100
+}
+
+// recordForkCommit counts a fork commit for its author
+func (r *Repository) recordForkCommit(c *git.Commit, upstreamed bool) {
+	if r.Fork == nil {
+		r.Fork = make(map[string]*ForkContribution)
+	}
+	f, ok := r.Fork[c.Author.Email]
+	if !ok {
+		f = &ForkContribution{Name: c.Author.Name, Email: c.Author.Email}
+		r.Fork[c.Author.Email] = f
+	}
+	if upstreamed {
+		f.Upstreamed++
+	} else {
+		f.ForkOnly++
+	}
+}
+
+// GetForkContributions returns the upstreamed / fork-only split per author,
+// most fork commits first
+func (r *Repository) GetForkContributions() []*ForkContribution {
+	result := make([]*ForkContribution, 0, len(r.Fork))
+	for _, f := range r.Fork {
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total() != result[j].Total() {
+			return result[i].Total() > result[j].Total()
+		}
+		return result[i].Email < result[j].Email
+	})
+	return result
+}
+
+// mergeForkContributions folds aliases into their primary identity
+func (r *Repository) mergeForkContributions(merges map[string]string) {
+	for aliasEmail, primaryEmail := range merges {
+		alias, ok := r.Fork[aliasEmail]
+		if !ok || aliasEmail == primaryEmail {
+			continue
+		}
+		primary, ok := r.Fork[primaryEmail]
+		if !ok {
+			primary = &ForkContribution{Email: primaryEmail, Name: alias.Name}
+			r.Fork[primaryEmail] = primary
+		}
+		if author, exists := r.Authors[primaryEmail]; exists {
+			primary.Name = author.Name
+		}
+		primary.Upstreamed += alias.Upstreamed
+		primary.ForkOnly += alias.ForkOnly
+		delete(r.Fork, aliasEmail)
+	}
+}
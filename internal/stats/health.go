@@ -0,0 +1,216 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Components of the health score, in breakdown order
+const (
+	HealthBusFactor    = "bus_factor"
+	HealthHotspots     = "hotspots"
+	HealthChurn        = "churn"
+	HealthPRSize       = "pr_size"
+	HealthContributors = "contributors"
+)
+
+// HealthComponents lists the components of the health score, in breakdown
+// order
+var HealthComponents = []string{HealthBusFactor, HealthHotspots, HealthChurn, HealthPRSize, HealthContributors}
+
+const (
+	// Bus factor from which the bus factor component scores full marks
+	healthBusFactor = 4
+
+	// Share of the changed files being high-risk hotspots at which the
+	// hotspot component scores nothing
+	healthHotspotShare = 10.0
+
+	// Merges changing at most this many lines count as small, which the
+	// pull request component rewards
+	healthSmallPR = 400
+)
+
+// HealthScore combines several risk indicators into one 0-100 score, so
+// repositories can be compared at a glance
+type HealthScore struct {
+	Score      float64 // weighted mean of the measured components
+	Components []*HealthComponent
+}
+
+// HealthComponent is one indicator of the health score
+type HealthComponent struct {
+	Name     string  // one of HealthComponents
+	Score    float64 // 0-100, higher is healthier
+	Weight   float64 // configured weight
+	Share    float64 // percentage of the total score the component makes up
+	Measured bool    // false when the scan has nothing to measure, e.g. no merges
+	Detail   string  // what was measured
+}
+
+// Health scores the repository with the weights of HealthComponents.
+// Components without a weight, and components the scan has no data for,
+// are left out and the others weighed up accordingly.
+func (r *Repository) Health(weights map[string]float64) *HealthScore {
+	health := &HealthScore{}
+	for _, name := range HealthComponents {
+		c := &HealthComponent{Name: name, Weight: weights[name]}
+		switch name {
+		case HealthBusFactor:
+			r.healthBusFactor(c)
+		case HealthHotspots:
+			r.healthHotspots(c)
+		case HealthChurn:
+			r.healthChurn(c)
+		case HealthPRSize:
+			r.healthPRSize(c)
+		case HealthContributors:
+			r.healthContributors(c)
+		}
+		health.Components = append(health.Components, c)
+	}
+
+	var total, weighted float64
+	for _, c := range health.Components {
+		if c.Measured && c.Weight > 0 {
+			total += c.Weight
+			weighted += c.Weight * c.Score
+		}
+	}
+	if total == 0 {
+		return health
+	}
+	health.Score = weighted / total
+	for _, c := range health.Components {
+		if c.Measured && c.Weight > 0 {
+			c.Share = c.Weight / total * 100
+		}
+	}
+	return health
+}
+
+// Measured reports whether any weighted component had data to score
+func (h *HealthScore) Measured() bool {
+	for _, c := range h.Components {
+		if c.Measured && c.Weight > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the score and its breakdown as plain text
+func (h *HealthScore) String() string {
+	var sb strings.Builder
+	if !h.Measured() {
+		sb.WriteString("Health score: not measured\n")
+	} else {
+		fmt.Fprintf(&sb, "Health score: %.0f/100\n", h.Score)
+	}
+	for _, c := range h.Components {
+		switch {
+		case c.Weight <= 0:
+			fmt.Fprintf(&sb, "  %-13s   off  %s\n", c.Name, c.Detail)
+		case !c.Measured:
+			fmt.Fprintf(&sb, "  %-13s     -  %s\n", c.Name, c.Detail)
+		default:
+			fmt.Fprintf(&sb, "  %-13s %5.0f  %s (weight %.0f%%)\n", c.Name, c.Score, c.Detail, c.Share)
+		}
+	}
+	return sb.String()
+}
+
+// healthBusFactor rewards knowledge spread over several authors
+func (r *Repository) healthBusFactor(c *HealthComponent) {
+	if len(r.Authors) == 0 {
+		c.Detail = "no authors"
+		return
+	}
+	bf := r.BusFactor()
+	c.Measured = true
+	c.Score = float64(min(bf, healthBusFactor)) / healthBusFactor * 100
+	c.Detail = fmt.Sprintf("bus factor %d of %d authors", bf, len(r.Authors))
+}
+
+// healthHotspots penalizes a large share of high-risk hotspots among the
+// changed files
+func (r *Repository) healthHotspots(c *HealthComponent) {
+	if len(r.FileStats) == 0 {
+		c.Detail = "no files changed"
+		return
+	}
+	risky := 0
+	for _, h := range r.GetHotspots(0) {
+		if h.RiskScore >= HighRiskScore {
+			risky++
+		}
+	}
+	share := safePercent(risky, len(r.FileStats))
+	c.Measured = true
+	c.Score = max(0, 100-share/healthHotspotShare*100)
+	c.Detail = fmt.Sprintf("%d of %d changed files high-risk (%.1f%%)", risky, len(r.FileStats), share)
+}
+
+// healthChurn rewards added lines that survive rather than being rewritten
+func (r *Repository) healthChurn(c *HealthComponent) {
+	codebase := r.GetCodebaseStats()
+	if codebase.SurvivingLines+codebase.ChurnedLines == 0 {
+		c.Detail = "no lines added"
+		return
+	}
+	c.Measured = true
+	c.Score = codebase.ProductivePercent
+	c.Detail = fmt.Sprintf("%.0f%% of added lines survive, %d churned",
+		codebase.ProductivePercent, codebase.ChurnedLines)
+}
+
+// healthPRSize rewards merges small enough to review
+func (r *Repository) healthPRSize(c *HealthComponent) {
+	if r.PRStats == nil || len(r.PRStats.PRList) == 0 {
+		c.Detail = "no merges"
+		return
+	}
+	small := 0
+	for _, pr := range r.PRStats.PRList {
+		if pr.Additions+pr.Deletions <= healthSmallPR {
+			small++
+		}
+	}
+	c.Measured = true
+	c.Score = safePercent(small, len(r.PRStats.PRList))
+	c.Detail = fmt.Sprintf("%d of %d merges change at most %d lines", small, len(r.PRStats.PRList), healthSmallPR)
+}
+
+// healthContributors compares the authors of the second half of the
+// activity with those of the first: a shrinking team scores lower
+func (r *Repository) healthContributors(c *HealthComponent) {
+	days := r.activityDays()
+	if len(days) < 2 {
+		c.Detail = "less than two days of activity"
+		return
+	}
+	half := days[len(days)/2]
+	var earlier, recent int
+	for _, a := range r.Authors {
+		var before, after bool
+		for day := range a.Daily {
+			if day < half {
+				before = true
+			} else {
+				after = true
+			}
+		}
+		if before {
+			earlier++
+		}
+		if after {
+			recent++
+		}
+	}
+	c.Measured = true
+	c.Score = 100
+	if earlier > 0 {
+		c.Score = min(100, float64(recent)/float64(earlier)*100)
+	}
+	c.Detail = fmt.Sprintf("%d authors in the second half of the activity, %d in the first", recent, earlier)
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// WorkEstimate is the effort estimated from an author's commit times, in
+// the manner of git-hours: commits closer together than a maximum gap form
+// a session, the time between them counts as work, and every session is
+// credited a fixed amount for the work before its first commit
+type WorkEstimate struct {
+	Hours    float64
+	Sessions int
+	Weekly   map[string]float64 // WeekKey -> hours, joinable with AuthorStats.Weekly
+	Commits  map[string]int     // WeekKey -> commits
+}
+
+// ActiveWeekAverage returns the mean hours over weeks with any commit
+func (w *WorkEstimate) ActiveWeekAverage() float64 {
+	if len(w.Weekly) == 0 {
+		return 0
+	}
+	return w.Hours / float64(len(w.Weekly))
+}
+
+// Weeks returns the weeks with estimated work, oldest first
+func (w *WorkEstimate) Weeks() []string {
+	weeks := make([]string, 0, len(w.Weekly))
+	for week := range w.Weekly {
+		weeks = append(weeks, week)
+	}
+	sort.Strings(weeks)
+	return weeks
+}
+
+// EstimateHours clusters commit times into sessions. Time is credited to
+// the week of the commit that ends each interval.
+func EstimateHours(times []time.Time, maxGap, sessionStart time.Duration) *WorkEstimate {
+	w := &WorkEstimate{Weekly: make(map[string]float64), Commits: make(map[string]int)}
+	if len(times) == 0 {
+		return w
+	}
+
+	sorted := append([]time.Time(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	for i, t := range sorted {
+		credit := sessionStart
+		if i > 0 {
+			if gap := t.Sub(sorted[i-1]); gap <= maxGap {
+				credit = gap
+			} else {
+				w.Sessions++
+			}
+		} else {
+			w.Sessions++
+		}
+		week := WeekKey(t)
+		w.Hours += credit.Hours()
+		w.Weekly[week] += credit.Hours()
+		w.Commits[week]++
+	}
+	return w
+}
+
+// GetWorkEstimates returns the estimated effort per author email
+func (r *Repository) GetWorkEstimates(maxGap, sessionStart time.Duration) map[string]*WorkEstimate {
+	estimates := make(map[string]*WorkEstimate, len(r.Authors))
+	for email, a := range r.Authors {
+		estimates[email] = EstimateHours(a.CommitTimes, maxGap, sessionStart)
+	}
+	return estimates
+}
+
+// hoursCollector records commit times for the work estimate
+type hoursCollector struct{}
+
+func (hoursCollector) Name() string { return "hours" }
+
+func (hoursCollector) Collect(repo *Repository, cc *CommitContext) {
+	cc.Author.CommitTimes = append(cc.Author.CommitTimes, cc.LocalTime)
+}
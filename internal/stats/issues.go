@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Match GitHub/GitLab closing keywords followed by one or more issue references,
+// e.g. "Fixes #12", "closes owner/repo#7", "Resolved: #3, #4"
+var (
+	closingKeywordRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+((?:[\w.-]+/[\w.-]+)?#\d+(?:\s*(?:,|and)\s*(?:[\w.-]+/[\w.-]+)?#\d+)*)`)
+	issueRefRegex       = regexp.MustCompile(`(?:[\w.-]+/[\w.-]+)?#\d+`)
+)
+
+// IssueClose records a commit that closes one or more issues
+type IssueClose struct {
+	Hash        string
+	Author      string
+	AuthorEmail string
+	At          time.Time
+	Subject     string
+	Issues      []string // "#123" or "owner/repo#123"
+}
+
+// IssueStats summarizes issues closed via commit message keywords
+type IssueStats struct {
+	TotalIssues int // distinct issues closed
+	Commits     []*IssueClose
+	ByAuthor    []*AuthorIssues
+	Weeks       []string // week start dates "2006-01-02" of the active range, oldest first
+	ByWeek      []int    // distinct issues closed per week, zero for quiet weeks
+}
+
+// AuthorIssues holds the number of issues closed by a single author
+type AuthorIssues struct {
+	Name    string
+	Email   string
+	Issues  int // distinct issues closed
+	Commits int // closing commits
+}
+
+// ParseClosedIssues extracts issue references following closing keywords
+func ParseClosedIssues(message string) []string {
+	var issues []string
+	seen := make(map[string]bool)
+	for _, match := range closingKeywordRegex.FindAllStringSubmatch(message, -1) {
+		for _, ref := range issueRefRegex.FindAllString(match[1], -1) {
+			if !seen[ref] {
+				seen[ref] = true
+				issues = append(issues, ref)
+			}
+		}
+	}
+	return issues
+}
+
+// GetIssueStats returns issues closed per author and per week, plus the
+// list of closing commits, newest first
+func (r *Repository) GetIssueStats() *IssueStats {
+	result := &IssueStats{}
+
+	commits := make([]*IssueClose, len(r.IssueCloses))
+	copy(commits, r.IssueCloses)
+	sort.Slice(commits, func(i, j int) bool {
+		if !commits[i].At.Equal(commits[j].At) {
+			return commits[i].At.After(commits[j].At)
+		}
+		return commits[i].Hash < commits[j].Hash
+	})
+	result.Commits = commits
+
+	// An issue counts once, for the first commit that closed it
+	closed := make(map[string]*IssueClose)
+	for i := len(commits) - 1; i >= 0; i-- {
+		for _, issue := range commits[i].Issues {
+			if _, ok := closed[issue]; !ok {
+				closed[issue] = commits[i]
+			}
+		}
+	}
+	result.TotalIssues = len(closed)
+
+	authors := make(map[string]*AuthorIssues)
+	weeks := make(map[string]int)
+	for _, ic := range closed {
+		author, ok := authors[ic.AuthorEmail]
+		if !ok {
+			author = &AuthorIssues{Name: ic.Author, Email: ic.AuthorEmail}
+			authors[ic.AuthorEmail] = author
+		}
+		author.Issues++
+		weeks[WeekKey(ic.At)]++
+	}
+	// Authors whose commits only closed issues again still show up, with
+	// no issues of their own
+	for _, ic := range commits {
+		author, ok := authors[ic.AuthorEmail]
+		if !ok {
+			author = &AuthorIssues{Name: ic.Author, Email: ic.AuthorEmail}
+			authors[ic.AuthorEmail] = author
+		}
+		author.Commits++
+	}
+
+	for _, author := range authors {
+		result.ByAuthor = append(result.ByAuthor, author)
+	}
+	sort.Slice(result.ByAuthor, func(i, j int) bool {
+		if result.ByAuthor[i].Issues != result.ByAuthor[j].Issues {
+			return result.ByAuthor[i].Issues > result.ByAuthor[j].Issues
+		}
+		return result.ByAuthor[i].Email < result.ByAuthor[j].Email
+	})
+
+	// Every week of the active range, empty weeks included
+	result.Weeks = r.ActivityWeeks()
+	for _, week := range result.Weeks {
+		result.ByWeek = append(result.ByWeek, weeks[week])
+	}
+
+	return result
+}
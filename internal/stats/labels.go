@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// LabelStats aggregates the commits annotated with one label via git notes
+// (see git.ReadLabels)
+type LabelStats struct {
+	Label       string
+	Commits     int
+	Additions   int
+	Deletions   int
+	Authors     map[string]int // author email -> labeled commits
+	FirstCommit time.Time
+	LastCommit  time.Time
+}
+
+// labelCollector aggregates commits per gitstat note label
+type labelCollector struct{}
+
+func (labelCollector) Name() string { return "labels" }
+
+func (labelCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	for _, label := range c.Labels {
+		ls, ok := repo.Labels[label]
+		if !ok {
+			ls = &LabelStats{Label: label, Authors: make(map[string]int)}
+			repo.Labels[label] = ls
+		}
+		ls.Commits++
+		ls.Authors[c.Author.Email]++
+		for _, fc := range c.FileChanges {
+			if !fc.IsBinary {
+				ls.Additions += fc.Additions
+				ls.Deletions += fc.Deletions
+			}
+		}
+		if ls.FirstCommit.IsZero() || c.AuthorDate.Before(ls.FirstCommit) {
+			ls.FirstCommit = c.AuthorDate
+		}
+		if c.AuthorDate.After(ls.LastCommit) {
+			ls.LastCommit = c.AuthorDate
+		}
+	}
+}
+
+// GetLabelStats returns the labels by commits, most used first
+func (r *Repository) GetLabelStats() []*LabelStats {
+	labels := make([]*LabelStats, 0, len(r.Labels))
+	for _, ls := range r.Labels {
+		labels = append(labels, ls)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Commits != labels[j].Commits {
+			return labels[i].Commits > labels[j].Commits
+		}
+		return labels[i].Label < labels[j].Label
+	})
+	return labels
+}
+
+// mergeLabelAuthors folds aliases into their primary identity
+func (r *Repository) mergeLabelAuthors(merges map[string]string) {
+	for _, ls := range r.Labels {
+		for aliasEmail, primaryEmail := range merges {
+			if count, ok := ls.Authors[aliasEmail]; ok && aliasEmail != primaryEmail {
+				ls.Authors[primaryEmail] += count
+				delete(ls.Authors, aliasEmail)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// languageNames maps file extensions to the language they are written in
+var languageNames = map[string]string{
+	".go": "Go", ".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript",
+	".cjs": "JavaScript", ".ts": "TypeScript", ".tsx": "TypeScript",
+	".py": "Python", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin",
+	".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++", ".cxx": "C++",
+	".hpp": "C++", ".hh": "C++", ".cs": "C#", ".rs": "Rust", ".rb": "Ruby",
+	".php": "PHP", ".swift": "Swift", ".scala": "Scala", ".m": "Objective-C",
+	".mm": "Objective-C", ".dart": "Dart", ".ex": "Elixir", ".exs": "Elixir",
+	".erl": "Erlang", ".hs": "Haskell", ".clj": "Clojure", ".lua": "Lua",
+	".pl": "Perl", ".r": "R", ".sh": "Shell", ".bash": "Shell", ".zsh": "Shell",
+	".ps1": "PowerShell", ".sql": "SQL", ".vue": "Vue", ".svelte": "Svelte",
+	".html": "HTML", ".htm": "HTML", ".css": "CSS", ".scss": "SCSS",
+	".sass": "SCSS", ".less": "Less", ".zig": "Zig", ".proto": "Protobuf",
+	".graphql": "GraphQL", ".tf": "Terraform", ".md": "Markdown",
+	".markdown": "Markdown", ".rst": "reStructuredText", ".json": "JSON",
+	".yaml": "YAML", ".yml": "YAML", ".toml": "TOML", ".xml": "XML",
+	".gradle": "Gradle", ".mod": "Go Module", ".sum": "Go Module",
+}
+
+// languageFiles names the languages of files known by name
+var languageFiles = map[string]string{
+	"dockerfile": "Dockerfile", "makefile": "Makefile", "gnumakefile": "Makefile",
+	"cmakelists.txt": "CMake", "gemfile": "Ruby", "rakefile": "Ruby",
+	"jenkinsfile": "Groovy", "vagrantfile": "Ruby",
+}
+
+// LanguageStats aggregates the changes to the files with one extension
+type LanguageStats struct {
+	Extension string // ".go"; a file name such as "Makefile" for files without one
+	Language  string // "Go", or the extension when it is not a known language
+	Touches   int    // file changes, counting each file once per commit
+	Additions int
+	Deletions int
+	Authors   map[string]int // author email -> lines changed
+	files     map[string]bool
+}
+
+// Files returns the number of distinct files changed
+func (l *LanguageStats) Files() int {
+	return len(l.files)
+}
+
+// Changes returns the lines added and deleted
+func (l *LanguageStats) Changes() int {
+	return l.Additions + l.Deletions
+}
+
+// languageKey returns the extension and language of a file. Files known by
+// name keep their name as the extension; other files without an extension
+// share the key "(none)".
+func languageKey(name string) (ext, language string) {
+	base := strings.ToLower(path.Base(name))
+	if language, ok := languageFiles[base]; ok {
+		return path.Base(name), language
+	}
+	ext = path.Ext(base)
+	if ext == "" || ext == base {
+		return "(none)", "(none)"
+	}
+	if language, ok := languageNames[ext]; ok {
+		return ext, language
+	}
+	return ext, ext
+}
+
+// languageCollector aggregates file changes per extension
+type languageCollector struct{}
+
+func (languageCollector) Name() string { return "languages" }
+
+func (languageCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	for _, fc := range c.FileChanges {
+		ext, language := languageKey(fc.FilePath)
+		ls, ok := repo.Languages[ext]
+		if !ok {
+			ls = &LanguageStats{Extension: ext, Language: language,
+				Authors: make(map[string]int), files: make(map[string]bool)}
+			repo.Languages[ext] = ls
+		}
+		ls.Touches++
+		ls.files[fc.FilePath] = true
+		lines := 0
+		if !fc.IsBinary {
+			ls.Additions += fc.Additions
+			ls.Deletions += fc.Deletions
+			lines = fc.Additions + fc.Deletions
+		}
+		ls.Authors[c.Author.Email] += lines
+	}
+}
+
+// GetLanguageStats returns the extensions by lines changed, most first
+func (r *Repository) GetLanguageStats() []*LanguageStats {
+	languages := make([]*LanguageStats, 0, len(r.Languages))
+	for _, ls := range r.Languages {
+		languages = append(languages, ls)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Changes() != languages[j].Changes() {
+			return languages[i].Changes() > languages[j].Changes()
+		}
+		if languages[i].Touches != languages[j].Touches {
+			return languages[i].Touches > languages[j].Touches
+		}
+		return languages[i].Extension < languages[j].Extension
+	})
+	return languages
+}
+
+// mergeLanguageAuthors folds aliases into their primary identity
+func (r *Repository) mergeLanguageAuthors(merges map[string]string) {
+	for _, ls := range r.Languages {
+		for aliasEmail, primaryEmail := range merges {
+			if lines, ok := ls.Authors[aliasEmail]; ok && aliasEmail != primaryEmail {
+				ls.Authors[primaryEmail] += lines
+				delete(ls.Authors, aliasEmail)
+			}
+		}
+	}
+}
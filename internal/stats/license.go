@@ -0,0 +1,75 @@
+package stats
+
+import "sort"
+
+// LicenseCompliance summarizes license header coverage of source files
+type LicenseCompliance struct {
+	Checked   int
+	Compliant int
+	Dirs      []*DirLicense
+	Missing   []string // files without a header, sorted
+}
+
+// DirLicense holds license header coverage for a top-level directory
+type DirLicense struct {
+	Path      string
+	Checked   int
+	Compliant int
+}
+
+// Percent returns the share of checked files that carry the header
+func (d *DirLicense) Percent() float64 {
+	if d.Checked == 0 {
+		return 0
+	}
+	return float64(d.Compliant) / float64(d.Checked) * 100
+}
+
+// Percent returns the share of checked files that carry the header
+func (l *LicenseCompliance) Percent() float64 {
+	if l.Checked == 0 {
+		return 0
+	}
+	return float64(l.Compliant) / float64(l.Checked) * 100
+}
+
+// GetLicenseCompliance returns license header coverage per top-level
+// directory, least compliant first, or nil if the check did not run
+func (r *Repository) GetLicenseCompliance() *LicenseCompliance {
+	if r.LicenseHeaders == nil {
+		return nil
+	}
+
+	result := &LicenseCompliance{}
+	dirs := make(map[string]*DirLicense)
+	for file, ok := range r.LicenseHeaders {
+		path := getTopDir(file)
+		dir, exists := dirs[path]
+		if !exists {
+			dir = &DirLicense{Path: path}
+			dirs[path] = dir
+		}
+		dir.Checked++
+		result.Checked++
+		if ok {
+			dir.Compliant++
+			result.Compliant++
+		} else {
+			result.Missing = append(result.Missing, file)
+		}
+	}
+
+	for _, dir := range dirs {
+		result.Dirs = append(result.Dirs, dir)
+	}
+	sort.Slice(result.Dirs, func(i, j int) bool {
+		pi, pj := result.Dirs[i].Percent(), result.Dirs[j].Percent()
+		if pi != pj {
+			return pi < pj
+		}
+		return result.Dirs[i].Path < result.Dirs[j].Path
+	})
+	sort.Strings(result.Missing)
+
+	return result
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IdentityMerge records an author identity folded into another
+type IdentityMerge struct {
+	PrimaryName  string
+	PrimaryEmail string
+	AliasName    string
+	AliasEmail   string
+}
+
+// Mailmap returns the applied identity merges as .mailmap entries mapping
+// each alias to its canonical name and email, sorted by primary
+func (r *Repository) Mailmap() []string {
+	merges := make([]*IdentityMerge, len(r.IdentityMerges))
+	copy(merges, r.IdentityMerges)
+	sort.Slice(merges, func(i, j int) bool {
+		if merges[i].PrimaryEmail != merges[j].PrimaryEmail {
+			return merges[i].PrimaryEmail < merges[j].PrimaryEmail
+		}
+		return merges[i].AliasEmail < merges[j].AliasEmail
+	})
+
+	var lines []string
+	for _, m := range merges {
+		lines = append(lines, fmt.Sprintf("%s <%s> %s <%s>",
+			m.PrimaryName, m.PrimaryEmail, m.AliasName, m.AliasEmail))
+	}
+	return lines
+}
+
+// MergeMailmap appends the entries missing from an existing .mailmap file,
+// returning the new contents and the number of entries added
+func MergeMailmap(existing string, entries []string) (string, int) {
+	present := make(map[string]bool)
+	for _, line := range strings.Split(existing, "\n") {
+		present[strings.Join(strings.Fields(line), " ")] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString(existing)
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		sb.WriteString("\n")
+	}
+	added := 0
+	for _, entry := range entries {
+		if present[entry] {
+			continue
+		}
+		present[entry] = true
+		sb.WriteString(entry + "\n")
+		added++
+	}
+	return sb.String(), added
+}
+
+// IdentityMergeMap returns the applied identity merges in the email ->
+// primary email form of ApplyAuthorMerges, to apply them again to
+// statistics recomputed from the same commits
+func (r *Repository) IdentityMergeMap() map[string]string {
+	merges := make(map[string]string)
+	for _, m := range r.IdentityMerges {
+		merges[m.AliasEmail] = m.PrimaryEmail
+		merges[m.PrimaryEmail] = m.PrimaryEmail
+	}
+	return merges
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// MarkdownReport renders a summary of the statistics as Markdown: the
+// totals and filters of the scan, the authors leaderboard and the most
+// changed files, limited to maxAuthors and maxFiles rows
+func (r *Repository) MarkdownReport(maxAuthors, maxFiles int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# gitstat report: %s\n\n", r.Path)
+	fmt.Fprintf(&sb, "%s: %d commits by %d authors, +%d/-%d lines\n\n",
+		r.DateRange, r.TotalCommits, r.TotalAuthors, r.TotalAdditions, r.TotalDeletions)
+	if filters := r.Filters.Describe(); len(filters) > 0 {
+		sb.WriteString("Filters:\n\n")
+		for _, line := range filters {
+			fmt.Fprintf(&sb, "- %s\n", markdownCell(line))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Authors\n\n")
+	sb.WriteString("| # | Author | Email | Commits | Additions | Deletions | Files |\n")
+	sb.WriteString("|---|--------|-------|--------:|----------:|----------:|------:|\n")
+	for i, a := range r.GetLeaderboard("commits", false) {
+		if i == maxAuthors {
+			break
+		}
+		fmt.Fprintf(&sb, "| %d | %s | %s | %d | %d | %d | %d |\n",
+			i+1, markdownCell(a.Name), markdownCell(a.Email), a.Commits, a.Additions, a.Deletions, len(a.FilesTouched))
+	}
+
+	sb.WriteString("\n## Top Files\n\n")
+	sb.WriteString("| # | File | Changes | Touches | Authors |\n")
+	sb.WriteString("|---|------|--------:|--------:|--------:|\n")
+	for i, f := range r.GetTopFiles("changes", false, maxFiles) {
+		fmt.Fprintf(&sb, "| %d | %s | %d | %d | %d |\n",
+			i+1, markdownCell(f.Path), f.TotalChanges, f.TouchCount, len(f.Authors))
+	}
+	return sb.String()
+}
+
+// ReportTitle names a published report by the repository and period
+func (r *Repository) ReportTitle() string {
+	return fmt.Sprintf("gitstat report: %s, %s", filepath.Base(r.Path), r.DateRange)
+}
+
+// markdownCell escapes the characters that would break a table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// lockfiles are dependency lockfiles written by package managers
+var lockfiles = map[string]bool{
+	"go.sum": true, "package-lock.json": true, "npm-shrinkwrap.json": true,
+	"yarn.lock": true, "pnpm-lock.yaml": true, "bun.lockb": true,
+	"cargo.lock": true, "gemfile.lock": true, "poetry.lock": true,
+	"pipfile.lock": true, "composer.lock": true, "podfile.lock": true,
+	"packages.lock.json": true, "pubspec.lock": true, "mix.lock": true,
+	"flake.lock": true, "uv.lock": true,
+}
+
+// versionFiles hold nothing but a version number
+var versionFiles = map[string]bool{
+	"version": true, "version.txt": true, ".version": true,
+}
+
+// versionManifests also declare dependencies and metadata, so they count as
+// a version bump only when few lines change
+var versionManifests = map[string]bool{
+	"package.json": true, "cargo.toml": true, "pyproject.toml": true,
+	"setup.cfg": true, "chart.yaml": true, "pom.xml": true,
+	"build.gradle": true, "build.gradle.kts": true, "gradle.properties": true,
+	"pubspec.yaml": true, "mix.exs": true,
+}
+
+// mechanicalManifestLines is the most lines a version bump changes in a manifest
+const mechanicalManifestLines = 4
+
+// isMechanicalFile reports whether a changed file is a lockfile, version
+// file or changelog
+func isMechanicalFile(fc git.FileChange) bool {
+	name := strings.ToLower(filepath.Base(fc.FilePath))
+	switch {
+	case lockfiles[name], versionFiles[name]:
+		return true
+	case versionManifests[name]:
+		return fc.Additions+fc.Deletions <= mechanicalManifestLines
+	}
+
+	// Changelogs are plain text; "history.go" is source code
+	ext := filepath.Ext(name)
+	switch ext {
+	case "", ".md", ".txt", ".rst", ".adoc":
+	default:
+		return false
+	}
+	switch strings.TrimSuffix(name, ext) {
+	case "changelog", "changes", "history", "news", "release_notes", "release-notes":
+		return true
+	}
+	return false
+}
+
+// IsMechanicalCommit reports whether a commit only touches lockfiles,
+// version files and changelogs, as automated version bumps and dependency
+// updates do
+func IsMechanicalCommit(c *git.Commit) bool {
+	if c.IsMerge || len(c.FileChanges) == 0 {
+		return false
+	}
+	for _, fc := range c.FileChanges {
+		if !isMechanicalFile(fc) {
+			return false
+		}
+	}
+	return true
+}
+
+// MechanicalStats summarizes commits classified by IsMechanicalCommit
+type MechanicalStats struct {
+	Commits  int
+	Lines    int  // non-binary lines changed
+	Excluded bool // left out of all other statistics
+}
+
+// record adds a mechanical commit to the summary
+func (m *MechanicalStats) record(c *git.Commit) {
+	m.Commits++
+	for _, fc := range c.FileChanges {
+		if !fc.IsBinary {
+			m.Lines += fc.Additions + fc.Deletions
+		}
+	}
+}
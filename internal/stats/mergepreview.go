@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"cmp"
+	"sort"
+)
+
+// MergePreview describes the identity an author merge would produce,
+// computed without modifying the repository
+type MergePreview struct {
+	Combined   *AuthorStats     // primary with aliases folded in
+	Identities []*AuthorStats   // primary first, then aliases by commits
+	Dirs       []*MergeDirShare // directories the identities touched, by changes
+}
+
+// MergeDirShare is the combined contribution of merged identities to one
+// top-level directory
+type MergeDirShare struct {
+	Path    string
+	Commits int
+	Changes int
+	Share   float64 // percentage of the directory's total changes
+}
+
+// PreviewAuthorMerges returns one preview per primary in merges, in the
+// same email -> primary email form accepted by ApplyAuthorMerges
+func (r *Repository) PreviewAuthorMerges(merges map[string]string) []*MergePreview {
+	groups := make(map[string][]*AuthorStats)
+	for email, target := range merges {
+		author, ok := r.Authors[email]
+		if !ok {
+			continue
+		}
+		if _, ok := r.Authors[target]; !ok {
+			continue
+		}
+		groups[target] = append(groups[target], author)
+	}
+
+	var previews []*MergePreview
+	for primaryEmail, members := range groups {
+		primary := r.Authors[primaryEmail]
+		sort.Slice(members, func(i, j int) bool {
+			a, b := members[i], members[j]
+			if (a.Email == primaryEmail) != (b.Email == primaryEmail) {
+				return a.Email == primaryEmail
+			}
+			if a.Commits != b.Commits {
+				return a.Commits > b.Commits
+			}
+			return a.Email < b.Email
+		})
+		if members[0] != primary {
+			members = append([]*AuthorStats{primary}, members...)
+		}
+
+		combined := NewAuthorStats(primary.Name, primary.Email)
+		emails := make(map[string]bool, len(members))
+		for _, m := range members {
+			emails[m.Email] = true
+			combined.Commits += m.Commits
+			combined.Additions += m.Additions
+			combined.Deletions += m.Deletions
+			combined.SurvivingLines += m.SurvivingLines
+			combined.ChurnedLines += m.ChurnedLines
+			combined.RefactorCommits += m.RefactorCommits
+			for file, count := range m.FilesTouched {
+				combined.FilesTouched[file] += count
+			}
+			if combined.FirstCommit.IsZero() || m.FirstCommit.Before(combined.FirstCommit) {
+				combined.FirstCommit = m.FirstCommit
+			}
+			if m.LastCommit.After(combined.LastCommit) {
+				combined.LastCommit = m.LastCommit
+			}
+		}
+
+		var dirs []*MergeDirShare
+		for path, dir := range r.DirStats {
+			entry := &MergeDirShare{Path: path}
+			for email, da := range dir.Authors {
+				if emails[email] {
+					entry.Commits += da.Commits
+					entry.Changes += da.Changes
+				}
+			}
+			if entry.Commits == 0 {
+				continue
+			}
+			if dir.TotalChanges > 0 {
+				entry.Share = float64(entry.Changes) / float64(dir.TotalChanges) * 100
+			}
+			dirs = append(dirs, entry)
+		}
+		sort.Slice(dirs, func(i, j int) bool {
+			if c := cmp.Compare(dirs[i].Changes, dirs[j].Changes); c != 0 {
+				return c > 0
+			}
+			return dirs[i].Path < dirs[j].Path
+		})
+
+		previews = append(previews, &MergePreview{
+			Combined:   combined,
+			Identities: members,
+			Dirs:       dirs,
+		})
+	}
+
+	sort.Slice(previews, func(i, j int) bool {
+		a, b := previews[i].Combined, previews[j].Combined
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Email < b.Email
+	})
+	return previews
+}
@@ -0,0 +1,238 @@
+package stats
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	// Match gitmoji shortcodes like ":sparkles:" at the start of a subject
+	gitmojiRegex = regexp.MustCompile(`^:[a-z0-9_+-]+:`)
+
+	// Common words in English commit subjects
+	englishWords = map[string]bool{
+		"add": true, "added": true, "adds": true, "fix": true, "fixed": true, "fixes": true,
+		"update": true, "updated": true, "updates": true, "remove": true, "removed": true,
+		"the": true, "to": true, "for": true, "and": true, "of": true, "in": true, "on": true,
+		"with": true, "from": true, "use": true, "make": true, "move": true, "refactor": true,
+		"change": true, "improve": true, "bump": true, "initial": true, "support": true,
+		"merge": true, "revert": true, "test": true, "tests": true, "docs": true, "when": true,
+	}
+)
+
+// Message languages detected from commit subjects
+const (
+	LangEnglish    = "English"
+	LangOtherLatin = "Other Latin"
+	LangCyrillic   = "Cyrillic"
+	LangCJK        = "CJK"
+	LangOther      = "Other"
+	LangUnknown    = "Unknown"
+)
+
+// MessageStats holds commit message convention counters
+type MessageStats struct {
+	Commits         int            // non-merge commits analyzed
+	EmojiPrefixed   int            // subjects starting with an emoji or gitmoji shortcode
+	GitmojiPrefixed int            // subjects starting with a ":shortcode:"
+	Languages       map[string]int // detected language -> commits
+	EmojiLanguages  map[string]int // detected language -> emoji-prefixed commits
+
+	// Message quality over the full message, see GetCommitQuality
+	Lengths      []int // characters of each message, subject and body
+	WithBody     int   // messages with a body below the subject
+	Conventional int   // subjects in the Conventional Commits format
+	TicketRefs   int   // messages referencing a ticket, e.g. JIRA-123 or #123
+	Fixups       int   // fixup!, squash! and amend! commits
+	WIP          int   // subjects marking work in progress
+}
+
+// NewMessageStats creates a new MessageStats
+func NewMessageStats() *MessageStats {
+	return &MessageStats{
+		Languages:      make(map[string]int),
+		EmojiLanguages: make(map[string]int),
+	}
+}
+
+// Add records a commit message
+func (m *MessageStats) Add(subject, body string) {
+	m.Commits++
+	m.addQuality(subject, strings.TrimSpace(body))
+
+	gitmoji := gitmojiRegex.MatchString(subject)
+	if gitmoji {
+		m.GitmojiPrefixed++
+	}
+	lang := DetectMessageLanguage(subject)
+	if gitmoji || startsWithEmoji(subject) {
+		m.EmojiPrefixed++
+		m.EmojiLanguages[lang]++
+	}
+	m.Languages[lang]++
+}
+
+// Merge adds another author's counters into this one
+func (m *MessageStats) Merge(other *MessageStats) {
+	if other == nil {
+		return
+	}
+	m.Commits += other.Commits
+	m.EmojiPrefixed += other.EmojiPrefixed
+	m.GitmojiPrefixed += other.GitmojiPrefixed
+	m.Lengths = append(m.Lengths, other.Lengths...)
+	m.WithBody += other.WithBody
+	m.Conventional += other.Conventional
+	m.TicketRefs += other.TicketRefs
+	m.Fixups += other.Fixups
+	m.WIP += other.WIP
+	for lang, count := range other.Languages {
+		m.Languages[lang] += count
+	}
+	for lang, count := range other.EmojiLanguages {
+		m.EmojiLanguages[lang] += count
+	}
+}
+
+// EmojiPercent returns the share of emoji-prefixed subjects
+func (m *MessageStats) EmojiPercent() float64 {
+	if m.Commits == 0 {
+		return 0
+	}
+	return float64(m.EmojiPrefixed) / float64(m.Commits) * 100
+}
+
+// DominantLanguage returns the most used message language
+func (m *MessageStats) DominantLanguage() string {
+	best := LangUnknown
+	bestCount := 0
+	for lang, count := range m.Languages {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best = lang
+			bestCount = count
+		}
+	}
+	return best
+}
+
+// MessageConventions holds repository-wide message convention results
+type MessageConventions struct {
+	Total         *MessageStats
+	UsesEmoji     bool   // true if the majority of subjects are emoji-prefixed
+	Language      string // dominant repository language
+	AuthorReports []*AuthorConventionReport
+}
+
+// AuthorConventionReport holds convention adherence for a single author
+type AuthorConventionReport struct {
+	Name      string
+	Email     string
+	Messages  *MessageStats
+	Adherence float64 // percentage of commits following the repo conventions
+}
+
+// GetMessageConventions infers the repository's message conventions and
+// reports how closely each author follows them
+func (r *Repository) GetMessageConventions() *MessageConventions {
+	total := NewMessageStats()
+	for _, a := range r.Authors {
+		total.Merge(a.Messages)
+	}
+
+	conv := &MessageConventions{
+		Total:     total,
+		UsesEmoji: total.Commits > 0 && total.EmojiPrefixed*2 > total.Commits,
+		Language:  total.DominantLanguage(),
+	}
+
+	for _, a := range r.Authors {
+		if a.Messages == nil || a.Messages.Commits == 0 {
+			continue
+		}
+		m := a.Messages
+
+		// Commits matching both the emoji convention and the language
+		matches := m.Languages[conv.Language] - m.EmojiLanguages[conv.Language]
+		if conv.UsesEmoji {
+			matches = m.EmojiLanguages[conv.Language]
+		}
+
+		conv.AuthorReports = append(conv.AuthorReports, &AuthorConventionReport{
+			Name:      a.Name,
+			Email:     a.Email,
+			Messages:  m,
+			Adherence: float64(matches) / float64(m.Commits) * 100,
+		})
+	}
+
+	sort.Slice(conv.AuthorReports, func(i, j int) bool {
+		if conv.AuthorReports[i].Messages.Commits != conv.AuthorReports[j].Messages.Commits {
+			return conv.AuthorReports[i].Messages.Commits > conv.AuthorReports[j].Messages.Commits
+		}
+		return conv.AuthorReports[i].Email < conv.AuthorReports[j].Email
+	})
+
+	return conv
+}
+
+// DetectMessageLanguage guesses the language of a commit subject from its
+// script and, for Latin text, the presence of common English words
+func DetectMessageLanguage(subject string) string {
+	var latin, cyrillic, cjk, other int
+	for _, r := range subject {
+		switch {
+		case !unicode.IsLetter(r):
+			continue
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hiragana, r),
+			unicode.Is(unicode.Katakana, r), unicode.Is(unicode.Hangul, r):
+			cjk++
+		default:
+			other++
+		}
+	}
+
+	switch {
+	case latin+cyrillic+cjk+other == 0:
+		return LangUnknown
+	case cyrillic >= latin && cyrillic >= cjk && cyrillic >= other:
+		return LangCyrillic
+	case cjk >= latin && cjk >= other:
+		return LangCJK
+	case other > latin:
+		return LangOther
+	}
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(subject), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if englishWords[word] {
+			return LangEnglish
+		}
+	}
+	return LangOtherLatin
+}
+
+func startsWithEmoji(subject string) bool {
+	for _, r := range strings.TrimSpace(subject) {
+		return isEmoji(r)
+	}
+	return false
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F300 && r <= 0x1FAFF: // pictographs, emoticons, transport, supplemental
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows and stars
+		return true
+	}
+	return false
+}
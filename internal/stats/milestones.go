@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Kinds of milestones
+const (
+	MilestoneCommits     = "commits"     // an author's Nth commit
+	MilestoneDirectory   = "directory"   // first change to a top-level directory
+	MilestoneAnniversary = "anniversary" // years since an author's first commit
+)
+
+// Commit counts celebrated as milestones
+var commitMilestones = []int{100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Milestone is an event in an author's history worth celebrating. Commits
+// and first changes are counted from the start of the scanned range.
+type Milestone struct {
+	Date  string // "2024-01-15"
+	Kind  string // MilestoneCommits, MilestoneDirectory or MilestoneAnniversary
+	Name  string
+	Email string
+	Count int    // the commits reached, or the years of an anniversary
+	Dir   string // the directory of a MilestoneDirectory
+}
+
+// Title describes the milestone, e.g. "100th commit"
+func (m *Milestone) Title() string {
+	switch m.Kind {
+	case MilestoneCommits:
+		return fmt.Sprintf("%dth commit", m.Count)
+	case MilestoneDirectory:
+		return fmt.Sprintf("First change to %s/", m.Dir)
+	case MilestoneAnniversary:
+		if m.Count == 1 {
+			return "1 year in the repository"
+		}
+		return fmt.Sprintf("%d years in the repository", m.Count)
+	}
+	return m.Kind
+}
+
+// GetMilestones returns the milestones of every author, newest first: the
+// day of their 100th, 250th, 500th... commit, of their first change to each
+// top-level directory after their first commit, and the anniversaries of
+// their first commit up to their last
+func (r *Repository) GetMilestones() []*Milestone {
+	var milestones []*Milestone
+	firstDays := make(map[string]string, len(r.Authors))
+	for email, author := range r.Authors {
+		days := make([]string, 0, len(author.Daily))
+		for day := range author.Daily {
+			days = append(days, day)
+		}
+		if len(days) == 0 {
+			continue
+		}
+		slices.Sort(days)
+		firstDays[email] = days[0]
+
+		// The day the running count passes each milestone
+		total, next := 0, 0
+		for _, day := range days {
+			total += author.Daily[day]
+			for next < len(commitMilestones) && total >= commitMilestones[next] {
+				milestones = append(milestones, &Milestone{Date: day, Kind: MilestoneCommits,
+					Name: author.Name, Email: email, Count: commitMilestones[next]})
+				next++
+			}
+		}
+
+		first, _ := time.Parse("2006-01-02", days[0])
+		last := days[len(days)-1]
+		for years := 1; ; years++ {
+			day := first.AddDate(years, 0, 0).Format("2006-01-02")
+			if day > last {
+				break
+			}
+			milestones = append(milestones, &Milestone{Date: day, Kind: MilestoneAnniversary,
+				Name: author.Name, Email: email, Count: years})
+		}
+	}
+
+	for path, dir := range r.DirStats {
+		if path == "." {
+			continue
+		}
+		for email, a := range dir.Authors {
+			if a.FirstDay == "" || a.FirstDay == firstDays[email] {
+				continue
+			}
+			name := a.Name
+			if author, ok := r.Authors[email]; ok {
+				name = author.Name
+			}
+			milestones = append(milestones, &Milestone{Date: a.FirstDay, Kind: MilestoneDirectory,
+				Name: name, Email: email, Dir: path})
+		}
+	}
+
+	slices.SortFunc(milestones, func(a, b *Milestone) int {
+		return cmp.Or(cmp.Compare(b.Date, a.Date), cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Email, b.Email), cmp.Compare(a.Dir, b.Dir), cmp.Compare(a.Count, b.Count))
+	})
+	return milestones
+}
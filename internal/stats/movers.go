@@ -0,0 +1,136 @@
+package stats
+
+import "sort"
+
+// Only paths ranked within MoverRanks places by churn in either period are
+// compared, so the long tail of rarely touched files does not swamp the
+// report
+const MoverRanks = 50
+
+// Mover is a file or top-level directory whose churn rank changed against
+// the previous period
+type Mover struct {
+	Path      string
+	IsDir     bool
+	Rank      int // churn rank in the selected period, 1 = most churn; 0 if untouched
+	PrevRank  int // churn rank in the previous period; 0 if untouched
+	Churn     int // lines changed in the selected period
+	PrevChurn int
+}
+
+// Change returns the number of places the path climbed, negative when it
+// fell. An untouched path ranks one below the last ranked path of its
+// period, and at least one below MoverRanks.
+func (m *Mover) Change() int {
+	return m.PrevRank - m.Rank
+}
+
+// GetTopMovers compares the churn ranks of files and top-level directories
+// with the previous period, returning the risers and the fallers, largest
+// change first. Both are nil if the previous period was not scanned.
+func (r *Repository) GetTopMovers() (risers, fallers []*Mover) {
+	if r.Previous == nil {
+		return nil, nil
+	}
+
+	files := func(repo *Repository) map[string]int {
+		churn := make(map[string]int, len(repo.FileStats))
+		for path, f := range repo.FileStats {
+			churn[path] = f.TotalChanges
+		}
+		return churn
+	}
+	dirs := func(repo *Repository) map[string]int {
+		churn := make(map[string]int, len(repo.DirStats))
+		for path, d := range repo.DirStats {
+			churn[path] = d.TotalChanges
+		}
+		return churn
+	}
+
+	movers := compareRanks(files(r), files(r.Previous), false)
+	movers = append(movers, compareRanks(dirs(r), dirs(r.Previous), true)...)
+	for _, m := range movers {
+		switch {
+		case m.Change() > 0:
+			risers = append(risers, m)
+		case m.Change() < 0:
+			fallers = append(fallers, m)
+		}
+	}
+
+	sort.Slice(risers, func(i, j int) bool { return moverLess(risers[i], risers[j]) })
+	sort.Slice(fallers, func(i, j int) bool { return moverLess(fallers[i], fallers[j]) })
+	return risers, fallers
+}
+
+// compareRanks ranks the paths of both periods by churn and pairs them up
+func compareRanks(cur, prev map[string]int, isDir bool) []*Mover {
+	curRanks, prevRanks := churnRanks(cur), churnRanks(prev)
+
+	var movers []*Mover
+	add := func(path string) {
+		m := &Mover{
+			Path:      path,
+			IsDir:     isDir,
+			Rank:      curRanks[path],
+			PrevRank:  prevRanks[path],
+			Churn:     cur[path],
+			PrevChurn: prev[path],
+		}
+		if m.Rank == 0 {
+			m.Rank = max(len(curRanks), MoverRanks) + 1
+		}
+		if m.PrevRank == 0 {
+			m.PrevRank = max(len(prevRanks), MoverRanks) + 1
+		}
+		movers = append(movers, m)
+	}
+	for path, rank := range curRanks {
+		if rank <= MoverRanks {
+			add(path)
+		}
+	}
+	for path, rank := range prevRanks {
+		if rank <= MoverRanks && (curRanks[path] == 0 || curRanks[path] > MoverRanks) {
+			add(path)
+		}
+	}
+	return movers
+}
+
+// churnRanks numbers paths with churn by descending churn, ties broken by
+// path
+func churnRanks(churn map[string]int) map[string]int {
+	paths := make([]string, 0, len(churn))
+	for path, c := range churn {
+		if c > 0 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if churn[paths[i]] != churn[paths[j]] {
+			return churn[paths[i]] > churn[paths[j]]
+		}
+		return paths[i] < paths[j]
+	})
+
+	ranks := make(map[string]int, len(paths))
+	for i, path := range paths {
+		ranks[path] = i + 1
+	}
+	return ranks
+}
+
+// moverLess orders movers by the size of their change, then by their best
+// rank in either period
+func moverLess(a, b *Mover) bool {
+	ca, cb := max(a.Change(), -a.Change()), max(b.Change(), -b.Change())
+	if ca != cb {
+		return ca > cb
+	}
+	if ba, bb := min(a.Rank, a.PrevRank), min(b.Rank, b.PrevRank); ba != bb {
+		return ba < bb
+	}
+	return a.Path < b.Path
+}
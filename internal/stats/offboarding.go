@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// OffboardingRisk describes an inactive author who still owns significant code
+type OffboardingRisk struct {
+	Name          string
+	Email         string
+	FirstCommit   time.Time
+	LastCommit    time.Time
+	WeeksInactive int
+	Dirs          []*OwnedDir // owned directories at risk, largest share first
+}
+
+// OwnedDir is a directory in which an author holds a large churn share
+type OwnedDir struct {
+	Path    string
+	Share   float64
+	Changes int
+}
+
+// GetOffboardingRisks returns authors whose last commit is at least
+// inactiveWeeks old at the end of the range but who still hold at least
+// minShare percent of a directory's churn, most directories at risk first
+func (r *Repository) GetOffboardingRisks(inactiveWeeks int, minShare float64) []*OffboardingRisk {
+	reference := r.DateRange.Until
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	cutoff := reference.AddDate(0, 0, -7*inactiveWeeks)
+
+	risks := make([]*OffboardingRisk, 0)
+	for email, a := range r.Authors {
+		if a.LastCommit.After(cutoff) {
+			continue
+		}
+
+		risk := &OffboardingRisk{
+			Name:          a.Name,
+			Email:         email,
+			FirstCommit:   a.FirstCommit,
+			LastCommit:    a.LastCommit,
+			WeeksInactive: int(reference.Sub(a.LastCommit).Hours() / (24 * 7)),
+		}
+
+		for path, dir := range r.DirStats {
+			if da, ok := dir.Authors[email]; ok && da.Share >= minShare {
+				risk.Dirs = append(risk.Dirs, &OwnedDir{Path: path, Share: da.Share, Changes: da.Changes})
+			}
+		}
+		if len(risk.Dirs) == 0 {
+			continue
+		}
+
+		sort.Slice(risk.Dirs, func(i, j int) bool {
+			if risk.Dirs[i].Share != risk.Dirs[j].Share {
+				return risk.Dirs[i].Share > risk.Dirs[j].Share
+			}
+			return risk.Dirs[i].Path < risk.Dirs[j].Path
+		})
+
+		risks = append(risks, risk)
+	}
+
+	sort.Slice(risks, func(i, j int) bool {
+		if len(risks[i].Dirs) != len(risks[j].Dirs) {
+			return len(risks[i].Dirs) > len(risks[j].Dirs)
+		}
+		if risks[i].Dirs[0].Share != risks[j].Dirs[0].Share {
+			return risks[i].Dirs[0].Share > risks[j].Dirs[0].Share
+		}
+		return risks[i].Email < risks[j].Email
+	})
+
+	return risks
+}
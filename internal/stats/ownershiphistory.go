@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// OwnershipFrame is the ownership of a directory at the end of one month,
+// counting the changes from the start of the range up to it
+type OwnershipFrame struct {
+	Month   string // "2024-01"
+	Changes int    // lines changed in the directory up to the month
+	Added   int    // lines changed in the month itself
+	Authors []*DirAuthorStats
+}
+
+// GetOwnershipHistory returns how the ownership of a directory built up
+// month by month, from its first changed month to the last month of the
+// range. The authors of each frame are sorted by share, largest first; the
+// last frame matches the directory's final ownership.
+func (r *Repository) GetOwnershipHistory(path string) []OwnershipFrame {
+	dir := r.GetDir(path)
+	if dir == nil || len(r.DailyActivity) == 0 {
+		return nil
+	}
+
+	first := ""
+	for _, a := range dir.Authors {
+		for month := range a.Monthly {
+			if first == "" || month < first {
+				first = month
+			}
+		}
+	}
+	if first == "" {
+		return nil
+	}
+	start, _ := time.Parse("2006-01", first)
+	_, end := r.activityBounds()
+
+	cumulative := make(map[string]int)
+	total := 0
+	var frames []OwnershipFrame
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		month := m.Format("2006-01")
+		frame := OwnershipFrame{Month: month}
+		for email, a := range dir.Authors {
+			if changes := a.Monthly[month]; changes > 0 {
+				cumulative[email] += changes
+				frame.Added += changes
+			}
+		}
+		total += frame.Added
+		frame.Changes = total
+
+		for email, changes := range cumulative {
+			a := dir.Authors[email]
+			author := &DirAuthorStats{Name: a.Name, Email: email, Changes: changes}
+			if total > 0 {
+				author.Share = float64(changes) / float64(total) * 100
+			}
+			frame.Authors = append(frame.Authors, author)
+		}
+		sort.Slice(frame.Authors, func(i, j int) bool {
+			if frame.Authors[i].Share != frame.Authors[j].Share {
+				return frame.Authors[i].Share > frame.Authors[j].Share
+			}
+			return frame.Authors[i].Email < frame.Authors[j].Email
+		})
+		frames = append(frames, frame)
+	}
+	return frames
+}
@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// UnhealthyHours defines when committing counts against a sustainable pace:
+// from After until Before the next morning, and on weekends if Weekends is
+// set. After equal to Before leaves the evenings out.
+type UnhealthyHours struct {
+	After    int // hour of the day, 0-23, e.g. 22
+	Before   int // hour of the day, 0-23, e.g. 6
+	Weekends bool
+}
+
+// Includes reports whether a commit at local time t falls in the unhealthy
+// hours
+func (u UnhealthyHours) Includes(t time.Time) bool {
+	if u.Weekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	hour := t.Hour()
+	switch {
+	case u.After == u.Before:
+		return false
+	case u.After < u.Before:
+		return hour >= u.After && hour < u.Before
+	default:
+		// The window wraps around midnight
+		return hour >= u.After || hour < u.Before
+	}
+}
+
+// PaceReport holds the share of commits made in unhealthy hours, per author
+// and month by month for the whole team
+type PaceReport struct {
+	Hours     UnhealthyHours
+	Commits   int
+	Unhealthy int
+	Authors   []*AuthorPace // highest share first
+	Months    []string      // "2024-01", oldest first
+	Monthly   []PaceMonth   // per entry of Months
+}
+
+// AuthorPace holds an author's commits in unhealthy hours
+type AuthorPace struct {
+	Name      string
+	Email     string
+	Commits   int
+	Unhealthy int
+}
+
+// PaceMonth holds the team's commits in one month
+type PaceMonth struct {
+	Commits   int
+	Unhealthy int
+}
+
+// Percent returns the share of commits in unhealthy hours
+func (r *PaceReport) Percent() float64 {
+	return paceShare(r.Unhealthy, r.Commits)
+}
+
+// Percent returns the share of the author's commits in unhealthy hours
+func (a *AuthorPace) Percent() float64 {
+	return paceShare(a.Unhealthy, a.Commits)
+}
+
+// Percent returns the share of the month's commits in unhealthy hours
+func (m PaceMonth) Percent() float64 {
+	return paceShare(m.Unhealthy, m.Commits)
+}
+
+func paceShare(unhealthy, commits int) float64 {
+	if commits == 0 {
+		return 0
+	}
+	return float64(unhealthy) / float64(commits) * 100
+}
+
+// GetPaceReport classifies every author's commit times, in the scan's
+// timezone, against the unhealthy hours
+func (r *Repository) GetPaceReport(hours UnhealthyHours) *PaceReport {
+	report := &PaceReport{Hours: hours}
+	months := make(map[string]*PaceMonth)
+
+	for email, a := range r.Authors {
+		pace := &AuthorPace{Name: a.Name, Email: email}
+		for _, t := range a.CommitTimes {
+			month := t.Format("2006-01")
+			m, ok := months[month]
+			if !ok {
+				m = &PaceMonth{}
+				months[month] = m
+			}
+			pace.Commits++
+			m.Commits++
+			if hours.Includes(t) {
+				pace.Unhealthy++
+				m.Unhealthy++
+			}
+		}
+		if pace.Commits == 0 {
+			continue
+		}
+		report.Commits += pace.Commits
+		report.Unhealthy += pace.Unhealthy
+		report.Authors = append(report.Authors, pace)
+	}
+
+	sort.Slice(report.Authors, func(i, j int) bool {
+		a, b := report.Authors[i], report.Authors[j]
+		if a.Percent() != b.Percent() {
+			return a.Percent() > b.Percent()
+		}
+		if a.Unhealthy != b.Unhealthy {
+			return a.Unhealthy > b.Unhealthy
+		}
+		return a.Email < b.Email
+	})
+
+	for month := range months {
+		report.Months = append(report.Months, month)
+	}
+	sort.Strings(report.Months)
+	for _, month := range report.Months {
+		report.Monthly = append(report.Monthly, *months[month])
+	}
+	return report
+}
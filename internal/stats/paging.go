@@ -0,0 +1,242 @@
+package stats
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed, was issued for a
+// different listing or sort order, or points at an entry that no longer exists
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// PageRequest selects a window of a sorted listing. When Cursor is set the
+// page starts right after the entry it points at and Offset is ignored.
+// A Limit of zero or less returns everything from the start position.
+type PageRequest struct {
+	Offset int
+	Limit  int
+	Cursor string
+}
+
+// Page is one window of a sorted listing
+type Page[T any] struct {
+	Items      []T
+	Offset     int    // position of the first item in the full listing
+	Total      int    // number of entries in the full listing
+	NextCursor string // empty on the last page
+}
+
+// sortedList is a sorted listing with a lazily built key -> position index
+type sortedList[T any] struct {
+	items []T
+	key   func(T) string
+	index map[string]int
+}
+
+func (l *sortedList[T]) position(key string) (int, bool) {
+	if l.index == nil {
+		l.index = make(map[string]int, len(l.items))
+		for i, item := range l.items {
+			l.index[l.key(item)] = i
+		}
+	}
+	pos, ok := l.index[key]
+	return pos, ok
+}
+
+// sortCache keeps sorted listings per sort order so paging through them
+// does not re-sort the full data set for every page
+type sortCache struct {
+	mu      sync.Mutex
+	authors map[string]*sortedList[*AuthorStats]
+	files   map[string]*sortedList[*FileStats]
+	dirs    map[string]*sortedList[*DirStats]
+	prs     map[string]*sortedList[*PRInfo]
+}
+
+func newSortCache() *sortCache {
+	return &sortCache{
+		authors: make(map[string]*sortedList[*AuthorStats]),
+		files:   make(map[string]*sortedList[*FileStats]),
+		dirs:    make(map[string]*sortedList[*DirStats]),
+		prs:     make(map[string]*sortedList[*PRInfo]),
+	}
+}
+
+// invalidateSorted drops cached listings after the underlying data changed
+func (r *Repository) invalidateSorted() {
+	if r.sorted == nil {
+		return
+	}
+	r.sorted.mu.Lock()
+	defer r.sorted.mu.Unlock()
+	clear(r.sorted.authors)
+	clear(r.sorted.files)
+	clear(r.sorted.dirs)
+	clear(r.sorted.prs)
+}
+
+// cachedList returns the cached listing for spec, building it on first use.
+// Without a cache (e.g. a Repository not built by NewRepository) it builds
+// the listing every time.
+func cachedList[T any](c *sortCache, lists map[string]*sortedList[T], spec string, key func(T) string, build func() []T) *sortedList[T] {
+	if c == nil {
+		return &sortedList[T]{items: build(), key: key}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := lists[spec]; ok {
+		return l
+	}
+	l := &sortedList[T]{items: build(), key: key}
+	lists[spec] = l
+	return l
+}
+
+// paginate slices one page out of a sorted listing
+func paginate[T any](c *sortCache, l *sortedList[T], spec string, req PageRequest) (*Page[T], error) {
+	start := req.Offset
+	if req.Cursor != "" {
+		key, err := decodeCursor(req.Cursor, spec)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			c.mu.Lock()
+		}
+		pos, ok := l.position(key)
+		if c != nil {
+			c.mu.Unlock()
+		}
+		if !ok {
+			return nil, ErrInvalidCursor
+		}
+		start = pos + 1
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start > len(l.items) {
+		start = len(l.items)
+	}
+
+	end := len(l.items)
+	if req.Limit > 0 && start+req.Limit < end {
+		end = start + req.Limit
+	}
+
+	page := &Page[T]{
+		Items:  l.items[start:end:end],
+		Offset: start,
+		Total:  len(l.items),
+	}
+	if end < len(l.items) && end > start {
+		page.NextCursor = encodeCursor(spec, l.key(l.items[end-1]))
+	}
+	return page, nil
+}
+
+// sortSpec identifies a listing and its order, e.g. "files|changes|desc"
+func sortSpec(listing, sortBy string, ascending bool) string {
+	order := "desc"
+	if ascending {
+		order = "asc"
+	}
+	return listing + "|" + sortBy + "|" + order
+}
+
+func encodeCursor(spec, key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(spec + "\x00" + key))
+}
+
+func decodeCursor(cursor, spec string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	gotSpec, key, ok := strings.Cut(string(raw), "\x00")
+	if !ok || gotSpec != spec {
+		return "", ErrInvalidCursor
+	}
+	return key, nil
+}
+
+// GetLeaderboardPage returns one page of authors sorted by the given criteria
+func (r *Repository) GetLeaderboardPage(sortBy string, ascending bool, req PageRequest) (*Page[*AuthorStats], error) {
+	spec := sortSpec("authors", sortBy, ascending)
+	return paginate(r.sorted, r.sortedAuthors(spec, sortBy, ascending), spec, req)
+}
+
+// GetTopFilesPage returns one page of files sorted by the given criteria
+func (r *Repository) GetTopFilesPage(sortBy string, ascending bool, req PageRequest) (*Page[*FileStats], error) {
+	spec := sortSpec("files", sortBy, ascending)
+	return paginate(r.sorted, r.sortedFiles(spec, sortBy, ascending), spec, req)
+}
+
+// GetOwnershipPage returns one page of directories sorted by the given criteria
+func (r *Repository) GetOwnershipPage(sortBy string, ascending bool, req PageRequest) (*Page[*DirStats], error) {
+	spec := sortSpec("dirs", sortBy, ascending)
+	return paginate(r.sorted, r.sortedDirs(spec, sortBy, ascending), spec, req)
+}
+
+// GetPRListPage returns one page of PRs sorted by date, size or files
+func (r *Repository) GetPRListPage(sortBy string, ascending bool, req PageRequest) (*Page[*PRInfo], error) {
+	spec := sortSpec("prs", sortBy, ascending)
+	return paginate(r.sorted, r.sortedPRs(spec, sortBy, ascending), spec, req)
+}
+
+func (r *Repository) sortedAuthors(spec, sortBy string, ascending bool) *sortedList[*AuthorStats] {
+	return cachedList(r.sorted, r.sorted.authorLists(), spec,
+		func(a *AuthorStats) string { return a.Email },
+		func() []*AuthorStats { return r.sortAuthors(sortBy, ascending) })
+}
+
+func (r *Repository) sortedFiles(spec, sortBy string, ascending bool) *sortedList[*FileStats] {
+	return cachedList(r.sorted, r.sorted.fileLists(), spec,
+		func(f *FileStats) string { return f.Path },
+		func() []*FileStats { return r.sortFiles(sortBy, ascending) })
+}
+
+func (r *Repository) sortedDirs(spec, sortBy string, ascending bool) *sortedList[*DirStats] {
+	return cachedList(r.sorted, r.sorted.dirLists(), spec,
+		func(d *DirStats) string { return d.Path },
+		func() []*DirStats { return r.sortDirs(sortBy, ascending) })
+}
+
+func (r *Repository) sortedPRs(spec, sortBy string, ascending bool) *sortedList[*PRInfo] {
+	return cachedList(r.sorted, r.sorted.prLists(), spec,
+		func(p *PRInfo) string { return p.Hash },
+		func() []*PRInfo { return r.sortPRs(sortBy, ascending) })
+}
+
+// The list accessors are nil-safe so an uncached Repository still works
+
+func (c *sortCache) authorLists() map[string]*sortedList[*AuthorStats] {
+	if c == nil {
+		return nil
+	}
+	return c.authors
+}
+
+func (c *sortCache) fileLists() map[string]*sortedList[*FileStats] {
+	if c == nil {
+		return nil
+	}
+	return c.files
+}
+
+func (c *sortCache) dirLists() map[string]*sortedList[*DirStats] {
+	if c == nil {
+		return nil
+	}
+	return c.dirs
+}
+
+func (c *sortCache) prLists() map[string]*sortedList[*PRInfo] {
+	if c == nil {
+		return nil
+	}
+	return c.prs
+}
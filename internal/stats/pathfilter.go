@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// pathFilter keeps the files matching an include glob, or every file when
+// there are none, unless they match an exclude glob
+type pathFilter struct {
+	include []string
+	exclude []string
+}
+
+func (f pathFilter) empty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// keeps reports whether the filter keeps the file at name
+func (f pathFilter) keeps(name string) bool {
+	if len(f.include) > 0 && !matchAnyGlob(f.include, name) {
+		return false
+	}
+	return !matchAnyGlob(f.exclude, name)
+}
+
+func matchAnyGlob(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if MatchGlob(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGlob reports whether the slash-separated file path name matches
+// pattern, as in .gitignore: * ? and [...] match within a path segment, **
+// matches any number of segments, and a pattern also matches every file
+// below a directory it matches. A pattern without a slash matches a file or
+// directory name at any depth, so "*.lock" matches every lockfile and
+// "vendor" every vendor directory.
+func MatchGlob(pattern, name string) bool {
+	pattern = strings.Trim(pattern, "/")
+	parts := strings.Split(name, "/")
+	if !strings.Contains(pattern, "/") {
+		for _, part := range parts {
+			if ok, _ := path.Match(pattern, part); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return matchSegments(strings.Split(pattern, "/"), parts)
+}
+
+// matchSegments matches pattern segments against the leading path segments
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range parts {
+				if matchSegments(pattern, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	// Whatever remains lies below a matched directory
+	return true
+}
+
+// CheckGlobs reports the first malformed glob, such as one with an
+// unclosed [
+func CheckGlobs(patterns []string) error {
+	for _, p := range patterns {
+		for _, segment := range strings.Split(p, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid path glob %q", p)
+			}
+		}
+	}
+	return nil
+}
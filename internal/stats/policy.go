@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Policy holds the limits gitstat check enforces on files
+type Policy struct {
+	// Hotspot risk score from which a file breaches the hotspot policy
+	MaxRisk float64
+
+	// Share of a file's commits, in percent, from which a single author
+	// holding it breaches the ownership policy: nobody else knows the file
+	MaxOwnerShare float64
+
+	// Files with fewer commits are too young to judge their ownership
+	MinCommits int
+}
+
+// DefaultPolicy returns the limits used when none are given
+func DefaultPolicy() Policy {
+	return Policy{MaxRisk: HighRiskScore, MaxOwnerShare: 90, MinCommits: 5}
+}
+
+// Violation is a file breaching a policy
+type Violation struct {
+	Path    string
+	Rule    string // "hotspot" or "ownership"
+	Message string
+}
+
+// CheckPolicy returns the files among paths that breach the policy, by path
+// and rule. A path naming a directory covers every file below it; no paths
+// check every file.
+func (r *Repository) CheckPolicy(p Policy, paths []string) []Violation {
+	checked := make(map[string]bool)
+	if len(paths) == 0 {
+		for file := range r.FileStats {
+			checked[file] = true
+		}
+	} else {
+		for _, file := range r.expandReviewPaths(paths) {
+			checked[file] = true
+		}
+	}
+
+	var violations []Violation
+	for _, h := range r.GetHotspots(0) {
+		if checked[h.Path] && h.RiskScore >= p.MaxRisk {
+			violations = append(violations, Violation{
+				Path: h.Path,
+				Rule: "hotspot",
+				Message: fmt.Sprintf("hotspot risk %.0f (limit %.0f): %d changed lines in %d commits by %d authors",
+					h.RiskScore, p.MaxRisk, h.Changes, h.TouchCount, h.AuthorCount),
+			})
+		}
+	}
+	for file := range checked {
+		f, ok := r.FileStats[file]
+		if !ok || f.TouchCount < p.MinCommits {
+			continue
+		}
+		owner, commits := "", 0
+		for email, n := range f.Authors {
+			if n > commits || (n == commits && email < owner) {
+				owner, commits = email, n
+			}
+		}
+		share := float64(commits) / float64(f.TouchCount) * 100
+		if share >= p.MaxOwnerShare {
+			violations = append(violations, Violation{
+				Path: file,
+				Rule: "ownership",
+				Message: fmt.Sprintf("%s made %.0f%% of the %d commits (limit %.0f%%): the file has a bus factor of 1",
+					owner, share, f.TouchCount, p.MaxOwnerShare),
+			})
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Path != violations[j].Path {
+			return violations[i].Path < violations[j].Path
+		}
+		return violations[i].Rule < violations[j].Rule
+	})
+	return violations
+}
+
+// GitHubAnnotations renders violations as GitHub Actions workflow commands,
+// which annotate the files in the pull request's diff
+func GitHubAnnotations(violations []Violation) string {
+	var sb strings.Builder
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "::warning file=%s,title=%s::%s\n",
+			escapeAnnotationProperty(v.Path), escapeAnnotationProperty("gitstat "+v.Rule), escapeAnnotationData(v.Message))
+	}
+	return sb.String()
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// checkstyleReport is the checkstyle XML format, which Jenkins (Warnings
+// Next Generation) and most CI servers read
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Checkstyle renders violations as a checkstyle XML report, as warnings on
+// the first line of each file
+func Checkstyle(violations []Violation) ([]byte, error) {
+	report := checkstyleReport{Version: "8.0"}
+	for _, v := range violations {
+		if n := len(report.Files); n == 0 || report.Files[n-1].Name != v.Path {
+			report.Files = append(report.Files, checkstyleFile{Name: v.Path})
+		}
+		file := &report.Files[len(report.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Line: 1, Severity: "warning", Message: v.Message, Source: "gitstat." + v.Rule,
+		})
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// SetPRReviews attaches the GitHub data of pull requests, by merge commit
+// hash, and computes the review latency over the PRs that have it. Calling
+// it again replaces the data of the previous call.
+func (r *Repository) SetPRReviews(reviews map[string]*PRReview) {
+	s := r.PRStats
+	s.Reviewed, s.Unreviewed, s.Reviews = 0, 0, 0
+	s.MedianTimeToMerge, s.MedianFirstReview = 0, 0
+	s.ReviewsBy = make(map[string]int)
+
+	var toMerge, toReview []time.Duration
+	for _, pr := range s.PRList {
+		pr.Review = reviews[pr.Hash]
+		review := pr.Review
+		if review == nil {
+			continue
+		}
+		s.Reviewed++
+		s.Reviews += review.Reviews
+		for _, login := range review.Reviewers {
+			s.ReviewsBy[login]++
+		}
+		toMerge = append(toMerge, review.TimeToMerge())
+		if wait, ok := review.TimeToFirstReview(); ok {
+			toReview = append(toReview, wait)
+		} else {
+			s.Unreviewed++
+		}
+	}
+	s.MedianTimeToMerge = medianDuration(toMerge)
+	s.MedianFirstReview = medianDuration(toReview)
+}
+
+// TopReviewers returns the GitHub logins that reviewed the most PRs, most
+// first, ties by login
+func (s *PRStatistics) TopReviewers(limit int) []string {
+	logins := make([]string, 0, len(s.ReviewsBy))
+	for login := range s.ReviewsBy {
+		logins = append(logins, login)
+	}
+	slices.SortFunc(logins, func(a, b string) int {
+		return cmp.Or(cmp.Compare(s.ReviewsBy[b], s.ReviewsBy[a]), cmp.Compare(a, b))
+	})
+	return logins[:min(limit, len(logins))]
+}
+
+// medianDuration returns the median of durations, the lower one of an even
+// count, sorting them; 0 for none
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	slices.Sort(durations)
+	return durations[(len(durations)-1)/2]
+}
@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MessageLengthThresholds are the upper bounds, in characters of subject
+// and body, of the message length buckets; longer messages fall into a
+// last bucket
+var MessageLengthThresholds = []int{20, 50, 100, 300}
+
+var (
+	// Conventional Commits: "type(scope)!: description"
+	conventionalRegex = regexp.MustCompile(`(?i)^(feat|fix|docs|style|refactor|perf|test|tests|build|ci|chore|revert)(\([^()]+\))?!?: \S`)
+
+	// Ticket keys like JIRA-123 and issue numbers like #123 or GH-123
+	ticketRegex = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-[1-9][0-9]*\b|(^|[\s(\[])#[0-9]+\b`)
+
+	// Words in upper case followed by a number that are not ticket keys
+	ticketFalseKeys = map[string]bool{"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true}
+
+	// Subjects git writes for git commit --fixup and --squash
+	fixupRegex = regexp.MustCompile(`^(fixup|squash|amend)! `)
+
+	// Work in progress markers: "WIP", "[wip]", "wip:"
+	wipRegex = regexp.MustCompile(`(?i)(^|[^a-z])wip([^a-z]|$)|work in progress`)
+
+	// Trailer lines like "Signed-off-by: Name <email>", which alone do not
+	// make a body
+	trailerRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*: \S|^\(cherry picked from commit [0-9a-f]+\)$`)
+)
+
+// addQuality records the quality markers of a message, body trimmed
+func (m *MessageStats) addQuality(subject, body string) {
+	m.Lengths = append(m.Lengths, utf8.RuneCountInString(subject)+utf8.RuneCountInString(body))
+	if hasDescription(body) {
+		m.WithBody++
+	}
+	if conventionalRegex.MatchString(subject) {
+		m.Conventional++
+	}
+	if hasTicketRef(subject) || hasTicketRef(body) {
+		m.TicketRefs++
+	}
+	if fixupRegex.MatchString(subject) {
+		m.Fixups++
+	}
+	if wipRegex.MatchString(subject) {
+		m.WIP++
+	}
+}
+
+// hasDescription reports whether a body holds more than trailers
+func hasDescription(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !trailerRegex.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTicketRef(text string) bool {
+	for _, match := range ticketRegex.FindAllStringSubmatch(text, -1) {
+		if match[1] == "" || !ticketFalseKeys[match[1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// ConventionalPercent returns the share of Conventional Commits subjects
+func (m *MessageStats) ConventionalPercent() float64 {
+	return safePercent(m.Conventional, m.Commits)
+}
+
+// TicketPercent returns the share of messages referencing a ticket
+func (m *MessageStats) TicketPercent() float64 {
+	return safePercent(m.TicketRefs, m.Commits)
+}
+
+// BodyPercent returns the share of messages with a body
+func (m *MessageStats) BodyPercent() float64 {
+	return safePercent(m.WithBody, m.Commits)
+}
+
+// LengthBuckets counts the messages per MessageLengthThresholds bucket
+func (m *MessageStats) LengthBuckets() []int {
+	counts := make([]int, len(MessageLengthThresholds)+1)
+	for _, length := range m.Lengths {
+		counts[sort.SearchInts(MessageLengthThresholds, length)]++
+	}
+	return counts
+}
+
+// LengthPercentiles returns the percentiles of the message lengths
+func (m *MessageStats) LengthPercentiles() SizePercentiles {
+	return sizePercentiles(append([]int(nil), m.Lengths...))
+}
+
+// CommitQuality holds the message quality of the whole team and per author
+type CommitQuality struct {
+	Total   *MessageStats
+	Authors []*AuthorConventionReport // most commits first; Adherence unset
+}
+
+// GetCommitQuality reports the message quality of the non-merge commits
+func (r *Repository) GetCommitQuality() *CommitQuality {
+	quality := &CommitQuality{Total: NewMessageStats()}
+	for _, a := range r.Authors {
+		if a.Messages == nil || a.Messages.Commits == 0 {
+			continue
+		}
+		quality.Total.Merge(a.Messages)
+		quality.Authors = append(quality.Authors, &AuthorConventionReport{
+			Name:     a.Name,
+			Email:    a.Email,
+			Messages: a.Messages,
+		})
+	}
+
+	sort.Slice(quality.Authors, func(i, j int) bool {
+		if quality.Authors[i].Messages.Commits != quality.Authors[j].Messages.Commits {
+			return quality.Authors[i].Messages.Commits > quality.Authors[j].Messages.Commits
+		}
+		return quality.Authors[i].Email < quality.Authors[j].Email
+	})
+	return quality
+}
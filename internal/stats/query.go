@@ -0,0 +1,343 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryEntity describes a listing that can be filtered with a query
+type queryEntity struct {
+	fields  []string // available fields, in display order
+	columns []string // fields shown in results
+	rows    func(r *Repository) []func(string) value
+}
+
+func numValue(n int) value       { return value{num: float64(n)} }
+func floatValue(f float64) value { return value{num: f} }
+func textValue(s string) value   { return value{str: s, isStr: true} }
+func daysAgo(t time.Time) int    { return int(time.Since(t).Hours() / 24) }
+
+var queryEntities = map[string]*queryEntity{
+	"authors": {
+		fields: []string{"name", "email", "commits", "additions", "deletions", "net", "changes",
+			"files", "surviving", "churned", "refactor", "first_days", "last_days"},
+		columns: []string{"name", "commits", "additions", "deletions", "files"},
+		rows: func(r *Repository) []func(string) value {
+			var rows []func(string) value
+			for _, a := range r.sortAuthors("commits", false) {
+				rows = append(rows, func(field string) value {
+					switch field {
+					case "name":
+						return textValue(a.Name)
+					case "email":
+						return textValue(a.Email)
+					case "commits":
+						return numValue(a.Commits)
+					case "additions":
+						return numValue(a.Additions)
+					case "deletions":
+						return numValue(a.Deletions)
+					case "net":
+						return numValue(a.Additions - a.Deletions)
+					case "changes":
+						return numValue(a.Additions + a.Deletions)
+					case "files":
+						return numValue(len(a.FilesTouched))
+					case "surviving":
+						return numValue(a.SurvivingLines)
+					case "churned":
+						return numValue(a.ChurnedLines)
+					case "refactor":
+						return numValue(a.RefactorCommits)
+					case "first_days":
+						return numValue(daysAgo(a.FirstCommit))
+					case "last_days":
+						return numValue(daysAgo(a.LastCommit))
+					}
+					return value{}
+				})
+			}
+			return rows
+		},
+	},
+	"files": {
+		fields: []string{"path", "changes", "additions", "deletions", "touches", "authors",
+			"created", "deleted", "resurrections"},
+		columns: []string{"path", "changes", "touches", "authors"},
+		rows: func(r *Repository) []func(string) value {
+			var rows []func(string) value
+			for _, f := range r.sortFiles("changes", false) {
+				rows = append(rows, func(field string) value {
+					switch field {
+					case "path":
+						return textValue(f.Path)
+					case "changes":
+						return numValue(f.TotalChanges)
+					case "additions":
+						return numValue(f.Additions)
+					case "deletions":
+						return numValue(f.Deletions)
+					case "touches":
+						return numValue(f.TouchCount)
+					case "authors":
+						return numValue(len(f.Authors))
+					case "created":
+						return numValue(f.Created)
+					case "deleted":
+						return numValue(f.Deleted)
+					case "resurrections":
+						return numValue(f.Resurrections)
+					}
+					return value{}
+				})
+			}
+			return rows
+		},
+	},
+	"dirs": {
+		fields:  []string{"path", "changes", "touches", "authors", "owner", "share", "refactor", "turnover"},
+		columns: []string{"path", "changes", "authors", "owner", "share"},
+		rows: func(r *Repository) []func(string) value {
+			var rows []func(string) value
+			for _, d := range r.sortDirs("changes", false) {
+				top := topOwner(d)
+				rows = append(rows, func(field string) value {
+					switch field {
+					case "path":
+						return textValue(d.Path)
+					case "changes":
+						return numValue(d.TotalChanges)
+					case "touches":
+						return numValue(d.TouchCount)
+					case "authors":
+						return numValue(len(d.Authors))
+					case "owner":
+						if top != nil {
+							return textValue(top.Name)
+						}
+						return textValue("")
+					case "share":
+						if top != nil {
+							return floatValue(top.Share)
+						}
+						return value{}
+					case "refactor":
+						return numValue(d.RefactorCommits)
+					case "turnover":
+						return floatValue(turnoverShift(d))
+					}
+					return value{}
+				})
+			}
+			return rows
+		},
+	},
+	"prs": {
+		fields:  []string{"number", "by", "branch", "subject", "additions", "deletions", "size", "files", "age_days"},
+		columns: []string{"number", "by", "branch", "size", "files"},
+		rows: func(r *Repository) []func(string) value {
+			var rows []func(string) value
+			for _, p := range r.sortPRs("date", false) {
+				rows = append(rows, func(field string) value {
+					switch field {
+					case "number":
+						return numValue(p.PRNumber)
+					case "by":
+						return textValue(p.MergedBy)
+					case "branch":
+						return textValue(p.Branch)
+					case "subject":
+						return textValue(p.Subject)
+					case "additions":
+						return numValue(p.Additions)
+					case "deletions":
+						return numValue(p.Deletions)
+					case "size":
+						return numValue(p.Additions + p.Deletions)
+					case "files":
+						return numValue(p.FilesCount)
+					case "age_days":
+						return numValue(daysAgo(p.MergedAt))
+					}
+					return value{}
+				})
+			}
+			return rows
+		},
+	},
+}
+
+// QueryEntities returns the names of the listings a query can select from
+func QueryEntities() []string {
+	names := make([]string, 0, len(queryEntities))
+	for name := range queryEntities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QueryFields returns the fields available for an entity
+func QueryFields(entity string) []string {
+	if e, ok := queryEntities[entity]; ok {
+		return e.fields
+	}
+	return nil
+}
+
+// Query is a compiled filter expression such as
+// `authors where commits > 50 and additions/deletions > 3 order by commits desc limit 10`
+type Query struct {
+	Entity    string
+	where     node
+	orderBy   node
+	ascending bool
+	limit     int
+}
+
+// QueryResult holds the rows matching a query, formatted for display
+type QueryResult struct {
+	Columns []string
+	Rows    [][]string
+	Matched int // rows matching before the limit was applied
+	Scanned int // rows considered
+}
+
+// ParseQuery compiles a query string
+func ParseQuery(input string) (*Query, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	first := tokens[0]
+	entity, ok := queryEntities[first.text]
+	if first.kind != tokIdent || !ok {
+		return nil, fmt.Errorf("query must start with one of: %s", strings.Join(QueryEntities(), ", "))
+	}
+
+	fields := make(map[string]bool, len(entity.fields))
+	for _, f := range entity.fields {
+		fields[f] = true
+	}
+	p := &exprParser{tokens: tokens, pos: 1, fields: fields}
+	q := &Query{Entity: first.text}
+
+	if p.accept("where") {
+		if q.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		if !isCondition(q.where) {
+			return nil, fmt.Errorf("where clause must be a comparison, e.g. commits > 10")
+		}
+	}
+
+	if p.accept("order") {
+		if !p.accept("by") {
+			return nil, p.errorf("expected by")
+		}
+		if q.orderBy, err = p.parseSum(); err != nil {
+			return nil, err
+		}
+		if p.accept("asc") {
+			q.ascending = true
+		} else {
+			p.accept("desc")
+		}
+	}
+
+	if p.accept("limit") {
+		t := p.peek()
+		n, err := strconv.Atoi(t.text)
+		if t.kind != tokNumber || err != nil || n <= 0 {
+			return nil, p.errorf("expected positive limit")
+		}
+		p.next()
+		q.limit = n
+	}
+
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected input")
+	}
+	return q, nil
+}
+
+// isCondition reports whether a node yields a boolean
+func isCondition(n node) bool {
+	switch n.(type) {
+	case compareNode, matchNode, logicNode, notNode:
+		return true
+	}
+	return false
+}
+
+// Run evaluates the query against the repository statistics
+func (q *Query) Run(r *Repository) *QueryResult {
+	entity := queryEntities[q.Entity]
+	result := &QueryResult{Columns: entity.columns}
+
+	var matched []func(string) value
+	for _, row := range entity.rows(r) {
+		result.Scanned++
+		if q.where == nil || q.where.eval(row).b {
+			matched = append(matched, row)
+		}
+	}
+	result.Matched = len(matched)
+
+	if q.orderBy != nil {
+		keys := make([]value, len(matched))
+		for i, row := range matched {
+			keys[i] = q.orderBy.eval(row)
+		}
+		idx := make([]int, len(matched))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			c := compareValues(keys[idx[i]], keys[idx[j]])
+			if q.ascending {
+				return c < 0
+			}
+			return c > 0
+		})
+		sorted := make([]func(string) value, len(matched))
+		for i, k := range idx {
+			sorted[i] = matched[k]
+		}
+		matched = sorted
+	}
+
+	if q.limit > 0 && q.limit < len(matched) {
+		matched = matched[:q.limit]
+	}
+
+	for _, row := range matched {
+		cells := make([]string, len(entity.columns))
+		for i, col := range entity.columns {
+			v := row(col)
+			switch {
+			case v.isStr:
+				cells[i] = v.str
+			case v.num != float64(int64(v.num)):
+				cells[i] = strconv.FormatFloat(v.num, 'f', 1, 64)
+			default:
+				cells[i] = strconv.FormatFloat(v.num, 'f', -1, 64)
+			}
+		}
+		result.Rows = append(result.Rows, cells)
+	}
+	return result
+}
+
+// RunQuery parses and evaluates a query in one step
+func (r *Repository) RunQuery(input string) (*QueryResult, error) {
+	q, err := ParseQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	return q.Run(r), nil
+}
@@ -0,0 +1,423 @@
+package stats
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter expression grammar:
+//
+//	query   := entity [ "where" expr ] [ "order" "by" expr [ "asc" | "desc" ] ] [ "limit" number ]
+//	expr    := and { "or" and }
+//	and     := not { "and" not }
+//	not     := "not" not | compare
+//	compare := sum [ ( "=" | "==" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~" ) sum ]
+//	sum     := product { ( "+" | "-" ) product }
+//	product := unary { ( "*" | "/" ) unary }
+//	unary   := "-" unary | number | string | field | "(" expr ")"
+//
+// "~" matches a string against a regular expression. Division by zero
+// yields 0 so ratios of empty counters never match "greater than" filters.
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := rune(input[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(input) && (unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i])) || input[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, strings.ToLower(input[start:i]), start})
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(input) && (unicode.IsDigit(rune(input[i])) || input[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, input[start:i], start})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(input) && rune(input[i]) != c {
+				if input[i] == '\\' && i+1 < len(input) {
+					i++
+				}
+				sb.WriteByte(input[i])
+				i++
+			}
+			if i >= len(input) {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, token{tokString, sb.String(), start})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(input) {
+				two = input[i : i+2]
+			}
+			switch two {
+			case "==", "!=", "<=", ">=", "!~":
+				tokens = append(tokens, token{tokOp, two, start})
+				i += 2
+				continue
+			}
+			if !strings.ContainsRune("=<>~+-*/()", c) {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, start+1)
+			}
+			tokens = append(tokens, token{tokOp, string(c), start})
+			i++
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(input)}), nil
+}
+
+// value is the result of evaluating an expression: a number, string or boolean
+type value struct {
+	num   float64
+	str   string
+	b     bool
+	isStr bool
+	isB   bool
+}
+
+func (v value) String() string {
+	switch {
+	case v.isStr:
+		return v.str
+	case v.isB:
+		return strconv.FormatBool(v.b)
+	default:
+		return strconv.FormatFloat(v.num, 'f', -1, 64)
+	}
+}
+
+// node is a compiled expression evaluated against one row
+type node interface {
+	eval(row func(string) value) value
+}
+
+type numberNode float64
+
+func (n numberNode) eval(func(string) value) value { return value{num: float64(n)} }
+
+type stringNode string
+
+func (n stringNode) eval(func(string) value) value { return value{str: string(n), isStr: true} }
+
+type fieldNode string
+
+func (n fieldNode) eval(row func(string) value) value { return row(string(n)) }
+
+type negNode struct{ x node }
+
+func (n negNode) eval(row func(string) value) value { return value{num: -n.x.eval(row).num} }
+
+type notNode struct{ x node }
+
+func (n notNode) eval(row func(string) value) value { return value{b: !n.x.eval(row).b, isB: true} }
+
+type logicNode struct {
+	op   string
+	l, r node
+}
+
+func (n logicNode) eval(row func(string) value) value {
+	l := n.l.eval(row).b
+	if n.op == "and" && !l || n.op == "or" && l {
+		return value{b: l, isB: true}
+	}
+	return value{b: n.r.eval(row).b, isB: true}
+}
+
+type arithNode struct {
+	op   string
+	l, r node
+}
+
+func (n arithNode) eval(row func(string) value) value {
+	l, r := n.l.eval(row).num, n.r.eval(row).num
+	switch n.op {
+	case "+":
+		return value{num: l + r}
+	case "-":
+		return value{num: l - r}
+	case "*":
+		return value{num: l * r}
+	default:
+		if r == 0 {
+			return value{}
+		}
+		return value{num: l / r}
+	}
+}
+
+type compareNode struct {
+	op   string
+	l, r node
+}
+
+func (n compareNode) eval(row func(string) value) value {
+	c := compareValues(n.l.eval(row), n.r.eval(row))
+
+	var ok bool
+	switch n.op {
+	case "=", "==":
+		ok = c == 0
+	case "!=":
+		ok = c != 0
+	case "<":
+		ok = c < 0
+	case "<=":
+		ok = c <= 0
+	case ">":
+		ok = c > 0
+	case ">=":
+		ok = c >= 0
+	}
+	return value{b: ok, isB: true}
+}
+
+// compareValues orders two values: case-insensitively as text when either
+// side is a string, numerically otherwise
+func compareValues(l, r value) int {
+	if l.isStr || r.isStr {
+		return strings.Compare(strings.ToLower(l.String()), strings.ToLower(r.String()))
+	}
+	switch {
+	case l.num < r.num:
+		return -1
+	case l.num > r.num:
+		return 1
+	}
+	return 0
+}
+
+type matchNode struct {
+	l      node
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n matchNode) eval(row func(string) value) value {
+	return value{b: n.re.MatchString(n.l.eval(row).String()) != n.negate, isB: true}
+}
+
+// exprParser is a recursive-descent parser over the token stream
+type exprParser struct {
+	tokens []token
+	pos    int
+	fields map[string]bool // valid field names for the queried entity
+}
+
+func (p *exprParser) peek() token { return p.tokens[p.pos] }
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the given keyword or operator
+func (p *exprParser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokIdent || t.kind == tokOp) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	t := p.peek()
+	where := "end of query"
+	if t.kind != tokEOF {
+		where = fmt.Sprintf("%q at position %d", t.text, t.pos+1)
+	}
+	return fmt.Errorf("%s near %s", fmt.Sprintf(format, args...), where)
+}
+
+func (p *exprParser) parseExpr() (node, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = logicNode{"or", l, r}
+	}
+	return l, nil
+}
+
+func (p *exprParser) parseAnd() (node, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = logicNode{"and", l, r}
+	}
+	return l, nil
+}
+
+func (p *exprParser) parseNot() (node, error) {
+	if p.accept("not") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{x}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *exprParser) parseCompare() (node, error) {
+	l, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	if t.kind != tokOp {
+		return l, nil
+	}
+	switch t.text {
+	case "~", "!~":
+		p.next()
+		pattern := p.peek()
+		if pattern.kind != tokString {
+			return nil, p.errorf("expected quoted pattern after %s", t.text)
+		}
+		p.next()
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern.text, err)
+		}
+		return matchNode{l, re, t.text == "!~"}, nil
+	case "=", "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		r, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{t.text, l, r}, nil
+	}
+	return l, nil
+}
+
+func (p *exprParser) parseSum() (node, error) {
+	l, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokOp || (t.text != "+" && t.text != "-") {
+			return l, nil
+		}
+		p.next()
+		r, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l = arithNode{t.text, l, r}
+	}
+}
+
+func (p *exprParser) parseProduct() (node, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokOp || (t.text != "*" && t.text != "/") {
+			return l, nil
+		}
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = arithNode{t.text, l, r}
+	}
+}
+
+func (p *exprParser) parseUnary() (node, error) {
+	if p.accept("-") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{x}, nil
+	}
+	if p.accept("(") {
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf("expected )")
+		}
+		return x, nil
+	}
+
+	t := p.peek()
+	switch t.kind {
+	case tokNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number")
+		}
+		p.next()
+		return numberNode(n), nil
+	case tokString:
+		p.next()
+		return stringNode(t.text), nil
+	case tokIdent:
+		if !p.fields[t.text] {
+			names := make([]string, 0, len(p.fields))
+			for name := range p.fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, p.errorf("unknown field (available: %s)", strings.Join(names, ", "))
+		}
+		p.next()
+		return fieldNode(t.text), nil
+	}
+	return nil, p.errorf("expected value")
+}
@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Date format of git's default log output, used by git-quick-stats
+const gitDateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+
+// QuickStats renders the author contributions like the detailed stats of
+// git-quick-stats (git quick-stats -T), so scripts parsing its output can
+// read gitstat's instead. Authors are ordered by commits.
+func (r *Repository) QuickStats() string {
+	var sb strings.Builder
+	sb.WriteString("\n Contribution stats (by author) on the current branch:\n\n")
+
+	total := quickStatsTotal(r)
+	for _, a := range r.GetLeaderboard("commits", false) {
+		fmt.Fprintf(&sb, "\t %s <%s>:\n", a.Name, a.Email)
+		writeQuickStats(&sb, quickStatsRow(a), total)
+		fmt.Fprintf(&sb, "\t  first commit:  %s\n", a.FirstCommit.Format(gitDateFormat))
+		fmt.Fprintf(&sb, "\t  last commit:   %s\n\n", a.LastCommit.Format(gitDateFormat))
+	}
+
+	sb.WriteString("\t total:\n")
+	writeQuickStats(&sb, total, total)
+	return sb.String()
+}
+
+// QuickStatsCSV renders the author contributions like the CSV output of
+// git-quick-stats (git quick-stats -V)
+func (r *Repository) QuickStatsCSV() string {
+	var sb strings.Builder
+	sb.WriteString("author,insertions,insertions_per,deletions,deletions_per,files,files_per,commits,commits_per,lines_changed,lines_changed_per\n")
+
+	total := quickStatsTotal(r)
+	for _, a := range r.GetLeaderboard("commits", false) {
+		row := quickStatsRow(a)
+		author := fmt.Sprintf("%s <%s>", a.Name, a.Email)
+		if strings.ContainsAny(author, ",\"") {
+			author = `"` + strings.ReplaceAll(author, `"`, `""`) + `"`
+		}
+		sb.WriteString(author)
+		for i, v := range row {
+			fmt.Fprintf(&sb, ",%d,%.0f%%", v, quickStatsShare(v, total[i]))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// quickStatsRow returns insertions, deletions, files, commits and lines
+// changed, the figures git-quick-stats reports. Files counts every change
+// to a file, not distinct files.
+func quickStatsRow(a *AuthorStats) [5]int {
+	files := 0
+	for _, n := range a.FilesTouched {
+		files += n
+	}
+	return [5]int{a.Additions, a.Deletions, files, a.Commits, a.Additions + a.Deletions}
+}
+
+func quickStatsTotal(r *Repository) [5]int {
+	var total [5]int
+	for _, a := range r.Authors {
+		for i, v := range quickStatsRow(a) {
+			total[i] += v
+		}
+	}
+	return total
+}
+
+func writeQuickStats(sb *strings.Builder, row, total [5]int) {
+	labels := [5]string{"insertions:   ", "deletions:    ", "files:        ", "commits:      ", "lines changed:"}
+	for i, label := range labels {
+		if total[i] > 0 {
+			fmt.Fprintf(sb, "\t  %s %d\t(%.0f%%)\n", label, row[i], quickStatsShare(row[i], total[i]))
+		}
+	}
+}
+
+func quickStatsShare(v, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(v) / float64(total) * 100
+}
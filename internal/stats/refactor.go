@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/audi70r/gitstat/internal/git"
+)
+
+// Refactor detection thresholds
+const (
+	refactorRenameShare = 0.5 // share of files renamed or moved
+	refactorBalance     = 0.8 // min(additions, deletions) / max(additions, deletions)
+	refactorMinLines    = 10  // smaller balanced commits are too small to judge
+)
+
+// IsRefactorCommit reports whether a commit looks like restructuring rather
+// than feature work: mostly renames/moves, or additions and deletions that
+// balance out across the changed files
+func IsRefactorCommit(c *git.Commit) bool {
+	if c.IsMerge || len(c.FileChanges) == 0 {
+		return false
+	}
+
+	renamed, additions, deletions := 0, 0, 0
+	for _, fc := range c.FileChanges {
+		if fc.Status == git.FileRenamed {
+			renamed++
+		}
+		additions += fc.Additions
+		deletions += fc.Deletions
+	}
+
+	if float64(renamed)/float64(len(c.FileChanges)) >= refactorRenameShare {
+		return true
+	}
+
+	if additions+deletions < refactorMinLines || additions == 0 || deletions == 0 {
+		return false
+	}
+	return float64(min(additions, deletions))/float64(max(additions, deletions)) >= refactorBalance
+}
+
+// RefactorShare holds refactoring vs total commits for an author or directory
+type RefactorShare struct {
+	Name            string
+	Commits         int
+	RefactorCommits int
+}
+
+// Percent returns the share of commits classified as refactoring
+func (s *RefactorShare) Percent() float64 {
+	if s.Commits == 0 {
+		return 0
+	}
+	return float64(s.RefactorCommits) / float64(s.Commits) * 100
+}
+
+// GetRefactorShares returns refactoring shares per author and per top-level
+// directory, highest share first
+func (r *Repository) GetRefactorShares() (authors, dirs []*RefactorShare) {
+	for _, a := range r.Authors {
+		commits := a.Messages.Commits // non-merge commits
+		if commits == 0 {
+			continue
+		}
+		authors = append(authors, &RefactorShare{
+			Name:            a.Name,
+			Commits:         commits,
+			RefactorCommits: a.RefactorCommits,
+		})
+	}
+
+	for _, d := range r.DirStats {
+		commits := 0
+		for _, count := range d.DailyCommits {
+			commits += count
+		}
+		if commits == 0 {
+			continue
+		}
+		dirs = append(dirs, &RefactorShare{
+			Name:            d.Path,
+			Commits:         commits,
+			RefactorCommits: d.RefactorCommits,
+		})
+	}
+
+	sortRefactorShares(authors)
+	sortRefactorShares(dirs)
+	return authors, dirs
+}
+
+func sortRefactorShares(shares []*RefactorShare) {
+	sort.Slice(shares, func(i, j int) bool {
+		pi, pj := shares[i].Percent(), shares[j].Percent()
+		if pi != pj {
+			return pi > pj
+		}
+		if shares[i].Commits != shares[j].Commits {
+			return shares[i].Commits > shares[j].Commits
+		}
+		return shares[i].Name < shares[j].Name
+	})
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"sort"
+)
+
+// Owners listed per changed file
+const reviewOwners = 3
+
+// ChangedFile describes a file touched by a pending change: how risky it is
+// and who knows it
+type ChangedFile struct {
+	Path      string
+	Commits   int     // commits to the file in the period; 0 for a new file
+	Changes   int     // lines changed in the period
+	RiskScore float64 // hotspot risk; 0 for files with a single author
+	BusFactor int     // fewest authors who made more than half of its commits
+	Owners    []FileOwner
+}
+
+// FileOwner is an author's share of a file's commits
+type FileOwner struct {
+	Name    string
+	Email   string
+	Commits int
+	Share   float64 // percent of the file's commits
+}
+
+// ReviewChange describes the files touched by a change, riskiest first. A
+// path naming a directory covers every file below it.
+func (r *Repository) ReviewChange(paths []string) []*ChangedFile {
+	risk := make(map[string]float64)
+	for _, h := range r.GetHotspots(0) {
+		risk[h.Path] = h.RiskScore
+	}
+
+	var files []*ChangedFile
+	for _, path := range r.expandReviewPaths(paths) {
+		file := &ChangedFile{Path: path, RiskScore: risk[path]}
+		files = append(files, file)
+		f, ok := r.FileStats[path]
+		if !ok || f.TouchCount == 0 {
+			continue
+		}
+		file.Commits = f.TouchCount
+		file.Changes = f.TotalChanges
+
+		for email, commits := range f.Authors {
+			owner := FileOwner{Name: email, Email: email, Commits: commits,
+				Share: float64(commits) / float64(f.TouchCount) * 100}
+			if a, ok := r.Authors[email]; ok {
+				owner.Name = a.Name
+			}
+			file.Owners = append(file.Owners, owner)
+		}
+		sort.Slice(file.Owners, func(i, j int) bool {
+			if file.Owners[i].Commits != file.Owners[j].Commits {
+				return file.Owners[i].Commits > file.Owners[j].Commits
+			}
+			return file.Owners[i].Email < file.Owners[j].Email
+		})
+
+		sum := 0
+		for i, o := range file.Owners {
+			sum += o.Commits
+			if sum*2 > f.TouchCount {
+				file.BusFactor = i + 1
+				break
+			}
+		}
+		if len(file.Owners) > reviewOwners {
+			file.Owners = file.Owners[:reviewOwners]
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].RiskScore != files[j].RiskScore {
+			return files[i].RiskScore > files[j].RiskScore
+		}
+		return files[i].Commits > files[j].Commits
+	})
+	return files
+}
@@ -0,0 +1,184 @@
+package stats
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reviewer suggestion weights: ownership of the changed files over the whole
+// range versus activity in them during the last reviewRecentMonths months
+const (
+	reviewOwnershipWeight = 0.6
+	reviewRecentWeight    = 0.4
+	reviewRecentMonths    = 3
+	reviewDirFallback     = 0.5 // weight of directory ownership for files without history
+)
+
+// ReviewerSuggestion is a candidate reviewer for a set of paths
+type ReviewerSuggestion struct {
+	Name          string
+	Email         string
+	Score         float64 // summed per-file scores, higher is better
+	Files         int     // changed files the author has committed to
+	Commits       int     // the author's commits to those files
+	RecentCommits int     // of which in the last reviewRecentMonths months
+	LastCommit    time.Time
+}
+
+// SuggestReviewers ranks authors for reviewing changes to paths. A path
+// naming a directory covers every file below it. Files without history fall
+// back to ownership of their top-level directory. Authors listed in exclude
+// (typically the change's author) are skipped.
+func (r *Repository) SuggestReviewers(paths []string, exclude []string, limit int) []*ReviewerSuggestion {
+	excluded := make(map[string]bool, len(exclude))
+	for _, email := range exclude {
+		excluded[strings.ToLower(email)] = true
+	}
+
+	_, end := r.activityBounds()
+	recentFrom := end.AddDate(0, -reviewRecentMonths+1, 0).Format("2006-01")
+
+	byEmail := make(map[string]*ReviewerSuggestion)
+	suggestion := func(email string) *ReviewerSuggestion {
+		s, ok := byEmail[email]
+		if !ok {
+			s = &ReviewerSuggestion{Email: email}
+			if author, exists := r.Authors[email]; exists {
+				s.Name = author.Name
+				s.LastCommit = author.LastCommit
+			}
+			byEmail[email] = s
+		}
+		return s
+	}
+
+	for _, file := range r.expandReviewPaths(paths) {
+		fileStat, ok := r.FileStats[file]
+		if !ok || fileStat.TouchCount == 0 {
+			// New file: whoever owns the surrounding directory
+			if dir, ok := r.DirStats[getTopDir(file)]; ok {
+				for email, da := range dir.Authors {
+					if !excluded[strings.ToLower(email)] {
+						suggestion(email).Score += reviewDirFallback * da.Share / 100
+					}
+				}
+			}
+			continue
+		}
+
+		recent := make(map[string]int)
+		recentTotal := 0
+		for month, m := range fileStat.Monthly {
+			if month < recentFrom {
+				continue
+			}
+			for email, commits := range m.Authors {
+				recent[email] += commits
+				recentTotal += commits
+			}
+		}
+
+		for email, commits := range fileStat.Authors {
+			if excluded[strings.ToLower(email)] {
+				continue
+			}
+			s := suggestion(email)
+			s.Files++
+			s.Commits += commits
+			s.RecentCommits += recent[email]
+			s.Score += reviewOwnershipWeight * float64(commits) / float64(fileStat.TouchCount)
+			if recentTotal > 0 {
+				s.Score += reviewRecentWeight * float64(recent[email]) / float64(recentTotal)
+			}
+		}
+	}
+
+	suggestions := make([]*ReviewerSuggestion, 0, len(byEmail))
+	for _, s := range byEmail {
+		if s.Name == "" {
+			s.Name = s.Email
+		}
+		suggestions = append(suggestions, s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		if suggestions[i].RecentCommits != suggestions[j].RecentCommits {
+			return suggestions[i].RecentCommits > suggestions[j].RecentCommits
+		}
+		return suggestions[i].Email < suggestions[j].Email
+	})
+
+	if limit > 0 && limit < len(suggestions) {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// expandReviewPaths resolves paths to the known files they name, keeping
+// unknown files so they can fall back to directory ownership
+func (r *Repository) expandReviewPaths(paths []string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, p := range paths {
+		p = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(p)), "/")
+		if p == "." {
+			p = ""
+		}
+		if _, ok := r.FileStats[p]; ok {
+			add(p)
+			continue
+		}
+
+		var under []string
+		for file := range r.FileStats {
+			if p == "" || strings.HasPrefix(file, p+"/") {
+				under = append(under, file)
+			}
+		}
+		if len(under) == 0 {
+			add(p)
+			continue
+		}
+		sort.Strings(under)
+		for _, file := range under {
+			add(file)
+		}
+	}
+	return files
+}
+
+// ParseDiffPaths returns the files changed by a unified diff, such as the
+// output of git diff, in order of appearance
+func ParseDiffPaths(diff string) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, line := range strings.Split(diff, "\n") {
+		var path string
+		switch {
+		case strings.HasPrefix(line, "+++ b/"):
+			path = strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "--- a/"):
+			// Deleted files only have a source path
+			path = strings.TrimPrefix(line, "--- a/")
+		default:
+			continue
+		}
+		path = strings.TrimRight(path, "\t\r")
+		if path != "" && !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
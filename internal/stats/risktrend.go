@@ -0,0 +1,134 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// computeRiskTrends fills monthly risk scores and their slope for the given
+// hotspots. Each month is scored with the same formula as GetHotspots,
+// normalized against that month's activity across all files.
+func (r *Repository) computeRiskTrends(hotspots []*HotspotFile) {
+	months := r.activeMonths()
+	if len(months) == 0 {
+		return
+	}
+
+	maxChanges := make(map[string]int)
+	maxTouches := make(map[string]int)
+	monthAuthors := make(map[string]map[string]bool)
+	for _, f := range r.FileStats {
+		for key, m := range f.Monthly {
+			maxChanges[key] = max(maxChanges[key], m.Changes)
+			maxTouches[key] = max(maxTouches[key], m.Touches)
+			if monthAuthors[key] == nil {
+				monthAuthors[key] = make(map[string]bool)
+			}
+			for email := range m.Authors {
+				monthAuthors[key][email] = true
+			}
+		}
+	}
+
+	maxComplexity := r.maxComplexity()
+	for _, spot := range hotspots {
+		f, ok := r.FileStats[spot.Path]
+		if !ok {
+			continue
+		}
+		factor := r.complexityFactor(spot.Path, maxComplexity)
+
+		spot.RiskTrend = make([]float64, len(months))
+		for i, key := range months {
+			m, ok := f.Monthly[key]
+			if !ok || maxChanges[key] == 0 || maxTouches[key] == 0 {
+				continue // untouched months score zero
+			}
+			churnScore := float64(m.Changes) / float64(maxChanges[key])
+			touchScore := float64(m.Touches) / float64(maxTouches[key])
+			authorScore := float64(len(m.Authors)) / float64(len(monthAuthors[key]))
+			spot.RiskTrend[i] = min(100, (churnScore*0.4+touchScore*0.3+authorScore*0.3)*100*factor)
+		}
+		spot.TrendSlope = linearSlope(spot.RiskTrend)
+	}
+}
+
+// activeMonths returns every month from the first to the last with file
+// activity, gaps included, as "2006-01" keys
+func (r *Repository) activeMonths() []string {
+	seen := make(map[string]bool)
+	for _, f := range r.FileStats {
+		for key := range f.Monthly {
+			seen[key] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	first, err := time.Parse("2006-01", keys[0])
+	if err != nil {
+		return keys
+	}
+	last, err := time.Parse("2006-01", keys[len(keys)-1])
+	if err != nil {
+		return keys
+	}
+
+	var months []string
+	for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format("2006-01"))
+	}
+	return months
+}
+
+// linearSlope returns the least squares slope of values over their index
+func linearSlope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// maxComplexity returns the highest complexity of a changed file, at
+// least 1
+func (r *Repository) maxComplexity() int {
+	highest := 1
+	for path, c := range r.Complexity {
+		if _, changed := r.FileStats[path]; changed {
+			highest = max(highest, c.Total)
+		}
+	}
+	return highest
+}
+
+// complexityFactor scales the risk of a file by its complexity against the
+// most complex changed file, from 0.5 for a file without functions to 1.5.
+// Files whose complexity is not measured, e.g. in languages without an
+// analyzer, keep their risk.
+func (r *Repository) complexityFactor(path string, maxComplexity int) float64 {
+	c, ok := r.Complexity[path]
+	if !ok {
+		return 1
+	}
+	return 0.5 + float64(c.Total)/float64(maxComplexity)
+}
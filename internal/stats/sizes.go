@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// SizeCategories names the commit size buckets, smallest first
+var SizeCategories = []string{"trivial", "small", "medium", "large", "huge"}
+
+// FileCountThresholds are the inclusive upper bounds of the buckets of files
+// touched per commit: 1, 2-3, 4-10, 11-30 and more
+var FileCountThresholds = []int{1, 3, 10, 30}
+
+// CommitSize records the size of a single non-merge commit
+type CommitSize struct {
+	At    time.Time
+	Email string
+	Lines int // additions + deletions
+	Files int
+}
+
+// CommitSizeMix holds the distribution of commits over size categories
+type CommitSizeMix struct {
+	Thresholds []int
+	Total      []int // commits per category
+	FilesTotal []int // commits per FileCountThresholds bucket
+	Lines      SizePercentiles
+	Files      SizePercentiles
+	ByAuthor   []*AuthorSizeMix
+	Months     []string // "2024-01"
+	ByMonth    [][]int  // month index -> commits per category
+}
+
+// SizePercentiles summarizes a size distribution: half of the commits are
+// at most P50 large, 90% at most P90 and 99% at most P99
+type SizePercentiles struct {
+	P50 int
+	P90 int
+	P99 int
+	Max int
+}
+
+// AuthorSizeMix holds the size category distribution for a single author
+type AuthorSizeMix struct {
+	Name    string
+	Email   string
+	Commits int
+	Counts  []int // commits per category
+	Lines   SizePercentiles
+	Files   SizePercentiles
+
+	lines, files []int
+}
+
+// LargeShare returns the percentage of the author's commits that are large or huge
+func (m *AuthorSizeMix) LargeShare() float64 {
+	if m.Commits == 0 {
+		return 0
+	}
+	start := len(m.Counts) - 2
+	if start < 0 {
+		start = 0
+	}
+	large := 0
+	for i := start; i < len(m.Counts); i++ {
+		large += m.Counts[i]
+	}
+	return float64(large) / float64(m.Commits) * 100
+}
+
+// ClassifyCommitSize returns the category index for a commit of the given
+// size. thresholds are inclusive upper bounds for each category but the last.
+func ClassifyCommitSize(lines int, thresholds []int) int {
+	for i, limit := range thresholds {
+		if lines <= limit {
+			return i
+		}
+	}
+	return len(thresholds)
+}
+
+// GetCommitSizeMix classifies all commits into size categories using the
+// given thresholds and breaks the mix down per author and per month
+func (r *Repository) GetCommitSizeMix(thresholds []int) *CommitSizeMix {
+	categories := len(thresholds) + 1
+	mix := &CommitSizeMix{
+		Thresholds: thresholds,
+		Total:      make([]int, categories),
+		FilesTotal: make([]int, len(FileCountThresholds)+1),
+	}
+	var lines, files []int
+
+	authors := make(map[string]*AuthorSizeMix)
+	months := make(map[string][]int)
+
+	for _, cs := range r.CommitSizes {
+		cat := ClassifyCommitSize(cs.Lines, thresholds)
+		mix.Total[cat]++
+		mix.FilesTotal[ClassifyCommitSize(cs.Files, FileCountThresholds)]++
+		lines = append(lines, cs.Lines)
+		files = append(files, cs.Files)
+
+		author, ok := authors[cs.Email]
+		if !ok {
+			author = &AuthorSizeMix{Email: cs.Email, Counts: make([]int, categories)}
+			if a, exists := r.Authors[cs.Email]; exists {
+				author.Name = a.Name
+			} else {
+				author.Name = cs.Email
+			}
+			authors[cs.Email] = author
+		}
+		author.Commits++
+		author.Counts[cat]++
+		author.lines = append(author.lines, cs.Lines)
+		author.files = append(author.files, cs.Files)
+
+		month := cs.At.Format("2006-01")
+		if _, ok := months[month]; !ok {
+			months[month] = make([]int, categories)
+		}
+		months[month][cat]++
+	}
+
+	mix.Lines, mix.Files = sizePercentiles(lines), sizePercentiles(files)
+	for _, author := range authors {
+		author.Lines, author.Files = sizePercentiles(author.lines), sizePercentiles(author.files)
+		author.lines, author.files = nil, nil
+		mix.ByAuthor = append(mix.ByAuthor, author)
+	}
+	sort.Slice(mix.ByAuthor, func(i, j int) bool {
+		if mix.ByAuthor[i].Commits != mix.ByAuthor[j].Commits {
+			return mix.ByAuthor[i].Commits > mix.ByAuthor[j].Commits
+		}
+		return mix.ByAuthor[i].Email < mix.ByAuthor[j].Email
+	})
+
+	for month := range months {
+		mix.Months = append(mix.Months, month)
+	}
+	sort.Strings(mix.Months)
+	for _, month := range mix.Months {
+		mix.ByMonth = append(mix.ByMonth, months[month])
+	}
+
+	return mix
+}
+
+// sizePercentiles returns the nearest-rank percentiles of sizes, sorting
+// them in place
+func sizePercentiles(sizes []int) SizePercentiles {
+	if len(sizes) == 0 {
+		return SizePercentiles{}
+	}
+	sort.Ints(sizes)
+	rank := func(p int) int {
+		// Smallest size at least p percent of the commits do not exceed
+		return sizes[max(0, (p*len(sizes)+99)/100-1)]
+	}
+	return SizePercentiles{
+		P50: rank(50),
+		P90: rank(90),
+		P99: rank(99),
+		Max: sizes[len(sizes)-1],
+	}
+}
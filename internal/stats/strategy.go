@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"regexp"
+	"sort"
+)
+
+var (
+	// GitHub appends the PR number to squash-merged subjects: "Fix login (#123)"
+	squashSubjectRegex = regexp.MustCompile(`\(#\d+\)\s*$`)
+	// GitLab squash and merge trailers
+	squashBodyRegex = regexp.MustCompile(`(?m)^See merge request \S+!\d+`)
+)
+
+// Integration records how one commit reached the trunk, classified later
+// against the first-parent chain
+type Integration struct {
+	Hash     string
+	MonthKey string
+	Merge    bool // merge commit
+	Squash   bool // subject or body references the squashed PR
+	Rebased  bool // committed by someone else or after it was authored
+}
+
+// StrategyCounts counts trunk integrations by strategy
+type StrategyCounts struct {
+	Merge  int // merge commits
+	Squash int // single commits referencing a PR
+	Rebase int // rebased or fast-forwarded commits
+	Direct int // commits made on the trunk as authored
+}
+
+// Total returns the number of integrations
+func (s StrategyCounts) Total() int {
+	return s.Merge + s.Squash + s.Rebase + s.Direct
+}
+
+// Dominant returns the most used strategy, or "" without integrations
+func (s StrategyCounts) Dominant() string {
+	best, name := 0, ""
+	for _, c := range []struct {
+		name  string
+		count int
+	}{{"merge", s.Merge}, {"squash", s.Squash}, {"rebase", s.Rebase}, {"direct", s.Direct}} {
+		if c.count > best {
+			best, name = c.count, c.name
+		}
+	}
+	return name
+}
+
+// MergeStrategyStats is the split of integration strategies over time
+type MergeStrategyStats struct {
+	Total         StrategyCounts
+	Months        []string // "2024-01", oldest first
+	Monthly       []StrategyCounts
+	BranchCommits int  // non-merge commits that arrived through a merge
+	GraphKnown    bool // false when the first-parent chain was not scanned
+}
+
+// strategyCollector records the facts needed to classify integrations
+type strategyCollector struct{}
+
+func (strategyCollector) Name() string { return "strategies" }
+
+func (strategyCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	repo.Integrations = append(repo.Integrations, Integration{
+		Hash:     c.Hash,
+		MonthKey: cc.MonthKey,
+		Merge:    c.IsMerge,
+		Squash:   !c.IsMerge && (squashSubjectRegex.MatchString(c.Subject) || squashBodyRegex.MatchString(c.Body)),
+		Rebased: c.Committer.Email != "" && c.Committer.Email != c.Author.Email ||
+			c.CommitDate.After(c.AuthorDate),
+	})
+}
+
+// GetMergeStrategies splits trunk integrations into merge commits, squash
+// merges, rebased commits and direct commits per month. Commits off the
+// first-parent chain came in through a merge and are counted separately.
+// Without first-parent data every non-merge commit is treated as on the trunk.
+func (r *Repository) GetMergeStrategies() *MergeStrategyStats {
+	s := &MergeStrategyStats{GraphKnown: r.FirstParent != nil}
+
+	byMonth := make(map[string]*StrategyCounts)
+	for _, in := range r.Integrations {
+		if !in.Merge && s.GraphKnown && !r.FirstParent[in.Hash] {
+			s.BranchCommits++
+			continue
+		}
+
+		month, ok := byMonth[in.MonthKey]
+		if !ok {
+			month = &StrategyCounts{}
+			byMonth[in.MonthKey] = month
+		}
+		for _, counts := range []*StrategyCounts{month, &s.Total} {
+			switch {
+			case in.Merge:
+				counts.Merge++
+			case in.Squash:
+				counts.Squash++
+			case in.Rebased:
+				counts.Rebase++
+			default:
+				counts.Direct++
+			}
+		}
+	}
+
+	for month := range byMonth {
+		s.Months = append(s.Months, month)
+	}
+	sort.Strings(s.Months)
+	for _, month := range s.Months {
+		s.Monthly = append(s.Monthly, *byMonth[month])
+	}
+	return s
+}
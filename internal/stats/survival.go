@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+// lineEdit records the line counts of a single commit to a single file
+type lineEdit struct {
+	at        time.Time
+	email     string
+	additions int
+	deletions int
+}
+
+// lineChunk is a run of lines added by one author within the range
+type lineChunk struct {
+	email string
+	lines int
+}
+
+// survivalCollector records line edits per file to split additions into
+// surviving and churned lines
+type survivalCollector struct {
+	edits map[string][]lineEdit // file -> line edits, in log order
+}
+
+func (*survivalCollector) Name() string { return "survival" }
+
+func (s *survivalCollector) Collect(repo *Repository, cc *CommitContext) {
+	c := cc.Commit
+	for _, fc := range c.FileChanges {
+		if fc.IsBinary {
+			continue
+		}
+		s.edits[fc.FilePath] = append(s.edits[fc.FilePath], lineEdit{
+			at:        c.AuthorDate,
+			email:     c.Author.Email,
+			additions: fc.Additions,
+			deletions: fc.Deletions,
+		})
+	}
+}
+
+// Finalize replays each file's edits oldest first. Deletions consume the
+// most recently added in-range lines before any pre-existing lines;
+// consumed lines count as churn for the author who added them.
+func (s *survivalCollector) Finalize(repo *Repository) {
+	churned := make(map[string]int)
+
+	for _, edits := range s.edits {
+		// git log emits newest first, so reverse before the stable sort
+		// to keep log order for commits sharing a timestamp
+		for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+			edits[i], edits[j] = edits[j], edits[i]
+		}
+		sort.SliceStable(edits, func(i, j int) bool {
+			return edits[i].at.Before(edits[j].at)
+		})
+
+		var pool []lineChunk
+		for _, e := range edits {
+			remaining := e.deletions
+			for remaining > 0 && len(pool) > 0 {
+				top := &pool[len(pool)-1]
+				n := min(remaining, top.lines)
+				churned[top.email] += n
+				top.lines -= n
+				remaining -= n
+				if top.lines == 0 {
+					pool = pool[:len(pool)-1]
+				}
+			}
+			if e.additions > 0 {
+				pool = append(pool, lineChunk{email: e.email, lines: e.additions})
+			}
+		}
+	}
+
+	for email, author := range repo.Authors {
+		author.ChurnedLines = churned[email]
+		author.SurvivingLines = author.Additions - author.ChurnedLines
+	}
+}
+
+// ProductivePercent returns the share of the author's additions that survived
+func (a *AuthorStats) ProductivePercent() float64 {
+	if a.Additions == 0 {
+		return 0
+	}
+	return float64(a.SurvivingLines) / float64(a.Additions) * 100
+}
+
+// GetProductivity returns authors with additions, most surviving lines first
+func (r *Repository) GetProductivity() []*AuthorStats {
+	authors := make([]*AuthorStats, 0, len(r.Authors))
+	for _, a := range r.Authors {
+		if a.Additions > 0 {
+			authors = append(authors, a)
+		}
+	}
+
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].SurvivingLines != authors[j].SurvivingLines {
+			return authors[i].SurvivingLines > authors[j].SurvivingLines
+		}
+		return authors[i].Email < authors[j].Email
+	})
+
+	return authors
+}
@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HighRiskScore is the hotspot risk score from which a file counts as a
+// high-risk hotspot
+const HighRiskScore = 50
+
+// Authors committing within this many days before the end of the period
+// count as active
+const activeAuthorDays = 30
+
+// Snapshot holds the headline metrics of one scan. Appended to a trends
+// file after every scan, snapshots chart how a repository develops.
+type Snapshot struct {
+	At            time.Time `json:"at"` // when the scan ran
+	Since         time.Time `json:"since"`
+	Until         time.Time `json:"until"`
+	Commits       int       `json:"commits"`
+	Churn         int       `json:"churn"` // lines added plus lines deleted
+	BusFactor     int       `json:"bus_factor"`
+	Hotspots      int       `json:"hotspots"` // files at HighRiskScore or above
+	Authors       int       `json:"authors"`
+	ActiveAuthors int       `json:"active_authors"` // committed in the last 30 days of the period
+}
+
+// Snapshot returns the headline metrics of the statistics, taken at at
+func (r *Repository) Snapshot(at time.Time) Snapshot {
+	s := Snapshot{
+		At:        at,
+		Since:     r.DateRange.Since,
+		Until:     r.DateRange.Until,
+		Commits:   r.TotalCommits,
+		Churn:     r.TotalAdditions + r.TotalDeletions,
+		BusFactor: r.BusFactor(),
+		Authors:   r.TotalAuthors,
+	}
+	for _, h := range r.GetHotspots(0) {
+		if h.RiskScore >= HighRiskScore {
+			s.Hotspots++
+		}
+	}
+	active := r.DateRange.Until.AddDate(0, 0, -activeAuthorDays)
+	for _, a := range r.Authors {
+		if a.LastCommit.After(active) {
+			s.ActiveAuthors++
+		}
+	}
+	return s
+}
+
+// BusFactor returns the smallest number of authors who together changed more
+// than half of all lines: losing them loses most of the knowledge
+func (r *Repository) BusFactor() int {
+	changes := make([]int, 0, len(r.Authors))
+	total := 0
+	for _, a := range r.Authors {
+		changes = append(changes, a.Additions+a.Deletions)
+		total += a.Additions + a.Deletions
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(changes)))
+
+	sum := 0
+	for i, c := range changes {
+		sum += c
+		if sum*2 > total {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LoadTrends reads the snapshots of a trends file, oldest first. A missing
+// file has none; unreadable lines are skipped.
+func LoadTrends(path string) ([]Snapshot, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var snapshots []Snapshot
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s Snapshot
+		if json.Unmarshal(scanner.Bytes(), &s) == nil {
+			snapshots = append(snapshots, s)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].At.Before(snapshots[j].At)
+	})
+	return snapshots, scanner.Err()
+}
+
+// AppendTrend adds a snapshot to a trends file, one JSON object per line
+func AppendTrend(path string, s Snapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// OwnershipTurnover compares a directory's ownership with the previous period
+type OwnershipTurnover struct {
+	PrevOwner    string  // top owner name in the previous period
+	PrevShare    float64 // top owner share in the previous period
+	CurrentOwner string
+	CurrentShare float64
+	OwnerChanged bool    // top owner email differs between periods
+	Shift        float64 // half the sum of absolute share changes, 0-100
+}
+
+// SetPrevious attaches statistics for the preceding equal-length period
+// and computes ownership turnover against it
+func (r *Repository) SetPrevious(prev *Repository) {
+	r.Previous = prev
+	r.computeTurnover()
+	r.invalidateSorted()
+}
+
+func (r *Repository) computeTurnover() {
+	for path, dir := range r.DirStats {
+		dir.Turnover = nil
+		if r.Previous == nil {
+			continue
+		}
+		prevDir, ok := r.Previous.DirStats[path]
+		if !ok || len(prevDir.Authors) == 0 || len(dir.Authors) == 0 {
+			continue // new or previously untouched directory
+		}
+		dir.Turnover = compareOwnership(prevDir, dir)
+	}
+}
+
+func compareOwnership(prev, cur *DirStats) *OwnershipTurnover {
+	prevTop := topOwner(prev)
+	curTop := topOwner(cur)
+
+	// Total variation distance between the two share distributions
+	var shift float64
+	for email, a := range cur.Authors {
+		prevShare := 0.0
+		if p, ok := prev.Authors[email]; ok {
+			prevShare = p.Share
+		}
+		shift += math.Abs(a.Share - prevShare)
+	}
+	for email, p := range prev.Authors {
+		if _, ok := cur.Authors[email]; !ok {
+			shift += p.Share
+		}
+	}
+
+	return &OwnershipTurnover{
+		PrevOwner:    prevTop.Name,
+		PrevShare:    prevTop.Share,
+		CurrentOwner: curTop.Name,
+		CurrentShare: curTop.Share,
+		OwnerChanged: prevTop.Email != curTop.Email,
+		Shift:        shift / 2,
+	}
+}
+
+// topOwner returns the author with the largest share, ties broken by email
+func topOwner(dir *DirStats) *DirAuthorStats {
+	var top *DirAuthorStats
+	for _, a := range dir.Authors {
+		if top == nil || a.Share > top.Share || (a.Share == top.Share && a.Email < top.Email) {
+			top = a
+		}
+	}
+	return top
+}
+
+// GetTurnoverHotspots returns directories whose top owner changed or whose
+// ownership shifted by at least minShift percentage points, largest shift first
+func (r *Repository) GetTurnoverHotspots(minShift float64) []*DirStats {
+	dirs := make([]*DirStats, 0)
+	for _, d := range r.DirStats {
+		if d.Turnover != nil && (d.Turnover.OwnerChanged || d.Turnover.Shift >= minShift) {
+			dirs = append(dirs, d)
+		}
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		if dirs[i].Turnover.Shift != dirs[j].Turnover.Shift {
+			return dirs[i].Turnover.Shift > dirs[j].Turnover.Shift
+		}
+		return dirs[i].Path < dirs[j].Path
+	})
+
+	return dirs
+}
+
+// turnoverShift returns the directory's shift, or -1 if there is nothing to compare
+func turnoverShift(d *DirStats) float64 {
+	if d.Turnover == nil {
+		return -1
+	}
+	return d.Turnover.Shift
+}
@@ -3,6 +3,9 @@ package stats
 import (
 	"path/filepath"
 	"time"
+
+	"github.com/audi70r/gitstat/internal/complexity"
+	"github.com/audi70r/gitstat/internal/git"
 )
 
 // DateRange represents the time range for analysis
@@ -11,6 +14,22 @@ type DateRange struct {
 	Until time.Time
 }
 
+// String formats the range as dates for report headers, leaving out an
+// open bound: "2024-01-01 to 2024-06-30", "since 2024-01-01", "until
+// 2024-06-30" or "all history"
+func (d DateRange) String() string {
+	const layout = "2006-01-02"
+	switch {
+	case d.Since.IsZero() && d.Until.IsZero():
+		return "all history"
+	case d.Since.IsZero():
+		return "until " + d.Until.Format(layout)
+	case d.Until.IsZero():
+		return "since " + d.Since.Format(layout)
+	}
+	return d.Since.Format(layout) + " to " + d.Until.Format(layout)
+}
+
 // Repository holds all computed statistics
 type Repository struct {
 	Path         string
@@ -18,18 +37,43 @@ type Repository struct {
 	TotalCommits int
 	TotalAuthors int
 
+	// What the scan was restricted to besides the period
+	Filters Filters
+
 	// Author statistics
 	Authors map[string]*AuthorStats
 
 	// File statistics
 	FileStats map[string]*FileStats
 
+	// Lines changed in files marked generated or vendored via
+	// .gitattributes, which are left out of all other statistics
+	Generated map[string]int
+
 	// Directory statistics
 	DirStats map[string]*DirStats
 
+	// Every directory at every depth, rooted at the repository itself,
+	// see GetSubdirs. Only the top level is merged into components.
+	DirTree *DirStats
+
+	// Directory co-change data (full directory paths, not just top level)
+	DirCommits map[string]int  // directory -> commits touching it
+	DirPairs   map[DirPair]int // directory pair -> commits touching both
+
+	// File co-change data, under the files' current paths
+	FilePairs map[FilePair]int // file pair -> commits touching both
+
 	// Time-based data
-	DailyActivity map[string]int // "2024-01-15" -> count
-	HourlyMatrix  [7][24]int     // weekday x hour
+	DailyActivity map[string]int          // "2024-01-15" -> count
+	Days          map[string]*DayActivity // authors and largest commits per day, see GetAnomalies
+	HourlyMatrix  [7][24]int              // weekday x hour
+	MonthDay      [12][31]int             // month x day of month
+	MonthWeekday  [12][7]int              // month x weekday (Monday first)
+	MonthEnd      int                     // commits in the last 5 days of their month
+
+	// Per-commit sizes (non-merge commits only)
+	CommitSizes []CommitSize
 
 	// Totals
 	TotalAdditions int
@@ -38,8 +82,84 @@ type Repository struct {
 	// Codebase info
 	CodebaseSize int // Total lines in current codebase
 
+	// Half-life of the churn weighting of FileStats, 0 when every commit
+	// weighs the same
+	ChurnHalfLife time.Duration
+
+	// Tracked files outside a sparse checkout, which CodebaseSize and the
+	// license header check miss
+	SparseFiles int
+
+	// A scanned repository is a partial clone; debt markers whose blame
+	// needed objects missing from it are left unattributed
+	PartialClone bool
+
 	// Pull Request / Merge statistics
 	PRStats *PRStatistics
+
+	// Commit graph shape
+	TotalParents       int // sum of parent counts over all commits
+	RootCommits        int // commits without parents
+	OctopusMerges      int // merges with more than two parents
+	FirstParentCommits int // non-merge commits made directly on the first-parent chain
+
+	// Cherry-picks on the scanned branch and backports on release branches
+	CherryPicks []*CherryPickInfo
+	Backports   []*git.BranchBackports
+
+	// Tags dated within the period, widened by CrunchTagWindow, oldest
+	// first; nil if not listed
+	Tags []git.Tag
+
+	// Commits closing issues via "Fixes #123" style keywords
+	IssueCloses []*IssueClose
+
+	// TODO/FIXME/HACK markers in the current worktree, attributed via blame
+	DebtMarkers []*git.DebtMarker
+
+	// Age of the surviving lines, nil when the blame sample is not taken
+	CodeAge *CodeAge
+
+	// Tracked source file -> has license header, nil when not checked
+	LicenseHeaders map[string]bool
+
+	// Complexity of the tracked source files a complexity analyzer handles,
+	// nil until the worktree pass measured it; folded into the hotspot risk
+	Complexity map[string]complexity.File
+
+	// Statistics for the preceding equal-length period, nil if not scanned
+	Previous *Repository
+
+	// How each commit reached the trunk, see GetMergeStrategies
+	Integrations []Integration
+	FirstParent  map[string]bool // hashes on HEAD's first-parent chain, nil if not scanned
+
+	// Commits skipped as copies of an already counted one, see
+	// Aggregator.SetDeduplicate
+	DuplicateCommits int
+
+	// Commits per label from gitstat notes
+	Labels map[string]*LabelStats
+
+	// Changes per file extension, see GetLanguageStats
+	Languages map[string]*LanguageStats
+
+	// Fork commits per author email, split by whether they reached the
+	// upstream repository; nil unless a fork was scanned with its upstream
+	Fork map[string]*ForkContribution
+
+	// Version bumps, lockfile updates and changelog edits
+	Mechanical MechanicalStats
+
+	// Author identities merged by ApplyAuthorMerges, see Mailmap
+	IdentityMerges []*IdentityMerge
+
+	// Former paths of renamed files -> the path their FileStats are kept
+	// under
+	renames map[string]string
+
+	// Sorted listings cached for paging
+	sorted *sortCache
 }
 
 // NewRepository creates a new Repository stats container
@@ -49,9 +169,19 @@ func NewRepository(path string, dateRange DateRange) *Repository {
 		DateRange:     dateRange,
 		Authors:       make(map[string]*AuthorStats),
 		FileStats:     make(map[string]*FileStats),
+		Generated:     make(map[string]int),
 		DirStats:      make(map[string]*DirStats),
+		DirTree:       NewDirStats("."),
+		DirCommits:    make(map[string]int),
+		DirPairs:      make(map[DirPair]int),
+		FilePairs:     make(map[FilePair]int),
 		DailyActivity: make(map[string]int),
+		Days:          make(map[string]*DayActivity),
+		Labels:        make(map[string]*LabelStats),
+		Languages:     make(map[string]*LanguageStats),
+		renames:       make(map[string]string),
 		PRStats:       NewPRStatistics(),
+		sorted:        newSortCache(),
 	}
 }
 
@@ -65,6 +195,25 @@ type AuthorStats struct {
 	FilesTouched map[string]int // file -> touch count
 	FirstCommit  time.Time
 	LastCommit   time.Time
+	Messages     *MessageStats // commit message conventions
+
+	// Split of Additions into lines that survived the range and lines
+	// deleted again within it (filled by Finalize)
+	SurvivingLines int
+	ChurnedLines   int
+
+	// Non-merge commits classified as refactoring (see IsRefactorCommit)
+	RefactorCommits int
+
+	// Local commit times, for the session-based work estimate (see
+	// EstimateHours)
+	CommitTimes []time.Time
+
+	// Commits per week, keyed by the week's Monday ("2024-01-15")
+	Weekly map[string]int
+
+	// Commits per day, keyed "2024-01-15"
+	Daily map[string]int
 }
 
 // NewAuthorStats creates a new AuthorStats
@@ -73,6 +222,9 @@ func NewAuthorStats(name, email string) *AuthorStats {
 		Name:         name,
 		Email:        email,
 		FilesTouched: make(map[string]int),
+		Messages:     NewMessageStats(),
+		Weekly:       make(map[string]int),
+		Daily:        make(map[string]int),
 	}
 }
 
@@ -84,6 +236,42 @@ type FileStats struct {
 	Authors      map[string]int // author email -> commits
 	Additions    int
 	Deletions    int
+
+	// TotalChanges and TouchCount with each commit weighted by its age,
+	// equal to them without a churn half-life
+	DecayedChanges float64
+	DecayedTouches float64
+
+	// Lifecycle tracking
+	Created       int         // times the file was created
+	Deleted       int         // times the file was deleted
+	Resurrections int         // times the file was re-created after a deletion
+	Lifecycle     []FileEvent // create/delete events in commit order
+
+	// Renames within the range; the history under former paths is
+	// counted under the current one
+	Renames     int
+	LastRenamed time.Time // author date of the latest rename
+	RenamedFrom string    // path before the latest rename
+
+	// Per-month activity, keyed "2024-01"
+	Monthly map[string]*FileMonth
+
+	// Lines changed per week, keyed by the week's Monday ("2024-01-15")
+	Weekly map[string]int
+}
+
+// FileMonth holds a file's activity within one month
+type FileMonth struct {
+	Changes int
+	Touches int
+	Authors map[string]int // author email -> commits
+}
+
+// FileEvent records a create or delete of a file
+type FileEvent struct {
+	At      time.Time
+	Deleted bool
 }
 
 // NewFileStats creates a new FileStats
@@ -91,42 +279,89 @@ func NewFileStats(path string) *FileStats {
 	return &FileStats{
 		Path:    path,
 		Authors: make(map[string]int),
+		Monthly: make(map[string]*FileMonth),
+		Weekly:  make(map[string]int),
 	}
 }
 
+// IsDeleted reports whether the file was deleted by the end of the range
+func (f *FileStats) IsDeleted() bool {
+	return len(f.Lifecycle) > 0 && f.Lifecycle[len(f.Lifecycle)-1].Deleted
+}
+
+// Files renamed this many days before the end of the range or later count
+// as recently renamed
+const recentRenameDays = 30
+
+// RenamedRecently reports whether the file was renamed in the last 30 days
+// up to until
+func (f *FileStats) RenamedRecently(until time.Time) bool {
+	return !f.LastRenamed.IsZero() && f.LastRenamed.After(until.AddDate(0, 0, -recentRenameDays))
+}
+
 // DirStats holds statistics for a directory
 type DirStats struct {
 	Path         string
 	Authors      map[string]*DirAuthorStats
 	TotalChanges int
 	TouchCount   int
+
+	// Time-based data
+	DailyCommits map[string]int // "2024-01-15" -> commits touching this directory
+	DailyChurn   map[string]int // "2024-01-15" -> lines changed in this directory
+
+	// Commits touching this directory classified as refactoring
+	RefactorCommits int
+
+	// Directories merged into this one as a logical component
+	Merged []string
+
+	// Ownership change against the previous period, nil if not comparable
+	Turnover *OwnershipTurnover
+
+	// Child directories by path, only within Repository.DirTree
+	Subdirs map[string]*DirStats
 }
 
 // NewDirStats creates a new DirStats
 func NewDirStats(path string) *DirStats {
 	return &DirStats{
-		Path:    path,
-		Authors: make(map[string]*DirAuthorStats),
+		Path:         path,
+		Authors:      make(map[string]*DirAuthorStats),
+		DailyCommits: make(map[string]int),
+		DailyChurn:   make(map[string]int),
 	}
 }
 
 // DirAuthorStats holds per-author stats within a directory
 type DirAuthorStats struct {
-	Name    string
-	Email   string
-	Commits int
-	Changes int
-	Share   float64 // percentage of total changes
+	Name     string
+	Email    string
+	Commits  int
+	Changes  int
+	Share    float64        // percentage of total changes
+	Monthly  map[string]int // "2024-01" -> lines changed, see GetOwnershipHistory
+	FirstDay string         // "2024-01-15", the author's first change here
 }
 
 // TimelineData holds time-series commit data
 type TimelineData struct {
-	Period     string // "day" or "week"
+	Period     string // PeriodDay, PeriodWeek, PeriodMonth or PeriodQuarter
 	Labels     []string
 	Values     []int
 	RollingAvg []float64
 }
 
+// DirTimelineData holds time-series activity for a single directory
+type DirTimelineData struct {
+	Path       string
+	Labels     []string
+	Commits    []int
+	Churn      []int
+	ActiveDays int
+	LastActive string // "2024-01-15", empty if never active
+}
+
 // HeatmapData holds work hours heatmap data
 type HeatmapData struct {
 	Matrix   [7][24]int // weekday x hour
@@ -134,14 +369,25 @@ type HeatmapData struct {
 	Timezone *time.Location
 }
 
+// CalendarHeatmaps holds month-based commit matrices
+type CalendarHeatmaps struct {
+	MonthDay        [][]int // 12 x 31
+	MonthWeekday    [][]int // 12 x 7
+	MonthEndPercent float64 // share of commits in the last 5 days of a month
+}
+
 // HotspotFile represents a file with risk signals
 type HotspotFile struct {
-	Path        string
-	ChurnScore  float64 // normalized changes
-	AuthorCount int
-	RiskScore   float64 // combined score
-	Changes     int
-	TouchCount  int
+	Path          string
+	ChurnScore    float64 // normalized changes
+	AuthorCount   int
+	Complexity    int     // cyclomatic complexity of the file, see Repository.Complexity
+	HasComplexity bool    // the file's complexity was measured
+	RiskScore     float64 // combined score
+	Changes       int
+	TouchCount    int
+	RiskTrend     []float64 // monthly risk scores, oldest first
+	TrendSlope    float64   // risk points per month, positive when worsening
 }
 
 // CodebaseStats holds overall codebase change statistics
@@ -152,8 +398,12 @@ type CodebaseStats struct {
 	FilesAdded        int
 	FilesModified     int
 	FilesDeleted      int
+	FilesResurrected  int     // files re-created after being deleted
 	CodebaseSize      int     // Total lines in current codebase
 	RefactoredPercent float64 // Percentage of codebase touched
+	SurvivingLines    int     // added lines still present at the end of the range
+	ChurnedLines      int     // added lines deleted again within the range
+	ProductivePercent float64 // SurvivingLines as a percentage of additions
 }
 
 // PRStatistics holds pull request / merge commit statistics
@@ -163,6 +413,15 @@ type PRStatistics struct {
 	MergesByAuthor map[string]*PRAuthorStats
 	PRList         []*PRInfo
 	DailyMerges    map[string]int // "2024-01-15" -> count
+
+	// Review latency of the PRs with GitHub data (see SetPRReviews); zero
+	// without the GitHub integration
+	Reviewed          int            // PRs with GitHub data
+	Unreviewed        int            // of which merged without a review
+	Reviews           int            // submitted reviews, comments included
+	MedianTimeToMerge time.Duration  // from opening to merge
+	MedianFirstReview time.Duration  // from opening to the first review, of the reviewed PRs
+	ReviewsBy         map[string]int // GitHub login -> PRs reviewed
 }
 
 // NewPRStatistics creates a new PRStatistics
@@ -185,6 +444,7 @@ type PRAuthorStats struct {
 
 // PRInfo holds information about a single PR/merge
 type PRInfo struct {
+	Hash          string // merge commit hash
 	PRNumber      int
 	MergedBy      string // Author name
 	MergedByEmail string
@@ -194,6 +454,53 @@ type PRInfo struct {
 	Additions     int
 	Deletions     int
 	FilesCount    int
+	Files         []git.FileChange // files changed by the merge
+	Review        *PRReview        // from the GitHub API; nil without
+}
+
+// PRReview is what the GitHub API knows about a pull request beyond its
+// merge commit
+type PRReview struct {
+	Repo          string // "owner/name"
+	Author        string // GitHub login of the PR's author
+	CreatedAt     time.Time
+	MergedAt      time.Time
+	FirstReviewAt time.Time // zero without reviews
+	Reviews       int       // submitted reviews, comments included
+	Approvals     int
+	Reviewers     []string // logins, in the order of their first review
+	Labels        []string
+}
+
+// TimeToMerge returns how long the PR was open
+func (p *PRReview) TimeToMerge() time.Duration {
+	return p.MergedAt.Sub(p.CreatedAt)
+}
+
+// TimeToFirstReview returns how long the PR waited for its first review;
+// false when it had none
+func (p *PRReview) TimeToFirstReview() (time.Duration, bool) {
+	if p.FirstReviewAt.IsZero() {
+		return 0, false
+	}
+	return p.FirstReviewAt.Sub(p.CreatedAt), true
+}
+
+// PRDirChange summarizes a pull request's changes in one top-level directory
+type PRDirChange struct {
+	Path    string
+	Files   int
+	Changes int
+}
+
+// CherryPickInfo describes a commit created with git cherry-pick -x
+type CherryPickInfo struct {
+	Hash        string
+	SourceHash  string
+	Author      string
+	AuthorEmail string
+	At          time.Time
+	Subject     string
 }
 
 // GetDirectory returns the top-level directory of a file path
@@ -2,27 +2,49 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/chart"
 	"github.com/audi70r/gitstat/internal/config"
-	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/scan"
 	"github.com/audi70r/gitstat/internal/stats"
 	"github.com/audi70r/gitstat/internal/ui/views"
 )
 
 // App represents the main application
 type App struct {
-	tview      *tview.Application
-	pages      *tview.Pages
-	config     *config.Config
-	repoStats  *stats.Repository
-	aggregator *stats.Aggregator
+	tview     *tview.Application
+	pages     *tview.Pages
+	config    *config.Config
+	repoStats *stats.Repository
+
+	// Runs the scans, cancelled with ctx on shutdown
+	scanner *scan.Controller
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	// Macros of the configuration file, and the rest of the running one
+	// once the scan it started completes
+	macros    map[string][]macroStep
+	afterScan func()
+
+	// Working context of the scanned repositories, nil until the first scan
+	// completes or when Config.RestoreUIState is off
+	uiState *config.UIState
+
+	// Worktree passes applied since the last scan, applied again to the
+	// statistics recomputed from its commits
+	worktree []worktreeResult
 
 	// UI components
 	setupView    *views.SetupView
@@ -30,13 +52,31 @@ type App struct {
 	mainView     *MainView
 }
 
-// NewApp creates a new application instance
-func NewApp() *App {
+// NewApp creates a new application instance with cfg, failing when its key
+// bindings are invalid or conflict
+func NewApp(cfg *config.Config) (*App, error) {
+	keys := views.NewKeyMap()
+	if err := keys.Remap(cfg.KeyBindings); err != nil {
+		return nil, fmt.Errorf("invalid key bindings:\n%v", err)
+	}
+	macros, err := parseMacros(cfg.Macros, keys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid macros:\n%v", err)
+	}
+
 	app := &App{
 		tview:  tview.NewApplication(),
 		pages:  tview.NewPages(),
-		config: config.Default(),
+		config: cfg,
+		macros: macros,
 	}
+	app.ctx, app.cancel = context.WithCancel(context.Background())
+	app.scanner = scan.NewController(app.ctx, cfg, &presenter{app: app})
+	app.scanner.Trends = true
+	app.scanner.Blame = true
+	app.scanner.GitHub = true
+	app.scanner.ComparePrevious = true
+	app.scanner.KeepCommits = true
 
 	// Set current directory as default
 	cwd, err := os.Getwd()
@@ -48,19 +88,24 @@ func NewApp() *App {
 	app.config.Until = time.Now()
 	app.config.Since = app.config.Until.AddDate(-1, 0, 0)
 
-	app.setupViews()
-	return app
+	app.setupViews(keys)
+	return app, nil
 }
 
-func (a *App) setupViews() {
+func (a *App) setupViews(keys *views.KeyMap) {
 	// Setup view
-	a.setupView = views.NewSetupView(a.config, a.onSetupComplete, a.tview)
+	a.setupView = views.NewSetupView(a.config, a.onSetupComplete, a.tview, keys)
 
 	// Progress view
 	a.progressView = views.NewProgressView()
 
 	// Main view (will be populated after scan)
-	a.mainView = NewMainView(a.tview, a.onRescan, a.onMergeAuthors)
+	a.mainView = NewMainView(a.tview, keys, a.onRescan, a.onMergeAuthors, a.onMergeDirs, a.onExportMailmap)
+	a.mainView.SetBatch(a)
+	a.mainView.SetMacros(a.macroNames(), a.runMacro)
+	a.mainView.SetQueueUpdate(a.queueUpdateDraw)
+	a.mainView.SetSettingsFunc(a.applySettings)
+	a.mainView.SetChartFunc(a.exportChart)
 
 	// Add pages
 	a.pages.AddPage("setup", a.setupView.Root(), true, true)
@@ -68,6 +113,33 @@ func (a *App) setupViews() {
 	a.pages.AddPage("main", a.mainView.Root(), true, false)
 
 	a.tview.SetRoot(a.pages, true)
+
+	// Adapt the main view to the terminal width, including after resizes
+	a.tview.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		width, _ := screen.Size()
+		a.mainView.Resize(width)
+		return false
+	})
+
+	// Esc on the progress screen aborts a running scan
+	a.pages.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if name, _ := a.pages.GetFrontPage(); name == "progress" && event.Key() == tcell.KeyEsc {
+			if a.scanner.Cancel() {
+				a.onRescan()
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// queueUpdateDraw schedules a UI update unless the app is shutting down,
+// when nothing drains the update queue anymore
+func (a *App) queueUpdateDraw(f func()) {
+	if a.ctx.Err() != nil {
+		return
+	}
+	a.tview.QueueUpdateDraw(f)
 }
 
 func (a *App) onMergeAuthors(merges map[string]string) {
@@ -76,7 +148,7 @@ func (a *App) onMergeAuthors(merges map[string]string) {
 	}
 
 	// Show progress
-	a.progressView.SetStatus("Applying author merges...")
+	a.progressView.SetStatus(i18n.T("Applying author merges..."))
 	a.progressView.SetProgress(0, 100)
 	a.pages.SwitchToPage("progress")
 
@@ -85,146 +157,286 @@ func (a *App) onMergeAuthors(merges map[string]string) {
 		a.repoStats.ApplyAuthorMerges(merges)
 
 		// Refresh all views and switch back, keeping focus on Authors view
-		a.tview.QueueUpdateDraw(func() {
+		a.queueUpdateDraw(func() {
 			a.mainView.RefreshAllViews()
 			a.pages.SwitchToPage("main")
 			a.mainView.FocusAuthorsView()
+			a.notify(i18n.T("Merged %d author identities", len(merges)), nil)
 		})
 	}()
 }
 
-func (a *App) onSetupComplete() {
-	// Get repos to scan
+// onExportMailmap adds the applied author merges to the .mailmap file of
+// every scanned repository, keeping entries already there
+func (a *App) onExportMailmap() (string, error) {
+	if a.repoStats == nil || len(a.repoStats.IdentityMerges) == 0 {
+		return "", fmt.Errorf("no author merges applied yet")
+	}
+	entries := a.repoStats.Mailmap()
+
 	repos := a.config.RepoPaths
 	if len(repos) == 0 && a.config.RepoPath != "" {
 		repos = []string{a.config.RepoPath}
 	}
 
-	if len(repos) == 0 {
-		a.setupView.ShowError("No repositories selected")
-		return
-	}
-
-	// Validate all repos
-	for _, path := range repos {
-		if !git.IsGitRepo(path) {
-			a.setupView.ShowError(fmt.Sprintf("Not a git repository: %s", path))
-			return
+	var written []string
+	for _, repoPath := range repos {
+		path := filepath.Join(repoPath, ".mailmap")
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		content, added := stats.MergeMailmap(string(existing), entries)
+		if added == 0 {
+			continue
 		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return "", err
+		}
+		written = append(written, path)
 	}
 
-	// Switch to progress view and start scanning
-	a.pages.SwitchToPage("progress")
-	go a.scanRepositories(repos)
+	if len(written) == 0 {
+		return "all entries already in .mailmap", nil
+	}
+	if len(written) == 1 {
+		return fmt.Sprintf("wrote %d entries to %s", len(entries), written[0]), nil
+	}
+	return fmt.Sprintf("wrote %d entries to .mailmap in %d repositories", len(entries), len(written)), nil
 }
 
-func (a *App) scanRepositories(repos []string) {
-	ctx := context.Background()
-
-	// Estimate total commits across all repos
-	totalEstimate := 0
-	for _, repoPath := range repos {
-		parser := git.NewParser(repoPath)
-		estimate, _ := parser.EstimateCommitCount(ctx, a.config.Since, a.config.Until)
-		if estimate > 0 {
-			totalEstimate += estimate
-		}
+func (a *App) onMergeDirs(merges map[string]string) {
+	if a.repoStats == nil || len(merges) == 0 {
+		return
 	}
-	a.progressView.SetTotal(totalEstimate)
 
-	// Create aggregator with combined path info
-	dateRange := stats.DateRange{
-		Since: a.config.Since,
-		Until: a.config.Until,
+	// Remember merges so they survive a rescan
+	if a.config.DirMerges == nil {
+		a.config.DirMerges = make(map[string]string)
+	}
+	for dir, primary := range merges {
+		a.config.DirMerges[dir] = primary
 	}
 
-	combinedPath := repos[0]
-	if len(repos) > 1 {
-		combinedPath = fmt.Sprintf("%d repositories", len(repos))
+	a.repoStats.ApplyDirMerges(merges)
+	a.mainView.RefreshAllViews()
+	a.mainView.FocusOwnershipView()
+}
+
+func (a *App) onSetupComplete() {
+	repos, err := a.scanner.Repositories()
+	if err != nil {
+		a.setupView.ShowError(err.Error())
+		return
 	}
-	a.aggregator = stats.NewAggregator(combinedPath, dateRange, a.config.Timezone)
 
-	// Scan each repository
-	totalCommits := 0
-	totalCodebaseSize := 0
+	// Switch to progress view and start scanning
+	a.pages.SwitchToPage("progress")
+	a.progressView.SetStatus(i18n.T("Starting scan... [gray](Esc to cancel)[-]"))
+	a.scanner.Start(repos)
+}
 
-	for i, repoPath := range repos {
-		repoName := filepath.Base(repoPath)
+// How long a toast stays over the status bar
+const toastDuration = 4 * time.Second
 
-		a.tview.QueueUpdateDraw(func() {
-			a.progressView.SetStatus(fmt.Sprintf("Scanning %s (%d/%d)...", repoName, i+1, len(repos)))
+// notify reports a finished background operation as a toast and in the
+// notification log. It must run on the UI goroutine.
+func (a *App) notify(message string, err error) {
+	n := views.Notification{At: time.Now(), Message: message}
+	if err != nil {
+		n.Message = fmt.Sprintf("%s: %v", message, err)
+		n.Failed = true
+	}
+	seq := a.mainView.Notify(n)
+	time.AfterFunc(toastDuration, func() {
+		a.queueUpdateDraw(func() {
+			a.mainView.HideToast(seq)
 		})
+	})
+}
 
-		parser := git.NewParser(repoPath)
-
-		// Parse commits from this repo
-		err := parser.Parse(ctx, a.config.Since, a.config.Until,
-			func(progress git.ScanProgress) {
-				a.tview.QueueUpdateDraw(func() {
-					a.progressView.SetProgress(totalCommits+progress.CommitsParsed, totalEstimate)
-					if progress.CurrentHash != "" {
-						a.progressView.SetStatus(fmt.Sprintf("[%s] Processing %s...", repoName, progress.CurrentHash))
-					}
-				})
-			},
-			func(commit *git.Commit) {
-				a.aggregator.ProcessCommit(commit)
-			},
-		)
-
-		if err != nil {
-			a.tview.QueueUpdateDraw(func() {
-				a.progressView.SetStatus(fmt.Sprintf("Error in %s: %v", repoName, err))
-			})
-			// Continue with other repos
-		}
+// elapsed formats the duration of a background operation for notifications
+func elapsed(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
 
-		// Update total commits processed
-		totalCommits = a.aggregator.GetResult().TotalCommits
+// worktreeResult is the outcome of a worktree pass, see scan.Presenter
+type worktreeResult interface {
+	Apply(repo *stats.Repository)
+}
 
-		// Calculate codebase size for this repo
-		a.tview.QueueUpdateDraw(func() {
-			a.progressView.SetStatus(fmt.Sprintf("Calculating size for %s...", repoName))
-		})
-		size, _ := git.GetCodebaseSize(repoPath)
-		totalCodebaseSize += size
+// applySettings takes the settings of the Settings view. The rolling average
+// only redraws the Timeline; a new timezone or churn half-life recomputes
+// the statistics.
+func (a *App) applySettings(s views.Settings) {
+	recompute := s.Timezone.String() != a.config.Timezone.String() || s.ChurnHalfLife != a.config.ChurnHalfLife
+	a.config.Timezone = s.Timezone
+	a.config.ChurnHalfLife = s.ChurnHalfLife
+	if s.RollingWindow != a.config.RollingWindow {
+		a.config.RollingWindow = s.RollingWindow
+		a.mainView.SetRollingWindow(s.RollingWindow)
+	}
+	if recompute {
+		a.recompute()
+	}
+}
+
+// recompute aggregates the commits of the last scan again after a setting
+// applied to them changed, keeping the worktree passes and the author
+// merges made since. It rescans when no commits were kept.
+func (a *App) recompute() {
+	if a.repoStats == nil {
+		a.onSetupComplete()
+		return
 	}
+	previous := a.repoStats
 
-	// Finalize statistics
-	a.repoStats = a.aggregator.Finalize()
-	a.repoStats.CodebaseSize = totalCodebaseSize
+	a.progressView.SetStatus(i18n.T("Recomputing statistics..."))
+	a.progressView.SetProgress(0, 0)
+	a.pages.SwitchToPage("progress")
 
-	// Switch to main view
-	a.tview.QueueUpdateDraw(func() {
-		a.mainView.SetData(a.repoStats, a.config)
-		a.pages.SwitchToPage("main")
-		a.tview.SetFocus(a.mainView.GetFocusable())
-	})
+	go func() {
+		start := time.Now()
+		repoStats, err := a.scanner.Reaggregate(a.ctx)
+		a.queueUpdateDraw(func() {
+			if errors.Is(err, scan.ErrNothingKept) {
+				a.onSetupComplete()
+				return
+			}
+			a.pages.SwitchToPage("main")
+			a.tview.SetFocus(a.mainView.GetFocusable())
+			if err != nil {
+				a.notify(i18n.T("Statistics not recomputed"), err)
+				return
+			}
+
+			for _, result := range a.worktree {
+				result.Apply(repoStats)
+			}
+			repoStats.ApplyAuthorMerges(previous.IdentityMergeMap())
+			a.repoStats = repoStats
+			a.mainView.SetData(repoStats, a.config)
+			a.notify(i18n.T("Statistics recomputed (%s)", elapsed(time.Since(start))), nil)
+		})
+	}()
 }
 
 func (a *App) onRescan() {
+	// Going back to setup, e.g. after aborting a scan, stops a running macro
+	a.afterScan = nil
 	a.pages.SwitchToPage("setup")
 	a.tview.SetFocus(a.setupView.Root())
 }
 
-// Run starts the application
+// Run starts the application. SIGINT and SIGTERM stop it like 'q' does,
+// so the terminal is restored; running git subprocesses are cancelled
+// before Run returns.
 func (a *App) Run() error {
-	return a.tview.Run()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	go func() {
+		select {
+		case <-signals:
+			a.tview.Stop()
+		case <-a.ctx.Done():
+		}
+	}()
+
+	err := a.tview.Run()
+	a.shutdown()
+	a.saveUIState()
+	return err
+}
+
+// saveUIState stores the working context of the repositories on screen
+func (a *App) saveUIState() {
+	if a.uiState == nil {
+		return
+	}
+	a.mainView.CaptureState(a.uiState)
+	a.uiState.Save()
+}
+
+// shutdown cancels running scans and waits briefly for them to exit
+func (a *App) shutdown() {
+	a.cancel()
+	a.scanner.Close(2 * time.Second)
+}
+
+// menuItem is an entry of the view menu; narrow terminals show its icon
+// instead of the name
+type menuItem struct {
+	name     string
+	icon     string
+	shortcut rune
 }
 
+var menuItems = []menuItem{
+	{"Leaderboard", "★", '1'},
+	{"Codebase", "▤", '2'},
+	{"Timeline", "∿", '3'},
+	{"Work Hours", "◷", '4'},
+	{"Top Files", "≡", '5'},
+	{"Hotspots", "▲", '6'},
+	{"Ownership", "⌂", '7'},
+	{"Pull Requests", "⇄", '8'},
+	{"Authors", "@", '9'},
+	{"Health", "♥", 0},
+	{"Highlights", "✦", 0},
+	{"Conventions", "✎", 0},
+	{"Commit Quality", "¶", 0},
+	{"Architecture", "◫", 0},
+	{"Coupling", "⚭", 0},
+	{"Commit Sizes", "▮", 0},
+	{"Backports", "↩", 0},
+	{"Issues", "✓", 0},
+	{"Debt Markers", "⚑", 0},
+	{"Code Age", "⌛", 0},
+	{"Licenses", "§", 0},
+	{"Refactoring", "↻", 0},
+	{"Offboarding", "⇥", 0},
+	{"Bus Factor", "⚠", 0},
+	{"Branching", "⎇", 0},
+	{"Upstream", "↑", 0},
+	{"Labels", "#", 0},
+	{"Languages", "λ", 0},
+	{"Top Movers", "⇅", 0},
+	{"Trends", "↗", 0},
+	{"Query", "?", 0},
+	{"Settings", "⚙", 0},
+	{"Notifications", "✉", 0},
+}
+
+// Terminals narrower than compactWidth collapse the menu to icons and switch
+// views to compact renderings
+const (
+	compactWidth     = 100
+	menuWidth        = 18
+	compactMenuWidth = 7
+)
+
 // MainView is the main statistics display view
 type MainView struct {
-	root      *tview.Flex
-	menuList  *tview.List
-	viewPages *tview.Pages
-	statusBar *tview.TextView
-	header    *tview.TextView
-	app       *tview.Application
-	onRescan  func()
-	onMerge   func(merges map[string]string)
+	pages       *tview.Pages // layout, with the command palette over it
+	root        *tview.Flex
+	content     *tview.Flex
+	menuList    *tview.List
+	viewPages   *tview.Pages
+	statusBar   *tview.TextView
+	header      *tview.TextView
+	app         *tview.Application
+	onRescan    func()
+	onMerge     func(merges map[string]string)
+	onMergeDirs func(merges map[string]string)
+	onExport    func() (string, error)
+	onSettings  func(views.Settings)
+	onChart     func(view string, c *chart.Chart)
 
 	// Views
 	leaderboardView *views.LeaderboardView
+	authorDetail    *views.AuthorDetailView
 	codebaseView    *views.CodebaseView
 	timelineView    *views.TimelineView
 	heatmapView     *views.HeatmapView
@@ -233,18 +445,55 @@ type MainView struct {
 	ownershipView   *views.OwnershipView
 	prView          *views.PullRequestsView
 	authorsView     *views.AuthorsView
-
+	healthView      *views.HealthView
+	conventionsView *views.ConventionsView
+	archView        *views.ArchitectureView
+	couplingView    *views.CouplingView
+	commitSizesView *views.CommitSizesView
+	backportsView   *views.BackportsView
+	issuesView      *views.IssuesView
+	debtView        *views.DebtView
+	codeAgeView     *views.CodeAgeView
+	licenseView     *views.LicenseView
+	refactorView    *views.RefactoringView
+	offboardView    *views.OffboardingView
+	qualityView     *views.QualityView
+	busFactorView   *views.BusFactorView
+	branchingView   *views.BranchingView
+	upstreamView    *views.UpstreamView
+	labelsView      *views.LabelsView
+	languagesView   *views.LanguagesView
+	moversView      *views.MoversView
+	highlightsView  *views.HighlightsView
+	trendsView      *views.TrendsView
+	queryView       *views.QueryView
+	settingsView    *views.SettingsView
+	notifyView      *views.NotificationsView
+	helpView        *tview.TextView
+	palette         *views.PaletteView
+
+	keys        *views.KeyMap
+	helpReturn  string // view the help page returns to
+	macros      []string
+	onMacro     func(name string)
+	paletteFrom tview.Primitive // focus before the palette opened
 	currentView string
 	repoStats   *stats.Repository
+	trends      []stats.Snapshot // recorded scans, for the Trends view
 	config      *config.Config
+	width       int // terminal columns the layout was last adapted to
+	toast       int // sequence number of the latest toast
 }
 
 // NewMainView creates the main statistics view
-func NewMainView(app *tview.Application, onRescan func(), onMerge func(map[string]string)) *MainView {
+func NewMainView(app *tview.Application, keys *views.KeyMap, onRescan func(), onMerge, onMergeDirs func(map[string]string), onExport func() (string, error)) *MainView {
 	m := &MainView{
-		app:      app,
-		onRescan: onRescan,
-		onMerge:  onMerge,
+		app:         app,
+		keys:        keys,
+		onRescan:    onRescan,
+		onMerge:     onMerge,
+		onMergeDirs: onMergeDirs,
+		onExport:    onExport,
 	}
 
 	m.setupLayout()
@@ -264,26 +513,11 @@ func (m *MainView) setupLayout() {
 		SetHighlightFullLine(true).
 		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
 
-	m.menuList.SetBorder(true).SetTitle(" Views ")
-
-	menuItems := []struct {
-		name     string
-		shortcut rune
-	}{
-		{"Leaderboard", '1'},
-		{"Codebase", '2'},
-		{"Timeline", '3'},
-		{"Work Hours", '4'},
-		{"Top Files", '5'},
-		{"Hotspots", '6'},
-		{"Ownership", '7'},
-		{"Pull Requests", '8'},
-		{"Authors", '9'},
-	}
+	m.menuList.SetBorder(true).SetTitle(" " + i18n.T("Views") + " ")
 
 	for _, item := range menuItems {
 		name := item.name
-		m.menuList.AddItem(item.name, "", item.shortcut, func() {
+		m.menuList.AddItem(i18n.T(item.name), "", item.shortcut, func() {
 			m.switchView(name)
 		})
 	}
@@ -294,14 +528,54 @@ func (m *MainView) setupLayout() {
 
 	// Create individual views
 	m.leaderboardView = views.NewLeaderboardView()
+	m.authorDetail = views.NewAuthorDetailView()
 	m.codebaseView = views.NewCodebaseView()
 	m.timelineView = views.NewTimelineView()
 	m.heatmapView = views.NewHeatmapView()
 	m.filesView = views.NewFilesView()
 	m.hotspotsView = views.NewHotspotsView()
-	m.ownershipView = views.NewOwnershipView()
+	m.ownershipView = views.NewOwnershipView(m.onMergeDirs)
 	m.prView = views.NewPullRequestsView()
-	m.authorsView = views.NewAuthorsView(m.onMerge)
+	m.authorsView = views.NewAuthorsView(m.onMerge, m.onExport)
+	focus := func(p tview.Primitive) { m.app.SetFocus(p) }
+	m.authorsView.SetFocusFunc(focus)
+	m.leaderboardView.SetFocusFunc(focus)
+	m.filesView.SetFocusFunc(focus)
+	m.hotspotsView.SetFocusFunc(focus)
+	m.ownershipView.SetFocusFunc(focus)
+	m.prView.SetFocusFunc(focus)
+	m.healthView = views.NewHealthView()
+	m.conventionsView = views.NewConventionsView()
+	m.archView = views.NewArchitectureView()
+	m.couplingView = views.NewCouplingView()
+	m.commitSizesView = views.NewCommitSizesView()
+	m.backportsView = views.NewBackportsView()
+	m.issuesView = views.NewIssuesView()
+	m.debtView = views.NewDebtView()
+	m.codeAgeView = views.NewCodeAgeView()
+	m.licenseView = views.NewLicenseView()
+	m.refactorView = views.NewRefactoringView()
+	m.offboardView = views.NewOffboardingView()
+	m.qualityView = views.NewQualityView()
+	m.busFactorView = views.NewBusFactorView()
+	m.branchingView = views.NewBranchingView()
+	m.upstreamView = views.NewUpstreamView()
+	m.labelsView = views.NewLabelsView()
+	m.languagesView = views.NewLanguagesView()
+	m.moversView = views.NewMoversView()
+	m.highlightsView = views.NewHighlightsView()
+	m.trendsView = views.NewTrendsView()
+	m.queryView = views.NewQueryView()
+	m.settingsView = views.NewSettingsView(func(s views.Settings) {
+		if m.onSettings != nil {
+			m.onSettings(s)
+		}
+	})
+	m.notifyView = views.NewNotificationsView()
+	m.helpView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	m.bindKeys()
 
 	// Add views to pages
 	m.viewPages.AddPage("Leaderboard", m.leaderboardView.Root(), true, true)
@@ -313,9 +587,35 @@ func (m *MainView) setupLayout() {
 	m.viewPages.AddPage("Ownership", m.ownershipView.Root(), true, false)
 	m.viewPages.AddPage("Pull Requests", m.prView.Root(), true, false)
 	m.viewPages.AddPage("Authors", m.authorsView.Root(), true, false)
+	m.viewPages.AddPage("Health", m.healthView.Root(), true, false)
+	m.viewPages.AddPage("Conventions", m.conventionsView.Root(), true, false)
+	m.viewPages.AddPage("Commit Quality", m.qualityView.Root(), true, false)
+	m.viewPages.AddPage("Architecture", m.archView.Root(), true, false)
+	m.viewPages.AddPage("Coupling", m.couplingView.Root(), true, false)
+	m.viewPages.AddPage("Commit Sizes", m.commitSizesView.Root(), true, false)
+	m.viewPages.AddPage("Backports", m.backportsView.Root(), true, false)
+	m.viewPages.AddPage("Issues", m.issuesView.Root(), true, false)
+	m.viewPages.AddPage("Debt Markers", m.debtView.Root(), true, false)
+	m.viewPages.AddPage("Code Age", m.codeAgeView.Root(), true, false)
+	m.viewPages.AddPage("Licenses", m.licenseView.Root(), true, false)
+	m.viewPages.AddPage("Refactoring", m.refactorView.Root(), true, false)
+	m.viewPages.AddPage("Offboarding", m.offboardView.Root(), true, false)
+	m.viewPages.AddPage("Bus Factor", m.busFactorView.Root(), true, false)
+	m.viewPages.AddPage("Branching", m.branchingView.Root(), true, false)
+	m.viewPages.AddPage("Upstream", m.upstreamView.Root(), true, false)
+	m.viewPages.AddPage("Labels", m.labelsView.Root(), true, false)
+	m.viewPages.AddPage("Languages", m.languagesView.Root(), true, false)
+	m.viewPages.AddPage("Top Movers", m.moversView.Root(), true, false)
+	m.viewPages.AddPage("Highlights", m.highlightsView.Root(), true, false)
+	m.viewPages.AddPage("Trends", m.trendsView.Root(), true, false)
+	m.viewPages.AddPage("Query", m.queryView.Root(), true, false)
+	m.viewPages.AddPage("Settings", m.settingsView.Root(), true, false)
+	m.viewPages.AddPage("Notifications", m.notifyView.Root(), true, false)
+	m.viewPages.AddPage("Help", m.helpView, true, false)
+	m.viewPages.AddPage("Author Drill-down", m.authorDetail.Root(), true, false)
 
 	m.currentView = "Leaderboard"
-	m.viewPages.SetTitle(" Leaderboard ")
+	m.viewPages.SetTitle(" " + i18n.T("Leaderboard") + " ")
 
 	// Create status bar
 	m.statusBar = tview.NewTextView().
@@ -325,79 +625,217 @@ func (m *MainView) setupLayout() {
 	m.updateStatusBar()
 
 	// Create content area (menu + views)
-	contentFlex := tview.NewFlex().
-		AddItem(m.menuList, 18, 0, true).
+	m.content = tview.NewFlex().
+		AddItem(m.menuList, menuWidth, 0, true).
 		AddItem(m.viewPages, 0, 1, false)
 
 	// Create main layout
 	m.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(m.header, 1, 0, false).
-		AddItem(contentFlex, 0, 1, true).
+		AddItem(m.content, 0, 1, true).
 		AddItem(m.statusBar, 1, 0, false)
 
 	// Set up input handling
 	m.root.SetInputCapture(m.handleInput)
-}
 
-func (m *MainView) handleInput(event *tcell.EventKey) *tcell.EventKey {
-	switch event.Key() {
-	case tcell.KeyTab, tcell.KeyBacktab:
-		m.toggleFocus()
-		return nil
-	case tcell.KeyEsc:
-		if m.app.GetFocus() != m.menuList {
-			m.app.SetFocus(m.menuList)
-			return nil
-		}
-	}
+	m.palette = views.NewPaletteView(m.hidePalette)
+	m.pages = tview.NewPages().
+		AddPage("main", m.root, true, true).
+		AddPage("palette", m.palette.Root(), true, false)
+}
 
-	switch event.Rune() {
-	case 'q', 'Q':
-		m.app.Stop()
-		return nil
-	case 'R':
+// bindKeys attaches the main view's handlers to the key map and hands it to
+// the views that bind or mention keys
+func (m *MainView) bindKeys() {
+	m.keys.Handle(views.ScopeGlobal, "focus", m.toggleFocus)
+	m.keys.Handle(views.ScopeGlobal, "menu", func() { m.app.SetFocus(m.menuList) })
+	m.keys.Handle(views.ScopeGlobal, "query", m.FocusQueryView)
+	m.keys.Handle(views.ScopeGlobal, "notifications", func() {
+		m.switchView("Notifications")
+		m.app.SetFocus(m.notifyView.GetFocusable())
+	})
+	m.keys.Handle(views.ScopeGlobal, "help", m.showHelp)
+	m.keys.Handle(views.ScopeGlobal, "palette", m.showPalette)
+	m.keys.Handle(views.ScopeGlobal, "chart", m.exportChart)
+	m.keys.Handle(views.ScopeGlobal, "rescan", func() {
 		if m.onRescan != nil {
 			m.onRescan()
 		}
-		return nil
-	case 's', 'S':
-		m.cycleSortColumn()
-		return nil
-	case 'r':
-		m.reverseSortOrder()
-		return nil
-	case 't', 'T':
-		if m.currentView == "Pull Requests" {
-			m.prView.ToggleView()
-			m.prView.Refresh(m.repoStats)
+	})
+	m.keys.Handle(views.ScopeGlobal, "quit", m.app.Stop)
+
+	for name := range m.sortableViews() {
+		m.keys.Handle(name, "sort", m.cycleSortColumn)
+		m.keys.Handle(name, "reverse", m.reverseSortOrder)
+	}
+	for _, name := range []string{"Leaderboard", "Codebase", "Ownership"} {
+		m.keys.Handle(name, "numbers", m.toggleNumberFormat)
+	}
+	m.keys.Handle("Leaderboard", "open", m.openAuthorDetail)
+	m.keys.Handle("Author Drill-down", "back", func() {
+		m.switchView("Leaderboard")
+		m.app.SetFocus(m.leaderboardView.GetFocusable())
+	})
+	m.keys.Handle("Health", "open", func() {
+		if name := m.healthView.SelectedView(); name != "" {
+			m.ShowView(name)
+			m.app.SetFocus(m.menuList)
+			m.toggleFocus()
 		}
-		return nil
-	case '?':
-		m.showHelp()
-		return nil
+	})
+	m.keys.Handle("Pull Requests", "toggle", func() {
+		m.prView.ToggleView()
+		m.prView.Refresh(m.repoStats)
+	})
+	m.keys.Handle("Timeline", "period", func() {
+		m.timelineView.CyclePeriod()
+		m.timelineView.Refresh(m.repoStats)
+	})
+	m.keys.Handle("Timeline", "expand", m.timelineView.ToggleExpanded)
+	m.keys.Handle("Timeline", "shorter", func() { m.adjustRollingWindow(-1) })
+	m.keys.Handle("Timeline", "longer", func() { m.adjustRollingWindow(1) })
+	m.keys.Handle("Work Hours", "toggle", func() {
+		m.heatmapView.ToggleMatrix()
+		m.heatmapView.Refresh(m.repoStats, m.config.Timezone)
+	})
+
+	m.leaderboardView.SetKeyMap(m.keys)
+	m.authorDetail.SetKeyMap(m.keys)
+	m.timelineView.SetKeyMap(m.keys)
+	m.heatmapView.SetKeyMap(m.keys)
+	m.filesView.SetKeyMap(m.keys)
+	m.hotspotsView.SetKeyMap(m.keys)
+	m.ownershipView.SetKeyMap(m.keys)
+	m.prView.SetKeyMap(m.keys)
+	m.authorsView.SetKeyMap(m.keys)
+	m.busFactorView.SetKeyMap(m.keys)
+	m.healthView.SetKeyMap(m.keys)
+}
+
+func (m *MainView) handleInput(event *tcell.EventKey) *tcell.EventKey {
+	// The author merge dialogs handle their own keys, including Tab and Esc
+	if m.currentView == "Authors" && m.authorsView.HasDialog() {
+		return event
+	}
+
+	// The filter bars of the tables take every key while typed into,
+	// including Esc and Enter
+	if m.filtering() {
+		return event
+	}
+
+	// Keys typed into the query bar or a setting are text, not shortcuts
+	if event.Key() == tcell.KeyRune && (m.app.GetFocus() == m.queryView.GetFocusable() || m.settingsView.Editing()) {
+		return event
 	}
 
+	// View bindings marked Focused act on the view's rows, so they are
+	// left to the menu while it has focus
+	focused := m.app.GetFocus() != m.menuList
+	if m.keys.Dispatch(m.currentView, focused, event) {
+		return nil
+	}
 	return event
 }
 
+// filtering reports whether the filter bar of the current view has focus
+func (m *MainView) filtering() bool {
+	switch m.currentView {
+	case "Leaderboard":
+		return m.leaderboardView.Filtering()
+	case "Top Files":
+		return m.filesView.Filtering()
+	case "Hotspots":
+		return m.hotspotsView.Filtering()
+	case "Ownership":
+		return m.ownershipView.Filtering()
+	case "Pull Requests":
+		return m.prView.Filtering()
+	}
+	return false
+}
+
 func (m *MainView) toggleFocus() {
 	if m.app.GetFocus() == m.menuList {
 		// Focus the active view's focusable component
 		switch m.currentView {
 		case "Leaderboard":
 			m.app.SetFocus(m.leaderboardView.GetFocusable())
+		case "Author Drill-down":
+			m.app.SetFocus(m.authorDetail.GetFocusable())
 		case "Top Files":
 			m.app.SetFocus(m.filesView.GetFocusable())
 		case "Hotspots":
 			m.app.SetFocus(m.hotspotsView.GetFocusable())
+		case "Timeline":
+			m.app.SetFocus(m.timelineView.GetFocusable())
 		case "Ownership":
 			m.app.SetFocus(m.ownershipView.GetFocusable())
 		case "Pull Requests":
 			m.app.SetFocus(m.prView.GetFocusable())
 		case "Authors":
 			m.app.SetFocus(m.authorsView.GetFocusable())
+		case "Conventions":
+			m.app.SetFocus(m.conventionsView.GetFocusable())
+		case "Commit Quality":
+			m.app.SetFocus(m.qualityView.GetFocusable())
+		case "Architecture":
+			m.app.SetFocus(m.archView.GetFocusable())
+		case "Coupling":
+			m.app.SetFocus(m.couplingView.GetFocusable())
+		case "Commit Sizes":
+			m.app.SetFocus(m.commitSizesView.GetFocusable())
+		case "Backports":
+			m.app.SetFocus(m.backportsView.GetFocusable())
+		case "Issues":
+			m.app.SetFocus(m.issuesView.GetFocusable())
+		case "Debt Markers":
+			m.app.SetFocus(m.debtView.GetFocusable())
+		case "Code Age":
+			m.app.SetFocus(m.codeAgeView.GetFocusable())
+		case "Licenses":
+			m.app.SetFocus(m.licenseView.GetFocusable())
+		case "Refactoring":
+			m.app.SetFocus(m.refactorView.GetFocusable())
+		case "Offboarding":
+			m.app.SetFocus(m.offboardView.GetFocusable())
+		case "Bus Factor":
+			m.app.SetFocus(m.busFactorView.GetFocusable())
+		case "Health":
+			m.app.SetFocus(m.healthView.GetFocusable())
+		case "Branching":
+			m.app.SetFocus(m.branchingView.GetFocusable())
+		case "Upstream":
+			m.app.SetFocus(m.upstreamView.GetFocusable())
+		case "Labels":
+			m.app.SetFocus(m.labelsView.GetFocusable())
+		case "Languages":
+			m.app.SetFocus(m.languagesView.GetFocusable())
+		case "Top Movers":
+			m.app.SetFocus(m.moversView.GetFocusable())
+		case "Highlights":
+			m.app.SetFocus(m.highlightsView.GetFocusable())
+		case "Trends":
+			m.app.SetFocus(m.trendsView.GetFocusable())
+		case "Query":
+			m.app.SetFocus(m.queryView.GetFocusable())
+		case "Settings":
+			m.app.SetFocus(m.settingsView.GetFocusable())
+		case "Notifications":
+			m.app.SetFocus(m.notifyView.GetFocusable())
+		case "Help":
+			m.app.SetFocus(m.helpView)
 		}
+	} else if m.currentView == "Refactoring" && m.app.GetFocus() == m.refactorView.GetFocusable() {
+		// Author table -> directory table -> menu
+		m.app.SetFocus(m.refactorView.GetSecondaryFocusable())
+	} else if m.currentView == "Timeline" && m.app.GetFocus() == m.timelineView.GetFocusable() {
+		// Chart -> statistics -> menu
+		m.app.SetFocus(m.timelineView.GetSecondaryFocusable())
+	} else if m.currentView == "Query" && m.app.GetFocus() == m.queryView.GetFocusable() {
+		// Query input -> results -> menu
+		m.app.SetFocus(m.queryView.GetSecondaryFocusable())
 	} else {
 		m.app.SetFocus(m.menuList)
 	}
@@ -443,34 +881,83 @@ func (m *MainView) reverseSortOrder() {
 	}
 }
 
+// showHelp lists the keys of the current view, or returns to the view when
+// the help is already shown
 func (m *MainView) showHelp() {
-	// Could show a modal with help text
+	if m.currentView == "Help" {
+		m.switchView(m.helpReturn)
+		return
+	}
+	m.helpReturn = m.currentView
+	m.helpView.SetText(m.keys.Help(m.currentView)).ScrollToBeginning()
+	m.switchView("Help")
+}
+
+// showPalette opens the command palette with the macros and the actions
+// of the current view
+func (m *MainView) showPalette() {
+	var commands []views.Command
+	for _, name := range m.macros {
+		commands = append(commands, views.Command{
+			Name:        name,
+			Description: i18n.T("Macro"),
+			Run:         func() { m.onMacro(name) },
+		})
+	}
+	for _, b := range m.keys.Commands(m.currentView) {
+		if b.Action == "palette" {
+			continue
+		}
+		commands = append(commands, views.Command{
+			Name:        b.Name(),
+			Description: i18n.T(b.Description),
+			Run:         b.Run,
+		})
+	}
+
+	m.paletteFrom = m.app.GetFocus()
+	m.palette.Open(commands)
+	m.pages.ShowPage("palette")
+	m.app.SetFocus(m.palette.GetFocusable())
+}
+
+func (m *MainView) hidePalette() {
+	m.pages.HidePage("palette")
+	m.app.SetFocus(m.paletteFrom)
+}
+
+// SetMacros sets the macros the command palette offers and runs with run
+func (m *MainView) SetMacros(names []string, run func(name string)) {
+	m.macros = names
+	m.onMacro = run
+}
+
+// ShowView switches to a view and selects it in the menu
+func (m *MainView) ShowView(name string) {
+	for i, item := range menuItems {
+		if item.name == name {
+			m.menuList.SetCurrentItem(i)
+			m.switchView(name)
+		}
+	}
+}
+
+// RunAction runs a key binding action by name, as if its key was pressed
+// in the current view, and reports whether the view has it
+func (m *MainView) RunAction(name string) bool {
+	return m.keys.Run(m.currentView, name)
 }
 
 func (m *MainView) switchView(name string) {
 	m.currentView = name
 	m.viewPages.SwitchToPage(name)
-	m.viewPages.SetTitle(" " + name + " ")
+	m.viewPages.SetTitle(" " + i18n.T(name) + " ")
 	m.updateStatusBar()
 }
 
 // updateStatusBar shows context-sensitive controls
 func (m *MainView) updateStatusBar() {
-	baseControls := "[yellow]Tab[-] Focus  [yellow]↑↓[-] Navigate  [yellow]R[-] Rescan  [yellow]q[-] Quit"
-
-	var viewControls string
-	switch m.currentView {
-	case "Leaderboard", "Top Files", "Hotspots", "Ownership":
-		viewControls = "[yellow]s[-] Sort  [yellow]r[-] Reverse  "
-	case "Pull Requests":
-		viewControls = "[yellow]t[-] Toggle View  [yellow]s[-] Sort  [yellow]r[-] Reverse  "
-	case "Authors":
-		viewControls = "[yellow]Space[-] Select  [yellow]m[-] Merge  [yellow]a[-] Apply  [yellow]c[-] Clear  "
-	default:
-		viewControls = ""
-	}
-
-	m.statusBar.SetText(viewControls + baseControls)
+	m.statusBar.SetText(m.keys.Hints(m.currentView))
 }
 
 // SetData updates all views with repository statistics
@@ -480,22 +967,285 @@ func (m *MainView) SetData(repoStats *stats.Repository, cfg *config.Config) {
 
 	// Update header
 	repoName := filepath.Base(repoStats.Path)
-	dateRange := fmt.Sprintf("%s to %s",
-		cfg.Since.Format("2006-01-02"),
-		cfg.Until.Format("2006-01-02"))
-	m.header.SetText(fmt.Sprintf("[::b]GitStat[-:-:-] - %s (%s) - %d commits by %d authors",
-		repoName, dateRange, repoStats.TotalCommits, repoStats.TotalAuthors))
+	dateRange := i18n.T("%s to %s", i18n.Date(cfg.Since), i18n.Date(cfg.Until))
+	excluded := ""
+	if n := len(cfg.ExcludePaths) + len(cfg.ExcludeAuthors); n > 0 {
+		excluded = i18n.T(" - %d excluded", n)
+	}
+	if n := len(cfg.IncludeGlobs) + len(cfg.ExcludeGlobs); n > 0 {
+		excluded += i18n.T(" - %d path globs", n)
+	}
+	m.header.SetText("[::b]GitStat[-:-:-] - " + i18n.T("%s (%s) - %d commits by %d authors%s",
+		repoName, dateRange, repoStats.TotalCommits, repoStats.TotalAuthors, excluded))
 
 	// Refresh all views
+	views.SetAbbreviateNumbers(cfg.AbbreviateNumbers)
+	views.SetTimeFormat24h(cfg.TimeFormat24h)
+	m.timelineView.SetRollingWindow(cfg.RollingWindow)
+	m.timelineView.SetHolidays(cfg.Holidays)
+	m.leaderboardView.SetSessionLimits(cfg.SessionMaxGap, cfg.SessionStart)
 	m.leaderboardView.Refresh(repoStats)
+	m.authorDetail.Refresh(repoStats)
 	m.codebaseView.Refresh(repoStats)
 	m.timelineView.Refresh(repoStats)
+	m.heatmapView.SetUnhealthyHours(stats.UnhealthyHours{
+		After:    cfg.UnhealthyAfter,
+		Before:   cfg.UnhealthyBefore,
+		Weekends: cfg.UnhealthyWeekends,
+	})
 	m.heatmapView.Refresh(repoStats, cfg.Timezone)
 	m.filesView.Refresh(repoStats)
 	m.hotspotsView.Refresh(repoStats)
+	m.ownershipView.SetTurnoverThreshold(cfg.TurnoverShiftThreshold)
 	m.ownershipView.Refresh(repoStats)
 	m.prView.Refresh(repoStats)
+	m.authorsView.SetSessionLimits(cfg.SessionMaxGap, cfg.SessionStart)
 	m.authorsView.Refresh(repoStats)
+	m.healthView.Refresh(repoStats, cfg.HealthWeights)
+	m.conventionsView.Refresh(repoStats)
+	m.qualityView.Refresh(repoStats)
+	m.archView.Refresh(repoStats, cfg.CouplingMinShared, cfg.CouplingThreshold)
+	m.couplingView.Refresh(repoStats, cfg.CouplingMinShared, cfg.CouplingThreshold)
+	m.commitSizesView.Refresh(repoStats, cfg.CommitSizeThresholds)
+	m.backportsView.Refresh(repoStats)
+	m.issuesView.Refresh(repoStats)
+	m.debtView.Refresh(repoStats, cfg.DebtMarkers)
+	m.codeAgeView.Refresh(repoStats, cfg.CodeAgeSample)
+	m.licenseView.Refresh(repoStats)
+	m.refactorView.Refresh(repoStats)
+	m.offboardView.Refresh(repoStats, cfg.OffboardingInactiveWeeks, cfg.OffboardingMinShare)
+	m.busFactorView.Refresh(repoStats)
+	m.branchingView.Refresh(repoStats)
+	m.upstreamView.Refresh(repoStats)
+	m.labelsView.Refresh(repoStats)
+	m.languagesView.Refresh(repoStats)
+	m.moversView.Refresh(repoStats)
+	m.highlightsView.Refresh(repoStats)
+	m.queryView.Refresh(repoStats)
+	m.settingsView.Refresh(views.Settings{
+		Timezone:      cfg.Timezone,
+		RollingWindow: cfg.RollingWindow,
+		ChurnHalfLife: cfg.ChurnHalfLife,
+	})
+}
+
+// SetTrends updates the Trends view with the recorded scans, oldest first
+func (m *MainView) SetTrends(snapshots []stats.Snapshot) {
+	m.trends = snapshots
+	m.trendsView.Refresh(snapshots)
+}
+
+// Resize adapts the layout to the terminal width: below compactWidth the
+// menu collapses to icons, and views with bars or grids drawn into text are
+// re-rendered to fit the remaining space
+func (m *MainView) Resize(width int) {
+	if width == m.width {
+		return
+	}
+	m.width = width
+
+	compact := width < compactWidth
+	menu, title := menuWidth, " "+i18n.T("Views")+" "
+	if compact {
+		menu, title = compactMenuWidth, ""
+	}
+	for i, item := range menuItems {
+		text := i18n.T(item.name)
+		if compact {
+			text = item.icon
+		}
+		m.menuList.SetItemText(i, text, "")
+	}
+	m.menuList.SetTitle(title)
+	m.content.ResizeItem(m.menuList, menu, 0)
+
+	// Everything right of the menu, inside the view border
+	layout := views.Layout{Width: width - menu - 2, Compact: compact}
+	m.timelineView.SetLayout(layout)
+	m.heatmapView.SetLayout(layout)
+	m.codebaseView.SetLayout(layout)
+	m.commitSizesView.SetLayout(layout)
+	m.ownershipView.SetLayout(layout)
+	m.trendsView.SetLayout(layout)
+	m.codeAgeView.SetLayout(layout)
+	m.authorDetail.SetLayout(layout)
+	m.healthView.SetLayout(layout)
+	if m.trends != nil {
+		m.trendsView.Refresh(m.trends)
+	}
+
+	if m.repoStats == nil || m.config == nil {
+		return
+	}
+	m.codebaseView.Refresh(m.repoStats)
+	m.timelineView.Refresh(m.repoStats)
+	m.heatmapView.Refresh(m.repoStats, m.config.Timezone)
+	m.commitSizesView.Refresh(m.repoStats, m.config.CommitSizeThresholds)
+	m.codeAgeView.Refresh(m.repoStats, m.config.CodeAgeSample)
+	m.authorDetail.Refresh(m.repoStats)
+	m.healthView.Refresh(m.repoStats, m.config.HealthWeights)
+}
+
+// Notify shows a notification as a toast over the status bar and adds it to
+// the notification log. It returns the toast's sequence number for HideToast.
+func (m *MainView) Notify(n views.Notification) int {
+	m.notifyView.Add(n)
+	m.toast++
+
+	style := "[black:green]"
+	if n.Failed {
+		style = "[white:red]"
+	}
+	m.statusBar.SetText(fmt.Sprintf("%s %s [-:-]", style, tview.Escape(n.Message)))
+	return m.toast
+}
+
+// HideToast restores the status bar unless a newer toast replaced seq
+func (m *MainView) HideToast(seq int) {
+	if seq == m.toast {
+		m.updateStatusBar()
+	}
+}
+
+// toggleNumberFormat switches line counts between abbreviated and exact
+// values in the views showing them
+func (m *MainView) toggleNumberFormat() {
+	if m.repoStats == nil || m.config == nil {
+		return
+	}
+	m.config.AbbreviateNumbers = !m.config.AbbreviateNumbers
+	views.SetAbbreviateNumbers(m.config.AbbreviateNumbers)
+	m.codebaseView.Refresh(m.repoStats)
+	m.leaderboardView.Refresh(m.repoStats)
+	m.ownershipView.Refresh(m.repoStats)
+	m.offboardView.Refresh(m.repoStats, m.config.OffboardingInactiveWeeks, m.config.OffboardingMinShare)
+}
+
+// RefreshWorktreeViews redraws the views fed by the background worktree
+// passes: codebase size, the complexity in the hotspot risk, license
+// headers, debt markers, code age and the GitHub reviews of pull requests
+func (m *MainView) RefreshWorktreeViews() {
+	if m.repoStats == nil || m.config == nil {
+		return
+	}
+	m.codebaseView.Refresh(m.repoStats)
+	m.hotspotsView.Refresh(m.repoStats)
+	m.licenseView.Refresh(m.repoStats)
+	m.debtView.Refresh(m.repoStats, m.config.DebtMarkers)
+	m.codeAgeView.Refresh(m.repoStats, m.config.CodeAgeSample)
+	m.prView.Refresh(m.repoStats)
+}
+
+// SetSettingsFunc sets the callback taking the settings applied in the
+// Settings view
+func (m *MainView) SetSettingsFunc(apply func(views.Settings)) {
+	m.onSettings = apply
+}
+
+// SetChartFunc sets the callback writing the chart of the current view; it
+// gets a nil chart for views without one
+func (m *MainView) SetChartFunc(export func(view string, c *chart.Chart)) {
+	m.onChart = export
+}
+
+// exportChart hands the chart of the current view to the chart callback
+func (m *MainView) exportChart() {
+	charters := map[string]views.Charter{
+		"Leaderboard": m.leaderboardView,
+		"Timeline":    m.timelineView,
+		"Work Hours":  m.heatmapView,
+	}
+	var c *chart.Chart
+	if charter, ok := charters[m.currentView]; ok {
+		c = charter.Chart()
+	}
+	if m.onChart != nil {
+		m.onChart(m.currentView, c)
+	}
+}
+
+// SetRollingWindow redraws the Timeline with a rolling average over days
+func (m *MainView) SetRollingWindow(days int) {
+	m.timelineView.SetRollingWindow(days)
+	if m.repoStats != nil {
+		m.timelineView.Refresh(m.repoStats)
+	}
+}
+
+// adjustRollingWindow lengthens the Timeline's rolling average by delta
+// days, keeping at least one, and shows the new window in the Settings view
+func (m *MainView) adjustRollingWindow(delta int) {
+	if m.config == nil {
+		return
+	}
+	m.config.RollingWindow = max(1, m.config.RollingWindow+delta)
+	m.SetRollingWindow(m.config.RollingWindow)
+	m.settingsView.Refresh(views.Settings{
+		Timezone:      m.config.Timezone,
+		RollingWindow: m.config.RollingWindow,
+		ChurnHalfLife: m.config.ChurnHalfLife,
+	})
+}
+
+// SetBatch sets the actions applied to rows marked in the Top Files,
+// Ownership and Authors views
+func (m *MainView) SetBatch(batch views.Batch) {
+	m.filesView.SetBatch(batch)
+	m.ownershipView.SetBatch(batch)
+	m.authorsView.SetBatch(batch)
+}
+
+// SetQueueUpdate sets how views animating on a timer schedule their redraws
+func (m *MainView) SetQueueUpdate(queueUpdate func(func())) {
+	m.ownershipView.SetQueueUpdate(queueUpdate)
+}
+
+// sortableView is a view whose table sort is kept in the UI state
+type sortableView interface {
+	SortState() (column int, ascending bool)
+	SetSortState(column int, ascending bool)
+}
+
+func (m *MainView) sortableViews() map[string]sortableView {
+	return map[string]sortableView{
+		"Leaderboard":   m.leaderboardView,
+		"Top Files":     m.filesView,
+		"Hotspots":      m.hotspotsView,
+		"Ownership":     m.ownershipView,
+		"Pull Requests": m.prView,
+	}
+}
+
+// RestoreState applies a saved working context; sorts and the query take
+// effect with the next SetData
+func (m *MainView) RestoreState(s *config.UIState) {
+	for name, view := range m.sortableViews() {
+		if sort, ok := s.Sorts[name]; ok {
+			view.SetSortState(sort.Column, sort.Ascending)
+		}
+	}
+	m.queryView.SetQuery(s.Query)
+	m.ShowView(s.View)
+}
+
+// CaptureState records the current working context in s
+func (m *MainView) CaptureState(s *config.UIState) {
+	s.View = m.currentView
+	if s.View == "Help" {
+		s.View = m.helpReturn
+	}
+	if s.View == "Author Drill-down" {
+		s.View = "Leaderboard"
+	}
+	for name, view := range m.sortableViews() {
+		// The PR list has its own columns; its sort is not kept
+		if name == "Pull Requests" && m.prView.ShowsPRList() {
+			continue
+		}
+		column, ascending := view.SortState()
+		s.Sorts[name] = config.SortState{Column: column, Ascending: ascending}
+	}
+	s.Query = m.queryView.Query()
 }
 
 // RefreshAllViews refreshes all views after merge operations
@@ -508,7 +1258,7 @@ func (m *MainView) RefreshAllViews() {
 
 // Root returns the root primitive
 func (m *MainView) Root() tview.Primitive {
-	return m.root
+	return m.pages
 }
 
 // GetFocusable returns the focusable component
@@ -516,6 +1266,30 @@ func (m *MainView) GetFocusable() tview.Primitive {
 	return m.menuList
 }
 
+// openAuthorDetail drills down into the author of the selected Leaderboard
+// row
+func (m *MainView) openAuthorDetail() {
+	author := m.leaderboardView.SelectedAuthor()
+	if author == nil || m.repoStats == nil {
+		return
+	}
+	m.authorDetail.Show(m.repoStats, author.Email)
+	m.switchView("Author Drill-down")
+	m.app.SetFocus(m.authorDetail.GetFocusable())
+}
+
+// FocusOwnershipView sets focus on the Ownership view
+func (m *MainView) FocusOwnershipView() {
+	m.switchView("Ownership")
+	m.app.SetFocus(m.ownershipView.GetFocusable())
+}
+
+// FocusQueryView opens the query bar
+func (m *MainView) FocusQueryView() {
+	m.switchView("Query")
+	m.app.SetFocus(m.queryView.GetFocusable())
+}
+
 // FocusAuthorsView sets focus on the Authors view
 func (m *MainView) FocusAuthorsView() {
 	m.switchView("Authors")
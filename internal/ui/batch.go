@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/ui/views"
+)
+
+// Row kinds as named in messages, translated when used
+var kindNames = map[string]string{
+	views.KindFiles:   "files",
+	views.KindDirs:    "directories",
+	views.KindAuthors: "authors",
+}
+
+// Watched reports whether item is on the watchlist of the scanned
+// repositories
+func (a *App) Watched(kind, item string) bool {
+	return a.uiState != nil && a.uiState.Watched(kind, item)
+}
+
+// Watch adds items to the watchlist, or removes them if all are on it
+func (a *App) Watch(kind string, items []string) string {
+	if a.uiState == nil {
+		a.uiState = config.NewUIState()
+	}
+	if a.uiState.ToggleWatched(kind, items) {
+		return i18n.T("%d %s added to the watchlist", len(items), i18n.T(kindNames[kind]))
+	}
+	return i18n.T("%d %s removed from the watchlist", len(items), i18n.T(kindNames[kind]))
+}
+
+// Exclude leaves items out of all statistics, recomputing them from the
+// commits of the last scan. Excluding a merged author or directory also
+// excludes its aliases.
+func (a *App) Exclude(kind string, items []string) {
+	switch kind {
+	case views.KindAuthors:
+		a.config.ExcludeAuthors = append(a.config.ExcludeAuthors, items...)
+		for _, m := range a.repoStats.IdentityMerges {
+			for _, email := range items {
+				if m.PrimaryEmail == email {
+					a.config.ExcludeAuthors = append(a.config.ExcludeAuthors, m.AliasEmail)
+				}
+			}
+		}
+	case views.KindDirs:
+		for _, path := range items {
+			a.config.ExcludePaths = append(a.config.ExcludePaths, path)
+			if dir, ok := a.repoStats.DirStats[path]; ok {
+				a.config.ExcludePaths = append(a.config.ExcludePaths, dir.Merged...)
+			}
+		}
+	default:
+		a.config.ExcludePaths = append(a.config.ExcludePaths, items...)
+	}
+	a.recompute()
+}
+
+// Export writes the statistics of items to a CSV file in the working
+// directory
+func (a *App) Export(kind string, items []string) (string, error) {
+	var rows [][]string
+	switch kind {
+	case views.KindFiles:
+		rows = append(rows, []string{"path", "changes", "touches", "authors", "additions", "deletions"})
+		for _, path := range items {
+			if f, ok := a.repoStats.FileStats[path]; ok {
+				rows = append(rows, []string{f.Path, strconv.Itoa(f.TotalChanges), strconv.Itoa(f.TouchCount),
+					strconv.Itoa(len(f.Authors)), strconv.Itoa(f.Additions), strconv.Itoa(f.Deletions)})
+			}
+		}
+	case views.KindDirs:
+		rows = append(rows, []string{"path", "changes", "touches", "authors"})
+		for _, path := range items {
+			if d := a.repoStats.GetDir(path); d != nil {
+				rows = append(rows, []string{d.Path, strconv.Itoa(d.TotalChanges), strconv.Itoa(d.TouchCount),
+					strconv.Itoa(len(d.Authors))})
+			}
+		}
+	case views.KindAuthors:
+		rows = append(rows, []string{"name", "email", "commits", "additions", "deletions", "files"})
+		for _, email := range items {
+			if au, ok := a.repoStats.Authors[email]; ok {
+				rows = append(rows, []string{au.Name, au.Email, strconv.Itoa(au.Commits),
+					strconv.Itoa(au.Additions), strconv.Itoa(au.Deletions), strconv.Itoa(len(au.FilesTouched))})
+			}
+		}
+	}
+
+	path := fmt.Sprintf("gitstat-%s-%s.csv", kind, time.Now().Format("20060102-150405"))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	w := csv.NewWriter(file)
+	w.Comma = i18n.CSVSeparator()
+	w.WriteAll(append(csvHeader(a.repoStats), rows...))
+	if err := w.Error(); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return i18n.T("Exported %d %s to %s", len(rows)-1, i18n.T(kindNames[kind]), path), nil
+}
+
+// Open suspends the UI and opens the files still present in a scanned
+// worktree in $VISUAL or $EDITOR (vi if neither is set)
+func (a *App) Open(files []string) (string, error) {
+	repos := a.config.RepoPaths
+	if len(repos) == 0 && a.config.RepoPath != "" {
+		repos = []string{a.config.RepoPath}
+	}
+
+	var paths []string
+	for _, file := range files {
+		for _, repoPath := range repos {
+			path := filepath.Join(repoPath, file)
+			if _, err := os.Stat(path); err == nil {
+				paths = append(paths, path)
+				break
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return "", errors.New(i18n.T("none of the files exist in the worktree"))
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The editor may carry arguments, e.g. "code --wait"
+	args := strings.Fields(editor)
+
+	var err error
+	a.tview.Suspend(func() {
+		cmd := exec.Command(args[0], append(args[1:], paths...)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		err = cmd.Run()
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", args[0], err)
+	}
+	return i18n.T("Opened %d files in %s", len(paths), args[0]), nil
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/chart"
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Formats every chart is written in
+var chartFormats = []string{"svg", "png"}
+
+// chartPath names a chart file of a view in the working directory by the
+// last year of the scanned range, e.g. gitstat-timeline-2024.png
+func chartPath(view string, until time.Time, format string) string {
+	slug := strings.ToLower(strings.ReplaceAll(view, " ", "-"))
+	return fmt.Sprintf("gitstat-%s-%d.%s", slug, until.Year(), format)
+}
+
+// exportChart writes the chart of a view as SVG and PNG, titled with the
+// repository and the scanned range
+func (a *App) exportChart(view string, c *chart.Chart) {
+	if c == nil {
+		a.notify(i18n.T("No chart to export"), fmt.Errorf("the %s view has no chart", view))
+		return
+	}
+	c.Title = fmt.Sprintf("%s, %s to %s", c.Title,
+		a.config.Since.Format("2006-01-02"), a.config.Until.Format("2006-01-02"))
+
+	var written []string
+	for _, format := range chartFormats {
+		path := chartPath(view, a.config.Until, format)
+		if err := c.Save(path); err != nil {
+			a.notify(i18n.T("Chart export failed"), err)
+			return
+		}
+		written = append(written, path)
+	}
+	a.notify(i18n.T("Wrote %s", strings.Join(written, ", ")), nil)
+}
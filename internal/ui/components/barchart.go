@@ -0,0 +1,303 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Widest bar the chart zooms in to, in columns
+const maxBarWidth = 8
+
+// Background of shaded bars, e.g. days off
+var shadeColor = tcell.Color236
+
+// Series is a line drawn over the bars, one value per bar
+type Series struct {
+	Name   string
+	Values []int
+	Color  tcell.Color
+}
+
+// BarChart draws one vertical bar per label over its whole height. Left and
+// right move the highlighted bar, scrolling when it leaves the screen; up
+// and down zoom in and out by widening and narrowing the bars. The top row
+// shows the highlighted bar's label and value. Overlaid series are drawn
+// as lines across dimmed bars, with their values in the top row. Shaded
+// bars get a darker background and a note next to the value.
+type BarChart struct {
+	*tview.Box
+
+	labels []string
+	values []int
+	colors map[int]tcell.Color // bars not drawn in the default color
+	series []Series            // lines over the bars
+	shaded map[int]string      // notes of the shaded bars by index
+	unit   string              // after the value in the top row, e.g. "commits"
+	max    int
+
+	width  int  // columns per bar
+	fit    bool // pick the width that shows every bar on the next draw
+	cursor int  // highlighted bar
+	offset int  // first bar drawn
+}
+
+// NewBarChart creates an empty bar chart
+func NewBarChart() *BarChart {
+	return &BarChart{Box: tview.NewBox(), width: 1, fit: true}
+}
+
+// SetData sets the bars. New data highlights the last bar and zooms to show
+// them all, as far as the screen allows; redrawing the same labels keeps the
+// highlighted bar and the zoom.
+func (c *BarChart) SetData(labels []string, values []int) *BarChart {
+	same := len(labels) == len(c.labels) && len(labels) > 0 && labels[0] == c.labels[0]
+	c.labels, c.values = labels, values
+	c.max = 0
+	for _, v := range values {
+		c.max = max(c.max, v)
+	}
+	if !same {
+		c.cursor = len(labels) - 1
+		c.offset = 0
+		c.fit = true
+	}
+	return c
+}
+
+// SetBarColors sets the color of individual bars by index
+func (c *BarChart) SetBarColors(colors map[int]tcell.Color) *BarChart {
+	c.colors = colors
+	return c
+}
+
+// SetShading shades bars by index, with a note shown while one is
+// highlighted, e.g. "Saturday"
+func (c *BarChart) SetShading(shaded map[int]string) *BarChart {
+	c.shaded = shaded
+	return c
+}
+
+// SetOverlays sets the series drawn over the bars, replacing any before
+func (c *BarChart) SetOverlays(series []Series) *BarChart {
+	c.series = series
+	return c
+}
+
+// SetUnit sets the unit shown after the highlighted value
+func (c *BarChart) SetUnit(unit string) *BarChart {
+	c.unit = unit
+	return c
+}
+
+// Highlighted returns the index of the highlighted bar, or -1 without data
+func (c *BarChart) Highlighted() int {
+	if len(c.labels) == 0 {
+		return -1
+	}
+	return c.cursor
+}
+
+// step returns the columns a bar takes with the gap after it
+func (c *BarChart) step() int {
+	return barStep(c.width)
+}
+
+// barStep returns the columns a bar of width takes: bars of 3 columns and
+// more are followed by a gap
+func barStep(width int) int {
+	if width >= 3 {
+		return width + 1
+	}
+	return width
+}
+
+// plot returns the area of the bars: left of the y-axis labels, the first
+// and last row, and the number of bars that fit
+func (c *BarChart) plot() (x, top, bottom, visible int) {
+	x, y, width, height := c.GetInnerRect()
+	margin := len(strconv.Itoa(c.max)) + 1
+	top, bottom = y+1, y+height-2 // top row: highlighted value; bottom row: labels
+	return x + margin, top, bottom, max(1, (width-margin)/c.step())
+}
+
+// scroll keeps the highlighted bar on screen
+func (c *BarChart) scroll(visible int) {
+	c.cursor = max(0, min(c.cursor, len(c.labels)-1))
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	}
+	if c.cursor >= c.offset+visible {
+		c.offset = c.cursor - visible + 1
+	}
+	c.offset = max(0, min(c.offset, len(c.labels)-visible))
+}
+
+// Draw draws the bars, the y-axis and the labels of the first and last bar
+// on screen
+func (c *BarChart) Draw(screen tcell.Screen) {
+	c.DrawForSubclass(screen, c)
+	x, y, width, height := c.GetInnerRect()
+	if len(c.labels) == 0 {
+		tview.Print(screen, "[yellow]No data[-]", x, y, width, tview.AlignLeft, tcell.ColorDefault)
+		return
+	}
+	if height < 4 {
+		return
+	}
+
+	plotX, top, bottom, _ := c.plot()
+	if c.fit {
+		c.fit = false
+		c.width = 1
+		for c.width < maxBarWidth && len(c.labels)*barStep(c.width+1) <= x+width-plotX {
+			c.width++
+		}
+	}
+	_, _, _, visible := c.plot()
+	c.scroll(visible)
+
+	// Y-axis: the largest value at the top, zero at the bottom
+	rows := bottom - top + 1
+	tview.Print(screen, strconv.Itoa(c.max)+"┤", x, top, plotX-x, tview.AlignRight, tcell.ColorGray)
+	for row := top + 1; row < bottom; row++ {
+		screen.SetContent(plotX-1, row, '│', nil, tcell.StyleDefault.Foreground(tcell.ColorGray))
+	}
+	tview.Print(screen, "0┤", x, bottom, plotX-x, tview.AlignRight, tcell.ColorGray)
+
+	// Bars in eighths of a row, at least one eighth for any commit
+	for i := c.offset; i < min(len(c.labels), c.offset+visible); i++ {
+		left := plotX + (i-c.offset)*c.step()
+		eighths := 0
+		if c.max > 0 && c.values[i] > 0 {
+			eighths = max(1, c.values[i]*rows*8/c.max)
+		}
+		style := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+		if color, ok := c.colors[i]; ok {
+			style = style.Foreground(color)
+		}
+		if len(c.series) > 0 {
+			style = style.Foreground(tcell.ColorDarkGray)
+		}
+		if _, ok := c.shaded[i]; ok {
+			style = style.Background(shadeColor)
+		}
+		if i == c.cursor {
+			style = style.Background(tcell.ColorDimGray)
+		}
+		for row := 0; row < rows; row++ {
+			ch := ' '
+			switch cell := eighths - row*8; {
+			case cell >= 8:
+				ch = sparkBars[len(sparkBars)-1]
+			case cell > 0:
+				ch = sparkBars[cell-1]
+			}
+			for col := 0; col < c.width; col++ {
+				screen.SetContent(left+col, bottom-row, ch, nil, style)
+			}
+		}
+
+		// Series on the row of their value, the first one on top
+		for s := len(c.series) - 1; s >= 0; s-- {
+			value := c.series[s].Values[i]
+			if value == 0 {
+				continue
+			}
+			row := (max(1, value*rows*8/c.max) - 1) / 8
+			lineStyle := style.Foreground(c.series[s].Color)
+			for col := 0; col < c.width; col++ {
+				screen.SetContent(left+col, bottom-row, '━', nil, lineStyle)
+			}
+		}
+	}
+
+	// Highlighted bar above, first and last label on screen below
+	value := strconv.Itoa(c.values[c.cursor])
+	if c.unit != "" {
+		value += " " + c.unit
+	}
+	info := fmt.Sprintf("[yellow]%s[-]  [::b]%s[::-]  [gray]%d/%d[-]", c.labels[c.cursor], value, c.cursor+1, len(c.labels))
+	if note, ok := c.shaded[c.cursor]; ok {
+		info += fmt.Sprintf("  [gray::i]%s[-::-]", tview.Escape(note))
+	}
+	for _, s := range c.series {
+		info += fmt.Sprintf("  [%s]%s %d[-]", s.Color, tview.Escape(s.Name), s.Values[c.cursor])
+	}
+	tview.Print(screen, info, plotX, y, x+width-plotX, tview.AlignLeft, tcell.ColorDefault)
+	last := min(len(c.labels), c.offset+visible) - 1
+	first, lastLabel := c.labels[c.offset], c.labels[last]
+	if c.offset > 0 {
+		first = "◀ " + first
+	}
+	if last < len(c.labels)-1 {
+		lastLabel += " ▶"
+	}
+	tview.Print(screen, first, plotX, y+height-1, x+width-plotX, tview.AlignLeft, tcell.ColorGray)
+	if last > c.offset && len(first)+len(lastLabel)+2 <= x+width-plotX {
+		tview.Print(screen, lastLabel, plotX, y+height-1, x+width-plotX, tview.AlignRight, tcell.ColorGray)
+	}
+}
+
+// move highlights the bar delta bars away
+func (c *BarChart) move(delta int) {
+	c.cursor = max(0, min(c.cursor+delta, len(c.labels)-1))
+}
+
+// zoom widens the bars by delta columns
+func (c *BarChart) zoom(delta int) {
+	c.fit = false
+	c.width = max(1, min(c.width+delta, maxBarWidth))
+}
+
+// InputHandler moves the highlighted bar and zooms
+func (c *BarChart) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		_, _, _, visible := c.plot()
+		switch event.Key() {
+		case tcell.KeyLeft:
+			c.move(-1)
+		case tcell.KeyRight:
+			c.move(1)
+		case tcell.KeyPgUp:
+			c.move(-visible)
+		case tcell.KeyPgDn:
+			c.move(visible)
+		case tcell.KeyHome:
+			c.move(-len(c.labels))
+		case tcell.KeyEnd:
+			c.move(len(c.labels))
+		case tcell.KeyUp:
+			c.zoom(1)
+		case tcell.KeyDown:
+			c.zoom(-1)
+		}
+	})
+}
+
+// MouseHandler highlights the clicked bar and scrolls with the wheel
+func (c *BarChart) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return c.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		x, y := event.Position()
+		if !c.InRect(x, y) {
+			return false, nil
+		}
+		switch action {
+		case tview.MouseLeftClick:
+			setFocus(c)
+			plotX, _, _, visible := c.plot()
+			if bar := (x - plotX) / c.step(); x >= plotX && bar < visible && c.offset+bar < len(c.labels) {
+				c.cursor = c.offset + bar
+			}
+		case tview.MouseScrollUp:
+			c.move(-1)
+		case tview.MouseScrollDown:
+			c.move(1)
+		default:
+			return false, nil
+		}
+		return true, nil
+	})
+}
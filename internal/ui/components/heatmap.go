@@ -3,32 +3,43 @@ package components
 import (
 	"fmt"
 	"strings"
-)
 
-// Weekday labels (Monday first)
-var weekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	"github.com/audi70r/gitstat/internal/i18n"
+)
 
 // Heat intensity colors for tview
 var heatColors = []string{"gray", "blue", "green", "yellow", "red"}
 
+// HourLabel returns the short column label of an hour: "15" on the 24-hour
+// clock, "3p" on the 12-hour clock
+func HourLabel(hour int, clock24 bool) string {
+	if clock24 {
+		return fmt.Sprintf("%02d", hour)
+	}
+	suffix := "a"
+	if hour >= 12 {
+		suffix = "p"
+	}
+	if hour%12 == 0 {
+		return "12" + suffix
+	}
+	return fmt.Sprintf("%d%s", hour%12, suffix)
+}
+
 // RenderHeatmap creates a colored text-based heatmap for hour/weekday commits
-func RenderHeatmap(matrix [7][24]int, maxValue int) string {
+func RenderHeatmap(matrix [7][24]int, maxValue int, clock24 bool) string {
 	var sb strings.Builder
 
-	// Header: hours
+	// Header: a label every 3 hours over the 2-wide cells
 	sb.WriteString("      ")
-	for h := 0; h < 24; h++ {
-		if h%3 == 0 {
-			sb.WriteString(fmt.Sprintf("[white]%02d[-] ", h))
-		} else {
-			sb.WriteString("   ")
-		}
+	for h := 0; h < 24; h += 3 {
+		sb.WriteString(fmt.Sprintf("[white]%-6s[-]", HourLabel(h, clock24)))
 	}
 	sb.WriteString("\n")
 
 	// Body: weekday rows
 	for day := 0; day < 7; day++ {
-		sb.WriteString(fmt.Sprintf("[yellow]%-5s[-] ", weekdays[day]))
+		sb.WriteString(fmt.Sprintf("[yellow]%-5s[-] ", i18n.Weekday(day)))
 		for hour := 0; hour < 24; hour++ {
 			val := matrix[day][hour]
 			intensity := 0
@@ -53,20 +64,70 @@ func RenderHeatmap(matrix [7][24]int, maxValue int) string {
 	return sb.String()
 }
 
+// RenderGridHeatmap creates a colored heatmap for an arbitrary matrix.
+// Column labels are printed every labelEvery columns; cells are cellWidth wide.
+func RenderGridHeatmap(rowLabels, colLabels []string, matrix [][]int, cellWidth, labelEvery int) string {
+	var sb strings.Builder
+
+	maxValue := 0
+	for _, row := range matrix {
+		for _, val := range row {
+			if val > maxValue {
+				maxValue = val
+			}
+		}
+	}
+
+	// Header: column labels
+	sb.WriteString("      ")
+	for col, label := range colLabels {
+		if labelEvery <= 1 || col%labelEvery == 0 {
+			sb.WriteString(fmt.Sprintf("[white]%-*s[-]", cellWidth*max(labelEvery, 1), label))
+		}
+	}
+	sb.WriteString("\n")
+
+	// Body: one row per label
+	cell := strings.Repeat("█", cellWidth)
+	for r, row := range matrix {
+		sb.WriteString(fmt.Sprintf("[yellow]%-5s[-] ", rowLabels[r]))
+		for _, val := range row {
+			intensity := 0
+			if maxValue > 0 && val > 0 {
+				intensity = (val * (len(heatColors) - 1)) / maxValue
+				if intensity >= len(heatColors) {
+					intensity = len(heatColors) - 1
+				}
+			}
+			sb.WriteString(fmt.Sprintf("[%s]%s[-]", heatColors[intensity], cell))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Legend
+	sb.WriteString("\n      [gray]Low[-] ")
+	for _, color := range heatColors {
+		sb.WriteString(fmt.Sprintf("[%s]██[-]", color))
+	}
+	sb.WriteString(" [red]High[-]")
+
+	return sb.String()
+}
+
 // RenderHeatmapCompact creates a more compact heatmap
-func RenderHeatmapCompact(matrix [7][24]int, maxValue int) string {
+func RenderHeatmapCompact(matrix [7][24]int, maxValue int, clock24 bool) string {
 	var sb strings.Builder
 
 	// Header: hours (every 4 hours)
 	sb.WriteString("     ")
 	for h := 0; h < 24; h += 4 {
-		sb.WriteString(fmt.Sprintf("[white]%02d[-]  ", h))
+		sb.WriteString(fmt.Sprintf("[white]%-4s[-]", HourLabel(h, clock24)))
 	}
 	sb.WriteString("\n")
 
 	// Body: weekday rows
 	for day := 0; day < 7; day++ {
-		sb.WriteString(fmt.Sprintf("[yellow]%-4s[-] ", weekdays[day][:3]))
+		sb.WriteString(fmt.Sprintf("[yellow]%-4s[-] ", i18n.Weekday(day)))
 		for hour := 0; hour < 24; hour++ {
 			val := matrix[day][hour]
 			intensity := 0
@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/publish"
+	"github.com/audi70r/gitstat/internal/ui/views"
+)
+
+// macroStep is one step of a macro: a command and its arguments
+//
+//	range 7d | 4w | 3m | 1y     the period ending now
+//	range 2024-01-01 2024-03-31 a fixed period
+//	scan                        rescan the repositories; later steps wait for it
+//	view Top Files              switch to a view
+//	export md|csv [path]        write a report, by default to the working directory;
+//	                            quick-stats and quick-stats-csv are the formats of
+//	                            git-quick-stats
+//	open                        open the last exported report
+//	publish confluence|notion   send the Markdown report to a target of the
+//	                            configuration file; later steps wait for it
+//	Leaderboard.sort, numbers   any key binding action, by its name
+type macroStep struct {
+	command string
+	args    []string
+}
+
+// parseMacros checks the macros of the configuration file, reporting every
+// invalid step
+func parseMacros(macros map[string][]string, keys *views.KeyMap) (map[string][]macroStep, error) {
+	names := make([]string, 0, len(macros))
+	for name := range macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parsed := make(map[string][]macroStep, len(macros))
+	var problems []string
+	for _, name := range names {
+		if len(macros[name]) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no steps", name))
+			continue
+		}
+		for i, text := range macros[name] {
+			step, err := parseStep(text, keys)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: step %d: %v", name, i+1, err))
+				continue
+			}
+			parsed[name] = append(parsed[name], step)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+	return parsed, nil
+}
+
+func parseStep(text string, keys *views.KeyMap) (macroStep, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return macroStep{}, errors.New("empty step")
+	}
+	step := macroStep{command: fields[0], args: fields[1:]}
+
+	switch step.command {
+	case "range":
+		if _, _, err := step.dateRange(time.Now()); err != nil {
+			return step, err
+		}
+	case "scan", "open":
+		if len(step.args) > 0 {
+			return step, fmt.Errorf("%s takes no arguments", step.command)
+		}
+	case "publish":
+		if len(step.args) != 1 || !slices.Contains(publish.Targets, step.args[0]) {
+			return step, fmt.Errorf("publish needs a target (%s)", strings.Join(publish.Targets, ", "))
+		}
+	case "view":
+		name := strings.Join(step.args, " ")
+		if !slices.ContainsFunc(menuItems, func(item menuItem) bool { return item.name == name }) {
+			return step, fmt.Errorf("unknown view %q", name)
+		}
+		step.args = []string{name}
+	case "export":
+		if len(step.args) == 0 || len(step.args) > 2 || !slices.Contains(reportFormats, step.args[0]) {
+			return step, fmt.Errorf("export needs a format (%s) and optionally a path", strings.Join(reportFormats, ", "))
+		}
+	default:
+		if len(step.args) > 0 || !keys.HasAction(step.command) {
+			return step, fmt.Errorf("unknown step %q", text)
+		}
+	}
+	return step, nil
+}
+
+// dateRange returns the period of a range step
+func (s macroStep) dateRange(now time.Time) (since, until time.Time, err error) {
+	switch len(s.args) {
+	case 1:
+		arg := s.args[0]
+		n, err := strconv.Atoi(arg[:len(arg)-1])
+		if err != nil || n <= 0 {
+			return since, until, fmt.Errorf("invalid range %q, e.g. 7d, 4w, 3m or 1y", arg)
+		}
+		switch arg[len(arg)-1] {
+		case 'd':
+			return now.AddDate(0, 0, -n), now, nil
+		case 'w':
+			return now.AddDate(0, 0, -7*n), now, nil
+		case 'm':
+			return now.AddDate(0, -n, 0), now, nil
+		case 'y':
+			return now.AddDate(-n, 0, 0), now, nil
+		}
+		return since, until, fmt.Errorf("invalid range %q, e.g. 7d, 4w, 3m or 1y", arg)
+	case 2:
+		if since, err = time.Parse("2006-01-02", s.args[0]); err != nil {
+			return since, until, fmt.Errorf("invalid date %q, use YYYY-MM-DD", s.args[0])
+		}
+		if until, err = time.Parse("2006-01-02", s.args[1]); err != nil {
+			return since, until, fmt.Errorf("invalid date %q, use YYYY-MM-DD", s.args[1])
+		}
+		if until.Before(since) {
+			return since, until, errors.New("the range ends before it starts")
+		}
+		return since, until, nil
+	}
+	return since, until, errors.New("range needs a period like 7d or two dates")
+}
+
+// macroNames returns the names of the macros, sorted
+func (a *App) macroNames() []string {
+	names := make([]string, 0, len(a.macros))
+	for name := range a.macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runMacro runs the steps of a macro in order from the command palette
+func (a *App) runMacro(name string) {
+	a.runSteps(name, 0, "")
+}
+
+// runSteps runs the steps of a macro from the given one. A scan step
+// resumes the rest once the new statistics are on screen; a failing step
+// stops the macro. report is the file the last export step wrote.
+func (a *App) runSteps(name string, from int, report string) {
+	steps := a.macros[name]
+	for i := from; i < len(steps); i++ {
+		step := steps[i]
+		var err error
+		switch step.command {
+		case "range":
+			since, until, _ := step.dateRange(time.Now())
+			a.setupView.SetDateRange(since, until)
+		case "scan":
+			a.afterScan = func() { a.runSteps(name, i+1, report) }
+			a.onSetupComplete()
+			if page, _ := a.pages.GetFrontPage(); page != "progress" {
+				a.afterScan = nil
+				a.notify(i18n.T("Macro %s stopped at step %d", name, i+1), errors.New(i18n.T("the scan did not start")))
+			}
+			return
+		case "view":
+			a.mainView.ShowView(step.args[0])
+		case "export":
+			if a.repoStats == nil {
+				err = errors.New(i18n.T("nothing scanned yet"))
+				break
+			}
+			path := reportPath(step.args[0])
+			if len(step.args) > 1 {
+				path = step.args[1]
+			}
+			if err = writeReport(path, step.args[0], a.repoStats, a.config); err == nil {
+				report = path
+			}
+		case "open":
+			if report == "" {
+				err = errors.New(i18n.T("no report exported yet"))
+				break
+			}
+			err = openFile(report)
+		case "publish":
+			if a.repoStats == nil {
+				err = errors.New(i18n.T("nothing scanned yet"))
+				break
+			}
+			if err = publish.Check(a.config, step.args[0]); err != nil {
+				break
+			}
+			a.publishReport(name, i, report)
+			return
+		default:
+			if !a.mainView.RunAction(step.command) {
+				err = errors.New(i18n.T("%s is not available in this view", step.command))
+			}
+		}
+		if err != nil {
+			a.notify(i18n.T("Macro %s stopped at step %d", name, i+1), err)
+			return
+		}
+	}
+	a.notify(i18n.T("Macro %s finished", name), nil)
+}
+
+// publishReport runs the publish step of a macro in the background, as the
+// services can take a while to answer, then resumes the remaining steps
+func (a *App) publishReport(name string, step int, report string) {
+	target := a.macros[name][step].args[0]
+	title := a.repoStats.ReportTitle()
+	markdown := a.repoStats.MarkdownReport(a.config.MaxAuthors, a.config.MaxFiles)
+	go func() {
+		err := publish.Publish(a.ctx, a.config, target, title, markdown)
+		a.queueUpdateDraw(func() {
+			if err != nil {
+				a.notify(i18n.T("Macro %s stopped at step %d", name, step+1), err)
+				return
+			}
+			a.notify(i18n.T("Report published to %s", target), nil)
+			a.runSteps(name, step+1, report)
+		})
+	}()
+}
+
+// openFile opens path in the desktop's default application
+func openFile(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/scan"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// presenter shows the scans of the controller in the app: progress on the
+// progress page, results in the main view. The controller calls it from
+// the scanning goroutine, so every call is queued onto the UI goroutine.
+type presenter struct {
+	app *App
+}
+
+func (p *presenter) Progress(parsed, total int) {
+	p.app.queueUpdateDraw(func() {
+		p.app.progressView.SetProgress(parsed, total)
+	})
+}
+
+func (p *presenter) Status(message string) {
+	p.app.queueUpdateDraw(func() {
+		p.app.progressView.SetStatus(message)
+	})
+}
+
+// Results switches to the main view with the new statistics
+func (p *presenter) Results(ctx context.Context, result *scan.Result) {
+	a := p.app
+	a.queueUpdateDraw(func() {
+		a.repoStats = result.Stats
+		a.worktree = nil
+		if a.config.RestoreUIState {
+			a.saveUIState()
+			a.uiState = config.LoadUIState(result.Repos)
+			a.mainView.RestoreState(a.uiState)
+		} else if a.uiState == nil {
+			a.uiState = config.NewUIState()
+		}
+		a.mainView.SetData(a.repoStats, a.config)
+		a.mainView.SetTrends(result.Trends)
+		a.pages.SwitchToPage("main")
+		a.tview.SetFocus(a.mainView.GetFocusable())
+		if result.TrendErr != nil {
+			a.notify(i18n.T("Scan not recorded in the trends"), result.TrendErr)
+		}
+
+		// Resume the macro that started the scan
+		if next := a.afterScan; next != nil {
+			a.afterScan = nil
+			next()
+		}
+	})
+}
+
+// Codebase updates the views fed by the worktree line count
+func (p *presenter) Codebase(ctx context.Context, result *scan.CodebaseResult) {
+	a := p.app
+	a.queueUpdateDraw(func() {
+		// A newer scan replaced the statistics
+		if ctx.Err() != nil {
+			return
+		}
+		result.Apply(a.repoStats)
+		a.worktree = append(a.worktree, result)
+		a.mainView.RefreshWorktreeViews()
+
+		if result.Err != nil {
+			a.notify(i18n.T("Codebase size incomplete"), result.Err)
+			return
+		}
+		msg := i18n.T("Codebase size: %d lines (%s)", result.Lines, elapsed(result.Elapsed))
+		if result.LicenseHeaders != nil {
+			msg += i18n.T(", %d files checked for license headers", len(result.LicenseHeaders))
+		}
+		if result.Sparse > 0 {
+			msg += i18n.T(", %d files outside the sparse checkout skipped", result.Sparse)
+		}
+		a.notify(msg, nil)
+	})
+}
+
+// DebtMarkers updates the Debt Markers view
+func (p *presenter) DebtMarkers(ctx context.Context, result *scan.DebtResult) {
+	a := p.app
+	a.queueUpdateDraw(func() {
+		if ctx.Err() != nil {
+			return
+		}
+		result.Apply(a.repoStats)
+		a.worktree = append(a.worktree, result)
+		a.mainView.RefreshWorktreeViews()
+
+		if result.Err != nil {
+			a.notify(i18n.T("Blame pass incomplete"), result.Err)
+			return
+		}
+		msg := i18n.T("Blame pass: %d debt markers (%s)", len(result.Markers), elapsed(result.Elapsed))
+		if n := stats.UnattributedMarkers(result.Markers); result.Partial && n > 0 {
+			msg += i18n.T(", %d unattributed: history missing from the partial clone", n)
+		}
+		a.notify(msg, nil)
+	})
+}
+
+// CodeAge updates the Code Age view
+func (p *presenter) CodeAge(ctx context.Context, result *scan.CodeAgeResult) {
+	a := p.app
+	a.queueUpdateDraw(func() {
+		if ctx.Err() != nil {
+			return
+		}
+		result.Apply(a.repoStats)
+		a.worktree = append(a.worktree, result)
+		a.mainView.RefreshWorktreeViews()
+
+		if result.Err != nil {
+			a.notify(i18n.T("Code age sample incomplete"), result.Err)
+			return
+		}
+		a.notify(i18n.T("Code age: %d of %d files blamed (%s)",
+			result.Sample.Sampled, result.Sample.Files, elapsed(result.Elapsed)), nil)
+	})
+}
+
+// GitHub updates the Pull Requests view with the review data
+func (p *presenter) GitHub(ctx context.Context, result *scan.GitHubResult) {
+	a := p.app
+	a.queueUpdateDraw(func() {
+		if ctx.Err() != nil {
+			return
+		}
+		result.Apply(a.repoStats)
+		a.worktree = append(a.worktree, result)
+		a.mainView.RefreshWorktreeViews()
+
+		if result.Err != nil {
+			a.notify(i18n.T("GitHub reviews incomplete"), result.Err)
+			return
+		}
+		a.notify(i18n.T("GitHub: reviews of %d pull requests from %s, %d newly asked (%s)",
+			len(result.Reviews), strings.Join(result.Repos, ", "), result.Fetched, elapsed(result.Elapsed)), nil)
+	})
+}
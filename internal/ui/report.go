@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/config"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Report formats written by the export macro step. The quick-stats formats
+// match the author contributions of git-quick-stats.
+var reportFormats = []string{"md", "csv", "quick-stats", "quick-stats-csv"}
+
+// reportPath names a report file in the working directory
+func reportPath(format string) string {
+	ext := format
+	switch format {
+	case "quick-stats":
+		ext = "txt"
+	case "quick-stats-csv":
+		ext = "csv"
+	}
+	return fmt.Sprintf("gitstat-report-%s.%s", time.Now().Format("20060102-150405"), ext)
+}
+
+// writeReport writes a summary of the statistics to path: the authors
+// leaderboard and the most changed files as Markdown, every author as CSV,
+// or the author contributions in a git-quick-stats format. The Markdown and
+// CSV reports are headed by the period and filters of the scan; the
+// git-quick-stats formats are left as scripts expect them.
+func writeReport(path, format string, repo *stats.Repository, cfg *config.Config) error {
+	var content []byte
+	switch format {
+	case "md":
+		content = []byte(repo.MarkdownReport(cfg.MaxAuthors, cfg.MaxFiles))
+	case "quick-stats":
+		content = []byte(repo.QuickStats())
+	case "quick-stats-csv":
+		content = []byte(repo.QuickStatsCSV())
+	case "csv":
+		var sb strings.Builder
+		w := csv.NewWriter(&sb)
+		w.Comma = i18n.CSVSeparator()
+		w.WriteAll(csvHeader(repo))
+		w.Write([]string{"name", "email", "commits", "additions", "deletions", "files"})
+		for _, a := range repo.GetLeaderboard("commits", false) {
+			w.Write([]string{a.Name, a.Email, strconv.Itoa(a.Commits),
+				strconv.Itoa(a.Additions), strconv.Itoa(a.Deletions), strconv.Itoa(len(a.FilesTouched))})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		content = []byte(sb.String())
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// csvHeader returns the comment rows heading a CSV export: the repository,
+// period and filters its numbers were computed with
+func csvHeader(repo *stats.Repository) [][]string {
+	var rows [][]string
+	for _, line := range repo.ReportHeader() {
+		rows = append(rows, []string{"# " + line})
+	}
+	return rows
+}
@@ -0,0 +1,126 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// ArchitectureView displays directory pairs that change together
+type ArchitectureView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewArchitectureView creates a new directory coupling view
+func NewArchitectureView() *ArchitectureView {
+	v := &ArchitectureView{
+		columns: []string{"#", "Directory A", "Directory B", "Shared", "Coupling", "Boundary"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *ArchitectureView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *ArchitectureView) Refresh(repo *stats.Repository, minShared int, threshold float64) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	pairs := repo.GetDirCoupling(minShared, 100)
+
+	drift := 0
+	for i, pair := range pairs {
+		row := i + 1
+		strong := pair.Score >= threshold
+		violation := strong && pair.CrossBoundary
+		if violation {
+			drift++
+		}
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(truncatePath(pair.DirA, 35)).
+			SetTextColor(getDirColor(pair.DirA)).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(truncatePath(pair.DirB, 35)).
+			SetTextColor(getDirColor(pair.DirB)).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", pair.SharedCommits)).
+			SetAlign(tview.AlignRight))
+
+		scoreColor := tcell.ColorWhite
+		if strong {
+			scoreColor = tcell.ColorYellow
+		}
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.0f%%", pair.Score)).
+			SetTextColor(scoreColor).
+			SetAlign(tview.AlignRight))
+
+		boundary := "same component"
+		boundaryColor := tcell.ColorDarkGray
+		if violation {
+			boundary = "⚠ drift"
+			boundaryColor = tcell.ColorRed
+		} else if pair.CrossBoundary {
+			boundary = "cross"
+			boundaryColor = tcell.ColorWhite
+		}
+		v.table.SetCell(row, 5, tview.NewTableCell(boundary).
+			SetTextColor(boundaryColor))
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] coupled pairs (≥%d shared commits) | [red]%d[-] strongly coupled across boundaries (≥%.0f%%)",
+		len(pairs), minShared, drift, threshold))
+}
+
+func truncatePath(path string, maxLen int) string {
+	if len(path) > maxLen {
+		return "..." + path[len(path)-maxLen+3:]
+	}
+	return path
+}
+
+// Root returns the root primitive
+func (v *ArchitectureView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *ArchitectureView) GetFocusable() tview.Primitive {
+	return v.table
+}
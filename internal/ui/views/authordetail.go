@@ -0,0 +1,140 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
+)
+
+// Files, directories and merges listed in the author drill-down
+const authorDetailRows = 10
+
+// AuthorDetailView displays one author's activity, opened from a
+// Leaderboard row
+type AuthorDetailView struct {
+	root   *tview.Flex
+	detail *tview.TextView
+	info   *tview.TextView
+	email  string
+	layout Layout
+	keys   *KeyMap
+}
+
+// NewAuthorDetailView creates a new author drill-down view
+func NewAuthorDetailView() *AuthorDetailView {
+	v := &AuthorDetailView{}
+	v.setup()
+	return v
+}
+
+func (v *AuthorDetailView) setup() {
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.detail, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+}
+
+// SetLayout sets the space available for the timeline and share bars
+func (v *AuthorDetailView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// SetKeyMap sets the key map the info bar takes its keys from
+func (v *AuthorDetailView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+}
+
+// Show switches the view to the author with email
+func (v *AuthorDetailView) Show(repo *stats.Repository, email string) {
+	v.email = email
+	v.Refresh(repo)
+	v.detail.ScrollToBeginning()
+}
+
+// Refresh updates the view with new data, keeping the author
+func (v *AuthorDetailView) Refresh(repo *stats.Repository) {
+	if v.email == "" {
+		return
+	}
+	v.info.SetText(fmt.Sprintf("[gray][%s] back to the Leaderboard[-]", v.keys.Key("Author Drill-down", "back")))
+
+	detail := repo.GetAuthorDetail(v.email, authorDetailRows, v.layout.bar(60, textPadding))
+	if detail == nil {
+		v.detail.SetText(fmt.Sprintf("\n  [gray]%s has no commits in this scan[-]\n", v.email))
+		return
+	}
+	v.detail.SetText(v.layout.fit(v.render(detail)))
+}
+
+func (v *AuthorDetailView) render(d *stats.AuthorDetail) string {
+	var sb strings.Builder
+	a := d.Author
+
+	sb.WriteString(fmt.Sprintf("\n  [yellow::b]%s[-:-:-] [gray]<%s>[-]\n", tview.Escape(a.Name), a.Email))
+	sb.WriteString("  [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+
+	sb.WriteString(fmt.Sprintf("  %-18s [cyan]%s[-]  [green]+%s[-] [red]-%s[-]\n", "Commits",
+		formatNumber(a.Commits), formatNumber(a.Additions), formatNumber(a.Deletions)))
+	sb.WriteString(fmt.Sprintf("  %-18s %s to %s\n", "Active", a.FirstCommit.Format("2006-01-02"), a.LastCommit.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("  %-18s [cyan]%d[-] of %d (%.0f%%), longest streak [cyan]%d[-]\n", "Active days",
+		d.ActiveDays, d.Days, safeDivide(float64(d.ActiveDays), float64(d.Days))*100, d.LongestStreak))
+	sb.WriteString(fmt.Sprintf("  %-18s [cyan]%.0f[-] lines  %s\n", "Commit size", d.AverageSize, formatPercentiles(d.Sizes)))
+	merges := 0
+	if d.PRs != nil {
+		merges = d.PRs.MergeCount
+	}
+	sb.WriteString(fmt.Sprintf("  %-18s [cyan]%d[-]\n", "PR merges", merges))
+
+	sb.WriteString("\n  [::b]Commit Timeline[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  [green]%s[-]\n", components.RenderSparkline(d.Timeline)))
+
+	barWidth := v.layout.bar(20, 70)
+	sb.WriteString("\n  [::b]Top Files[-:-:-] [gray](commits, share of the file's commits)[-]\n\n")
+	for _, f := range d.Files {
+		sb.WriteString(fmt.Sprintf("  %5d [cyan]%s[-] %5.1f%%  %s\n",
+			f.Touches, shareBar(f.Share, barWidth), f.Share, tview.Escape(truncatePath(f.Path, 50))))
+	}
+
+	sb.WriteString("\n  [::b]Top Directories[-:-:-] [gray](lines changed, share of the directory's)[-]\n\n")
+	for _, dir := range d.Dirs {
+		sb.WriteString(fmt.Sprintf("  %7s [cyan]%s[-] %5.1f%%  %s\n",
+			formatNumber(dir.Changes), shareBar(dir.Share, barWidth), dir.Share, tview.Escape(dir.Path)))
+	}
+
+	if len(d.Merges) > 0 {
+		sb.WriteString("\n  [::b]Recent Merges[-:-:-]\n\n")
+		for _, pr := range d.Merges {
+			number := "     "
+			if pr.PRNumber > 0 {
+				number = fmt.Sprintf("#%-4d", pr.PRNumber)
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s [green]+%d[-] [red]-%d[-]  %s\n",
+				pr.MergedAt.Format("2006-01-02"), number, pr.Additions, pr.Deletions, tview.Escape(pr.Subject)))
+		}
+	}
+
+	return sb.String()
+}
+
+// Root returns the root primitive
+func (v *AuthorDetailView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *AuthorDetailView) GetFocusable() tview.Primitive {
+	return v.detail
+}
@@ -0,0 +1,112 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// setupPreview builds the confirmation dialog listing what pending merges
+// will produce
+func (v *AuthorsView) setupPreview() {
+	v.previewText = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	v.previewText.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			v.closePreview()
+			return nil
+		case event.Key() == tcell.KeyEnter, event.Key() == tcell.KeyRune && (event.Rune() == 'a' || event.Rune() == 'A'):
+			v.closePreview()
+			v.applyMerges()
+			return nil
+		}
+		return event
+	})
+
+	info := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText("[green][Enter] apply[-]  [Esc] back to editing")
+
+	dialog := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.previewText, 0, 1, true).
+		AddItem(info, 1, 0, false)
+	dialog.SetBorder(true).SetTitle(" " + i18n.T("Merge Preview") + " ")
+
+	v.preview = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(dialog, 0, 4, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+}
+
+func (v *AuthorsView) openPreview() {
+	if v.repoStats == nil || len(v.merges) == 0 {
+		return
+	}
+	previews := v.repoStats.PreviewAuthorMerges(v.merges)
+	if len(previews) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	for i, p := range previews {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		c := p.Combined
+		sb.WriteString(fmt.Sprintf("[yellow]━━━ %s <%s> ━━━[-]\n\n", tview.Escape(c.Name), c.Email))
+
+		sb.WriteString(fmt.Sprintf("  Identities:  [cyan]%d[-]\n", len(p.Identities)))
+		for _, a := range p.Identities {
+			marker := "[yellow]→[-]"
+			if a.Email == c.Email {
+				marker = "[green]★[-]"
+			}
+			sb.WriteString(fmt.Sprintf("    %s %s <%s> [gray]%d commits[-]\n", marker, tview.Escape(a.Name), a.Email, a.Commits))
+		}
+
+		sb.WriteString(fmt.Sprintf("\n  Commits:     [cyan]%d[-]\n", c.Commits))
+		sb.WriteString(fmt.Sprintf("  Additions:   [green]+%d[-]\n", c.Additions))
+		sb.WriteString(fmt.Sprintf("  Deletions:   [red]-%d[-]\n", c.Deletions))
+		sb.WriteString(fmt.Sprintf("  Files:       [cyan]%d[-]\n", len(c.FilesTouched)))
+		if !c.FirstCommit.IsZero() {
+			sb.WriteString(fmt.Sprintf("  Active:      [gray]%s to %s[-]\n",
+				i18n.Date(c.FirstCommit), i18n.Date(c.LastCommit)))
+		}
+
+		if len(p.Dirs) > 0 {
+			sb.WriteString("\n  Directories:\n")
+			for j, d := range p.Dirs {
+				if j == 8 {
+					sb.WriteString(fmt.Sprintf("    [gray]... and %d more[-]\n", len(p.Dirs)-j))
+					break
+				}
+				sb.WriteString(fmt.Sprintf("    %-30s [cyan]%6d[-] lines  [gray]%5.1f%% of dir[-]\n",
+					truncatePath(d.Path, 30), d.Changes, d.Share))
+			}
+		}
+	}
+	sb.WriteString("\n[gray]Merging cannot be undone without a rescan.[-]\n")
+
+	v.previewText.SetText(sb.String()).ScrollToBeginning()
+	v.previewing = true
+	v.root.ShowPage("preview")
+	v.focus(v.previewText)
+}
+
+func (v *AuthorsView) closePreview() {
+	v.previewing = false
+	v.root.HidePage("preview")
+	v.focus(v.list)
+}
@@ -3,10 +3,13 @@ package views
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/identity"
 	"github.com/audi70r/gitstat/internal/stats"
 )
 
@@ -20,24 +23,53 @@ type AuthorMerge struct {
 
 // AuthorsView allows managing and merging author identities
 type AuthorsView struct {
-	root        *tview.Flex
+	root        *tview.Pages
 	list        *tview.List
+	header      *tview.TextView
 	detail      *tview.TextView
 	info        *tview.TextView
 	authors     []*stats.AuthorStats
 	merges      map[string]string // email -> primary email
+	primary     string            // primary that new aliases join
 	selected    map[string]bool   // selected emails for batch operations
 	repoStats   *stats.Repository
 	onMerge     func(merges map[string]string)
+	onExport    func() (string, error)
 	selectedIdx int
+	batch       Batch
+	keys        *KeyMap
+
+	// Fuzzy-search merge dialog
+	search        *tview.Flex
+	searchInput   *tview.InputField
+	searchList    *tview.List
+	searchInfo    *tview.TextView
+	searchMatches []*stats.AuthorStats
+	searching     bool
+
+	// Confirmation dialog shown before merges are applied
+	preview     *tview.Flex
+	previewText *tview.TextView
+	previewing  bool
+
+	// Work estimate session limits (see stats.EstimateHours)
+	sessionGap   time.Duration
+	sessionStart time.Duration
+
+	focus func(p tview.Primitive)
 }
 
 // NewAuthorsView creates a new authors management view
-func NewAuthorsView(onMerge func(merges map[string]string)) *AuthorsView {
+func NewAuthorsView(onMerge func(merges map[string]string), onExport func() (string, error)) *AuthorsView {
 	v := &AuthorsView{
 		merges:   make(map[string]string),
 		selected: make(map[string]bool),
 		onMerge:  onMerge,
+		onExport: onExport,
+		focus:    func(tview.Primitive) {},
+
+		sessionGap:   2 * time.Hour,
+		sessionStart: 2 * time.Hour,
 	}
 	v.setup()
 	return v
@@ -45,23 +77,23 @@ func NewAuthorsView(onMerge func(merges map[string]string)) *AuthorsView {
 
 func (v *AuthorsView) setup() {
 	// Instructions header
-	instructions := tview.NewTextView().
+	v.header = tview.NewTextView().
 		SetDynamicColors(true).
-		SetTextAlign(tview.AlignCenter).
-		SetText("[yellow]MERGE AUTHORS:[-] [Space] select  [m] merge selected  [a] apply  [c] clear")
+		SetTextAlign(tview.AlignCenter)
+	v.renderHeader()
 
 	// Authors list
 	v.list = tview.NewList().
 		ShowSecondaryText(true).
 		SetHighlightFullLine(true).
 		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
-	v.list.SetBorder(true).SetTitle(" Authors ")
+	v.list.SetBorder(true).SetTitle(" " + i18n.T("Authors") + " ")
 
 	// Detail/merge panel
 	v.detail = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
-	v.detail.SetBorder(true).SetTitle(" Author Details ")
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Author Details") + " ")
 
 	// Info bar
 	v.info = tview.NewTextView().
@@ -73,12 +105,19 @@ func (v *AuthorsView) setup() {
 		AddItem(v.list, 45, 0, true).
 		AddItem(v.detail, 0, 1, false)
 
-	v.root = tview.NewFlex().
+	layout := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(instructions, 1, 0, false).
+		AddItem(v.header, 1, 0, false).
 		AddItem(content, 0, 1, true).
 		AddItem(v.info, 1, 0, false)
 
+	v.setupSearch()
+	v.setupPreview()
+	v.root = tview.NewPages().
+		AddPage("authors", layout, true, true).
+		AddPage("search", v.search, true, false).
+		AddPage("preview", v.preview, true, false)
+
 	// Handle selection
 	v.list.SetChangedFunc(func(idx int, main, secondary string, shortcut rune) {
 		v.selectedIdx = idx
@@ -86,39 +125,78 @@ func (v *AuthorsView) setup() {
 			v.showAuthorDetails(v.authors[idx])
 		}
 	})
+}
 
-	// Input handler for merging
-	v.list.SetInputCapture(v.handleInput)
-}
-
-func (v *AuthorsView) handleInput(event *tcell.EventKey) *tcell.EventKey {
-	switch event.Key() {
-	case tcell.KeyRune:
-		switch event.Rune() {
-		case ' ':
-			// Toggle selection for batch operations
-			v.toggleSelection()
-			return nil
-		case 'm', 'M':
-			// Mark for merge: if selected items exist, merge them
-			// Otherwise, mark current author for sequential merging
-			if len(v.selected) >= 2 {
-				v.mergeSelected()
-			} else {
-				v.markForMerge()
-			}
-			return nil
-		case 'c', 'C':
-			// Clear all selections and merges
-			v.clearAll()
-			return nil
-		case 'a', 'A':
-			// Apply merges
-			v.applyMerges()
-			return nil
+// mergeOrMark merges the selected authors, or marks the current one for
+// sequential merging when fewer than two are selected
+func (v *AuthorsView) mergeOrMark() {
+	if len(v.selected) >= 2 {
+		v.mergeSelected()
+	} else {
+		v.markForMerge()
+	}
+}
+
+// runBatch applies a batch action to the selected authors
+func (v *AuthorsView) runBatch(action string) {
+	status, ok := runBatch(v.batch, action, KindAuthors, v.selection())
+	if !ok {
+		return
+	}
+	v.selected = make(map[string]bool)
+	v.refreshList()
+	if status != "" {
+		v.info.SetText(status)
+	}
+}
+
+// selection returns the emails of the selected authors, or of the current
+// one if none is selected
+func (v *AuthorsView) selection() []string {
+	if len(v.selected) > 0 {
+		emails := make([]string, 0, len(v.selected))
+		for email := range v.selected {
+			emails = append(emails, email)
 		}
+		sort.Strings(emails)
+		return emails
 	}
-	return event
+	if v.selectedIdx >= 0 && v.selectedIdx < len(v.authors) {
+		return []string{v.authors[v.selectedIdx].Email}
+	}
+	return nil
+}
+
+// SetBatch sets the actions applied to selected authors
+func (v *AuthorsView) SetBatch(batch Batch) {
+	v.batch = batch
+}
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *AuthorsView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Authors", "find", v.openSearch)
+	keys.Handle("Authors", "select", v.toggleSelection)
+	keys.Handle("Authors", "merge", v.mergeOrMark)
+	keys.Handle("Authors", "auto", v.autoMerge)
+	keys.Handle("Authors", "apply", v.openPreview)
+	keys.Handle("Authors", "clear", v.clearAll)
+	keys.Handle("Authors", "mailmap", v.exportMailmap)
+	for _, action := range batchActions {
+		keys.Handle("Authors", action, func() { v.runBatch(action) })
+	}
+	v.renderHeader()
+}
+
+// key returns the key bound to an action of the view
+func (v *AuthorsView) key(action string) string {
+	return v.keys.Key("Authors", action)
+}
+
+// renderHeader lists the merge keys above the author list
+func (v *AuthorsView) renderHeader() {
+	v.header.SetText(fmt.Sprintf("[yellow]MERGE AUTHORS:[-] [%s] find  [%s] select  [%s] merge selected  [%s] auto  [%s] apply  [%s] clear  [%s] export .mailmap",
+		v.key("find"), v.key("select"), v.key("merge"), v.key("auto"), v.key("apply"), v.key("clear"), v.key("mailmap")))
 }
 
 func (v *AuthorsView) markForMerge() {
@@ -129,17 +207,12 @@ func (v *AuthorsView) markForMerge() {
 	selected := v.authors[v.selectedIdx]
 
 	// Check if there's already a primary
-	var primaryEmail string
-	for email, target := range v.merges {
-		if email == target {
-			primaryEmail = email
-			break
-		}
-	}
+	primaryEmail := v.primaryEmail()
 
 	if primaryEmail == "" {
 		// No primary yet - mark this as primary
 		v.merges[selected.Email] = selected.Email
+		v.primary = selected.Email
 	} else if selected.Email != primaryEmail {
 		// Add as alias of the primary
 		v.merges[selected.Email] = primaryEmail
@@ -148,6 +221,21 @@ func (v *AuthorsView) markForMerge() {
 	v.refreshList()
 }
 
+// primaryEmail returns the pending merge target new aliases join, or "" if
+// none is marked. That is the last primary marked by hand, falling back to
+// the most active one.
+func (v *AuthorsView) primaryEmail() string {
+	if v.primary != "" && v.merges[v.primary] == v.primary {
+		return v.primary
+	}
+	for _, a := range v.authors {
+		if v.merges[a.Email] == a.Email {
+			return a.Email
+		}
+	}
+	return ""
+}
+
 func (v *AuthorsView) toggleSelection() {
 	if v.selectedIdx < 0 || v.selectedIdx >= len(v.authors) {
 		return
@@ -221,6 +309,18 @@ func (v *AuthorsView) applyMerges() {
 	}
 }
 
+func (v *AuthorsView) exportMailmap() {
+	if v.onExport == nil {
+		return
+	}
+	msg, err := v.onExport()
+	if err != nil {
+		v.info.SetText(fmt.Sprintf("[red]Export failed: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	v.info.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(msg)))
+}
+
 // Refresh updates the view with new data
 func (v *AuthorsView) Refresh(repo *stats.Repository) {
 	v.repoStats = repo
@@ -233,14 +333,6 @@ func (v *AuthorsView) refreshList() {
 	// Get authors sorted by commits
 	v.authors = v.repoStats.GetLeaderboard("commits", false)
 
-	// Find pending merges for display
-	var primaryEmail string
-	for email, target := range v.merges {
-		if email == target {
-			primaryEmail = email
-		}
-	}
-
 	for _, author := range v.authors {
 		status := ""
 		mainText := author.Name
@@ -257,11 +349,15 @@ func (v *AuthorsView) refreshList() {
 				status = " [green]★ PRIMARY[-]"
 				mainText = fmt.Sprintf("[green]%s[-]", author.Name)
 			} else {
-				status = fmt.Sprintf(" [yellow]→ %s[-]", getPrimaryName(v.authors, primaryEmail))
+				status = fmt.Sprintf(" [yellow]→ %s[-]", getPrimaryName(v.authors, email))
 				mainText = fmt.Sprintf("[yellow]%s[-]", author.Name)
 			}
 		}
 
+		if v.batch != nil && v.batch.Watched(KindAuthors, author.Email) {
+			mainText = watchMark + mainText
+		}
+
 		secondary := fmt.Sprintf("<%s> %d commits%s", author.Email, author.Commits, status)
 		v.list.AddItem(mainText, secondary, 0, nil)
 	}
@@ -286,13 +382,13 @@ func (v *AuthorsView) refreshList() {
 	// Dynamic help text based on state
 	var helpText string
 	if len(v.selected) >= 2 {
-		helpText = fmt.Sprintf("[m] MERGE %d selected | [c] clear", len(v.selected))
+		helpText = fmt.Sprintf("[%s] MERGE %d selected | %s | [%s] clear", v.key("merge"), len(v.selected), batchHelp(v.keys, "Authors"), v.key("clear"))
 	} else if mergeCount > 0 {
-		helpText = fmt.Sprintf("[green][a] APPLY %d merge(s)[-] | [m] add more | [c] clear", mergeCount)
+		helpText = fmt.Sprintf("[green][%s] APPLY %d merge(s)[-] | [%s] add more | [%s] clear", v.key("apply"), mergeCount, v.key("merge"), v.key("clear"))
 	} else if hasPrimary {
-		helpText = "[m] add alias to PRIMARY | [a] apply | [c] clear"
+		helpText = fmt.Sprintf("[%s] add alias to PRIMARY | [%s] apply | [%s] clear", v.key("merge"), v.key("apply"), v.key("clear"))
 	} else {
-		helpText = "[m] mark as PRIMARY (first), then [m] on aliases"
+		helpText = fmt.Sprintf("[%s] mark as PRIMARY (first), then [%s] on aliases", v.key("merge"), v.key("merge"))
 	}
 
 	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors | %s", len(v.authors), helpText))
@@ -325,9 +421,33 @@ func (v *AuthorsView) showAuthorDetails(author *stats.AuthorStats) {
 	content += fmt.Sprintf("  Deletions:   [red]-%d[-]\n", author.Deletions)
 	content += fmt.Sprintf("  Files:       [cyan]%d[-]\n", len(author.FilesTouched))
 
+	estimate := stats.EstimateHours(author.CommitTimes, v.sessionGap, v.sessionStart)
+	content += fmt.Sprintf("  Est. hours:  [cyan]%.1f[-] [gray](%d sessions)[-]\n", estimate.Hours, estimate.Sessions)
+
 	if !author.FirstCommit.IsZero() {
-		content += fmt.Sprintf("\n  First:       [gray]%s[-]\n", author.FirstCommit.Format("2006-01-02"))
-		content += fmt.Sprintf("  Last:        [gray]%s[-]\n", author.LastCommit.Format("2006-01-02"))
+		content += fmt.Sprintf("\n  First:       [gray]%s[-]\n", i18n.Date(author.FirstCommit))
+		content += fmt.Sprintf("  Last:        [gray]%s[-]\n", i18n.Date(author.LastCommit))
+	}
+
+	// What kind of files the author works on, by touches
+	if kinds := author.FileKinds(); len(kinds) > 0 {
+		content += "\n[yellow]━━━ Work Mix ━━━[-]\n\n"
+		for _, k := range kinds {
+			content += fmt.Sprintf("  %-7s [green]%s[-] %5.1f%%  [gray]%d files[-]\n",
+				k.Kind, shareBar(k.Percent, 20), k.Percent, k.Files)
+		}
+	}
+
+	// Estimated hours of the most recent active weeks next to their commits
+	if weeks := estimate.Weeks(); len(weeks) > 0 {
+		if len(weeks) > 8 {
+			weeks = weeks[len(weeks)-8:]
+		}
+		content += "\n[yellow]━━━ Weekly Hours ━━━[-]\n\n"
+		for _, week := range weeks {
+			content += fmt.Sprintf("  %s  [cyan]%5.1fh[-]  [gray]%d commits[-]\n", week, estimate.Weekly[week], estimate.Commits[week])
+		}
+		content += fmt.Sprintf("\n  [gray]Average %.1fh per active week[-]\n", estimate.ActiveWeekAverage())
 	}
 
 	// Show similar authors (potential merge candidates)
@@ -335,9 +455,9 @@ func (v *AuthorsView) showAuthorDetails(author *stats.AuthorStats) {
 	similar := findSimilarAuthors(v.authors, author)
 	if len(similar) > 0 {
 		for _, s := range similar {
-			content += fmt.Sprintf("  • %s <%s>\n", s.Name, s.Email)
+			content += fmt.Sprintf("  • %s <%s> [gray]%s[-]\n", s.Name, s.Email, s.reason)
 		}
-		content += "\n[gray]Press [m] to merge selected authors[-]\n"
+		content += fmt.Sprintf("\n[gray]Press [%s] to merge selected authors[-]\n", v.key("merge"))
 	} else {
 		content += "  [gray]No similar authors found[-]\n"
 	}
@@ -356,72 +476,81 @@ func (v *AuthorsView) showAuthorDetails(author *stats.AuthorStats) {
 	v.detail.SetText(content)
 }
 
-func findSimilarAuthors(authors []*stats.AuthorStats, target *stats.AuthorStats) []*stats.AuthorStats {
-	var similar []*stats.AuthorStats
-
-	targetNameLower := toLowerCase(target.Name)
-	targetEmailPrefix := getEmailPrefix(target.Email)
+// SetSessionLimits sets how commits are grouped into work sessions for the
+// hours estimate
+func (v *AuthorsView) SetSessionLimits(maxGap, start time.Duration) {
+	v.sessionGap = maxGap
+	v.sessionStart = start
+}
 
-	for _, a := range authors {
-		if a.Email == target.Email {
-			continue
-		}
+// similarAuthor is a possible duplicate identity with the rule that matched
+type similarAuthor struct {
+	*stats.AuthorStats
+	reason string
+}
 
-		// Check name similarity
-		nameLower := toLowerCase(a.Name)
-		emailPrefix := getEmailPrefix(a.Email)
+func findSimilarAuthors(authors []*stats.AuthorStats, target *stats.AuthorStats) []similarAuthor {
+	candidates := identity.Similar(authorIdentity(target), authorIdentities(authors), identity.SuggestThreshold)
 
-		// Same first name or similar email prefix
-		if containsWord(nameLower, targetNameLower) ||
-			containsWord(targetNameLower, nameLower) ||
-			emailPrefix == targetEmailPrefix {
-			similar = append(similar, a)
+	// Strongest match first, then by commits
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
 		}
-	}
-
-	// Sort by commits
-	sort.Slice(similar, func(i, j int) bool {
-		return similar[i].Commits > similar[j].Commits
+		return authors[candidates[i].Index].Commits > authors[candidates[j].Index].Commits
 	})
 
 	// Limit to top 5
-	if len(similar) > 5 {
-		similar = similar[:5]
+	if len(candidates) > 5 {
+		candidates = candidates[:5]
 	}
 
+	similar := make([]similarAuthor, len(candidates))
+	for i, c := range candidates {
+		similar[i] = similarAuthor{authors[c.Index], c.Reason}
+	}
 	return similar
 }
 
-func toLowerCase(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c += 'a' - 'A'
+// autoMerge marks every group of confidently matching identities for
+// merging into its most active member
+func (v *AuthorsView) autoMerge() {
+	added := 0
+	for _, cluster := range identity.Clusters(authorIdentities(v.authors), identity.AutoMergeThreshold) {
+		// Leave groups the user already touched alone
+		pending := false
+		for _, idx := range cluster {
+			if _, ok := v.merges[v.authors[idx].Email]; ok {
+				pending = true
+			}
+		}
+		if pending {
+			continue
+		}
+
+		// Authors are sorted by commits, so the first member is the primary
+		primary := v.authors[cluster[0]].Email
+		for _, idx := range cluster {
+			v.merges[v.authors[idx].Email] = primary
 		}
-		result[i] = c
+		added += len(cluster) - 1
+	}
+	v.refreshList()
+	if added == 0 {
+		v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors | no confident duplicates found", len(v.authors)))
 	}
-	return string(result)
 }
 
-func getEmailPrefix(email string) string {
-	for i, c := range email {
-		if c == '@' {
-			return email[:i]
-		}
-	}
-	return email
+func authorIdentity(a *stats.AuthorStats) identity.Identity {
+	return identity.Identity{Name: a.Name, Email: a.Email}
 }
 
-func containsWord(s, word string) bool {
-	if len(word) == 0 || len(s) == 0 {
-		return false
-	}
-	// Check if first 3 chars match (handles "John" and "Johnny")
-	if len(word) >= 3 && len(s) >= 3 {
-		return s[:3] == word[:3]
+func authorIdentities(authors []*stats.AuthorStats) []identity.Identity {
+	ids := make([]identity.Identity, len(authors))
+	for i, a := range authors {
+		ids[i] = authorIdentity(a)
 	}
-	return s == word
+	return ids
 }
 
 // SetMerges sets the current merge mappings
@@ -0,0 +1,272 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// setupSearch builds the fuzzy-search merge dialog shown over the authors list
+func (v *AuthorsView) setupSearch() {
+	v.searchInput = tview.NewInputField().
+		SetLabel(" " + i18n.T("Find") + ": ").
+		SetLabelColor(tcell.ColorYellow).
+		SetPlaceholder(i18n.T("name or email fragment")).
+		SetFieldBackgroundColor(tcell.ColorDarkSlateGray)
+	v.searchInput.SetChangedFunc(func(text string) {
+		v.filterSearch()
+	})
+	v.searchInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			v.closeSearch()
+			return nil
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyDown:
+			if len(v.searchMatches) > 0 {
+				v.focus(v.searchList)
+			}
+			return nil
+		}
+		return event
+	})
+
+	v.searchList = tview.NewList().
+		ShowSecondaryText(true).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
+	v.searchList.SetInputCapture(v.handleSearchInput)
+
+	v.searchInfo = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	dialog := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.searchInput, 1, 0, true).
+		AddItem(v.searchList, 0, 1, false).
+		AddItem(v.searchInfo, 1, 0, false)
+	dialog.SetBorder(true).SetTitle(" " + i18n.T("Merge Authors") + " ")
+
+	// Center the dialog over the authors list
+	v.search = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(dialog, 0, 4, true).
+			AddItem(nil, 0, 1, false), 0, 3, true).
+		AddItem(nil, 0, 1, false)
+}
+
+func (v *AuthorsView) handleSearchInput(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc:
+		v.closeSearch()
+		return nil
+	case tcell.KeyTab, tcell.KeyBacktab:
+		v.focus(v.searchInput)
+		return nil
+	case tcell.KeyEnter:
+		if author := v.currentSearchMatch(); author != nil {
+			v.setPrimary(author.Email)
+			v.renderSearch()
+		}
+		return nil
+	case tcell.KeyRune:
+		if event.Rune() == ' ' {
+			if author := v.currentSearchMatch(); author != nil {
+				v.toggleAlias(author.Email)
+				v.renderSearch()
+			}
+			return nil
+		}
+		// Any other character refines the search
+		v.searchInput.SetText(v.searchInput.GetText() + string(event.Rune()))
+		v.focus(v.searchInput)
+		return nil
+	}
+	return event
+}
+
+func (v *AuthorsView) openSearch() {
+	if v.repoStats == nil {
+		return
+	}
+	v.searching = true
+	v.searchInput.SetText("")
+	v.filterSearch()
+	v.root.ShowPage("search")
+	v.focus(v.searchInput)
+}
+
+func (v *AuthorsView) closeSearch() {
+	v.searching = false
+	v.root.HidePage("search")
+	v.focus(v.list)
+	v.refreshList()
+}
+
+// filterSearch ranks authors against the typed fragment
+func (v *AuthorsView) filterSearch() {
+	pattern := v.searchInput.GetText()
+
+	type match struct {
+		author *stats.AuthorStats
+		score  int
+	}
+	var matches []match
+	for _, a := range v.authors {
+		if score, ok := fuzzyScore(pattern, a.Name+" "+a.Email); ok {
+			matches = append(matches, match{a, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].author.Commits != matches[j].author.Commits {
+			return matches[i].author.Commits > matches[j].author.Commits
+		}
+		return matches[i].author.Email < matches[j].author.Email
+	})
+
+	v.searchMatches = v.searchMatches[:0]
+	for _, m := range matches {
+		v.searchMatches = append(v.searchMatches, m.author)
+	}
+	v.searchList.SetCurrentItem(0)
+	v.renderSearch()
+}
+
+func (v *AuthorsView) renderSearch() {
+	current := v.searchList.GetCurrentItem()
+	v.searchList.Clear()
+
+	primary := v.primaryEmail()
+	for _, author := range v.searchMatches {
+		mainText := author.Name
+		status := ""
+		if target, ok := v.merges[author.Email]; ok {
+			if target == author.Email {
+				mainText = fmt.Sprintf("[green]★ %s[-]", author.Name)
+				status = " [green]PRIMARY[-]"
+			} else {
+				mainText = fmt.Sprintf("[yellow]→ %s[-]", author.Name)
+				status = " [yellow]ALIAS[-]"
+			}
+		}
+		secondary := fmt.Sprintf("<%s> %d commits%s", author.Email, author.Commits, status)
+		v.searchList.AddItem(mainText, secondary, 0, nil)
+	}
+	if current >= 0 && current < len(v.searchMatches) {
+		v.searchList.SetCurrentItem(current)
+	}
+
+	aliases := 0
+	for email, target := range v.merges {
+		if email != target {
+			aliases++
+		}
+	}
+	primaryText := "[gray]none[-]"
+	if primary != "" {
+		primaryText = fmt.Sprintf("[green]%s[-]", getPrimaryName(v.authors, primary))
+	}
+	v.searchInfo.SetText(fmt.Sprintf("[yellow]%d[-]/%d | primary: %s, %d alias(es) | [Enter] primary  [Space] alias  [Tab] find  [Esc] done",
+		len(v.searchMatches), len(v.authors), primaryText, aliases))
+}
+
+func (v *AuthorsView) currentSearchMatch() *stats.AuthorStats {
+	idx := v.searchList.GetCurrentItem()
+	if idx < 0 || idx >= len(v.searchMatches) {
+		return nil
+	}
+	return v.searchMatches[idx]
+}
+
+// setPrimary makes email the merge target, moving the aliases and the
+// previous primary of its group over to it
+func (v *AuthorsView) setPrimary(email string) {
+	previous := v.merges[email]
+	if previous == "" {
+		previous = v.primaryEmail()
+	}
+	for alias, target := range v.merges {
+		if target == previous {
+			v.merges[alias] = email
+		}
+	}
+	v.merges[email] = email
+	v.primary = email
+}
+
+// toggleAlias adds or removes email as an alias of the primary. Without a
+// primary the author becomes the primary.
+func (v *AuthorsView) toggleAlias(email string) {
+	primary := v.primaryEmail()
+	switch {
+	case primary == "":
+		v.merges[email] = email
+		v.primary = email
+	case email == primary:
+		return
+	case v.merges[email] != "":
+		delete(v.merges, email)
+	default:
+		v.merges[email] = primary
+	}
+}
+
+// fuzzyScore matches every space-separated word of pattern as a
+// case-insensitive subsequence of text. Consecutive characters and matches
+// at word starts score higher.
+func fuzzyScore(pattern, text string) (int, bool) {
+	text = strings.ToLower(text)
+	total := 0
+	for _, word := range strings.Fields(strings.ToLower(pattern)) {
+		score, ok := fuzzyWordScore(word, text)
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+func fuzzyWordScore(word, text string) (int, bool) {
+	pattern := []rune(word)
+	score, pi, last := 0, 0, -2
+	var prev rune
+	for i, c := range []rune(text) {
+		if pi < len(pattern) && c == pattern[pi] {
+			score++
+			if i == last+1 {
+				score += 5
+			}
+			if i == 0 || strings.ContainsRune(" .-_@<", prev) {
+				score += 3
+			}
+			last = i
+			pi++
+		}
+		prev = c
+	}
+	return score, pi == len(pattern)
+}
+
+// HasDialog reports whether the search or preview dialog is open and
+// should receive every key
+func (v *AuthorsView) HasDialog() bool {
+	return v.searching || v.previewing
+}
+
+// SetFocusFunc sets the callback used to move focus within the view
+func (v *AuthorsView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.focus = focus
+}
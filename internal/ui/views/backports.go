@@ -0,0 +1,152 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// BackportsView displays cherry-picks and backports to release branches
+type BackportsView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewBackportsView creates a new backports view
+func NewBackportsView() *BackportsView {
+	v := &BackportsView{
+		columns: []string{"#", "Branch", "Commits", "Cherry-picks", "Patch Dupes", "Backported", "Backport%"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *BackportsView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Cherry-picks") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 0, 1, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *BackportsView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.summary.SetText(v.renderCherryPicks(repo))
+
+	totalCommits, totalBackported := 0, 0
+	for i, bp := range repo.Backports {
+		row := i + 1
+		totalCommits += bp.Commits
+		totalBackported += bp.Backported
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(bp.Branch).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", bp.Commits)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", bp.CherryPicks)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", bp.PatchDuplicates)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", bp.Backported)).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignRight))
+
+		pct := safeDivide(float64(bp.Backported), float64(bp.Commits)) * 100
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%.1f%%", pct)).
+			SetTextColor(getBackportColor(pct)).
+			SetAlign(tview.AlignRight))
+	}
+
+	if len(repo.Backports) == 0 {
+		v.info.SetText("[gray]No release branches found (Config.BackportBranches)[-]")
+		return
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] release branches | [yellow]%d[-] of [yellow]%d[-] branch-only commits are backports",
+		len(repo.Backports), totalBackported, totalCommits))
+}
+
+func (v *BackportsView) renderCherryPicks(repo *stats.Repository) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("  [::b]%d[-:-:-] commits on the scanned branch were cherry-picked (git cherry-pick -x)\n\n",
+		len(repo.CherryPicks)))
+
+	for _, cp := range repo.CherryPicks {
+		source := cp.SourceHash
+		if len(source) > 7 {
+			source = source[:7]
+		}
+		subject := cp.Subject
+		if len(subject) > 60 {
+			subject = subject[:57] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("  [yellow]%s[-] ← [gray]%s[-]  %s  [aqua]%s[-]  %s\n",
+			cp.Hash, source, i18n.Date(cp.At), cp.Author, subject))
+	}
+
+	return sb.String()
+}
+
+func getBackportColor(pct float64) tcell.Color {
+	switch {
+	case pct >= 75:
+		return tcell.ColorGreen
+	case pct >= 25:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// Root returns the root primitive
+func (v *BackportsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *BackportsView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,75 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Kinds of rows a batch action applies to
+const (
+	KindFiles   = "files"
+	KindDirs    = "dirs"
+	KindAuthors = "authors"
+)
+
+// Batch applies actions to the rows marked with Space in the Top Files,
+// Ownership and Authors views, or to the current row when none is marked.
+// Items are file paths, directory paths or author emails depending on kind;
+// actions return a message for the view's info bar.
+type Batch interface {
+	Watched(kind, item string) bool
+	Watch(kind string, items []string) string
+	Exclude(kind string, items []string)
+	Export(kind string, items []string) (string, error)
+	Open(files []string) (string, error)
+}
+
+// Actions of the batch bindings, shared by the views offering them
+var batchActions = []string{"watch", "exclude", "export", "open"}
+
+// batchHelp describes the batch keys bound in scope for an info bar
+func batchHelp(keys *KeyMap, scope string) string {
+	var hints []string
+	for _, action := range batchActions {
+		if key := keys.Key(scope, action); key != "" {
+			hints = append(hints, fmt.Sprintf("[%s] %s", key, action))
+		}
+	}
+	return strings.Join(hints, "  ")
+}
+
+// runBatch runs a batch action on items, reporting whether it applied and
+// the colored result to show in the info bar once the view has redrawn its
+// rows
+func runBatch(batch Batch, action string, kind string, items []string) (string, bool) {
+	if batch == nil || len(items) == 0 {
+		return "", false
+	}
+	var msg string
+	var err error
+	switch action {
+	case "watch":
+		msg = batch.Watch(kind, items)
+	case "exclude":
+		batch.Exclude(kind, items)
+		return "", true
+	case "export":
+		msg, err = batch.Export(kind, items)
+	case "open":
+		if kind != KindFiles {
+			return "", false
+		}
+		msg, err = batch.Open(items)
+	default:
+		return "", false
+	}
+	if err != nil {
+		return fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())), true
+	}
+	return fmt.Sprintf("[green]%s[-]", tview.Escape(msg)), true
+}
+
+// watchMark prefixes watched rows
+const watchMark = "[aqua]◆[-] "
@@ -0,0 +1,142 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
+)
+
+// BranchingView displays commit graph and branching complexity metrics
+type BranchingView struct {
+	root *tview.Flex
+	text *tview.TextView
+}
+
+// NewBranchingView creates a new branching view
+func NewBranchingView() *BranchingView {
+	v := &BranchingView{}
+	v.setup()
+	return v
+}
+
+func (v *BranchingView) setup() {
+	v.text = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+
+	v.root = tview.NewFlex().
+		AddItem(nil, 2, 0, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(v.text, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 1, true).
+		AddItem(nil, 2, 0, false)
+}
+
+// Refresh updates the view with new data
+func (v *BranchingView) Refresh(repo *stats.Repository) {
+	b := repo.GetBranchingStats()
+
+	var sb strings.Builder
+	sb.WriteString("[::b]Branching Summary[-:-:-]\n")
+	sb.WriteString("[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+
+	sb.WriteString("  [::b]Commit Graph[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Commits:            [cyan]%d[-]\n", b.Commits))
+	sb.WriteString(fmt.Sprintf("  Merge Commits:      [cyan]%d[-]\n", b.Merges))
+	sb.WriteString(fmt.Sprintf("  Octopus Merges:     [cyan]%d[-] (more than two parents)\n", b.OctopusMerges))
+	sb.WriteString(fmt.Sprintf("  Root Commits:       [cyan]%d[-]\n", b.RootCommits))
+	sb.WriteString(fmt.Sprintf("  Merge Ratio:        [%s]%.1f%%[-] of commits\n", getMergeRatioColor(b.MergeRatio), b.MergeRatio*100))
+	sb.WriteString(fmt.Sprintf("  Avg Parents:        [cyan]%.2f[-] per commit\n", b.AvgParents))
+
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Integration Frequency[-:-:-]\n\n")
+	if b.Merges == 0 {
+		sb.WriteString("  [gray]No merge commits in the selected range[-]\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("  Merges per Week:    [cyan]%.1f[-]\n", b.MergesPerWeek))
+		sb.WriteString(fmt.Sprintf("  Weeks with Merges:  [cyan]%d[-] of %d\n", b.ActiveMergeWeeks, len(b.Weeks)))
+		sb.WriteString(fmt.Sprintf("  Avg Days Between:   [cyan]%.1f[-]\n", b.AvgDaysBetween))
+		sb.WriteString(fmt.Sprintf("  Longest Gap:        [cyan]%d[-] days\n", b.LongestMergeGap))
+		sb.WriteString(fmt.Sprintf("  Commits per Merge:  [cyan]%.1f[-]\n", b.CommitsPerMerge))
+		sb.WriteString(fmt.Sprintf("\n  Merges/week:  [green]%s[-]\n", components.RenderSparklineWithWidth(b.MergesByWeek, 52)))
+	}
+
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Trunk Integration[-:-:-]\n\n")
+	if b.DirectCommits > 0 {
+		sb.WriteString(fmt.Sprintf("  Direct to Trunk:    [green]%d[-] commits ([green]%.1f%%[-])\n", b.DirectCommits, b.DirectShare))
+		sb.WriteString(fmt.Sprintf("  Via Branches:       [yellow]%.1f%%[-] of non-merge commits\n", b.BranchCommitShare))
+	} else {
+		sb.WriteString("  Direct to Trunk:    [gray]n/a[-]\n")
+	}
+	sb.WriteString(fmt.Sprintf("  Workflow:           [::b]%s[-:-:-]\n", b.Style()))
+
+	v.writeStrategies(&sb, repo.GetMergeStrategies())
+
+	v.text.SetText(sb.String())
+	v.text.ScrollToBeginning()
+}
+
+// writeStrategies adds the split of integration strategies, overall and for
+// the most recent months
+func (v *BranchingView) writeStrategies(sb *strings.Builder, s *stats.MergeStrategyStats) {
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Integration Strategies[-:-:-]\n\n")
+
+	total := s.Total.Total()
+	if total == 0 {
+		sb.WriteString("  [gray]No commits in the selected range[-]\n")
+		return
+	}
+	share := func(n int) float64 { return float64(n) / float64(total) * 100 }
+	sb.WriteString(fmt.Sprintf("  Merge Commits:      [cyan]%d[-] (%.1f%%)\n", s.Total.Merge, share(s.Total.Merge)))
+	sb.WriteString(fmt.Sprintf("  Squash Merges:      [cyan]%d[-] (%.1f%%)\n", s.Total.Squash, share(s.Total.Squash)))
+	sb.WriteString(fmt.Sprintf("  Rebased:            [cyan]%d[-] (%.1f%%)\n", s.Total.Rebase, share(s.Total.Rebase)))
+	sb.WriteString(fmt.Sprintf("  Direct Commits:     [cyan]%d[-] (%.1f%%)\n", s.Total.Direct, share(s.Total.Direct)))
+	if s.GraphKnown {
+		sb.WriteString(fmt.Sprintf("  Branch Commits:     [gray]%d brought in by merges[-]\n", s.BranchCommits))
+	} else {
+		sb.WriteString("  [gray]First-parent chain not scanned: all non-merge commits count as trunk[-]\n")
+	}
+	sb.WriteString(fmt.Sprintf("  Predominant:        [::b]%s[-:-:-]\n", s.Total.Dominant()))
+
+	months := s.Months
+	monthly := s.Monthly
+	if len(months) > 12 {
+		months = months[len(months)-12:]
+		monthly = monthly[len(monthly)-12:]
+	}
+	sb.WriteString(fmt.Sprintf("\n  [yellow]%-9s %7s %7s %7s %7s[-]\n", "Month", "Merge", "Squash", "Rebase", "Direct"))
+	for i, month := range months {
+		c := monthly[i]
+		sb.WriteString(fmt.Sprintf("  %-9s %7d %7d %7d %7d  [gray]%s[-]\n",
+			month, c.Merge, c.Squash, c.Rebase, c.Direct, c.Dominant()))
+	}
+}
+
+func getMergeRatioColor(ratio float64) string {
+	switch {
+	case ratio >= 0.3:
+		return "red"
+	case ratio >= 0.1:
+		return "yellow"
+	default:
+		return "green"
+	}
+}
+
+// Root returns the root primitive
+func (v *BranchingView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *BranchingView) GetFocusable() tview.Primitive {
+	return v.text
+}
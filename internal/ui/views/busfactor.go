@@ -0,0 +1,258 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// maxLeavers bounds the simulated departures and the curve of unowned files
+const maxLeavers = 10
+
+// BusFactorView simulates the top contributors leaving and ranks the files
+// and directories nobody else knows
+type BusFactorView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	detail  *tview.TextView
+	info    *tview.TextView
+	columns []string
+	keys    *KeyMap
+	repo    *stats.Repository
+	leavers int
+	impact  *stats.DepartureImpact
+}
+
+// NewBusFactorView creates a new bus factor view
+func NewBusFactorView() *BusFactorView {
+	v := &BusFactorView{
+		columns: []string{"#", "Path", "Kind", "Churn", "Lost", "Remaining", "Owners"},
+		keys:    defaultKeys,
+	}
+	v.setup()
+	return v
+}
+
+func (v *BusFactorView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true)
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Risk Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 3, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if v.impact != nil && row > 0 && row <= len(v.impact.Risks) {
+			v.showRiskDetails(v.impact.Risks[row-1])
+		}
+	})
+}
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *BusFactorView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Bus Factor", "fewer", func() { v.setLeavers(v.leavers - 1) })
+	keys.Handle("Bus Factor", "more", func() { v.setLeavers(v.leavers + 1) })
+}
+
+// Refresh updates the view with new data, starting the simulation at the
+// repository's bus factor
+func (v *BusFactorView) Refresh(repo *stats.Repository) {
+	v.repo = repo
+	v.leavers = 0
+	v.setLeavers(repo.BusFactor())
+}
+
+func (v *BusFactorView) setLeavers(n int) {
+	if v.repo == nil {
+		return
+	}
+	n = max(1, min(n, maxLeavers, len(v.repo.Authors)))
+	if n == v.leavers && v.impact != nil {
+		return
+	}
+	v.leavers = n
+	v.render()
+}
+
+func (v *BusFactorView) render() {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.impact = v.repo.SimulateDeparture(v.leavers)
+	v.renderSummary()
+
+	for i, risk := range v.impact.Risks {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		path, kind := risk.Path, i18n.T("file")
+		if risk.IsDir {
+			path, kind = risk.Path+"/", i18n.T("dir")
+		}
+		v.table.SetCell(row, 1, tview.NewTableCell(truncatePath(path, 45)).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(kind).
+			SetTextColor(tcell.ColorDarkGray))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(formatNumber(risk.Churn)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.0f%%", risk.Lost)).
+			SetTextColor(lostShareColor(risk.Lost)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%.0f%%", risk.Remaining)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 6, tview.NewTableCell(strings.Join(risk.Owners, ", ")).
+			SetTextColor(tcell.ColorWhite))
+	}
+
+	hint := fmt.Sprintf("[yellow]%s[-]/[yellow]%s[-] fewer/more leaving",
+		v.keys.Key("Bus Factor", "fewer"), v.keys.Key("Bus Factor", "more"))
+	if len(v.impact.Risks) == 0 {
+		v.detail.SetText("")
+		v.info.SetText(fmt.Sprintf("[green]Every known file and directory keeps an owner[-] | %s", hint))
+		return
+	}
+
+	v.info.SetText(fmt.Sprintf("[red]%d[-] files and [red]%d[-] directories lose every author holding ≥%.0f%% | %s",
+		v.impact.LostFiles, v.impact.LostDirs, stats.KnowledgeShare, hint))
+
+	v.table.Select(1, 0)
+	v.table.ScrollToBeginning()
+	v.showRiskDetails(v.impact.Risks[0])
+}
+
+func (v *BusFactorView) renderSummary() {
+	var sb strings.Builder
+
+	names := make([]string, 0, len(v.impact.Leaving))
+	for _, a := range v.impact.Leaving {
+		names = append(names, a.Name)
+	}
+	sb.WriteString(fmt.Sprintf(" [::b]If the top %d leave[-:-:-] [gray](%s)[-]: ", v.leavers, strings.Join(names, ", ")))
+	sb.WriteString(fmt.Sprintf("[%s]%.0f%%[-] of files (%d of %d), %.0f%% of their churn, %d of %d directories become unowned\n",
+		busFactorRiskColor(v.impact.FilePercent()), v.impact.FilePercent(), v.impact.LostFiles, v.impact.Files,
+		v.impact.ChurnPercent(), v.impact.LostDirs, v.impact.Dirs))
+
+	sb.WriteString(fmt.Sprintf(" [gray]Bus factor %d. Unowned files as the top contributors leave:[-]", v.repo.BusFactor()))
+	for i, percent := range v.repo.DepartureCurve(maxLeavers) {
+		marker := ""
+		if i+1 == v.leavers {
+			marker = "::b"
+		}
+		sb.WriteString(fmt.Sprintf("  [%s:%s]%d→%.0f%%[-:-:-]", busFactorRiskColor(percent), marker, i+1, percent))
+	}
+	sb.WriteString("\n")
+
+	v.summary.SetText(sb.String())
+}
+
+func (v *BusFactorView) showRiskDetails(risk *stats.KnowledgeLoss) {
+	var sb strings.Builder
+
+	path := risk.Path
+	if risk.IsDir {
+		path += "/"
+	}
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n\n", path))
+
+	measure := "commits"
+	if risk.IsDir {
+		measure = "churn"
+	}
+	sb.WriteString(fmt.Sprintf("[cyan]Churn:[-]     %s lines\n", formatNumber(risk.Churn)))
+	sb.WriteString(fmt.Sprintf("[cyan]Leaving:[-]   %s %.0f%% of %s\n", shareBar(risk.Lost, 10), risk.Lost, measure))
+	sb.WriteString(fmt.Sprintf("[cyan]Remaining:[-] %s %.0f%%\n\n", shareBar(risk.Remaining, 10), risk.Remaining))
+
+	sb.WriteString("[yellow]Known only by:[-]\n")
+	for _, owner := range risk.Owners {
+		sb.WriteString(fmt.Sprintf("  %s\n", owner))
+	}
+
+	if risk.Remaining > 0 {
+		sb.WriteString(fmt.Sprintf("\n[gray]The remaining authors hold at most %.0f%%, below the %.0f%% needed to count as knowing it[-]\n",
+			risk.Remaining, stats.KnowledgeShare))
+	} else {
+		sb.WriteString("\n[red]Nobody else has touched it[-]\n")
+	}
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// busFactorRiskColor colors the share of files that become unowned
+func busFactorRiskColor(percent float64) string {
+	switch {
+	case percent >= 50:
+		return "red"
+	case percent >= 20:
+		return "yellow"
+	default:
+		return "green"
+	}
+}
+
+func lostShareColor(share float64) tcell.Color {
+	switch {
+	case share >= 90:
+		return tcell.ColorRed
+	case share >= 60:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// Root returns the root primitive
+func (v *BusFactorView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *BusFactorView) GetFocusable() tview.Primitive {
+	return v.table
+}
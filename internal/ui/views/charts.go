@@ -0,0 +1,112 @@
+package views
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/audi70r/gitstat/internal/chart"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Charter is a view whose data can be exported as a chart
+type Charter interface {
+	// Chart returns the data shown, or nil before the first scan
+	Chart() *chart.Chart
+}
+
+// Leaderboard rows exported as a chart
+const chartAuthors = 20
+
+// Chart returns the commits per period, or in overlay mode the commits of
+// the compared authors next to everyone's
+func (v *TimelineView) Chart() *chart.Chart {
+	if v.repo == nil {
+		return nil
+	}
+	timeline := v.repo.GetTimeline(v.period, 1)
+	c := &chart.Chart{
+		Title:  periodNames[v.period][0] + " Commits",
+		Kind:   chart.Bars,
+		Labels: timeline.Labels,
+		Series: []chart.Series{{Name: "All authors", Values: floats(timeline.Values)}},
+	}
+	if overlays := v.overlays(); len(overlays) > 0 {
+		c.Kind = chart.Lines
+		c.Series[0].Color = chartColor(tcell.ColorGray)
+		for _, s := range overlays {
+			c.Series = append(c.Series, chart.Series{Name: s.Name, Values: floats(s.Values), Color: chartColor(s.Color)})
+		}
+	}
+	return c
+}
+
+// Chart returns the sorted column of the top rows in display order;
+// columns without a single number chart the commits, and net losses show
+// no bar
+func (v *LeaderboardView) Chart() *chart.Chart {
+	if v.repo == nil {
+		return nil
+	}
+	column := v.columns[v.sortCol]
+	metric := func(a *stats.AuthorStats) float64 { return float64(a.Commits) }
+	switch column {
+	case "Additions":
+		metric = func(a *stats.AuthorStats) float64 { return float64(a.Additions) }
+	case "Deletions":
+		metric = func(a *stats.AuthorStats) float64 { return float64(a.Deletions) }
+	case "Net":
+		metric = func(a *stats.AuthorStats) float64 { return float64(a.Additions - a.Deletions) }
+	case "Files":
+		metric = func(a *stats.AuthorStats) float64 { return float64(len(a.FilesTouched)) }
+	default:
+		column = "Commits"
+	}
+
+	authors := v.authors[:min(len(v.authors), chartAuthors)]
+	c := &chart.Chart{
+		Title:  fmt.Sprintf("%s by Author", column),
+		Kind:   chart.Bars,
+		Series: []chart.Series{{Name: column}},
+	}
+	for _, a := range authors {
+		c.Labels = append(c.Labels, a.Name)
+		c.Series[0].Values = append(c.Series[0].Values, metric(a))
+	}
+	return c
+}
+
+// Chart returns the commits per hour of the day
+func (v *HeatmapView) Chart() *chart.Chart {
+	if v.repo == nil {
+		return nil
+	}
+	heatmap := v.repo.GetHeatmap(v.tz)
+	c := &chart.Chart{
+		Title:  "Commits by Hour",
+		Kind:   chart.Bars,
+		Series: []chart.Series{{Name: "Commits", Values: make([]float64, 24)}},
+	}
+	for hour := 0; hour < 24; hour++ {
+		c.Labels = append(c.Labels, fmt.Sprintf("%02d", hour))
+		for day := 0; day < 7; day++ {
+			c.Series[0].Values[hour] += float64(heatmap.Matrix[day][hour])
+		}
+	}
+	return c
+}
+
+func floats(values []int) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = float64(v)
+	}
+	return out
+}
+
+// chartColor converts a terminal color for a chart
+func chartColor(c tcell.Color) color.RGBA {
+	r, g, b := c.RGB()
+	return color.RGBA{uint8(r), uint8(g), uint8(b), 0xff}
+}
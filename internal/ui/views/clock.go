@@ -0,0 +1,41 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Whether times of day are shown on the 24-hour clock
+var clock24 = true
+
+// SetTimeFormat24h chooses between the 24-hour and the 12-hour clock for
+// every time of day the views show
+func SetTimeFormat24h(on bool) {
+	clock24 = on
+}
+
+// formatHour formats a whole hour: "15:00" or "3 PM"
+func formatHour(hour int) string {
+	if clock24 {
+		return fmt.Sprintf("%02d:00", hour)
+	}
+	return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC).Format("3 PM")
+}
+
+// formatTime formats the time of day of t to the second
+func formatTime(t time.Time) string {
+	if clock24 {
+		return t.Format("15:04:05")
+	}
+	return t.Format("3:04:05 PM")
+}
+
+// formatDateTime formats the date and the time of day of t
+func formatDateTime(t time.Time) string {
+	if clock24 {
+		return i18n.Date(t) + " " + t.Format("15:04")
+	}
+	return i18n.Date(t) + " " + t.Format("3:04 PM")
+}
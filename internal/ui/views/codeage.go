@@ -0,0 +1,204 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Directories stacked by name on the code age timeline; the rest are
+// stacked together
+const codeAgeStacked = 4
+
+// CodeAgeView displays when the surviving lines of the codebase were
+// written, per quarter and per directory
+type CodeAgeView struct {
+	root     *tview.Flex
+	timeline *tview.TextView
+	table    *tview.Table
+	info     *tview.TextView
+	columns  []string
+	layout   Layout
+}
+
+// NewCodeAgeView creates a new code age view
+func NewCodeAgeView() *CodeAgeView {
+	v := &CodeAgeView{
+		columns: []string{"#", "Directory", "Lines", "Share", "Median Age", "Oldest"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *CodeAgeView) setup() {
+	v.timeline = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+	v.timeline.SetBorder(true).SetTitle(" " + i18n.T("Surviving Lines by Quarter Written") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.timeline, 0, 1, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// SetLayout sets the space available for the timeline bars
+func (v *CodeAgeView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// Refresh updates the view with new data; sample is the configured number
+// of files to blame, 0 when the pass is disabled
+func (v *CodeAgeView) Refresh(repo *stats.Repository, sample int) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	age := repo.CodeAge
+	switch {
+	case sample == 0:
+		v.timeline.SetText("")
+		v.info.SetText(`[gray]Code age is not sampled: set "code_age_sample" in the config file to the number of files to blame[-]`)
+		return
+	case age == nil:
+		v.timeline.SetText("")
+		v.info.SetText("[gray]Blaming a sample of files...[-]")
+		return
+	case age.Lines == 0:
+		v.timeline.SetText("")
+		v.info.SetText("[gray]No text files at HEAD[-]")
+		return
+	}
+
+	v.timeline.SetText(v.renderTimeline(age))
+	v.timeline.ScrollToEnd()
+
+	for i, dir := range age.Dirs {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(dir.Path).
+			SetTextColor(getDirColor(dir.Path)).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(formatNumber(dir.Lines)).
+			SetAlign(tview.AlignRight))
+
+		share := safeDivide(float64(dir.Lines), float64(age.Lines)) * 100
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f%%", share)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(formatAge(dir.MedianAge)).
+			SetTextColor(codeAgeColor(dir.MedianAge)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(dir.Oldest.Format("2006-01-02")).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+	}
+
+	info := fmt.Sprintf("[yellow]%s[-] lines in [yellow]%d[-] of %d files blamed | median age [yellow]%s[-]",
+		formatNumber(age.Lines), age.Sampled, age.Files, formatAge(age.MedianAge))
+	if age.Sampled < age.Files {
+		info += " | [gray]sampled evenly over the tree[-]"
+	}
+	v.info.SetText(info)
+}
+
+// renderTimeline draws a bar per quarter, as long as the lines written in
+// it that survive, stacked by the largest directories
+func (v *CodeAgeView) renderTimeline(age *stats.CodeAge) string {
+	var sb strings.Builder
+
+	stacked := age.Dirs[:min(codeAgeStacked, len(age.Dirs))]
+
+	// The other directories take the first color, gray
+	sb.WriteString("  ")
+	if len(age.Dirs) > len(stacked) {
+		sb.WriteString(fmt.Sprintf("[%s]█[-] other  ", sizeColors[0]))
+	}
+	for i, dir := range stacked {
+		sb.WriteString(fmt.Sprintf("[%s]█[-] %s  ", sizeColors[(i+1)%len(sizeColors)], tview.Escape(dir.Path)))
+	}
+	sb.WriteString("\n\n")
+
+	peak := 0
+	for _, lines := range age.QuarterLines {
+		peak = max(peak, lines)
+	}
+
+	// Quarter, bar, lines and share
+	barWidth := v.layout.bar(50, 30)
+	for q, quarter := range age.Quarters {
+		lines := age.QuarterLines[q]
+		counts := make([]int, len(stacked)+1)
+		counts[0] = lines
+		for i, dir := range stacked {
+			counts[i+1] = dir.Quarters[q]
+			counts[0] -= dir.Quarters[q]
+		}
+		width := lines * barWidth / max(peak, 1)
+		if lines > 0 {
+			width = max(width, 1)
+		}
+
+		sb.WriteString(fmt.Sprintf("  %s ", quarter))
+		sb.WriteString(renderStackedBar(counts, lines, width))
+		sb.WriteString(strings.Repeat(" ", barWidth-width))
+		sb.WriteString(fmt.Sprintf(" %8s %5.1f%%\n", formatNumber(lines),
+			safeDivide(float64(lines), float64(age.Lines))*100))
+	}
+
+	return sb.String()
+}
+
+// codeAgeColor shades directories whose code has not been touched in years
+func codeAgeColor(median time.Duration) tcell.Color {
+	years := median.Hours() / 24 / 365
+	switch {
+	case years >= 5:
+		return tcell.ColorRed
+	case years >= 2:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// Root returns the root primitive
+func (v *CodeAgeView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *CodeAgeView) GetFocusable() tview.Primitive {
+	return v.table
+}
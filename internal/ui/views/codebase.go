@@ -2,16 +2,19 @@ package views
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
 )
 
 // CodebaseView displays overall codebase statistics
 type CodebaseView struct {
-	root *tview.Flex
-	text *tview.TextView
+	root   *tview.Flex
+	text   *tview.TextView
+	layout Layout
 }
 
 // NewCodebaseView creates a new codebase view
@@ -47,7 +50,7 @@ func (v *CodebaseView) Refresh(repo *stats.Repository) {
 	}
 
 	// Build visual bar
-	barWidth := 50
+	barWidth := v.layout.bar(50, textPadding+2)
 	addBar := int(addPct / 100 * float64(barWidth))
 	delBar := barWidth - addBar
 
@@ -64,20 +67,23 @@ func (v *CodebaseView) Refresh(repo *stats.Repository) {
 	churnIndicator := getChurnIndicator(cbStats.RefactoredPercent)
 
 	content := fmt.Sprintf(`[::b]Codebase Changes Overview[-:-:-]
-
+%s
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
 
   [::b]Summary[-:-:-]
 
-  Total Commits:      [cyan]%d[-]
-  Total Authors:      [cyan]%d[-]
-  Files Modified:     [cyan]%d[-]
+  Total Commits:      [cyan]%s[-]%s
+  Total Authors:      [cyan]%s[-]
+  Files Modified:     [cyan]%s[-]
+  Files Created:      [green]%s[-]
+  Files Deleted:      [red]%s[-]
+  Resurrected Files:  [%s]%s[-] (deleted then re-created)
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
 
   [::b]Codebase Size[-:-:-]
 
-  Current Size:       [cyan]%s[-] lines
+  Current Size:       %s
   Churn Rate:         [%s]%.1f%%[-] of codebase touched
   Churn Level:        %s
 
@@ -101,15 +107,21 @@ func (v *CodebaseView) Refresh(repo *stats.Repository) {
 
   [::b]Activity Metrics[-:-:-]
 
-  Net Change:         [%s]%+d[-] lines
-  Avg per Commit:     [cyan]%.1f[-] lines
-  Avg per Author:     [cyan]%.1f[-] lines
+  Net Change:         [%s]%s[-] lines
+  Avg per Commit:     [cyan]%s[-] lines
+  Avg per Author:     [cyan]%s[-] lines
 
 `,
-		repo.TotalCommits,
-		repo.TotalAuthors,
-		cbStats.FilesModified,
-		formatNumber(cbStats.CodebaseSize),
+		renderPeriodComparison(repo.GetPeriodComparison()),
+		i18n.Int(repo.TotalCommits),
+		duplicateNote(repo.DuplicateCommits),
+		i18n.Int(repo.TotalAuthors),
+		i18n.Int(cbStats.FilesModified),
+		i18n.Int(cbStats.FilesAdded),
+		i18n.Int(cbStats.FilesDeleted),
+		getResurrectedColor(cbStats.FilesResurrected),
+		i18n.Int(cbStats.FilesResurrected),
+		codebaseSize(cbStats.CodebaseSize, repo.SparseFiles),
 		getChurnColor(cbStats.RefactoredPercent),
 		cbStats.RefactoredPercent,
 		churnIndicator,
@@ -121,22 +133,94 @@ func (v *CodebaseView) Refresh(repo *stats.Repository) {
 		addPct,
 		delPct,
 		getNetColor(cbStats.TotalAdditions-cbStats.TotalDeletions),
-		cbStats.TotalAdditions-cbStats.TotalDeletions,
-		safeDivide(float64(totalChanges), float64(repo.TotalCommits)),
-		safeDivide(float64(totalChanges), float64(repo.TotalAuthors)),
+		formatSigned(cbStats.TotalAdditions-cbStats.TotalDeletions),
+		i18n.Float(safeDivide(float64(totalChanges), float64(repo.TotalCommits)), 1),
+		i18n.Float(safeDivide(float64(totalChanges), float64(repo.TotalAuthors)), 1),
 	)
 
-	v.text.SetText(content)
+	content += v.renderProductivity(repo, cbStats)
+
+	v.text.SetText(v.layout.fit(content))
+}
+
+// SetLayout sets the space available for the additions/deletions bar
+func (v *CodebaseView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// renderPeriodComparison shows deltas against the preceding equal-length period
+func renderPeriodComparison(c *stats.PeriodComparison) string {
+	if c == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n  [gray]vs previous period:[-]  Commits %s  |  Churn %s  |  New contributors [cyan]%d[-]\n",
+		formatDelta(c.CommitsDelta()), formatDelta(c.ChurnDelta()), len(c.NewContributors)))
+
+	if len(c.NewContributors) > 0 {
+		names := c.NewContributors
+		more := ""
+		if len(names) > 5 {
+			more = fmt.Sprintf(" +%d more", len(names)-5)
+			names = names[:5]
+		}
+		sb.WriteString(fmt.Sprintf("  [gray]New: %s%s[-]\n", strings.Join(names, ", "), more))
+	}
+
+	return sb.String()
+}
+
+// formatDelta renders a percentage change with sign and color
+func formatDelta(pct float64) string {
+	color := "yellow"
+	if pct > 0 {
+		color = "green"
+	} else if pct < 0 {
+		color = "red"
+	}
+	return fmt.Sprintf("[%s]%+.1f%%[-]", color, pct)
+}
+
+// renderProductivity shows surviving additions vs churn repo-wide and per author
+func (v *CodebaseView) renderProductivity(repo *stats.Repository, cbStats *stats.CodebaseStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Productive vs Churn[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Net-new Lines:      [green]%s[-] (survived the range)\n", formatNumber(cbStats.SurvivingLines)))
+	sb.WriteString(fmt.Sprintf("  Churned Lines:      [red]%s[-] (added, then deleted again)\n", formatNumber(cbStats.ChurnedLines)))
+	sb.WriteString(fmt.Sprintf("  Productive Ratio:   [%s]%.1f%%[-]\n\n", getProductiveColor(cbStats.ProductivePercent), cbStats.ProductivePercent))
+
+	authors := repo.GetProductivity()
+	if len(authors) > 10 {
+		authors = authors[:10]
+	}
+	for _, a := range authors {
+		name := a.Name
+		if len(name) > 20 {
+			name = name[:17] + "..."
+		}
+		pct := a.ProductivePercent()
+		sb.WriteString(fmt.Sprintf("  %-20s [green]%8s[-] / [red]%-8s[-] [%s]%5.1f%%[-]\n",
+			name, formatNumber(a.SurvivingLines), formatNumber(a.ChurnedLines), getProductiveColor(pct), pct))
+	}
+
+	return sb.String()
 }
 
-func formatNumber(n int) string {
-	if n >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1000000)
+// codebaseSize formats the worktree size, which is counted in the background
+// after the history scan
+func codebaseSize(lines, sparse int) string {
+	if lines == 0 {
+		return "[gray]" + i18n.T("calculating...") + "[-]"
 	}
-	if n >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
+	size := i18n.T("[cyan]%s[-] lines", formatNumber(lines))
+	if sparse > 0 {
+		// The churn rate is relative to the size, so it is overstated too
+		size = "≈ " + size + " [yellow]" + i18n.T("(approximate: %d files outside the sparse checkout)", sparse) + "[-]"
 	}
-	return fmt.Sprintf("%d", n)
+	return size
 }
 
 func getNetColor(net int) string {
@@ -148,6 +232,22 @@ func getNetColor(net int) string {
 	return "white"
 }
 
+func getProductiveColor(pct float64) string {
+	if pct >= 80 {
+		return "green"
+	} else if pct >= 50 {
+		return "yellow"
+	}
+	return "red"
+}
+
+func getResurrectedColor(count int) string {
+	if count > 0 {
+		return "yellow"
+	}
+	return "cyan"
+}
+
 func getChurnColor(pct float64) string {
 	if pct >= 100 {
 		return "red"
@@ -181,3 +281,11 @@ func safeDivide(a, b float64) float64 {
 func (v *CodebaseView) Root() tview.Primitive {
 	return v.root
 }
+
+// duplicateNote mentions commits skipped as copies of a counted change
+func duplicateNote(duplicates int) string {
+	if duplicates == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [gray](%d duplicates from other refs or repositories skipped)[-]", duplicates)
+}
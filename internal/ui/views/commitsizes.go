@@ -0,0 +1,282 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Colors for size categories, smallest first
+var sizeColors = []string{"gray", "green", "cyan", "yellow", "red"}
+
+// CommitSizesView displays commit size categories per author and over time
+type CommitSizesView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	layout  Layout
+}
+
+// NewCommitSizesView creates a new commit sizes view
+func NewCommitSizesView() *CommitSizesView {
+	v := &CommitSizesView{}
+	v.setup()
+	return v
+}
+
+func (v *CommitSizesView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Size Mix") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 0, 1, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+}
+
+// Refresh updates the view with new data
+func (v *CommitSizesView) Refresh(repo *stats.Repository, thresholds []int) {
+	mix := repo.GetCommitSizeMix(thresholds)
+	labels := sizeLabels(thresholds)
+
+	v.summary.SetText(v.renderSummary(mix, labels))
+	v.renderTable(mix, labels)
+
+	totalCommits := 0
+	for _, count := range mix.Total {
+		totalCommits += count
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] commits in [yellow]%d[-] categories | merge commits excluded",
+		totalCommits, len(labels)))
+}
+
+// SetLayout sets the space available for the size bars
+func (v *CommitSizesView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+func (v *CommitSizesView) renderSummary(mix *stats.CommitSizeMix, labels []string) string {
+	var sb strings.Builder
+
+	total := 0
+	for _, count := range mix.Total {
+		total += count
+	}
+
+	// Label, percentage and count around the bars
+	totalWidth := v.layout.bar(30, 40)
+
+	sb.WriteString("  [::b]Repository[-:-:-]\n\n")
+	writeSizeBars(&sb, labels, mix.Total, total, totalWidth)
+
+	sb.WriteString("\n  [::b]Files Touched[-:-:-]\n\n")
+	writeSizeBars(&sb, fileCountLabels(stats.FileCountThresholds), mix.FilesTotal, total, totalWidth)
+
+	sb.WriteString("\n  [::b]Percentiles[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  %-18s %s\n", "Lines changed", formatPercentiles(mix.Lines)))
+	sb.WriteString(fmt.Sprintf("  %-18s %s\n", "Files touched", formatPercentiles(mix.Files)))
+
+	// Monthly stacked bars
+	if len(mix.Months) > 0 {
+		sb.WriteString("\n  [::b]By Month[-:-:-]\n\n")
+		barWidth := v.layout.bar(40, 18)
+		for m, month := range mix.Months {
+			counts := mix.ByMonth[m]
+			monthTotal := 0
+			for _, c := range counts {
+				monthTotal += c
+			}
+			sb.WriteString(fmt.Sprintf("  %s ", month))
+			sb.WriteString(renderStackedBar(counts, monthTotal, barWidth))
+			sb.WriteString(fmt.Sprintf(" %d\n", monthTotal))
+		}
+	}
+
+	return sb.String()
+}
+
+// writeSizeBars draws one bar per bucket with its share of all commits
+func writeSizeBars(sb *strings.Builder, labels []string, counts []int, total, width int) {
+	for i, label := range labels {
+		pct := safeDivide(float64(counts[i]), float64(total)) * 100
+		bar := strings.Repeat("█", int(pct/100*float64(width)))
+		sb.WriteString(fmt.Sprintf("  %-18s [%s]%-*s[-] %5.1f%% (%d)\n",
+			label, sizeColors[i%len(sizeColors)], width, bar, pct, counts[i]))
+	}
+}
+
+// formatPercentiles lists the percentiles of a size distribution
+func formatPercentiles(p stats.SizePercentiles) string {
+	return fmt.Sprintf("p50 [cyan]%s[-]  p90 [cyan]%s[-]  p99 [cyan]%s[-]  max [cyan]%s[-]",
+		formatNumber(p.P50), formatNumber(p.P90), formatNumber(p.P99), formatNumber(p.Max))
+}
+
+func (v *CommitSizesView) renderTable(mix *stats.CommitSizeMix, labels []string) {
+	v.table.Clear()
+
+	headers := append([]string{"#", "Author", "Commits"}, sizeNames(len(labels))...)
+	headers = append(headers, "Large%", "Lines p50/90/99", "Files p50/90/99")
+	for col, name := range headers {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	for i, author := range mix.ByAuthor {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(author.Name).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", author.Commits)).
+			SetAlign(tview.AlignRight))
+
+		for cat, count := range author.Counts {
+			v.table.SetCell(row, 3+cat, tview.NewTableCell(fmt.Sprintf("%d", count)).
+				SetAlign(tview.AlignRight))
+		}
+
+		largeShare := author.LargeShare()
+		largeColor := tcell.ColorWhite
+		if largeShare >= 50 {
+			largeColor = tcell.ColorRed
+		} else if largeShare >= 25 {
+			largeColor = tcell.ColorYellow
+		}
+		v.table.SetCell(row, 3+len(author.Counts), tview.NewTableCell(fmt.Sprintf("%.1f%%", largeShare)).
+			SetTextColor(largeColor).
+			SetAlign(tview.AlignRight))
+
+		// A p99 in the largest category means the author lands huge commits
+		p99Color := tcell.ColorWhite
+		if len(mix.Thresholds) > 0 && author.Lines.P99 > mix.Thresholds[len(mix.Thresholds)-1] {
+			p99Color = tcell.ColorRed
+		}
+		v.table.SetCell(row, 4+len(author.Counts), tview.NewTableCell(fmt.Sprintf("%d/%d/%d",
+			author.Lines.P50, author.Lines.P90, author.Lines.P99)).
+			SetTextColor(p99Color).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5+len(author.Counts), tview.NewTableCell(fmt.Sprintf("%d/%d/%d",
+			author.Files.P50, author.Files.P90, author.Files.P99)).
+			SetAlign(tview.AlignRight))
+	}
+}
+
+// renderStackedBar draws one colored segment per category
+func renderStackedBar(counts []int, total, width int) string {
+	if total == 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	// Rounding leftovers go to the largest non-empty category
+	last := 0
+	for i, count := range counts {
+		if count > 0 {
+			last = i
+		}
+	}
+
+	var sb strings.Builder
+	used := 0
+	for i, count := range counts {
+		segment := count * width / total
+		if i == last {
+			segment = width - used
+		}
+		if segment > 0 {
+			sb.WriteString(fmt.Sprintf("[%s]%s[-]", sizeColors[i%len(sizeColors)], strings.Repeat("█", segment)))
+		}
+		used += segment
+		if i == last {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// sizeLabels describes each category with its line range
+func sizeLabels(thresholds []int) []string {
+	names := sizeNames(len(thresholds) + 1)
+	labels := make([]string, len(names))
+	lower := 0
+	for i := range names {
+		if i < len(thresholds) {
+			labels[i] = fmt.Sprintf("%s (%d-%d)", names[i], lower, thresholds[i])
+			lower = thresholds[i] + 1
+		} else {
+			labels[i] = fmt.Sprintf("%s (%d+)", names[i], lower)
+		}
+	}
+	return labels
+}
+
+// fileCountLabels describes each bucket of files touched
+func fileCountLabels(thresholds []int) []string {
+	labels := make([]string, len(thresholds)+1)
+	lower := 1
+	for i := range labels {
+		switch {
+		case i == len(thresholds):
+			labels[i] = fmt.Sprintf("%d+ files", lower)
+		case lower == thresholds[i]:
+			labels[i] = fmt.Sprintf("%d file", lower)
+			if lower != 1 {
+				labels[i] += "s"
+			}
+		default:
+			labels[i] = fmt.Sprintf("%d-%d files", lower, thresholds[i])
+		}
+		if i < len(thresholds) {
+			lower = thresholds[i] + 1
+		}
+	}
+	return labels
+}
+
+// sizeNames returns category names, numbering any beyond the predefined ones
+func sizeNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		if n == len(stats.SizeCategories) {
+			name := stats.SizeCategories[i]
+			names[i] = strings.ToUpper(name[:1]) + name[1:]
+		} else {
+			names[i] = fmt.Sprintf("Size %d", i+1)
+		}
+	}
+	return names
+}
+
+// Root returns the root primitive
+func (v *CommitSizesView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *CommitSizesView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,177 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// ConventionsView displays commit message convention adherence
+type ConventionsView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewConventionsView creates a new message conventions view
+func NewConventionsView() *ConventionsView {
+	v := &ConventionsView{
+		columns: []string{"#", "Author", "Commits", "Emoji%", "Gitmoji", "Language", "Adherence"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *ConventionsView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Repository Conventions") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 7, 0, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	v.renderHeader()
+}
+
+func (v *ConventionsView) renderHeader() {
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *ConventionsView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	conv := repo.GetMessageConventions()
+	v.updateSummary(conv)
+
+	for i, report := range conv.AuthorReports {
+		row := i + 1
+		m := report.Messages
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(report.Name).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", m.Commits)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f%%", m.EmojiPercent())).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", m.GitmojiPrefixed)).
+			SetAlign(tview.AlignRight))
+
+		lang := m.DominantLanguage()
+		langColor := tcell.ColorWhite
+		if lang != conv.Language {
+			langColor = tcell.ColorYellow
+		}
+		v.table.SetCell(row, 5, tview.NewTableCell(lang).
+			SetTextColor(langColor))
+
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%.1f%%", report.Adherence)).
+			SetTextColor(getAdherenceColor(report.Adherence)).
+			SetAlign(tview.AlignRight))
+	}
+
+	// Count authors that mostly follow the conventions
+	following := 0
+	for _, report := range conv.AuthorReports {
+		if report.Adherence >= 80 {
+			following++
+		}
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors | [green]%d[-] follow conventions (≥80%%)",
+		len(conv.AuthorReports), following))
+}
+
+func (v *ConventionsView) updateSummary(conv *stats.MessageConventions) {
+	total := conv.Total
+
+	style := "Plain subjects (no emoji prefix)"
+	if conv.UsesEmoji {
+		style = "Emoji / gitmoji prefixed subjects"
+	}
+
+	// Language breakdown, most used first
+	langs := make([]string, 0, len(total.Languages))
+	for lang := range total.Languages {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if total.Languages[langs[i]] != total.Languages[langs[j]] {
+			return total.Languages[langs[i]] > total.Languages[langs[j]]
+		}
+		return langs[i] < langs[j]
+	})
+
+	langText := ""
+	for i, lang := range langs {
+		if i >= 3 {
+			break
+		}
+		if i > 0 {
+			langText += ", "
+		}
+		langText += fmt.Sprintf("%s %.0f%%", lang, safeDivide(float64(total.Languages[lang]), float64(total.Commits))*100)
+	}
+
+	var content string
+	content += fmt.Sprintf("  [cyan]Messages Analyzed:[-] %d (merge commits excluded)\n", total.Commits)
+	content += fmt.Sprintf("  [cyan]Detected Style:[-]    %s\n", style)
+	content += fmt.Sprintf("  [cyan]Emoji Prefixed:[-]    %.1f%% (%d gitmoji shortcodes)\n", total.EmojiPercent(), total.GitmojiPrefixed)
+	content += fmt.Sprintf("  [cyan]Languages:[-]         %s\n", langText)
+
+	v.summary.SetText(content)
+}
+
+func getAdherenceColor(pct float64) tcell.Color {
+	if pct >= 80 {
+		return tcell.ColorGreen
+	} else if pct >= 50 {
+		return tcell.ColorYellow
+	}
+	return tcell.ColorRed
+}
+
+// Root returns the root primitive
+func (v *ConventionsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *ConventionsView) GetFocusable() tview.Primitive {
+	return v.table
+}
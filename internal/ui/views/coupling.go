@@ -0,0 +1,174 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// CouplingView displays file pairs that change together, flagging those
+// that hint at hidden dependencies
+type CouplingView struct {
+	root      *tview.Flex
+	table     *tview.Table
+	detail    *tview.TextView
+	info      *tview.TextView
+	columns   []string
+	pairs     []*stats.FileCoupling
+	threshold float64
+}
+
+// NewCouplingView creates a new file coupling view
+func NewCouplingView() *CouplingView {
+	v := &CouplingView{
+		columns: []string{"#", "File A", "File B", "Shared", "Coupling", "Kinds", "Flag"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *CouplingView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Pair Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if row > 0 && row <= len(v.pairs) {
+			v.showPairDetails(v.pairs[row-1])
+		}
+	})
+}
+
+// Refresh updates the view with new data
+func (v *CouplingView) Refresh(repo *stats.Repository, minShared int, threshold float64) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.pairs = repo.GetCoupledFiles(minShared, 200)
+	v.threshold = threshold
+
+	suspicious := 0
+	for i, pair := range v.pairs {
+		row := i + 1
+		strong := pair.Score >= threshold
+		flagged := strong && pair.Suspicious()
+		if flagged {
+			suspicious++
+		}
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(truncatePath(pair.FileA, 35)).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(truncatePath(pair.FileB, 35)).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", pair.SharedCommits)).
+			SetAlign(tview.AlignRight))
+
+		scoreColor := tcell.ColorWhite
+		if strong {
+			scoreColor = tcell.ColorYellow
+		}
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.0f%%", pair.Score)).
+			SetTextColor(scoreColor).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(pair.KindA+"+"+pair.KindB).
+			SetTextColor(tcell.ColorDarkGray))
+
+		flag, flagColor := "", tcell.ColorDarkGray
+		if flagged {
+			flag, flagColor = "⚠ suspicious", tcell.ColorRed
+		} else if pair.Suspicious() {
+			flag, flagColor = "unrelated", tcell.ColorWhite
+		}
+		v.table.SetCell(row, 6, tview.NewTableCell(flag).
+			SetTextColor(flagColor))
+	}
+
+	if len(v.pairs) == 0 {
+		v.detail.SetText("")
+		v.info.SetText(fmt.Sprintf("[gray]No file pairs changed together in %d or more commits[-]", minShared))
+		return
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] coupled file pairs (≥%d shared commits) | [red]%d[-] suspicious and strongly coupled (≥%.0f%%)",
+		len(v.pairs), minShared, suspicious, threshold))
+
+	v.table.Select(1, 0)
+	v.showPairDetails(v.pairs[0])
+}
+
+func (v *CouplingView) showPairDetails(pair *stats.FileCoupling) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n[gray]%s, %d commits[-]\n\n", pair.FileA, pair.KindA, pair.CommitsA))
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n[gray]%s, %d commits[-]\n\n", pair.FileB, pair.KindB, pair.CommitsB))
+
+	sb.WriteString(fmt.Sprintf("[cyan]Changed together:[-] %d commits\n", pair.SharedCommits))
+	sb.WriteString(fmt.Sprintf("[cyan]Coupling:[-]         %.0f%% of the less changed file's commits\n\n", pair.Score))
+
+	switch {
+	case !pair.Suspicious():
+		sb.WriteString("[green]Related files[-]: changing together is expected\n")
+	case pair.CrossBoundary:
+		sb.WriteString("[yellow]Unrelated directories[-]: a change to one needs the other, a dependency the layout does not show\n")
+	default:
+		sb.WriteString("[yellow]Code tied to configuration[-]: every change to the code needs a configuration change\n")
+	}
+	if pair.Suspicious() && pair.Score < v.threshold {
+		sb.WriteString(fmt.Sprintf("\n[gray]Below the %.0f%% threshold, so not flagged[-]\n", v.threshold))
+	}
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// Root returns the root primitive
+func (v *CouplingView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *CouplingView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,169 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// DebtView displays TODO/FIXME/HACK markers per directory and the oldest ones
+type DebtView struct {
+	root   *tview.Flex
+	table  *tview.Table
+	oldest *tview.TextView
+	info   *tview.TextView
+}
+
+// NewDebtView creates a new debt markers view
+func NewDebtView() *DebtView {
+	v := &DebtView{}
+	v.setup()
+	return v
+}
+
+func (v *DebtView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.oldest = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+	v.oldest.SetBorder(true).SetTitle(" " + i18n.T("Oldest Outstanding") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.oldest, 0, 1, false).
+		AddItem(v.info, 1, 0, false)
+}
+
+// Refresh updates the view with new data
+func (v *DebtView) Refresh(repo *stats.Repository, keywords []string) {
+	v.table.Clear()
+
+	summary := repo.GetDebtSummary(30)
+
+	headers := append([]string{"#", "Directory", "Markers"}, keywords...)
+	headers = append(headers, "Oldest")
+	for col, name := range headers {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	now := time.Now()
+	for i, dir := range summary.Dirs {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(dir.Path).
+			SetTextColor(getDirColor(dir.Path)).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", dir.Total)).
+			SetAlign(tview.AlignRight))
+
+		for k, kind := range keywords {
+			v.table.SetCell(row, 3+k, tview.NewTableCell(fmt.Sprintf("%d", dir.ByKind[kind])).
+				SetTextColor(tcell.GetColor(getMarkerColor(kind))).
+				SetAlign(tview.AlignRight))
+		}
+
+		age := "-"
+		if dir.Oldest != nil && !dir.Oldest.At.IsZero() {
+			age = formatAge(now.Sub(dir.Oldest.At))
+		}
+		v.table.SetCell(row, 3+len(keywords), tview.NewTableCell(age).
+			SetAlign(tview.AlignRight))
+	}
+
+	v.oldest.SetText(v.renderOldest(summary, now))
+	v.oldest.ScrollToBeginning()
+
+	var counts []string
+	for _, kind := range keywords {
+		counts = append(counts, fmt.Sprintf("%s [yellow]%d[-]", kind, summary.ByKind[kind]))
+	}
+	info := fmt.Sprintf("[yellow]%d[-] markers in [yellow]%d[-] directories | %s",
+		summary.Total, len(summary.Dirs), strings.Join(counts, " | "))
+	if n := stats.UnattributedMarkers(repo.DebtMarkers); repo.PartialClone && n > 0 {
+		info += " | [yellow]" + i18n.T("ages approximate: %d unattributed, history missing from the partial clone", n) + "[-]"
+	}
+	v.info.SetText(info)
+}
+
+func (v *DebtView) renderOldest(summary *stats.DebtSummary, now time.Time) string {
+	if len(summary.Oldest) == 0 {
+		return "  [gray]No debt markers found in the worktree[-]\n"
+	}
+
+	var sb strings.Builder
+	for _, m := range summary.Oldest {
+		age := "?"
+		if !m.At.IsZero() {
+			age = formatAge(now.Sub(m.At))
+		}
+		author := m.Author
+		if author == "" {
+			author = "unknown"
+		}
+		text := m.Text
+		if len(text) > 70 {
+			text = text[:67] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("  [%s]%-6s[-] %6s  [aqua]%-18s[-] [gray]%s:%d[-]\n         %s\n",
+			getMarkerColor(m.Kind), m.Kind, age, author, m.File, m.Line, tview.Escape(text)))
+	}
+	return sb.String()
+}
+
+func getMarkerColor(kind string) string {
+	switch kind {
+	case "FIXME":
+		return "red"
+	case "HACK":
+		return "orange"
+	default:
+		return "yellow"
+	}
+}
+
+// formatAge renders a duration in days, months or years
+func formatAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days >= 365:
+		return fmt.Sprintf("%.1fy", float64(days)/365)
+	case days >= 60:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dd", days)
+	}
+}
+
+// Root returns the root primitive
+func (v *DebtView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *DebtView) GetFocusable() tview.Primitive {
+	return v.table
+}
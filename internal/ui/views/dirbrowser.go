@@ -0,0 +1,269 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Directories under the home directory offered as bookmarks when they exist
+var sourceDirs = []string{"src", "code", "projects", "repos", "dev", "workspace", "git", filepath.Join("go", "src")}
+
+// browserEntry is a row of the directory browser
+type browserEntry struct {
+	path   string
+	isRepo bool
+	parent bool // the ".." row
+}
+
+func (s *SetupView) showDirBrowser() {
+	dirList := tview.NewList().
+		ShowSecondaryText(true).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
+	dirList.SetBorder(true)
+
+	// Path input for jumping straight to a directory
+	pathInput := tview.NewInputField().
+		SetLabel(" " + i18n.T("Go to") + ": ").
+		SetLabelColor(tcell.ColorYellow).
+		SetPlaceholder("~/src/project").
+		SetFieldBackgroundColor(tcell.ColorDarkSlateGray)
+	pathInput.SetAutocompleteFunc(completeDir)
+
+	// Help text for browser
+	hints := s.keys.Hints(ScopeBrowser)
+	browserHelp := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(hints)
+	browserHelp.SetBackgroundColor(tcell.ColorDarkBlue)
+
+	currentPath := s.currentPath
+	var entries []browserEntry
+
+	// addEntry lists a directory, marking repositories and directories
+	// holding repositories one level down
+	addEntry := func(path, label string) {
+		isRepo := git.IsGitRepo(path)
+		if isRepo {
+			dirList.AddItem("[cyan]📦 "+tview.Escape(label)+"[-]", i18n.T("%s to add this repo", tview.Escape("["+s.keys.Key(ScopeBrowser, "add")+"]")), 0, nil)
+		} else if n := nestedRepos(path); n > 0 {
+			dirList.AddItem("[aqua]▸ "+tview.Escape(label)+"[-]", i18n.T("Directory with %d repositories", n), 0, nil)
+		} else {
+			dirList.AddItem("   "+tview.Escape(label), i18n.T("Directory"), 0, nil)
+		}
+		entries = append(entries, browserEntry{path: path, isRepo: isRepo})
+	}
+
+	populateList := func(path string) {
+		dirList.Clear()
+		entries = nil
+		dirList.SetTitle(fmt.Sprintf(" %s ", path))
+		currentPath = path
+
+		// Parent directory
+		dirList.AddItem("..", i18n.T("Go up one directory"), 0, nil)
+		entries = append(entries, browserEntry{path: filepath.Dir(path), parent: true})
+
+		// List subdirectories
+		dirEntries, err := os.ReadDir(path)
+		if err != nil {
+			return
+		}
+		for _, entry := range dirEntries {
+			if entry.IsDir() && (s.showHidden || !isHiddenDir(entry.Name())) {
+				addEntry(filepath.Join(path, entry.Name()), entry.Name())
+			}
+		}
+	}
+
+	populateBookmarks := func() {
+		dirList.Clear()
+		entries = nil
+		dirList.SetTitle(" " + i18n.T("Bookmarks") + " ")
+		for _, path := range s.bookmarks() {
+			addEntry(path, path)
+		}
+	}
+
+	populateList(currentPath)
+
+	// Browser layout
+	browserBox := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(pathInput, 1, 0, false).
+		AddItem(dirList, 0, 1, true).
+		AddItem(browserHelp, 1, 0, false)
+	browserBox.SetBorder(true).SetTitle(" " + i18n.T("Select Repository") + " ")
+
+	// Modal centered layout
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(browserBox, 24, 0, true).
+			AddItem(nil, 0, 1, false), 80, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	focus := func(p tview.Primitive) {
+		if s.app != nil {
+			s.app.SetFocus(p)
+		}
+	}
+
+	// Enter, or a click, navigates into directories
+	open := func(idx int) {
+		if idx >= 0 && idx < len(entries) {
+			populateList(entries[idx].path)
+		}
+	}
+	dirList.SetSelectedFunc(func(idx int, main, secondary string, shortcut rune) { open(idx) })
+
+	// Helper to close modal and restore focus
+	closeModal := func() {
+		s.root.RemovePage("browser")
+		s.root.SwitchToPage("main")
+		focus(s.repoList)
+	}
+
+	// Enter jumps to the typed path, Esc returns to the list
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			path := expandHome(strings.TrimSpace(pathInput.GetText()))
+			if info, err := os.Stat(path); err != nil || !info.IsDir() {
+				browserHelp.SetText("[red]" + i18n.T("Not a directory: %s", tview.Escape(path)) + "[-]")
+				return
+			}
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			populateList(path)
+		}
+		pathInput.SetText("")
+		browserHelp.SetText(hints)
+		focus(dirList)
+	})
+
+	s.keys.Handle(ScopeBrowser, "open", func() { open(dirList.GetCurrentItem()) })
+	s.keys.Handle(ScopeBrowser, "add", func() {
+		// Add the current selection, or the current directory from the
+		// ".." row, if it's a repo
+		idx := dirList.GetCurrentItem()
+		if idx < 0 || idx >= len(entries) {
+			return
+		}
+		entry := entries[idx]
+		if entry.parent {
+			entry = browserEntry{path: currentPath, isRepo: git.IsGitRepo(currentPath)}
+		}
+		if entry.isRepo {
+			s.addRepo(entry.path)
+			s.currentPath = currentPath
+			closeModal()
+		}
+	})
+	s.keys.Handle(ScopeBrowser, "goto", func() { focus(pathInput) })
+	s.keys.Handle(ScopeBrowser, "bookmarks", populateBookmarks)
+	s.keys.Handle(ScopeBrowser, "hidden", func() {
+		s.showHidden = !s.showHidden
+		populateList(currentPath)
+	})
+	s.keys.Handle(ScopeBrowser, "close", closeModal)
+	dirList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if s.keys.Dispatch(ScopeBrowser, true, event) {
+			return nil
+		}
+		return event
+	})
+
+	// Add browser as a page and switch to it
+	s.root.AddPage("browser", modal, true, true)
+
+	// Set focus on the directory list
+	focus(dirList)
+}
+
+// bookmarks returns the home directory, the configured bookmarks and the
+// usual source directories that exist, without duplicates
+func (s *SetupView) bookmarks() []string {
+	home, _ := os.UserHomeDir()
+	candidates := []string{home}
+	for _, path := range s.config.Bookmarks {
+		candidates = append(candidates, expandHome(path))
+	}
+	for _, dir := range sourceDirs {
+		candidates = append(candidates, filepath.Join(home, dir))
+	}
+
+	var bookmarks []string
+	for _, path := range candidates {
+		if info, err := os.Stat(path); path == "" || err != nil || !info.IsDir() {
+			continue
+		}
+		if !slices.Contains(bookmarks, path) {
+			bookmarks = append(bookmarks, path)
+		}
+	}
+	return bookmarks
+}
+
+// nestedRepos counts the repositories directly inside path
+func nestedRepos(path string) int {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, entry := range entries {
+		if entry.IsDir() && git.HasGitDir(filepath.Join(path, entry.Name())) {
+			n++
+		}
+	}
+	return n
+}
+
+// completeDir suggests the directories starting with the typed path
+func completeDir(text string) []string {
+	if text == "" {
+		return nil
+	}
+	dir, prefix := filepath.Split(expandHome(text))
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var matches []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) && (prefix != "" || !isHiddenDir(entry.Name())) {
+			matches = append(matches, filepath.Join(dir, entry.Name())+string(filepath.Separator))
+		}
+	}
+	return matches
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+func isHiddenDir(name string) bool {
+	return len(name) > 0 && name[0] == '.'
+}
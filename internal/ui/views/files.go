@@ -3,10 +3,13 @@ package views
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
 )
 
@@ -14,10 +17,19 @@ import (
 type FilesView struct {
 	root    *tview.Flex
 	table   *tview.Table
+	detail  *tview.TextView
 	info    *tview.TextView
+	files   []*stats.FileStats
 	sortCol int
 	sortAsc bool
 	columns []string
+
+	// Rows marked with Space for batch actions
+	repo     *stats.Repository
+	selected map[string]bool
+	batch    Batch
+	keys     *KeyMap
+	filter   *tableFilter
 }
 
 // NewFilesView creates a new files view
@@ -25,7 +37,9 @@ func NewFilesView() *FilesView {
 	v := &FilesView{
 		sortCol: 2, // Default sort by changes
 		sortAsc: false,
-		columns: []string{"#", "File", "Changes", "Touches", "Authors", "+Lines", "-Lines"},
+		columns: []string{"#", "File", "Changes", "Touches", "Authors", "+Lines", "-Lines", "Activity"},
+
+		selected: make(map[string]bool),
 	}
 	v.setup()
 	return v
@@ -37,21 +51,109 @@ func (v *FilesView) setup() {
 		SetFixed(1, 0).
 		SetSeparator(' ')
 
+	// Detail pane for the selected file
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("File Details") + " ")
+
 	v.info = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.filter = newTableFilter(v.table, func() { v.Refresh(v.repo) })
+
 	v.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(v.table, 0, 1, true).
+		AddItem(content, 0, 1, true).
+		AddItem(v.filter.input, 0, 0, false).
 		AddItem(v.info, 1, 0, false)
+	v.filter.root = v.root
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if row > 0 && row <= len(v.files) {
+			v.showFileDetails(v.files[row-1])
+		}
+	})
 
 	v.renderHeader()
 }
 
+func (v *FilesView) toggleSelection() {
+	if row, _ := v.table.GetSelection(); v.repo != nil && row > 0 && row <= len(v.files) {
+		path := v.files[row-1].Path
+		if v.selected[path] {
+			delete(v.selected, path)
+		} else {
+			v.selected[path] = true
+		}
+		v.Refresh(v.repo)
+	}
+}
+
+func (v *FilesView) clearSelection() {
+	if v.repo == nil {
+		return
+	}
+	v.selected = make(map[string]bool)
+	v.Refresh(v.repo)
+}
+
+// runBatch applies a batch action to the marked files
+func (v *FilesView) runBatch(action string) {
+	if v.repo == nil {
+		return
+	}
+	status, ok := runBatch(v.batch, action, KindFiles, v.selection())
+	if !ok {
+		return
+	}
+	v.selected = make(map[string]bool)
+	v.Refresh(v.repo)
+	if status != "" {
+		v.info.SetText(status)
+	}
+}
+
+// selection returns the marked files, or the current one if none is marked
+func (v *FilesView) selection() []string {
+	if len(v.selected) > 0 {
+		paths := make([]string, 0, len(v.selected))
+		for path := range v.selected {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+	if row, _ := v.table.GetSelection(); row > 0 && row <= len(v.files) {
+		return []string{v.files[row-1].Path}
+	}
+	return nil
+}
+
+// SetBatch sets the actions applied to marked files
+func (v *FilesView) SetBatch(batch Batch) {
+	v.batch = batch
+}
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *FilesView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Top Files", "select", v.toggleSelection)
+	keys.Handle("Top Files", "clear", v.clearSelection)
+	keys.Handle("Top Files", "filter", v.filter.open)
+	for _, action := range batchActions {
+		keys.Handle("Top Files", action, func() { v.runBatch(action) })
+	}
+}
+
 func (v *FilesView) renderHeader() {
 	for col, name := range v.columns {
-		cell := tview.NewTableCell(name).
+		cell := tview.NewTableCell(i18n.T(name)).
 			SetTextColor(tcell.ColorYellow).
 			SetSelectable(false).
 			SetAttributes(tcell.AttrBold)
@@ -61,7 +163,7 @@ func (v *FilesView) renderHeader() {
 			if v.sortAsc {
 				arrow = "▲"
 			}
-			cell.SetText(name + arrow)
+			cell.SetText(i18n.T(name) + arrow)
 		}
 
 		v.table.SetCell(0, col, cell)
@@ -70,17 +172,31 @@ func (v *FilesView) renderHeader() {
 
 // Refresh updates the view with new data
 func (v *FilesView) Refresh(repo *stats.Repository) {
+	if repo == nil {
+		return
+	}
 	// Clear existing data rows
 	for row := v.table.GetRowCount() - 1; row > 0; row-- {
 		v.table.RemoveRow(row)
 	}
+	v.repo = repo
 
 	// Get sorted files
-	sortBy := []string{"", "path", "changes", "touches", "authors", "changes", "changes"}[v.sortCol]
+	sortBy := []string{"", "path", "changes", "touches", "authors", "changes", "changes", ""}[v.sortCol]
 	if sortBy == "" {
 		sortBy = "changes"
 	}
-	files := repo.GetTopFiles(sortBy, v.sortAsc, 50)
+	// The filter searches every file, not only the top ones
+	var files []*stats.FileStats
+	for _, file := range repo.GetTopFiles(sortBy, v.sortAsc, 0) {
+		if v.filter.match(file.Path) {
+			files = append(files, file)
+		}
+	}
+	matched := len(files)
+	files = files[:min(50, len(files))]
+	v.files = files
+	weeks := repo.ActivityWeeks()
 
 	// Render data
 	for i, file := range files {
@@ -100,6 +216,19 @@ func (v *FilesView) Refresh(repo *stats.Repository) {
 			SetTextColor(tcell.ColorDarkGray).
 			SetAlign(tview.AlignRight))
 
+		if file.Resurrections > 0 {
+			displayPath += " ↺"
+		}
+		if file.RenamedRecently(repo.DateRange.Until) {
+			displayPath += " ↪"
+		}
+		displayPath = tview.Escape(displayPath)
+		if v.selected[file.Path] {
+			displayPath = "[blue]◉[-] " + displayPath
+		}
+		if v.batch != nil && v.batch.Watched(KindFiles, file.Path) {
+			displayPath = watchMark + displayPath
+		}
 		v.table.SetCell(row, 1, tview.NewTableCell(displayPath).
 			SetTextColor(pathColor).
 			SetExpansion(1))
@@ -128,13 +257,72 @@ func (v *FilesView) Refresh(repo *stats.Repository) {
 		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("-%d", file.Deletions)).
 			SetTextColor(tcell.ColorRed).
 			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 7, tview.NewTableCell(renderActivity(file.Weekly, weeks)).
+			SetTextColor(tcell.ColorYellow))
 	}
 
 	// Update info
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] files shown (of %d) | Sort: [green]%s[-] | [s] cycle column, [r] reverse",
-		len(files), len(repo.FileStats), v.columns[v.sortCol]))
+	generated := ""
+	if len(repo.Generated) > 0 {
+		lines := 0
+		for _, changes := range repo.Generated {
+			lines += changes
+		}
+		generated = fmt.Sprintf(" | [gray]%d generated/vendored files (%d lines) skipped[-]", len(repo.Generated), lines)
+	}
+	selected := ""
+	if len(v.selected) > 0 {
+		selected = fmt.Sprintf(" | [blue]%d[-] selected", len(v.selected))
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] files shown (of %d)%s%s%s | Sort: [green]%s[-] | [%s] select  %s",
+		len(files), len(repo.FileStats), v.filter.status(matched, len(repo.FileStats)), generated, selected, v.columns[v.sortCol],
+		v.keys.Key("Top Files", "select"), batchHelp(v.keys, "Top Files")))
 
 	v.renderHeader()
+
+	if len(files) > 0 {
+		row, _ := v.table.GetSelection()
+		if row < 1 || row > len(files) {
+			row = 1
+		}
+		v.showFileDetails(files[row-1])
+	} else {
+		v.detail.SetText("")
+	}
+}
+
+func (v *FilesView) showFileDetails(file *stats.FileStats) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n", filepath.Base(file.Path)))
+	sb.WriteString(fmt.Sprintf("[gray]%s[-]\n\n", filepath.Dir(file.Path)))
+
+	sb.WriteString("[yellow]━━━ Changes ━━━[-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Total:      [cyan]%d[-]\n", file.TotalChanges))
+	sb.WriteString(fmt.Sprintf("  Touches:    [cyan]%d[-]\n", file.TouchCount))
+	sb.WriteString(fmt.Sprintf("  Authors:    [cyan]%d[-]\n", len(file.Authors)))
+
+	sb.WriteString("\n[yellow]━━━ Lifecycle ━━━[-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Created:    [green]%d[-]\n", file.Created))
+	sb.WriteString(fmt.Sprintf("  Deleted:    [red]%d[-]\n", file.Deleted))
+	if file.Resurrections > 0 {
+		sb.WriteString(fmt.Sprintf("  Resurrected: [yellow]%d[-] time(s)\n", file.Resurrections))
+		sb.WriteString("\n  [gray]Deleted and re-created paths often\n  indicate churny experiments or\n  broken refactors.[-]\n")
+	} else {
+		sb.WriteString("  Resurrected: [green]0[-]\n")
+	}
+
+	if file.Renames > 0 {
+		recent := ""
+		if v.repo != nil && file.RenamedRecently(v.repo.DateRange.Until) {
+			recent = " [yellow](recent)[-]"
+		}
+		sb.WriteString(fmt.Sprintf("  Renamed:    [cyan]%d[-] time(s)%s\n", file.Renames, recent))
+		sb.WriteString(fmt.Sprintf("  [gray]from %s\n  on %s[-]\n", tview.Escape(file.RenamedFrom), i18n.Date(file.LastRenamed)))
+	}
+
+	v.detail.SetText(sb.String())
 }
 
 func getDirColor(dir string) tcell.Color {
@@ -160,7 +348,7 @@ func getDirColor(dir string) tcell.Color {
 
 // CycleSortColumn cycles through sort columns
 func (v *FilesView) CycleSortColumn() {
-	v.sortCol = (v.sortCol + 1) % len(v.columns)
+	v.sortCol = (v.sortCol + 1) % (len(v.columns) - 1) // activity isn't sortable
 	if v.sortCol == 0 {
 		v.sortCol = 1
 	}
@@ -171,6 +359,29 @@ func (v *FilesView) ReverseSortOrder() {
 	v.sortAsc = !v.sortAsc
 }
 
+// SortState returns the sort column and direction
+func (v *FilesView) SortState() (column int, ascending bool) {
+	return v.sortCol, v.sortAsc
+}
+
+// SetSortState restores a sort, ignoring columns the view doesn't have
+func (v *FilesView) SetSortState(column int, ascending bool) {
+	if column > 0 && column < len(v.columns) {
+		v.sortCol, v.sortAsc = column, ascending
+	}
+}
+
+// SetFocusFunc sets the callback moving focus between the table and its
+// filter bar
+func (v *FilesView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.filter.focus = focus
+}
+
+// Filtering reports whether the filter bar has focus and takes every key
+func (v *FilesView) Filtering() bool {
+	return v.filter.typing
+}
+
 // Root returns the root primitive
 func (v *FilesView) Root() tview.Primitive {
 	return v.root
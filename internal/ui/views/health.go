@@ -0,0 +1,240 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// healthComponents names the components of the health score and the views
+// drilling into them
+var healthComponents = map[string]struct {
+	label, view, about string
+}{
+	stats.HealthBusFactor: {"Bus Factor", "Bus Factor",
+		"Authors who together changed more than half of all lines. Full marks from 4 up: with fewer, the knowledge of the codebase rests on a handful of people."},
+	stats.HealthHotspots: {"Hotspots", "Hotspots",
+		"Share of the changed files that are high-risk hotspots, churned by several authors. Scores nothing once a tenth of the files are."},
+	stats.HealthChurn: {"Churn", "Codebase",
+		"Share of the added lines still present at the end of the range rather than rewritten or deleted."},
+	stats.HealthPRSize: {"PR Size", "Pull Requests",
+		"Share of the merges changing at most 400 lines, which reviewers can still read carefully."},
+	stats.HealthContributors: {"Contributors", "Authors",
+		"Authors committing in the second half of the activity against the first. A shrinking team lowers the score; a growing one scores full marks."},
+}
+
+// HealthView combines the main risk indicators into one score and breaks it
+// down into its components
+type HealthView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	detail  *tview.TextView
+	info    *tview.TextView
+	columns []string
+	keys    *KeyMap
+	health  *stats.HealthScore
+	layout  Layout
+}
+
+// NewHealthView creates a new health score view
+func NewHealthView() *HealthView {
+	v := &HealthView{
+		columns: []string{"Component", "Score", "Weight", "Measured"},
+		keys:    defaultKeys,
+	}
+	v.setup()
+	return v
+}
+
+func (v *HealthView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true)
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Component Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 4, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if c := v.component(row); c != nil {
+			v.showDetails(c)
+		}
+	})
+}
+
+// SetLayout sets the space available for the score bar
+func (v *HealthView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// SetKeyMap sets the key map the details take their keys from
+func (v *HealthView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+}
+
+// Refresh updates the view with new data, scored with weights by component
+func (v *HealthView) Refresh(repo *stats.Repository, weights map[string]float64) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.health = repo.Health(weights)
+	v.renderSummary()
+
+	for i, c := range v.health.Components {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(i18n.T(healthComponents[c.Name].label)).
+			SetTextColor(tcell.ColorAqua))
+
+		score, weight := "-", i18n.T("off")
+		color := tcell.ColorDarkGray
+		if c.Measured {
+			score = fmt.Sprintf("%.0f", c.Score)
+			color = healthColor(c.Score)
+		}
+		if c.Weight > 0 {
+			weight = fmt.Sprintf("%.0f%%", c.Share)
+			if !c.Measured {
+				weight = "-"
+			}
+		}
+		v.table.SetCell(row, 1, tview.NewTableCell(score).
+			SetTextColor(color).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(weight).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(c.Detail).
+			SetTextColor(tcell.ColorDarkGray).
+			SetExpansion(1))
+	}
+
+	row, _ := v.table.GetSelection()
+	if c := v.component(row); c != nil {
+		v.showDetails(c)
+	} else {
+		v.table.Select(1, 0)
+	}
+
+	v.info.SetText(fmt.Sprintf(`[gray][%s] open the component's view | weights from "health_weights" in the config file[-]`,
+		v.keys.Key("Health", "open")))
+}
+
+func (v *HealthView) renderSummary() {
+	if !v.health.Measured() {
+		v.summary.SetText("\n  [gray]Nothing to score: the scan has no data for any weighted component[-]")
+		return
+	}
+	score := v.health.Score
+	barWidth := v.layout.bar(40, 30)
+	v.summary.SetText(fmt.Sprintf("\n  [::b]%s[-:-:-]  [%s::b]%3.0f[-:-:-] / 100  [%s]%s[-]  [gray]%s[-]",
+		i18n.T("Health"), healthColor(score), score, healthColor(score), shareBar(score, barWidth), healthGrade(score)))
+}
+
+// SelectedView returns the view drilling into the selected component
+func (v *HealthView) SelectedView() string {
+	row, _ := v.table.GetSelection()
+	if c := v.component(row); c != nil {
+		return healthComponents[c.Name].view
+	}
+	return ""
+}
+
+func (v *HealthView) component(row int) *stats.HealthComponent {
+	if v.health == nil || row < 1 || row > len(v.health.Components) {
+		return nil
+	}
+	return v.health.Components[row-1]
+}
+
+func (v *HealthView) showDetails(c *stats.HealthComponent) {
+	meta := healthComponents[c.Name]
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[yellow::b]%s[-:-:-]\n\n", i18n.T(meta.label)))
+	sb.WriteString(meta.about + "\n\n")
+
+	switch {
+	case c.Weight <= 0:
+		sb.WriteString(fmt.Sprintf("[gray]Left out: weight 0 in \"health_weights\" (%s)[-]\n\n", c.Name))
+	case !c.Measured:
+		sb.WriteString("[gray]Left out: nothing to measure in this scan[-]\n\n")
+	default:
+		sb.WriteString(fmt.Sprintf("[cyan]Score:[-]  [%s]%.0f[-] %s\n", healthColor(c.Score), c.Score, shareBar(c.Score, 10)))
+		sb.WriteString(fmt.Sprintf("[cyan]Weight:[-] %g, %.0f%% of the total\n", c.Weight, c.Share))
+		sb.WriteString(fmt.Sprintf("[cyan]Adds:[-]   %.1f points\n\n", c.Score*c.Share/100))
+	}
+	sb.WriteString(c.Detail + "\n\n")
+	sb.WriteString(fmt.Sprintf("[gray][%s] opens %s[-]", v.keys.Key("Health", "open"), i18n.T(meta.view)))
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// healthColor shades a 0-100 health score from red to green
+func healthColor(score float64) tcell.Color {
+	switch {
+	case score >= 75:
+		return tcell.ColorGreen
+	case score >= 50:
+		return tcell.ColorYellow
+	default:
+		return tcell.ColorRed
+	}
+}
+
+// healthGrade names a band of health scores
+func healthGrade(score float64) string {
+	switch {
+	case score >= 75:
+		return "healthy"
+	case score >= 50:
+		return "needs attention"
+	default:
+		return "at risk"
+	}
+}
+
+// Root returns the root primitive
+func (v *HealthView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *HealthView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -2,22 +2,50 @@ package views
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
 	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
-var weekdayNames = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+// monthNames returns the abbreviated month names of the current locale
+func monthNames() []string {
+	months := i18n.Current().Months
+	return months[:]
+}
+
+// Heatmap matrices cycled with the toggle key
+const (
+	matrixWeekdayHour = iota
+	matrixMonthDay
+	matrixMonthWeekday
+	matrixCount
+)
 
 // HeatmapView displays work hours heatmap
 type HeatmapView struct {
-	root *tview.Flex
-	text *tview.TextView
+	root   *tview.Flex
+	text   *tview.TextView
+	matrix int
+	layout Layout
+	keys   *KeyMap
+	pace   stats.UnhealthyHours
+	repo   *stats.Repository
+	tz     *time.Location
 }
 
+// Authors with fewer commits are left out of the pace ranking, where a
+// handful of late commits would top it
+const paceMinCommits = 5
+
+// paceAuthorRows limits the authors listed by unhealthy share
+const paceAuthorRows = 10
+
 // NewHeatmapView creates a new heatmap view
 func NewHeatmapView() *HeatmapView {
 	v := &HeatmapView{}
@@ -41,6 +69,12 @@ func (v *HeatmapView) setup() {
 
 // Refresh updates the view with new data
 func (v *HeatmapView) Refresh(repo *stats.Repository, tz *time.Location) {
+	v.repo, v.tz = repo, tz
+	if v.matrix != matrixWeekdayHour {
+		v.text.SetText(v.layout.fit(v.renderCalendar(repo)))
+		return
+	}
+
 	heatmap := repo.GetHeatmap(tz)
 	peakDay, peakHour, totalCommits := components.GetHeatmapStats(heatmap.Matrix)
 
@@ -79,8 +113,13 @@ func (v *HeatmapView) Refresh(repo *stats.Repository, tz *time.Location) {
 		}
 	}
 
-	// Render heatmap grid
-	heatmapGrid := components.RenderHeatmap(heatmap.Matrix, heatmap.MaxValue)
+	// Render heatmap grid, one cell per hour when two don't fit
+	var heatmapGrid string
+	if v.layout.Compact {
+		heatmapGrid = components.RenderHeatmapCompact(heatmap.Matrix, heatmap.MaxValue, clock24)
+	} else {
+		heatmapGrid = components.RenderHeatmap(heatmap.Matrix, heatmap.MaxValue, clock24)
+	}
 
 	// Calculate work hours vs off hours
 	var workHours, offHours int
@@ -100,9 +139,19 @@ func (v *HeatmapView) Refresh(repo *stats.Repository, tz *time.Location) {
 		workPct = float64(workHours) / float64(totalCommits) * 100
 	}
 
+	// Split of the day at noon
+	var amCommits int
+	for hour := 0; hour < 12; hour++ {
+		amCommits += hourTotals[hour]
+	}
+	amPct := 0.0
+	if totalCommits > 0 {
+		amPct = float64(amCommits) / float64(totalCommits) * 100
+	}
+
 	content := fmt.Sprintf(`[::b]Work Hours Heatmap[-:-:-]
 
-  Timezone: [cyan]%s[-]
+  Timezone: [cyan]%s[-]    [gray][%s] month × day / month × weekday[-]
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
 
@@ -112,17 +161,20 @@ func (v *HeatmapView) Refresh(repo *stats.Repository, tz *time.Location) {
 
   [::b]Peak Activity[-:-:-]
 
-  Peak Time:          [green]%s[-] at [green]%02d:00[-] ([cyan]%d[-] commits)
+  Peak Time:          [green]%s[-] at [green]%s[-] ([cyan]%d[-] commits)
   Busiest Day:        [green]%s[-] ([cyan]%d[-] commits total)
-  Busiest Hour:       [green]%02d:00[-] ([cyan]%d[-] commits total)
+  Busiest Hour:       [green]%s[-] ([cyan]%d[-] commits total)
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
 
   [::b]Work Patterns[-:-:-]
 
-  Work Hours (Mon-Fri, 9-18):   [cyan]%d[-] commits (%.1f%%)
+  Work Hours (Mon-Fri, %-8s [cyan]%d[-] commits (%.1f%%)
   Off Hours:                    [cyan]%d[-] commits (%.1f%%)
 
+  AM (before noon):             [cyan]%d[-] commits (%.1f%%)
+  PM (from noon):               [cyan]%d[-] commits (%.1f%%)
+
   Pattern:            %s
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
@@ -133,19 +185,219 @@ func (v *HeatmapView) Refresh(repo *stats.Repository, tz *time.Location) {
   Fri: [cyan]%4d[-]  Sat: [cyan]%4d[-]  Sun: [cyan]%4d[-]
 
 `,
-		tz.String(),
+		tz.String(), v.keys.Key("Work Hours", "toggle"),
 		heatmapGrid,
-		weekdayNames[peakDay], peakHour, heatmap.Matrix[peakDay][peakHour],
-		weekdayNames[busiestDay], weekdayTotals[busiestDay],
-		busiestHour, hourTotals[busiestHour],
-		workHours, workPct,
+		i18n.WeekdayLong(peakDay), formatHour(peakHour), heatmap.Matrix[peakDay][peakHour],
+		i18n.WeekdayLong(busiestDay), weekdayTotals[busiestDay],
+		formatHour(busiestHour), hourTotals[busiestHour],
+		workHoursLabel()+"):", workHours, workPct,
 		offHours, 100-workPct,
+		amCommits, amPct,
+		totalCommits-amCommits, 100-amPct,
 		getWorkPattern(workPct),
 		weekdayTotals[0], weekdayTotals[1], weekdayTotals[2], weekdayTotals[3],
 		weekdayTotals[4], weekdayTotals[5], weekdayTotals[6],
 	)
 
-	v.text.SetText(content)
+	v.text.SetText(v.layout.fit(content + v.renderPace(repo)))
+}
+
+// SetUnhealthyHours sets the hours counted against a sustainable pace
+func (v *HeatmapView) SetUnhealthyHours(hours stats.UnhealthyHours) {
+	v.pace = hours
+}
+
+// renderPace reports the share of commits in unhealthy hours per author and
+// its trend for the team
+func (v *HeatmapView) renderPace(repo *stats.Repository) string {
+	report := repo.GetPaceReport(v.pace)
+
+	var sb strings.Builder
+	sb.WriteString("[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Sustainable Pace[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Unhealthy Hours:    [gray]%s[-]\n", unhealthyHoursLabel(v.pace)))
+	sb.WriteString(fmt.Sprintf("  Team:               %s of [cyan]%d[-] commits\n", formatPaceShare(report.Percent()), report.Commits))
+
+	if len(report.Monthly) > 1 {
+		shares := make([]int, len(report.Monthly))
+		for i, m := range report.Monthly {
+			shares[i] = int(math.Round(m.Percent()))
+		}
+		last, prev := report.Monthly[len(report.Monthly)-1], report.Monthly[len(report.Monthly)-2]
+		sb.WriteString(fmt.Sprintf("  Monthly Trend:      [green]%s[-] %s to %s, latest %s (%s pts)\n",
+			components.RenderSparklineWithWidth(shares, v.layout.bar(24, textPadding+70)),
+			report.Months[0], report.Months[len(report.Months)-1],
+			formatPaceShare(last.Percent()), formatTrendChange(int(math.Round(last.Percent()-prev.Percent())), false)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n  [::b]By Author[-:-:-] [gray](at least %d commits)[-]\n\n", paceMinCommits))
+	rows := 0
+	for _, a := range report.Authors {
+		if a.Commits < paceMinCommits {
+			continue
+		}
+		name := a.Name
+		if len(name) > 20 {
+			name = name[:17] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("  %-20s %s  %s  [gray]%d of %d[-]\n",
+			name, formatPaceShare(a.Percent()), shareBar(a.Percent(), 20), a.Unhealthy, a.Commits))
+		rows++
+		if rows == paceAuthorRows {
+			break
+		}
+	}
+	if rows == 0 {
+		sb.WriteString("  [gray]No author with enough commits[-]\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// unhealthyHoursLabel describes the unhealthy hours on the selected clock
+func unhealthyHoursLabel(hours stats.UnhealthyHours) string {
+	var parts []string
+	if hours.After != hours.Before {
+		parts = append(parts, formatHour(hours.After)+"-"+formatHour(hours.Before))
+	}
+	if hours.Weekends {
+		parts = append(parts, "weekends")
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPaceShare colors a share of unhealthy commits by how worrying it is
+func formatPaceShare(percent float64) string {
+	color := "green"
+	if percent >= 25 {
+		color = "red"
+	} else if percent >= 10 {
+		color = "yellow"
+	}
+	return fmt.Sprintf("[%s]%5.1f%%[-]", color, percent)
+}
+
+// SetLayout sets the space available for the heatmap grid
+func (v *HeatmapView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// ToggleMatrix cycles between weekday × hour, month × day and month × weekday
+func (v *HeatmapView) ToggleMatrix() {
+	v.matrix = (v.matrix + 1) % matrixCount
+}
+
+// renderCalendar renders the month-based matrices
+func (v *HeatmapView) renderCalendar(repo *stats.Repository) string {
+	calendar := repo.GetCalendarHeatmaps()
+
+	var title, grid string
+	var cells [][]int
+	var colNames []string
+	cellWidth := 2
+	if v.layout.Compact {
+		cellWidth = 1
+	}
+	if v.matrix == matrixMonthDay {
+		title = "Month × Day of Month"
+		cells = calendar.MonthDay
+		colNames = make([]string, 31)
+		for d := range colNames {
+			colNames[d] = fmt.Sprintf("%02d", d+1)
+		}
+		grid = components.RenderGridHeatmap(monthNames(), colNames, cells, cellWidth, 5)
+	} else {
+		title = "Month × Weekday"
+		cells = calendar.MonthWeekday
+		colNames = make([]string, 7)
+		for d := range colNames {
+			colNames[d] = i18n.Weekday(d)
+		}
+		grid = components.RenderGridHeatmap(monthNames(), colNames, cells, cellWidth*2, 1)
+	}
+
+	// Busiest cell, month and column
+	peakRow, peakCol := 0, 0
+	monthTotals := make([]int, len(cells))
+	colTotals := make([]int, len(colNames))
+	for r, row := range cells {
+		for c, val := range row {
+			monthTotals[r] += val
+			colTotals[c] += val
+			if val > cells[peakRow][peakCol] {
+				peakRow, peakCol = r, c
+			}
+		}
+	}
+	busiestMonth, busiestCol := 0, 0
+	for i, total := range monthTotals {
+		if total > monthTotals[busiestMonth] {
+			busiestMonth = i
+		}
+	}
+	for i, total := range colTotals {
+		if total > colTotals[busiestCol] {
+			busiestCol = i
+		}
+	}
+
+	colLabel := "Busiest Weekday"
+	if v.matrix == matrixMonthDay {
+		colLabel = "Busiest Day"
+	}
+
+	// Month-end share vs an even spread (5 of ~30.4 days)
+	expected := 5 / 30.44 * 100
+	crunch := "[green]No month-end crunch[-]"
+	if calendar.MonthEndPercent >= expected*1.5 {
+		crunch = "[red]Strong month-end crunch[-]"
+	} else if calendar.MonthEndPercent >= expected*1.2 {
+		crunch = "[yellow]Some month-end pressure[-]"
+	}
+
+	return fmt.Sprintf(`[::b]%s Heatmap[-:-:-]
+
+  [gray][%s] next matrix[-]
+
+[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+
+%s
+
+[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+
+  [::b]Peak Activity[-:-:-]
+
+  Peak Cell:          [green]%s %s[-] ([cyan]%d[-] commits)
+  Busiest Month:      [green]%s[-] ([cyan]%d[-] commits total)
+  %-19s [green]%s[-] ([cyan]%d[-] commits total)
+
+[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+
+  [::b]Month-End Pattern[-:-:-]
+
+  Last 5 Days:        [cyan]%.1f%%[-] of commits (even spread: %.1f%%)
+  Pattern:            %s
+
+`,
+		title, v.keys.Key("Work Hours", "toggle"),
+		grid,
+		monthNames()[peakRow], colNames[peakCol], cells[peakRow][peakCol],
+		monthNames()[busiestMonth], monthTotals[busiestMonth],
+		colLabel+":", colNames[busiestCol], colTotals[busiestCol],
+		calendar.MonthEndPercent, expected,
+		crunch,
+	)
+}
+
+// workHoursLabel names the 9-18 work day on the selected clock
+func workHoursLabel() string {
+	if clock24 {
+		return "9-18"
+	}
+	return "9a-6p"
 }
 
 func getWorkPattern(workPct float64) string {
@@ -163,3 +415,8 @@ func getWorkPattern(workPct float64) string {
 func (v *HeatmapView) Root() tview.Primitive {
 	return v.root
 }
+
+// SetKeyMap sets the key map the heatmap takes the toggle key from
+func (v *HeatmapView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+}
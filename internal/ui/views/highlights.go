@@ -0,0 +1,128 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Milestones this many days before the last commit count as recent
+const recentMilestoneDays = 30
+
+// Markers of the milestone kinds
+var milestoneIcons = map[string]string{
+	stats.MilestoneCommits:     "[green]◆[-]",
+	stats.MilestoneDirectory:   "[aqua]▸[-]",
+	stats.MilestoneAnniversary: "[yellow]★[-]",
+}
+
+// HighlightsView lists the contributors' milestones, newest first
+type HighlightsView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewHighlightsView creates a new milestones view
+func NewHighlightsView() *HighlightsView {
+	v := &HighlightsView{
+		columns: []string{"Date", "", "Author", "Milestone"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *HighlightsView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true)
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 3, 0, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *HighlightsView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	milestones := repo.GetMilestones()
+	if len(milestones) == 0 {
+		v.summary.SetText("\n  [gray]No milestones in the selected range[-]")
+		v.info.SetText("")
+		return
+	}
+
+	// Recent relative to the last commit, so older ranges have highlights
+	// too
+	latest := ""
+	for day := range repo.DailyActivity {
+		latest = max(latest, day)
+	}
+	last, _ := time.Parse("2006-01-02", latest)
+	since := last.AddDate(0, 0, -recentMilestoneDays).Format("2006-01-02")
+
+	recent := 0
+	kinds := make(map[string]int)
+	for i, m := range milestones {
+		row := i + 1
+		kinds[m.Kind]++
+		color := tcell.ColorDarkGray
+		if m.Date > since {
+			recent++
+			color = tcell.ColorWhite
+		}
+
+		v.table.SetCell(row, 0, tview.NewTableCell(m.Date).SetTextColor(color))
+		v.table.SetCell(row, 1, tview.NewTableCell(milestoneIcons[m.Kind]))
+		v.table.SetCell(row, 2, tview.NewTableCell(tview.Escape(m.Name)).
+			SetTextColor(tcell.ColorAqua))
+		v.table.SetCell(row, 3, tview.NewTableCell(tview.Escape(m.Title())).
+			SetTextColor(color).
+			SetExpansion(1))
+	}
+
+	v.summary.SetText(fmt.Sprintf("\n  [::b]%d[::-] milestones, [green]%d[-] in the %d days up to the last commit (%s)",
+		len(milestones), recent, recentMilestoneDays, latest))
+	v.info.SetText(fmt.Sprintf("%s [yellow]%d[-] commit counts  %s [yellow]%d[-] new directories  %s [yellow]%d[-] anniversaries | counted from the start of the range",
+		milestoneIcons[stats.MilestoneCommits], kinds[stats.MilestoneCommits],
+		milestoneIcons[stats.MilestoneDirectory], kinds[stats.MilestoneDirectory],
+		milestoneIcons[stats.MilestoneAnniversary], kinds[stats.MilestoneAnniversary]))
+}
+
+// Root returns the root primitive
+func (v *HighlightsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *HighlightsView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -2,12 +2,15 @@ package views
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
 // HotspotsView displays high-risk files
@@ -18,14 +21,17 @@ type HotspotsView struct {
 	sortCol int
 	sortAsc bool
 	columns []string
+	keys    *KeyMap
+	repo    *stats.Repository
+	filter  *tableFilter
 }
 
 // NewHotspotsView creates a new hotspots view
 func NewHotspotsView() *HotspotsView {
 	v := &HotspotsView{
-		sortCol: 5, // Default sort by risk score
+		sortCol: 6, // Default sort by risk score
 		sortAsc: false,
-		columns: []string{"#", "File", "Churn%", "Touches", "Authors", "Risk"},
+		columns: []string{"#", "File", "Churn%", "Touches", "Authors", "Complexity", "Risk", "Trend"},
 	}
 	v.setup()
 	return v
@@ -41,17 +47,21 @@ func (v *HotspotsView) setup() {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
+	v.filter = newTableFilter(v.table, func() { v.Refresh(v.repo) })
+
 	v.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(v.table, 0, 1, true).
+		AddItem(v.filter.input, 0, 0, false).
 		AddItem(v.info, 1, 0, false)
+	v.filter.root = v.root
 
 	v.renderHeader()
 }
 
 func (v *HotspotsView) renderHeader() {
 	for col, name := range v.columns {
-		cell := tview.NewTableCell(name).
+		cell := tview.NewTableCell(i18n.T(name)).
 			SetTextColor(tcell.ColorYellow).
 			SetSelectable(false).
 			SetAttributes(tcell.AttrBold)
@@ -61,7 +71,7 @@ func (v *HotspotsView) renderHeader() {
 			if v.sortAsc {
 				arrow = "▲"
 			}
-			cell.SetText(name + arrow)
+			cell.SetText(i18n.T(name) + arrow)
 		}
 
 		v.table.SetCell(0, col, cell)
@@ -70,35 +80,59 @@ func (v *HotspotsView) renderHeader() {
 
 // Refresh updates the view with new data
 func (v *HotspotsView) Refresh(repo *stats.Repository) {
+	v.repo = repo
+	if repo == nil {
+		return
+	}
+
 	// Clear existing data rows
 	for row := v.table.GetRowCount() - 1; row > 0; row-- {
 		v.table.RemoveRow(row)
 	}
 
-	// Get hotspots and apply sorting
-	hotspots := repo.GetHotspots(50)
+	// Get the riskiest hotspots, among all files matching the filter
+	// when there is one
+	limit := 50
+	if v.filter.input.GetText() != "" {
+		limit = 0
+	}
+	var hotspots []*stats.HotspotFile
+	all := repo.GetHotspots(limit)
+	for _, spot := range all {
+		if v.filter.match(spot.Path) {
+			hotspots = append(hotspots, spot)
+		}
+	}
+	hotspots = hotspots[:min(50, len(hotspots))]
 
 	// Sort based on selected column
-	sort.Slice(hotspots, func(i, j int) bool {
-		var cmp bool
+	// Stable, so ties keep the risk order from GetHotspots
+	less := func(a, b *stats.HotspotFile) bool {
 		switch v.sortCol {
 		case 1: // File path
-			cmp = hotspots[i].Path < hotspots[j].Path
+			return a.Path < b.Path
 		case 2: // Churn
-			cmp = hotspots[i].ChurnScore < hotspots[j].ChurnScore
+			return a.ChurnScore < b.ChurnScore
 		case 3: // Touches
-			cmp = hotspots[i].TouchCount < hotspots[j].TouchCount
+			return a.TouchCount < b.TouchCount
 		case 4: // Authors
-			cmp = hotspots[i].AuthorCount < hotspots[j].AuthorCount
-		case 5: // Risk
-			cmp = hotspots[i].RiskScore < hotspots[j].RiskScore
-		default:
-			cmp = hotspots[i].RiskScore < hotspots[j].RiskScore
+			return a.AuthorCount < b.AuthorCount
+		case 5: // Complexity, unmeasured files first
+			if a.HasComplexity != b.HasComplexity {
+				return b.HasComplexity
+			}
+			return a.Complexity < b.Complexity
+		case 7: // Trend
+			return a.TrendSlope < b.TrendSlope
+		default: // Risk
+			return a.RiskScore < b.RiskScore
 		}
+	}
+	sort.SliceStable(hotspots, func(i, j int) bool {
 		if v.sortAsc {
-			return cmp
+			return less(hotspots[i], hotspots[j])
 		}
-		return !cmp
+		return less(hotspots[j], hotspots[i])
 	})
 
 	// Render data
@@ -137,24 +171,47 @@ func (v *HotspotsView) Refresh(repo *stats.Repository) {
 			SetTextColor(authorColor).
 			SetAlign(tview.AlignRight))
 
+		complexity := "-"
+		if spot.HasComplexity {
+			complexity = fmt.Sprintf("%d", spot.Complexity)
+		}
+		v.table.SetCell(row, 5, tview.NewTableCell(complexity).
+			SetAlign(tview.AlignRight))
+
 		// Risk score with visual bar
 		riskBar := getRiskBar(spot.RiskScore)
-		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%.0f %s", spot.RiskScore, riskBar)).
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%.0f %s", spot.RiskScore, riskBar)).
 			SetTextColor(riskColor).
 			SetAlign(tview.AlignRight))
+
+		// Monthly risk direction with a sparkline of the last 12 months
+		arrow, trendColor := getTrendArrow(spot.TrendSlope)
+		v.table.SetCell(row, 7, tview.NewTableCell(arrow+" "+renderRiskSparkline(spot.RiskTrend, 12)).
+			SetTextColor(trendColor))
 	}
 
-	// Count high-risk files
-	highRisk := 0
+	// Count high-risk and worsening files
+	highRisk, rising := 0, 0
 	for _, spot := range hotspots {
-		if spot.RiskScore >= 50 {
+		if spot.RiskScore >= stats.HighRiskScore {
 			highRisk++
 		}
+		if spot.TrendSlope >= riskTrendThreshold {
+			rising++
+		}
 	}
 
 	// Update info
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] hotspots | [red]%d[-] high-risk | Sort: [green]%s[-] | [s] cycle, [r] reverse",
-		len(hotspots), highRisk, v.columns[v.sortCol]))
+	decay := ""
+	if repo.ChurnHalfLife > 0 {
+		decay = fmt.Sprintf(" | churn half-life [yellow]%s[-]", formatAge(repo.ChurnHalfLife))
+	}
+	if repo.Complexity == nil {
+		decay += " | [gray]complexity pending[-]"
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] hotspots%s | [red]%d[-] high-risk | [red]%d[-] rising%s | Sort: [green]%s[-] | [%s] cycle, [%s] reverse",
+		len(hotspots), v.filter.status(len(hotspots), len(all)), highRisk, rising, decay, v.columns[v.sortCol],
+		v.keys.Key("Hotspots", "sort"), v.keys.Key("Hotspots", "reverse")))
 
 	v.renderHeader()
 }
@@ -186,6 +243,30 @@ func getRiskBar(score float64) string {
 	return bar
 }
 
+// riskTrendThreshold is the monthly risk change (in points) treated as a trend
+const riskTrendThreshold = 2.0
+
+func getTrendArrow(slope float64) (string, tcell.Color) {
+	if slope >= riskTrendThreshold {
+		return "↑", tcell.ColorRed
+	} else if slope <= -riskTrendThreshold {
+		return "↓", tcell.ColorGreen
+	}
+	return "→", tcell.ColorDarkGray
+}
+
+// renderRiskSparkline draws the most recent months of a risk trend
+func renderRiskSparkline(trend []float64, months int) string {
+	if len(trend) > months {
+		trend = trend[len(trend)-months:]
+	}
+	values := make([]int, len(trend))
+	for i, score := range trend {
+		values[i] = int(math.Round(score))
+	}
+	return components.RenderSparkline(values)
+}
+
 // CycleSortColumn cycles through sort columns
 func (v *HotspotsView) CycleSortColumn() {
 	v.sortCol = (v.sortCol + 1) % len(v.columns)
@@ -199,6 +280,18 @@ func (v *HotspotsView) ReverseSortOrder() {
 	v.sortAsc = !v.sortAsc
 }
 
+// SortState returns the sort column and direction
+func (v *HotspotsView) SortState() (column int, ascending bool) {
+	return v.sortCol, v.sortAsc
+}
+
+// SetSortState restores a sort, ignoring columns the view doesn't have
+func (v *HotspotsView) SetSortState(column int, ascending bool) {
+	if column > 0 && column < len(v.columns) {
+		v.sortCol, v.sortAsc = column, ascending
+	}
+}
+
 // Root returns the root primitive
 func (v *HotspotsView) Root() tview.Primitive {
 	return v.root
@@ -208,3 +301,20 @@ func (v *HotspotsView) Root() tview.Primitive {
 func (v *HotspotsView) GetFocusable() tview.Primitive {
 	return v.table
 }
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *HotspotsView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Hotspots", "filter", v.filter.open)
+}
+
+// SetFocusFunc sets the callback moving focus between the table and its
+// filter bar
+func (v *HotspotsView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.filter.focus = focus
+}
+
+// Filtering reports whether the filter bar has focus and takes every key
+func (v *HotspotsView) Filtering() bool {
+	return v.filter.typing
+}
@@ -0,0 +1,143 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// IssuesView displays issues closed via commit message keywords
+type IssuesView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewIssuesView creates a new issues view
+func NewIssuesView() *IssuesView {
+	v := &IssuesView{
+		columns: []string{"#", "Author", "Issues", "Commits", "Share"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *IssuesView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Closed Issues") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.summary, 0, 1, false).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *IssuesView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	issues := repo.GetIssueStats()
+
+	for i, author := range issues.ByAuthor {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(author.Name).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", author.Issues)).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", author.Commits)).
+			SetAlign(tview.AlignRight))
+
+		share := safeDivide(float64(author.Issues), float64(issues.TotalIssues)) * 100
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.1f%%", share)).
+			SetAlign(tview.AlignRight))
+	}
+
+	v.summary.SetText(v.renderSummary(issues))
+	v.summary.ScrollToBeginning()
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] issues closed by [yellow]%d[-] commits | keywords: close, fix, resolve",
+		issues.TotalIssues, len(issues.Commits)))
+}
+
+func (v *IssuesView) renderSummary(issues *stats.IssueStats) string {
+	var sb strings.Builder
+
+	if len(issues.Commits) == 0 {
+		sb.WriteString("  [gray]No commits reference issues with closing keywords (e.g. \"Fixes #123\")[-]\n")
+		return sb.String()
+	}
+
+	// Weekly bars
+	maxWeek := 0
+	for _, count := range issues.ByWeek {
+		if count > maxWeek {
+			maxWeek = count
+		}
+	}
+	sb.WriteString("  [yellow]━━━ Issues Closed per Week ━━━[-]\n\n")
+	for i, week := range issues.Weeks {
+		barLen := int(safeDivide(float64(issues.ByWeek[i]), float64(maxWeek)) * 30)
+		sb.WriteString(fmt.Sprintf("  %s [green]%-30s[-] %d\n", week, strings.Repeat("█", barLen), issues.ByWeek[i]))
+	}
+
+	// Closing commits, newest first
+	sb.WriteString("\n  [yellow]━━━ Closing Commits ━━━[-]\n\n")
+	for _, ic := range issues.Commits {
+		subject := ic.Subject
+		if len(subject) > 60 {
+			subject = subject[:57] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("  [yellow]%s[-]  %s  [aqua]%s[-]  [green]%s[-]  %s\n",
+			ic.Hash, i18n.Date(ic.At), ic.Author, strings.Join(ic.Issues, ", "), subject))
+	}
+
+	return sb.String()
+}
+
+// Root returns the root primitive
+func (v *IssuesView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *IssuesView) GetFocusable() tview.Primitive {
+	return v.table
+}
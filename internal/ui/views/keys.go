@@ -0,0 +1,496 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// ScopeGlobal is the scope of the bindings that work in every view
+const ScopeGlobal = "Global"
+
+// ScopeSetup is the scope of the setup screen, and ScopeBrowser that of
+// its directory browser. Both are shown before the views, so the global
+// bindings do not apply there.
+const (
+	ScopeSetup   = "Setup"
+	ScopeBrowser = "Browser"
+)
+
+// standalone reports whether the global bindings are left out of scope
+func standalone(scope string) bool {
+	return scope == ScopeSetup || scope == ScopeBrowser
+}
+
+// Binding maps keys to an action. Scope is the name of the view the action
+// belongs to, or ScopeGlobal.
+type Binding struct {
+	Scope       string
+	Action      string
+	Keys        []string // runes as typed, other keys by their tcell name ("Tab", "Esc"), or "Space"
+	Description string   // English; translated when shown
+	Label       string   // shown instead of the keys, e.g. "↑↓"
+	Group       string   // consecutive bindings of a group share one status bar hint
+	Focused     bool     // only while the view, not the menu, has focus
+	Hidden      bool     // left out of the status bar
+
+	handler func()
+}
+
+// defaultBindings lists the bindings in the order the status bar and help
+// show them. Bindings without a handler are handled by the focused widget
+// and only documented here.
+var defaultBindings = []Binding{
+	{Scope: ScopeGlobal, Action: "focus", Keys: []string{"Tab", "Backtab"}, Description: "Focus"},
+	{Scope: ScopeGlobal, Action: "menu", Keys: []string{"Esc"}, Description: "Menu", Hidden: true},
+	{Scope: ScopeGlobal, Action: "navigate", Keys: []string{"Up", "Down"}, Label: "↑↓", Description: "Navigate"},
+	{Scope: ScopeGlobal, Action: "query", Keys: []string{":"}, Description: "Query"},
+	{Scope: ScopeGlobal, Action: "notifications", Keys: []string{"n"}, Description: "Notifications"},
+	{Scope: ScopeGlobal, Action: "chart", Keys: []string{"g"}, Description: "Export Chart"},
+	{Scope: ScopeGlobal, Action: "help", Keys: []string{"?"}, Description: "Help"},
+	{Scope: ScopeGlobal, Action: "palette", Keys: []string{"Ctrl-P"}, Description: "Commands"},
+	{Scope: ScopeGlobal, Action: "rescan", Keys: []string{"R"}, Description: "Rescan"},
+	{Scope: ScopeGlobal, Action: "quit", Keys: []string{"q", "Q"}, Description: "Quit"},
+
+	{Scope: "Leaderboard", Action: "sort", Keys: []string{"s", "S"}, Description: "Sort"},
+	{Scope: "Leaderboard", Action: "reverse", Keys: []string{"r"}, Description: "Reverse"},
+	{Scope: "Leaderboard", Action: "numbers", Keys: []string{"f"}, Description: "Numbers"},
+	{Scope: "Leaderboard", Action: "open", Keys: []string{"Enter"}, Description: "Details", Focused: true},
+	{Scope: "Leaderboard", Action: "filter", Keys: []string{"/"}, Description: "Filter"},
+
+	{Scope: "Author Drill-down", Action: "back", Keys: []string{"Esc"}, Description: "Back"},
+
+	{Scope: "Codebase", Action: "numbers", Keys: []string{"f"}, Description: "Numbers"},
+
+	{Scope: "Timeline", Action: "period", Keys: []string{"p", "P"}, Description: "Period"},
+	{Scope: "Timeline", Action: "expand", Keys: []string{"f", "F"}, Description: "Full Screen"},
+	{Scope: "Timeline", Action: "overlay", Keys: []string{"o", "O"}, Description: "Compare Authors"},
+	{Scope: "Timeline", Action: "select", Keys: []string{"Space"}, Description: "Select", Focused: true},
+	{Scope: "Timeline", Action: "shorter", Keys: []string{"-"}, Description: "Shorter Average", Group: "window"},
+	{Scope: "Timeline", Action: "longer", Keys: []string{"+", "="}, Description: "Longer Average", Group: "window"},
+	{Scope: "Timeline", Action: "scroll", Keys: []string{"Left", "Right"}, Label: "←→", Description: "Scroll", Focused: true},
+	// Up and down are the global navigation keys, zooming while the chart
+	// has focus
+	{Scope: "Timeline", Action: "zoom", Label: "↑↓", Description: "Zoom", Focused: true},
+	{Scope: "Work Hours", Action: "toggle", Keys: []string{"t", "T"}, Description: "Toggle Matrix"},
+
+	{Scope: "Top Files", Action: "sort", Keys: []string{"s", "S"}, Description: "Sort"},
+	{Scope: "Top Files", Action: "reverse", Keys: []string{"r"}, Description: "Reverse"},
+	{Scope: "Top Files", Action: "filter", Keys: []string{"/"}, Description: "Filter"},
+	{Scope: "Top Files", Action: "select", Keys: []string{"Space"}, Description: "Select", Focused: true},
+	{Scope: "Top Files", Action: "clear", Keys: []string{"c", "C"}, Description: "Clear", Focused: true, Hidden: true},
+	{Scope: "Top Files", Action: "watch", Keys: []string{"w"}, Description: "Watch", Group: "batch", Focused: true},
+	{Scope: "Top Files", Action: "exclude", Keys: []string{"x"}, Description: "Exclude", Group: "batch", Focused: true},
+	{Scope: "Top Files", Action: "export", Keys: []string{"y"}, Description: "Export", Group: "batch", Focused: true},
+	{Scope: "Top Files", Action: "open", Keys: []string{"o"}, Description: "Open", Group: "batch", Focused: true},
+
+	{Scope: "Hotspots", Action: "sort", Keys: []string{"s", "S"}, Description: "Sort"},
+	{Scope: "Hotspots", Action: "reverse", Keys: []string{"r"}, Description: "Reverse"},
+	{Scope: "Hotspots", Action: "filter", Keys: []string{"/"}, Description: "Filter"},
+
+	{Scope: "Ownership", Action: "sort", Keys: []string{"s", "S"}, Description: "Sort"},
+	{Scope: "Ownership", Action: "reverse", Keys: []string{"r"}, Description: "Reverse"},
+	{Scope: "Ownership", Action: "numbers", Keys: []string{"f"}, Description: "Numbers"},
+	{Scope: "Ownership", Action: "filter", Keys: []string{"/"}, Description: "Filter"},
+	{Scope: "Ownership", Action: "open", Keys: []string{"Enter"}, Description: "Subdirectories", Focused: true},
+	{Scope: "Ownership", Action: "up", Keys: []string{"Backspace2", "Backspace"}, Description: "Parent", Focused: true},
+	{Scope: "Ownership", Action: "select", Keys: []string{"Space"}, Description: "Select", Focused: true},
+	{Scope: "Ownership", Action: "merge", Keys: []string{"m", "M"}, Description: "Merge", Focused: true},
+	{Scope: "Ownership", Action: "clear", Keys: []string{"c", "C"}, Description: "Clear", Focused: true},
+	{Scope: "Ownership", Action: "animate", Keys: []string{"a", "A"}, Description: "Play/Pause", Focused: true},
+	{Scope: "Ownership", Action: "earlier", Keys: []string{","}, Description: "Earlier", Group: "month", Focused: true},
+	{Scope: "Ownership", Action: "later", Keys: []string{"."}, Description: "Later", Group: "month", Focused: true},
+	{Scope: "Ownership", Action: "watch", Keys: []string{"w"}, Description: "Watch", Group: "batch", Focused: true},
+	{Scope: "Ownership", Action: "exclude", Keys: []string{"x"}, Description: "Exclude", Group: "batch", Focused: true},
+	{Scope: "Ownership", Action: "export", Keys: []string{"y"}, Description: "Export", Group: "batch", Focused: true},
+
+	{Scope: "Pull Requests", Action: "toggle", Keys: []string{"t", "T"}, Description: "Toggle View"},
+	{Scope: "Pull Requests", Action: "sort", Keys: []string{"s", "S"}, Description: "Sort"},
+	{Scope: "Pull Requests", Action: "reverse", Keys: []string{"r"}, Description: "Reverse"},
+	{Scope: "Pull Requests", Action: "filter", Keys: []string{"/"}, Description: "Filter"},
+
+	{Scope: "Authors", Action: "find", Keys: []string{"/"}, Description: "Find", Focused: true},
+	{Scope: "Authors", Action: "select", Keys: []string{"Space"}, Description: "Select", Focused: true},
+	{Scope: "Authors", Action: "merge", Keys: []string{"m", "M"}, Description: "Merge", Focused: true},
+	{Scope: "Authors", Action: "auto", Keys: []string{"u", "U"}, Description: "Auto", Focused: true},
+	{Scope: "Authors", Action: "apply", Keys: []string{"a", "A"}, Description: "Apply", Focused: true},
+	{Scope: "Authors", Action: "clear", Keys: []string{"c", "C"}, Description: "Clear", Focused: true},
+	{Scope: "Authors", Action: "mailmap", Keys: []string{"e", "E"}, Description: "Mailmap", Focused: true},
+	{Scope: "Authors", Action: "watch", Keys: []string{"w"}, Description: "Watch", Group: "batch", Focused: true},
+	{Scope: "Authors", Action: "exclude", Keys: []string{"x"}, Description: "Exclude", Group: "batch", Focused: true},
+	{Scope: "Authors", Action: "export", Keys: []string{"y"}, Description: "Export", Group: "batch", Focused: true},
+
+	{Scope: "Bus Factor", Action: "fewer", Keys: []string{"-"}, Description: "Fewer Leavers", Group: "leavers"},
+	{Scope: "Bus Factor", Action: "more", Keys: []string{"+", "="}, Description: "More Leavers", Group: "leavers"},
+
+	{Scope: "Health", Action: "open", Keys: []string{"Enter"}, Description: "Open", Focused: true},
+
+	{Scope: "Query", Action: "run", Keys: []string{"Enter"}, Description: "Run"},
+
+	{Scope: ScopeSetup, Action: "add", Keys: []string{"a", "A"}, Description: "Add repo"},
+	{Scope: ScopeSetup, Action: "remove", Keys: []string{"d", "D"}, Description: "Remove"},
+	{Scope: ScopeSetup, Action: "since", Keys: []string{"s"}, Description: "Since"},
+	{Scope: ScopeSetup, Action: "until", Keys: []string{"u"}, Description: "Until"},
+	{Scope: ScopeSetup, Action: "refs", Keys: []string{"r"}, Description: "Refs"},
+	{Scope: ScopeSetup, Action: "paths", Keys: []string{"g"}, Description: "Paths"},
+	{Scope: ScopeSetup, Action: "merges", Keys: []string{"m"}, Description: "No merges"},
+	{Scope: ScopeSetup, Action: "parent", Keys: []string{"f"}, Description: "First parent"},
+	{Scope: ScopeSetup, Action: "upstream", Keys: []string{"p"}, Description: "Upstream"},
+	{Scope: ScopeSetup, Action: "scan", Keys: []string{"Enter"}, Description: "Scan"},
+	{Scope: ScopeSetup, Action: "navigate", Label: "↑↓", Description: "Navigate"},
+
+	{Scope: ScopeBrowser, Action: "open", Keys: []string{"Enter"}, Description: "Open folder"},
+	{Scope: ScopeBrowser, Action: "add", Keys: []string{"Space"}, Description: "Add repo"},
+	{Scope: ScopeBrowser, Action: "goto", Keys: []string{"/"}, Description: "Go to"},
+	{Scope: ScopeBrowser, Action: "bookmarks", Keys: []string{"b"}, Description: "Bookmarks"},
+	{Scope: ScopeBrowser, Action: "hidden", Keys: []string{"."}, Description: "Hidden"},
+	{Scope: ScopeBrowser, Action: "close", Keys: []string{"Esc"}, Description: "Close"},
+}
+
+// KeyMap is the registry of key bindings. Views attach handlers to the
+// actions of their scope; the status bar hints and the help page are
+// generated from the same bindings, so they always show the keys in effect.
+type KeyMap struct {
+	bindings []*Binding
+}
+
+// defaultKeys answers key lookups of views that have no key map yet
+var defaultKeys = NewKeyMap()
+
+// NewKeyMap creates a key map with the default bindings
+func NewKeyMap() *KeyMap {
+	k := &KeyMap{}
+	for _, b := range defaultBindings {
+		b.Keys = append([]string(nil), b.Keys...)
+		k.bindings = append(k.bindings, &b)
+	}
+	return k
+}
+
+// Bindings returns all bindings in display order
+func (k *KeyMap) Bindings() []*Binding {
+	return k.bindings
+}
+
+// Binding returns the binding of action in scope, or nil
+func (k *KeyMap) Binding(scope, action string) *Binding {
+	for _, b := range k.bindings {
+		if b.Scope == scope && b.Action == action {
+			return b
+		}
+	}
+	return nil
+}
+
+// Handle attaches the handler of action in scope
+func (k *KeyMap) Handle(scope, action string, handler func()) {
+	if b := k.Binding(scope, action); b != nil {
+		b.handler = handler
+	}
+}
+
+// Dispatch runs the handler bound to the event's key, looking in scope
+// before the global bindings, and reports whether there was one. Focused
+// bindings only run when focused is set.
+func (k *KeyMap) Dispatch(scope string, focused bool, event *tcell.EventKey) bool {
+	for _, b := range k.active(scope) {
+		if b.handler == nil || (b.Focused && !focused) || !b.matches(event) {
+			continue
+		}
+		b.handler()
+		return true
+	}
+	return false
+}
+
+// Commands returns the bindings of scope and the global ones that have a
+// handler, for the command palette
+func (k *KeyMap) Commands(scope string) []*Binding {
+	var commands []*Binding
+	for _, b := range k.active(scope) {
+		if b.handler != nil {
+			commands = append(commands, b)
+		}
+	}
+	return commands
+}
+
+// Run runs the action called name as if its key was pressed in scope: a
+// plain action name is looked up in scope, then globally. It reports
+// whether the action has a handler there.
+func (k *KeyMap) Run(scope, name string) bool {
+	b := k.lookup(scope, name)
+	if b == nil || b.handler == nil {
+		return false
+	}
+	b.handler()
+	return true
+}
+
+// HasAction reports whether name is the name of an action in any scope
+func (k *KeyMap) HasAction(name string) bool {
+	if scope, action, ok := strings.Cut(name, "."); ok {
+		return k.Binding(scope, action) != nil
+	}
+	for _, b := range k.bindings {
+		if b.Action == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *KeyMap) lookup(scope, name string) *Binding {
+	if viewScope, action, ok := strings.Cut(name, "."); ok {
+		return k.Binding(viewScope, action)
+	}
+	if b := k.Binding(scope, name); b != nil || standalone(scope) {
+		return b
+	}
+	return k.Binding(ScopeGlobal, name)
+}
+
+// Run runs the binding's handler, if any
+func (b *Binding) Run() {
+	if b.handler != nil {
+		b.handler()
+	}
+}
+
+// Key returns the key shown for action in scope, e.g. in info bars
+func (k *KeyMap) Key(scope, action string) string {
+	if k == nil {
+		k = defaultKeys
+	}
+	if b := k.Binding(scope, action); b != nil {
+		return b.key()
+	}
+	return ""
+}
+
+// Hints renders the status bar hints of scope followed by the global ones,
+// which the setup screen and its browser leave out
+func (k *KeyMap) Hints(scope string) string {
+	var hints []string
+	scopes := [][]*Binding{k.scope(scope), k.scope(ScopeGlobal)}
+	if standalone(scope) {
+		scopes = scopes[:1]
+	}
+	for _, bindings := range scopes {
+		for i := 0; i < len(bindings); i++ {
+			b := bindings[i]
+			if b.Hidden {
+				continue
+			}
+			keys := []string{b.key()}
+			descriptions := []string{i18n.T(b.Description)}
+			for b.Group != "" && i+1 < len(bindings) && bindings[i+1].Group == b.Group {
+				i++
+				keys = append(keys, bindings[i].key())
+				descriptions = append(descriptions, i18n.T(bindings[i].Description))
+			}
+			hints = append(hints, fmt.Sprintf("[yellow]%s[-] %s", strings.Join(keys, "/"), strings.Join(descriptions, "/")))
+		}
+	}
+	return strings.Join(hints, "  ")
+}
+
+// Help renders every binding of scope and the global ones, with all of
+// their keys and the action names the configuration file uses, for the
+// help page
+func (k *KeyMap) Help(scope string) string {
+	var sb strings.Builder
+	for _, name := range []string{scope, ScopeGlobal} {
+		bindings := k.scope(name)
+		if len(bindings) == 0 || (name == ScopeGlobal && standalone(scope)) {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("[yellow::b]%s[-:-:-]\n\n", i18n.T(name)))
+		for _, b := range bindings {
+			keys := b.Label
+			if keys == "" {
+				keys = strings.Join(b.Keys, "/")
+			}
+			sb.WriteString(fmt.Sprintf("  [green]%-12s[-] %-28s [gray]%s[-]\n", tview.Escape(keys), i18n.T(b.Description), b.Name()))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Remap replaces the keys of actions, e.g. with those of the configuration
+// file. Names are "action", for the action in every scope that has it, or
+// "Scope.action", which wins over the plain name. It reports unknown actions
+// and keys, and keys left bound to two actions that are active together.
+func (k *KeyMap) Remap(keys map[string][]string) error {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	// Plain action names first, so scoped ones override them
+	sort.Slice(names, func(i, j int) bool {
+		si, sj := strings.Contains(names[i], "."), strings.Contains(names[j], ".")
+		if si != sj {
+			return sj
+		}
+		return names[i] < names[j]
+	})
+
+	var problems []string
+	for _, name := range names {
+		if len(keys[name]) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no keys given", name))
+			continue
+		}
+		for _, key := range keys[name] {
+			if !validKey(key) {
+				problems = append(problems, fmt.Sprintf("%s: unknown key %q", name, key))
+			}
+		}
+
+		scope, action, scoped := strings.Cut(name, ".")
+		if !scoped {
+			scope, action = "", name
+		}
+		found := false
+		for _, b := range k.bindings {
+			if b.Action == action && (!scoped || b.Scope == scope) {
+				b.Keys = append([]string(nil), keys[name]...)
+				b.Label = ""
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: unknown action", name))
+		}
+	}
+
+	problems = append(problems, k.conflicts()...)
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// conflicts describes keys bound to two actions that are active at the same
+// time: in one scope, or in a view and globally, where the view's binding
+// would hide the global one
+func (k *KeyMap) conflicts() []string {
+	var problems []string
+	reported := make(map[string]bool)
+	for _, scope := range k.scopes() {
+		owners := make(map[string]*Binding)
+		for _, b := range k.active(scope) {
+			for _, key := range b.Keys {
+				if key == " " {
+					key = "Space"
+				}
+				other, ok := owners[key]
+				if !ok {
+					owners[key] = b
+					continue
+				}
+				if other == b {
+					continue
+				}
+				problem := fmt.Sprintf("key %q is bound to both %s and %s", key, other.Name(), b.Name())
+				if !reported[problem] {
+					reported[problem] = true
+					problems = append(problems, problem)
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// scopes returns the scopes that have bindings, in display order
+func (k *KeyMap) scopes() []string {
+	var scopes []string
+	for _, b := range k.bindings {
+		if !slices.Contains(scopes, b.Scope) {
+			scopes = append(scopes, b.Scope)
+		}
+	}
+	return scopes
+}
+
+// active returns the bindings in effect in scope: its own, then the global
+// ones except on the setup screen and its browser
+func (k *KeyMap) active(scope string) []*Binding {
+	if standalone(scope) {
+		return k.scope(scope)
+	}
+	return append(k.scope(scope), k.scope(ScopeGlobal)...)
+}
+
+func (k *KeyMap) scope(scope string) []*Binding {
+	var bindings []*Binding
+	for _, b := range k.bindings {
+		if b.Scope == scope {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
+
+// key returns the label or first key of the binding
+func (b *Binding) key() string {
+	if b.Label != "" {
+		return b.Label
+	}
+	if len(b.Keys) == 0 {
+		return ""
+	}
+	return b.Keys[0]
+}
+
+// Name identifies the binding in the configuration file, macros and the
+// command palette: the action, prefixed with the view for view bindings
+func (b *Binding) Name() string {
+	if b.Scope == ScopeGlobal {
+		return b.Action
+	}
+	return b.Scope + "." + b.Action
+}
+
+// matches reports whether the event is one of the binding's keys
+func (b *Binding) matches(event *tcell.EventKey) bool {
+	for _, key := range b.Keys {
+		if keyMatches(key, event) {
+			return true
+		}
+	}
+	return false
+}
+
+func keyMatches(key string, event *tcell.EventKey) bool {
+	if key == "Space" {
+		key = " "
+	}
+	if r, size := utf8.DecodeRuneInString(key); size == len(key) && size > 0 {
+		return event.Key() == tcell.KeyRune && event.Rune() == r
+	}
+	return event.Key() != tcell.KeyRune && tcell.KeyNames[event.Key()] == key
+}
+
+// validKey reports whether key is a rune, "Space" or a tcell key name
+func validKey(key string) bool {
+	if key == "Space" || utf8.RuneCountInString(key) == 1 {
+		return true
+	}
+	for k, name := range tcell.KeyNames {
+		if name == key && k != tcell.KeyRune {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// LabelsView displays statistics per commit label from gitstat notes
+type LabelsView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	detail  *tview.TextView
+	info    *tview.TextView
+	columns []string
+	labels  []*stats.LabelStats
+	repo    *stats.Repository
+}
+
+// NewLabelsView creates a new labels view
+func NewLabelsView() *LabelsView {
+	v := &LabelsView{
+		columns: []string{"#", "Label", "Commits", "Share", "Authors", "Additions", "Deletions", "Last"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *LabelsView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Label Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if row > 0 && row <= len(v.labels) {
+			v.showLabelDetails(v.labels[row-1])
+		}
+	})
+}
+
+// Refresh updates the view with new data
+func (v *LabelsView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.repo = repo
+	v.labels = repo.GetLabelStats()
+
+	for i, ls := range v.labels {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(ls.Label).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", ls.Commits)).
+			SetAlign(tview.AlignRight))
+
+		share := safeDivide(float64(ls.Commits), float64(repo.TotalCommits)) * 100
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f%%", share)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", len(ls.Authors))).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("+%d", ls.Additions)).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("-%d", ls.Deletions)).
+			SetTextColor(tcell.ColorRed).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 7, tview.NewTableCell(i18n.Date(ls.LastCommit)).
+			SetAlign(tview.AlignRight))
+	}
+
+	if len(v.labels) == 0 {
+		v.detail.SetText("")
+		v.info.SetText("[gray]No labeled commits: gitstat label <commit> <label>... adds labels as git notes[-]")
+		return
+	}
+
+	labeled := 0
+	for _, ls := range v.labels {
+		labeled += ls.Commits
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] labels applied [yellow]%d[-] times | notes in refs/notes/gitstat",
+		len(v.labels), labeled))
+
+	v.table.Select(1, 0)
+	v.showLabelDetails(v.labels[0])
+}
+
+func (v *LabelsView) showLabelDetails(ls *stats.LabelStats) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n\n", ls.Label))
+	sb.WriteString(fmt.Sprintf("[cyan]Commits:[-]  %d\n", ls.Commits))
+	sb.WriteString(fmt.Sprintf("[cyan]Lines:[-]    [green]+%d[-] [red]-%d[-]\n", ls.Additions, ls.Deletions))
+	sb.WriteString(fmt.Sprintf("[cyan]Active:[-]   %s to %s\n\n",
+		i18n.Date(ls.FirstCommit), i18n.Date(ls.LastCommit)))
+
+	emails := make([]string, 0, len(ls.Authors))
+	for email := range ls.Authors {
+		emails = append(emails, email)
+	}
+	sort.Slice(emails, func(i, j int) bool {
+		if ls.Authors[emails[i]] != ls.Authors[emails[j]] {
+			return ls.Authors[emails[i]] > ls.Authors[emails[j]]
+		}
+		return emails[i] < emails[j]
+	})
+
+	sb.WriteString("[yellow]━━━ Authors ━━━[-]\n")
+	for _, email := range emails {
+		name := email
+		if author, ok := v.repo.Authors[email]; ok {
+			name = author.Name
+		}
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("  %-24s %d\n", name, ls.Authors[email]))
+	}
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// Root returns the root primitive
+func (v *LabelsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *LabelsView) GetFocusable() tview.Primitive {
+	return v.table
+}
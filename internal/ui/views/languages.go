@@ -0,0 +1,192 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// Width of the share bars in the table
+const languageBarWidth = 10
+
+// LanguagesView displays statistics per language and file extension
+type LanguagesView struct {
+	root      *tview.Flex
+	table     *tview.Table
+	detail    *tview.TextView
+	info      *tview.TextView
+	columns   []string
+	languages []*stats.LanguageStats
+	total     int // lines changed across all extensions
+	repo      *stats.Repository
+}
+
+// NewLanguagesView creates a new languages view
+func NewLanguagesView() *LanguagesView {
+	v := &LanguagesView{
+		columns: []string{"#", "Language", "Extension", "Files", "Touches", "Additions", "Deletions", "Authors", "Share"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *LanguagesView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Language Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if row > 0 && row <= len(v.languages) {
+			v.showLanguageDetails(v.languages[row-1])
+		}
+	})
+}
+
+// Refresh updates the view with new data
+func (v *LanguagesView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.repo = repo
+	v.languages = repo.GetLanguageStats()
+	v.total = 0
+	for _, ls := range v.languages {
+		v.total += ls.Changes()
+	}
+
+	for i, ls := range v.languages {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(ls.Language).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(ls.Extension).
+			SetTextColor(tcell.ColorDarkGray))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", ls.Files())).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", ls.Touches)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("+%s", formatNumber(ls.Additions))).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("-%s", formatNumber(ls.Deletions))).
+			SetTextColor(tcell.ColorRed).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 7, tview.NewTableCell(fmt.Sprintf("%d", len(ls.Authors))).
+			SetAlign(tview.AlignRight))
+
+		share := safeDivide(float64(ls.Changes()), float64(v.total)) * 100
+		v.table.SetCell(row, 8, tview.NewTableCell(fmt.Sprintf("%s %5.1f%%", shareBar(share, languageBarWidth), share)).
+			SetTextColor(tcell.ColorGreen))
+	}
+
+	if len(v.languages) == 0 {
+		v.detail.SetText("")
+		v.info.SetText("[gray]No file changes in this period[-]")
+		return
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] extensions | [yellow]%s[-] lines changed | share of lines changed",
+		len(v.languages), formatNumber(v.total)))
+
+	v.table.Select(1, 0)
+	v.showLanguageDetails(v.languages[0])
+}
+
+func (v *LanguagesView) showLanguageDetails(ls *stats.LanguageStats) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-] [gray]%s[-]\n\n", ls.Language, ls.Extension))
+	sb.WriteString(fmt.Sprintf("[cyan]Files:[-]    %d\n", ls.Files()))
+	sb.WriteString(fmt.Sprintf("[cyan]Touches:[-]  %d\n", ls.Touches))
+	sb.WriteString(fmt.Sprintf("[cyan]Lines:[-]    [green]+%d[-] [red]-%d[-]\n", ls.Additions, ls.Deletions))
+	sb.WriteString(fmt.Sprintf("[cyan]Share:[-]    %.1f%% of lines changed\n\n",
+		safeDivide(float64(ls.Changes()), float64(v.total))*100))
+
+	emails := make([]string, 0, len(ls.Authors))
+	for email := range ls.Authors {
+		emails = append(emails, email)
+	}
+	sort.Slice(emails, func(i, j int) bool {
+		if ls.Authors[emails[i]] != ls.Authors[emails[j]] {
+			return ls.Authors[emails[i]] > ls.Authors[emails[j]]
+		}
+		return emails[i] < emails[j]
+	})
+
+	sb.WriteString("[yellow]━━━ Authors by Lines ━━━[-]\n")
+	for _, email := range emails {
+		name := email
+		if author, ok := v.repo.Authors[email]; ok {
+			name = author.Name
+		}
+		if len(name) > 18 {
+			name = name[:15] + "..."
+		}
+		share := safeDivide(float64(ls.Authors[email]), float64(ls.Changes())) * 100
+		sb.WriteString(fmt.Sprintf("  %-18s [green]%s[-] %5.1f%%\n", name, shareBar(share, 8), share))
+	}
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// shareBar renders a percentage as a bar of width cells
+func shareBar(percent float64, width int) string {
+	filled := min(width, int(percent/100*float64(width)+0.5))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// Root returns the root primitive
+func (v *LanguagesView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *LanguagesView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,45 @@
+package views
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout describes the space the main view leaves for a view, so bars and
+// sparklines drawn into text shrink instead of overflowing small terminals
+type Layout struct {
+	Width   int  // columns inside the view border; 0 until the first draw
+	Compact bool // narrow terminal: prefer denser renderings
+}
+
+// Side padding of the text-only views
+const textPadding = 4
+
+// Bars never shrink below this many cells
+const minBarWidth = 8
+
+// bar returns the width for a bar preferring preferred cells when reserved
+// columns of the same line are taken by labels and numbers
+func (l Layout) bar(preferred, reserved int) int {
+	if l.Width == 0 {
+		return preferred
+	}
+	return max(minBarWidth, min(preferred, l.Width-reserved))
+}
+
+var ruleRun = regexp.MustCompile(`━{2,}`)
+
+// fit shortens the horizontal rules of content to the available width
+func (l Layout) fit(content string) string {
+	width := l.Width - textPadding
+	if l.Width == 0 {
+		return content
+	}
+	return ruleRun.ReplaceAllStringFunc(content, func(rule string) string {
+		if utf8.RuneCountInString(rule) <= width {
+			return rule
+		}
+		return strings.Repeat("━", max(width, 3))
+	})
+}
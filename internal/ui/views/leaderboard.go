@@ -2,13 +2,20 @@ package views
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
+// Cells of the per-row activity sparklines in the Leaderboard and Top Files
+const activityCells = 16
+
 // LeaderboardView displays author statistics
 type LeaderboardView struct {
 	root    *tview.Flex
@@ -17,6 +24,15 @@ type LeaderboardView struct {
 	sortCol int
 	sortAsc bool
 	columns []string
+	authors []*stats.AuthorStats // rows in display order
+	repo    *stats.Repository
+	filter  *tableFilter
+
+	// Work estimate session limits (see stats.EstimateHours)
+	sessionGap   time.Duration
+	sessionStart time.Duration
+
+	keys *KeyMap
 }
 
 // NewLeaderboardView creates a new leaderboard view
@@ -24,7 +40,10 @@ func NewLeaderboardView() *LeaderboardView {
 	v := &LeaderboardView{
 		sortCol: 2, // Default sort by commits
 		sortAsc: false,
-		columns: []string{"#", "Author", "Commits", "Additions", "Deletions", "Net", "Files"},
+		columns: []string{"#", "Author", "Commits", "Hours", "Hrs/Wk", "Additions", "Deletions", "Net", "Files", "Activity"},
+
+		sessionGap:   2 * time.Hour,
+		sessionStart: 2 * time.Hour,
 	}
 	v.setup()
 	return v
@@ -40,17 +59,21 @@ func (v *LeaderboardView) setup() {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
+	v.filter = newTableFilter(v.table, func() { v.Refresh(v.repo) })
+
 	v.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(v.table, 0, 1, true).
+		AddItem(v.filter.input, 0, 0, false).
 		AddItem(v.info, 1, 0, false)
+	v.filter.root = v.root
 
 	v.renderHeader()
 }
 
 func (v *LeaderboardView) renderHeader() {
 	for col, name := range v.columns {
-		cell := tview.NewTableCell(name).
+		cell := tview.NewTableCell(i18n.T(name)).
 			SetTextColor(tcell.ColorYellow).
 			SetSelectable(false).
 			SetAttributes(tcell.AttrBold)
@@ -60,7 +83,7 @@ func (v *LeaderboardView) renderHeader() {
 			if v.sortAsc {
 				arrow = "▲"
 			}
-			cell.SetText(name + arrow)
+			cell.SetText(i18n.T(name) + arrow)
 		}
 
 		v.table.SetCell(0, col, cell)
@@ -69,23 +92,47 @@ func (v *LeaderboardView) renderHeader() {
 
 // Refresh updates the view with new data
 func (v *LeaderboardView) Refresh(repo *stats.Repository) {
+	v.repo = repo
+	if repo == nil {
+		return
+	}
+
 	// Clear existing data rows
 	for row := v.table.GetRowCount() - 1; row > 0; row-- {
 		v.table.RemoveRow(row)
 	}
 
 	// Get sorted leaderboard
-	sortBy := []string{"", "name", "commits", "additions", "deletions", "net", ""}[v.sortCol]
-	if sortBy == "" {
+	sortBy := []string{"", "name", "commits", "hours", "", "additions", "deletions", "net", "", ""}[v.sortCol]
+	if sortBy == "" || sortBy == "hours" {
 		sortBy = "commits"
 	}
 	authors := repo.GetLeaderboard(sortBy, v.sortAsc)
 
+	// Hours depend on the session limits, so they are sorted here
+	estimates := repo.GetWorkEstimates(v.sessionGap, v.sessionStart)
+	weeks := repo.ActivityWeeks()
+	if v.columns[v.sortCol] == "Hours" {
+		sort.SliceStable(authors, func(i, j int) bool {
+			hi, hj := estimates[authors[i].Email].Hours, estimates[authors[j].Email].Hours
+			if v.sortAsc {
+				return hi < hj
+			}
+			return hi > hj
+		})
+	}
+
 	// Render data
-	for i, author := range authors {
+	v.authors = nil
+	for _, author := range authors {
+		if v.filter.match(author.Name, author.Email) {
+			v.authors = append(v.authors, author)
+		}
+	}
+	for i, author := range v.authors {
 		row := i + 1
 		net := author.Additions - author.Deletions
-		netStr := fmt.Sprintf("%+d", net)
+		netStr := formatSigned(net)
 		filesCount := len(author.FilesTouched)
 
 		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
@@ -95,14 +142,23 @@ func (v *LeaderboardView) Refresh(repo *stats.Repository) {
 		v.table.SetCell(row, 1, tview.NewTableCell(author.Name).
 			SetExpansion(1))
 
-		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", author.Commits)).
+		v.table.SetCell(row, 2, tview.NewTableCell(i18n.Int(author.Commits)).
+			SetAlign(tview.AlignRight))
+
+		estimate := estimates[author.Email]
+		v.table.SetCell(row, 3, tview.NewTableCell(i18n.Float(estimate.Hours, 1)).
+			SetTextColor(tcell.ColorAqua).
 			SetAlign(tview.AlignRight))
 
-		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("+%d", author.Additions)).
+		v.table.SetCell(row, 4, tview.NewTableCell(i18n.Float(estimate.ActiveWeekAverage(), 1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell("+"+formatNumber(author.Additions)).
 			SetTextColor(tcell.ColorGreen).
 			SetAlign(tview.AlignRight))
 
-		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("-%d", author.Deletions)).
+		v.table.SetCell(row, 6, tview.NewTableCell("-"+formatNumber(author.Deletions)).
 			SetTextColor(tcell.ColorRed).
 			SetAlign(tview.AlignRight))
 
@@ -112,34 +168,80 @@ func (v *LeaderboardView) Refresh(repo *stats.Repository) {
 		} else if net < 0 {
 			netColor = tcell.ColorRed
 		}
-		v.table.SetCell(row, 5, tview.NewTableCell(netStr).
+		v.table.SetCell(row, 7, tview.NewTableCell(netStr).
 			SetTextColor(netColor).
 			SetAlign(tview.AlignRight))
 
-		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%d", filesCount)).
+		v.table.SetCell(row, 8, tview.NewTableCell(i18n.Int(filesCount)).
 			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 9, tview.NewTableCell(renderActivity(author.Weekly, weeks)).
+			SetTextColor(tcell.ColorGreen))
 	}
 
 	// Update info
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors | Sort: [green]%s[-] | [s] cycle column, [r] reverse",
-		len(authors), v.columns[v.sortCol]))
+	mechanical := ""
+	if m := repo.Mechanical; m.Commits > 0 {
+		state := "included"
+		if m.Excluded {
+			state = "excluded"
+		}
+		mechanical = fmt.Sprintf(" | [gray]%d mechanical commits (%d lines) %s[-]", m.Commits, m.Lines, state)
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors%s | Sort: [green]%s[-]%s | [gray]hours: sessions with gaps ≤%gh[-] | [%s] cycle column, [%s] reverse",
+		len(authors), v.filter.status(len(v.authors), len(authors)), v.columns[v.sortCol], mechanical, v.sessionGap.Hours(),
+		v.keys.Key("Leaderboard", "sort"), v.keys.Key("Leaderboard", "reverse")))
 
 	v.renderHeader()
 }
 
+// SelectedAuthor returns the author of the selected row, or nil
+func (v *LeaderboardView) SelectedAuthor() *stats.AuthorStats {
+	row, _ := v.table.GetSelection()
+	if row < 1 || row > len(v.authors) {
+		return nil
+	}
+	return v.authors[row-1]
+}
+
 // CycleSortColumn cycles through sort columns
 func (v *LeaderboardView) CycleSortColumn() {
 	v.sortCol = (v.sortCol + 1) % len(v.columns)
-	if v.sortCol == 0 || v.sortCol == 6 {
-		v.sortCol = 1 // Skip rank and files columns
+	for v.sortCol == 0 || v.sortCol == 4 || v.sortCol >= 8 {
+		// Skip rank, hours per week, files and activity columns
+		v.sortCol = (v.sortCol + 1) % len(v.columns)
 	}
 }
 
+// renderActivity draws weekly activity over the scanned range as a sparkline
+func renderActivity(weekly map[string]int, weeks []string) string {
+	return components.RenderSparkline(stats.WeeklySeries(weekly, weeks, activityCells))
+}
+
+// SetSessionLimits sets how commits are grouped into work sessions for the
+// hours estimate
+func (v *LeaderboardView) SetSessionLimits(maxGap, start time.Duration) {
+	v.sessionGap = maxGap
+	v.sessionStart = start
+}
+
 // ReverseSortOrder reverses the sort order
 func (v *LeaderboardView) ReverseSortOrder() {
 	v.sortAsc = !v.sortAsc
 }
 
+// SortState returns the sort column and direction
+func (v *LeaderboardView) SortState() (column int, ascending bool) {
+	return v.sortCol, v.sortAsc
+}
+
+// SetSortState restores a sort, ignoring columns the view doesn't have
+func (v *LeaderboardView) SetSortState(column int, ascending bool) {
+	if column > 0 && column < len(v.columns) {
+		v.sortCol, v.sortAsc = column, ascending
+	}
+}
+
 // Root returns the root primitive
 func (v *LeaderboardView) Root() tview.Primitive {
 	return v.root
@@ -149,3 +251,20 @@ func (v *LeaderboardView) Root() tview.Primitive {
 func (v *LeaderboardView) GetFocusable() tview.Primitive {
 	return v.table
 }
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *LeaderboardView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Leaderboard", "filter", v.filter.open)
+}
+
+// SetFocusFunc sets the callback moving focus between the table and its
+// filter bar
+func (v *LeaderboardView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.filter.focus = focus
+}
+
+// Filtering reports whether the filter bar has focus and takes every key
+func (v *LeaderboardView) Filtering() bool {
+	return v.filter.typing
+}
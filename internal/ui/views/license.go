@@ -0,0 +1,143 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// LicenseView displays license header compliance per directory
+type LicenseView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	missing *tview.TextView
+	info    *tview.TextView
+	columns []string
+}
+
+// NewLicenseView creates a new license compliance view
+func NewLicenseView() *LicenseView {
+	v := &LicenseView{
+		columns: []string{"#", "Directory", "Files", "With Header", "Missing", "Compliance"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *LicenseView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.missing = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+	v.missing.SetBorder(true).SetTitle(" " + i18n.T("Missing Header") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.missing, 0, 1, false).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *LicenseView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	compliance := repo.GetLicenseCompliance()
+	if compliance == nil {
+		v.missing.SetText("  [gray]Set Config.LicenseHeader to a regular expression to check source files for a license header[-]\n")
+		v.info.SetText("[gray]License header check disabled[-]")
+		return
+	}
+
+	for i, dir := range compliance.Dirs {
+		row := i + 1
+		pct := dir.Percent()
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(dir.Path).
+			SetTextColor(getDirColor(dir.Path)).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", dir.Checked)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", dir.Compliant)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", dir.Checked-dir.Compliant)).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%5.1f%% %s", pct, getComplianceBar(pct))).
+			SetTextColor(tcell.GetColor(getComplianceColor(pct))).
+			SetAlign(tview.AlignRight))
+	}
+
+	var sb strings.Builder
+	if len(compliance.Missing) == 0 {
+		sb.WriteString("  [green]All checked source files carry the license header[-]\n")
+	}
+	for _, file := range compliance.Missing {
+		sb.WriteString(fmt.Sprintf("  [red]✗[-] %s\n", file))
+	}
+	v.missing.SetText(sb.String())
+	v.missing.ScrollToBeginning()
+
+	info := fmt.Sprintf("[yellow]%d[-] of [yellow]%d[-] source files have the header ([%s]%.1f%%[-]) | [red]%d[-] missing",
+		compliance.Compliant, compliance.Checked, getComplianceColor(compliance.Percent()),
+		compliance.Percent(), len(compliance.Missing))
+	if repo.SparseFiles > 0 {
+		info += " | [yellow]" + i18n.T("%d files outside the sparse checkout not checked", repo.SparseFiles) + "[-]"
+	}
+	v.info.SetText(info)
+}
+
+func getComplianceColor(pct float64) string {
+	if pct >= 95 {
+		return "green"
+	} else if pct >= 75 {
+		return "yellow"
+	}
+	return "red"
+}
+
+func getComplianceBar(pct float64) string {
+	filled := int(pct / 20)
+	return strings.Repeat("█", filled) + strings.Repeat("░", 5-filled)
+}
+
+// Root returns the root primitive
+func (v *LicenseView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *LicenseView) GetFocusable() tview.Primitive {
+	return v.table
+}
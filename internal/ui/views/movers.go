@@ -0,0 +1,206 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// MoversView lists the files and directories that climbed or fell most in
+// churn rank against the previous period, showing where development focus
+// is shifting
+type MoversView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	detail  *tview.TextView
+	info    *tview.TextView
+	columns []string
+	rows    map[int]*stats.Mover // table row -> mover; section headings have none
+}
+
+// NewMoversView creates a new top movers view
+func NewMoversView() *MoversView {
+	v := &MoversView{
+		columns: []string{"Move", "Path", "Kind", "Rank", "Prev", "Churn", "Prev Churn"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *MoversView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Mover Details") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if m, ok := v.rows[row]; ok {
+			v.showMoverDetails(m)
+		}
+	})
+}
+
+// Refresh updates the view with new data
+func (v *MoversView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+	v.rows = make(map[int]*stats.Mover)
+
+	if repo.Previous == nil {
+		v.detail.SetText("")
+		v.info.SetText("[gray]Needs the previous period: choose a start date and keep Config.ComparePrevious on[-]")
+		return
+	}
+
+	risers, fallers := repo.GetTopMovers()
+	row := 1
+	for _, section := range []struct {
+		title  string
+		movers []*stats.Mover
+	}{{"Risers", risers}, {"Fallers", fallers}} {
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%s (%d)", i18n.T(section.title), len(section.movers))).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+		row++
+		for _, m := range section.movers {
+			v.setMoverRow(row, m)
+			v.rows[row] = m
+			row++
+		}
+	}
+
+	if len(risers)+len(fallers) == 0 {
+		v.detail.SetText("")
+		v.info.SetText("[gray]Churn ranks did not change against the previous period[-]")
+		return
+	}
+
+	v.info.SetText(fmt.Sprintf("[green]%d[-] risers | [red]%d[-] fallers | churn rank against the previous period, top %d of either period",
+		len(risers), len(fallers), stats.MoverRanks))
+
+	first := 2
+	if len(risers) == 0 {
+		first = 3
+	}
+	v.table.Select(first, 0)
+	v.showMoverDetails(v.rows[first])
+}
+
+func (v *MoversView) setMoverRow(row int, m *stats.Mover) {
+	color, arrow := tcell.ColorGreen, "▲"
+	if m.Change() < 0 {
+		color, arrow = tcell.ColorRed, "▼"
+	}
+	v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%s %d", arrow, max(m.Change(), -m.Change()))).
+		SetTextColor(color).
+		SetAlign(tview.AlignRight))
+
+	path, kind := m.Path, i18n.T("file")
+	if m.IsDir {
+		path, kind = m.Path+"/", i18n.T("dir")
+	}
+	v.table.SetCell(row, 1, tview.NewTableCell(path).
+		SetTextColor(tcell.ColorAqua).
+		SetExpansion(1))
+
+	v.table.SetCell(row, 2, tview.NewTableCell(kind).
+		SetTextColor(tcell.ColorDarkGray))
+
+	v.table.SetCell(row, 3, tview.NewTableCell(formatMoverRank(m.Rank, m.Churn)).
+		SetAlign(tview.AlignRight))
+
+	v.table.SetCell(row, 4, tview.NewTableCell(formatMoverRank(m.PrevRank, m.PrevChurn)).
+		SetTextColor(tcell.ColorDarkGray).
+		SetAlign(tview.AlignRight))
+
+	v.table.SetCell(row, 5, tview.NewTableCell(formatNumber(m.Churn)).
+		SetAlign(tview.AlignRight))
+
+	v.table.SetCell(row, 6, tview.NewTableCell(formatNumber(m.PrevChurn)).
+		SetTextColor(tcell.ColorDarkGray).
+		SetAlign(tview.AlignRight))
+}
+
+// formatMoverRank shows a rank, or a dash for a path untouched in the period
+func formatMoverRank(rank, churn int) string {
+	if churn == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("#%d", rank)
+}
+
+func (v *MoversView) showMoverDetails(m *stats.Mover) {
+	var sb strings.Builder
+
+	kind := "File"
+	if m.IsDir {
+		kind = "Directory"
+	}
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n[gray]%s[-]\n\n", m.Path, kind))
+
+	switch {
+	case m.PrevChurn == 0:
+		sb.WriteString("[green]New this period[-]: untouched in the previous one\n\n")
+	case m.Churn == 0:
+		sb.WriteString("[red]Gone quiet[-]: untouched in this period\n\n")
+	case m.Change() > 0:
+		sb.WriteString(fmt.Sprintf("[green]Climbed %d places[-]\n\n", m.Change()))
+	default:
+		sb.WriteString(fmt.Sprintf("[red]Fell %d places[-]\n\n", -m.Change()))
+	}
+
+	sb.WriteString(fmt.Sprintf("[cyan]Rank:[-]      %s  [gray](previous %s)[-]\n",
+		formatMoverRank(m.Rank, m.Churn), formatMoverRank(m.PrevRank, m.PrevChurn)))
+	sb.WriteString(fmt.Sprintf("[cyan]Churn:[-]     %s  [gray](previous %s)[-]\n",
+		formatNumber(m.Churn), formatNumber(m.PrevChurn)))
+	if m.PrevChurn > 0 {
+		delta := safeDivide(float64(m.Churn-m.PrevChurn), float64(m.PrevChurn)) * 100
+		sb.WriteString(fmt.Sprintf("[cyan]Change:[-]    %+.0f%%\n", delta))
+	}
+
+	v.detail.SetText(sb.String())
+	v.detail.ScrollToBeginning()
+}
+
+// Root returns the root primitive
+func (v *MoversView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *MoversView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,111 @@
+package views
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Notifications kept in the log; older ones are dropped
+const maxNotifications = 100
+
+// Notification is a message about a finished background operation
+type Notification struct {
+	At      time.Time
+	Message string
+	Failed  bool
+}
+
+// NotificationsView lists the notifications of background operations,
+// newest first
+type NotificationsView struct {
+	root          *tview.Flex
+	table         *tview.Table
+	info          *tview.TextView
+	columns       []string
+	notifications []Notification
+}
+
+// NewNotificationsView creates a new notification log view
+func NewNotificationsView() *NotificationsView {
+	v := &NotificationsView{
+		columns: []string{"Time", "Message"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *NotificationsView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	v.render()
+}
+
+// Add appends a notification to the log
+func (v *NotificationsView) Add(n Notification) {
+	v.notifications = append(v.notifications, n)
+	if len(v.notifications) > maxNotifications {
+		v.notifications = v.notifications[len(v.notifications)-maxNotifications:]
+	}
+	v.render()
+}
+
+func (v *NotificationsView) render() {
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	if len(v.notifications) == 0 {
+		v.info.SetText("[gray]" + i18n.T("No notifications yet. Background operations report here when they finish.") + "[-]")
+		return
+	}
+
+	for i := range v.notifications {
+		n := v.notifications[len(v.notifications)-1-i]
+		row := i + 1
+
+		color := tcell.ColorGreen
+		if n.Failed {
+			color = tcell.ColorRed
+		}
+
+		v.table.SetCell(row, 0, tview.NewTableCell(formatTime(n.At)).
+			SetTextColor(tcell.ColorGray))
+		v.table.SetCell(row, 1, tview.NewTableCell(tview.Escape(n.Message)).
+			SetTextColor(color).
+			SetExpansion(1))
+	}
+
+	v.info.SetText("[gray]" + i18n.T("%d notifications", len(v.notifications)) + "[-]")
+}
+
+// Root returns the root primitive
+func (v *NotificationsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *NotificationsView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,41 @@
+package views
+
+import (
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Whether large line counts are abbreviated ("1.2K") or shown exactly
+var abbreviateNumbers = true
+
+// SetAbbreviateNumbers chooses between abbreviated and exact line counts in
+// the Codebase, Leaderboard and Ownership views
+func SetAbbreviateNumbers(abbreviate bool) {
+	abbreviateNumbers = abbreviate
+}
+
+// formatNumber formats a line count, abbreviated with K or M from a thousand
+// on unless exact values are selected
+func formatNumber(n int) string {
+	if !abbreviateNumbers {
+		return i18n.Int(n)
+	}
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	if n >= 1000000 {
+		return sign + i18n.Float(float64(n)/1000000, 1) + "M"
+	}
+	if n >= 1000 {
+		return sign + i18n.Float(float64(n)/1000, 1) + "K"
+	}
+	return sign + i18n.Int(n)
+}
+
+// formatSigned formats a line count with an explicit sign
+func formatSigned(n int) string {
+	if n > 0 {
+		return "+" + formatNumber(n)
+	}
+	return formatNumber(n)
+}
@@ -0,0 +1,161 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// OffboardingView lists inactive authors who still own significant code
+type OffboardingView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	detail  *tview.TextView
+	info    *tview.TextView
+	columns []string
+	risks   []*stats.OffboardingRisk
+}
+
+// NewOffboardingView creates a new offboarding risk view
+func NewOffboardingView() *OffboardingView {
+	v := &OffboardingView{
+		columns: []string{"#", "Author", "First", "Last", "Inactive", "Dirs", "Top Share"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *OffboardingView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	// Detail pane for the selected author
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Directories at Risk") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	content := tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 40, 0, false)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(content, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if row > 0 && row <= len(v.risks) {
+			v.showRiskDetails(v.risks[row-1])
+		}
+	})
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *OffboardingView) Refresh(repo *stats.Repository, inactiveWeeks int, minShare float64) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	v.risks = repo.GetOffboardingRisks(inactiveWeeks, minShare)
+
+	for i, risk := range v.risks {
+		row := i + 1
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(risk.Name).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(i18n.Date(risk.FirstCommit)).
+			SetTextColor(tcell.ColorDarkGray))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(i18n.Date(risk.LastCommit)))
+
+		inactiveColor := tcell.ColorYellow
+		if risk.WeeksInactive >= inactiveWeeks*2 {
+			inactiveColor = tcell.ColorRed
+		}
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%dw", risk.WeeksInactive)).
+			SetTextColor(inactiveColor).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", len(risk.Dirs))).
+			SetAlign(tview.AlignRight))
+
+		top := risk.Dirs[0]
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%.0f%% %s", top.Share, top.Path)).
+			SetTextColor(getDirColor(top.Path)))
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors inactive for [yellow]%d+[-] weeks still own ≥%.0f%% of a directory | plan knowledge transfer",
+		len(v.risks), inactiveWeeks, minShare))
+
+	if len(v.risks) > 0 {
+		row, _ := v.table.GetSelection()
+		if row < 1 || row > len(v.risks) {
+			row = 1
+		}
+		v.showRiskDetails(v.risks[row-1])
+	} else {
+		v.detail.SetText("[gray]No inactive owners[-]")
+	}
+}
+
+func (v *OffboardingView) showRiskDetails(risk *stats.OffboardingRisk) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n", risk.Name))
+	sb.WriteString(fmt.Sprintf("[gray]%s[-]\n\n", risk.Email))
+	sb.WriteString(fmt.Sprintf("Last commit: [yellow]%s[-]\n", i18n.Date(risk.LastCommit)))
+	sb.WriteString(fmt.Sprintf("Inactive:    [yellow]%d weeks[-]\n\n", risk.WeeksInactive))
+
+	sb.WriteString("[yellow]━━━ Owned Directories ━━━[-]\n\n")
+	for _, dir := range risk.Dirs {
+		sb.WriteString(fmt.Sprintf("[%s]%5.1f%%[-] %s\n", getBusFactorRiskColor(dir.Share), dir.Share, dir.Path))
+		sb.WriteString(fmt.Sprintf("       [gray]%s lines changed[-]\n", formatNumber(dir.Changes)))
+	}
+
+	v.detail.SetText(sb.String())
+}
+
+// getBusFactorRiskColor colors an inactive owner's share by how much knowledge is at stake
+func getBusFactorRiskColor(share float64) string {
+	if share >= 75 {
+		return "red"
+	} else if share >= 50 {
+		return "orange"
+	}
+	return "yellow"
+}
+
+// Root returns the root primitive
+func (v *OffboardingView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *OffboardingView) GetFocusable() tview.Primitive {
+	return v.table
+}
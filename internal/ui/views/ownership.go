@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
 // OwnershipView displays directory ownership with visual breakdown
@@ -22,14 +25,44 @@ type OwnershipView struct {
 	sortAsc   bool
 	columns   []string
 	repoStats *stats.Repository
+	selected  map[string]bool // directories selected for merging
+	onMerge   func(merges map[string]string)
+	batch     Batch
+	keys      *KeyMap
+	filter    *tableFilter
+
+	turnoverThreshold float64
+	layout            Layout
+
+	// Directories descended into, outermost first; empty at the top level
+	trail []string
+
+	// Month-by-month ownership of the selected directory, nil when the
+	// details are shown
+	history     *ownershipHistory
+	queueUpdate func(func())
+}
+
+// How long each month of the ownership history is shown while playing
+const historyFrameDuration = 700 * time.Millisecond
+
+// ownershipHistory steps through the ownership frames of one directory
+type ownershipHistory struct {
+	dir    *stats.DirStats
+	frames []stats.OwnershipFrame
+	frame  int
+	stop   chan struct{} // closed to pause; nil while paused
 }
 
 // NewOwnershipView creates a new ownership view
-func NewOwnershipView() *OwnershipView {
+func NewOwnershipView(onMerge func(merges map[string]string)) *OwnershipView {
 	v := &OwnershipView{
-		sortCol: 1, // Default sort by changes
-		sortAsc: false,
-		columns: []string{"path", "changes", "authors"},
+		sortCol:           1, // Default sort by changes
+		sortAsc:           false,
+		columns:           []string{"path", "changes", "authors", "turnover"},
+		turnoverThreshold: 20,
+		selected:          make(map[string]bool),
+		onMerge:           onMerge,
 	}
 	v.setup()
 	return v
@@ -41,13 +74,13 @@ func (v *OwnershipView) setup() {
 		ShowSecondaryText(true).
 		SetHighlightFullLine(true).
 		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
-	v.list.SetBorder(true).SetTitle(" Directories ")
+	v.list.SetBorder(true).SetTitle(" " + i18n.T("Directories") + " ")
 
 	// Detail view on the right
 	v.detail = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
-	v.detail.SetBorder(true).SetTitle(" Ownership Details ")
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("Ownership Details") + " ")
 
 	// Info bar at bottom
 	v.info = tview.NewTextView().
@@ -59,27 +92,158 @@ func (v *OwnershipView) setup() {
 		AddItem(v.list, 35, 0, true).
 		AddItem(v.detail, 0, 1, false)
 
+	v.filter = newTableFilter(v.list, func() { v.Refresh(v.repoStats) })
+
 	v.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(content, 0, 1, true).
+		AddItem(v.filter.input, 0, 0, false).
 		AddItem(v.info, 1, 0, false)
+	v.filter.root = v.root
 
 	// Handle list selection
 	v.list.SetChangedFunc(func(idx int, main, secondary string, shortcut rune) {
+		v.stopHistory()
 		if idx >= 0 && idx < len(v.dirs) {
 			v.showDirectoryDetails(v.dirs[idx])
 		}
 	})
 }
 
+func (v *OwnershipView) clearSelection() {
+	v.selected = make(map[string]bool)
+	v.Refresh(v.repoStats)
+}
+
+// runBatch applies a batch action to the marked directories
+func (v *OwnershipView) runBatch(action string) {
+	status, ok := runBatch(v.batch, action, KindDirs, v.selection())
+	if !ok {
+		return
+	}
+	idx := v.list.GetCurrentItem()
+	v.selected = make(map[string]bool)
+	v.Refresh(v.repoStats)
+	v.list.SetCurrentItem(idx)
+	if status != "" {
+		v.info.SetText(status)
+	}
+}
+
+// selection returns the marked directories, or the current one if none is
+// marked
+func (v *OwnershipView) selection() []string {
+	if len(v.selected) > 0 {
+		paths := make([]string, 0, len(v.selected))
+		for path := range v.selected {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+	if idx := v.list.GetCurrentItem(); idx >= 0 && idx < len(v.dirs) {
+		return []string{v.dirs[idx].Path}
+	}
+	return nil
+}
+
+// SetBatch sets the actions applied to marked directories
+func (v *OwnershipView) SetBatch(batch Batch) {
+	v.batch = batch
+}
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *OwnershipView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Ownership", "select", v.toggleSelection)
+	keys.Handle("Ownership", "merge", v.mergeSelected)
+	keys.Handle("Ownership", "clear", v.clearSelection)
+	keys.Handle("Ownership", "animate", v.toggleHistory)
+	keys.Handle("Ownership", "earlier", func() { v.stepHistory(-1) })
+	keys.Handle("Ownership", "later", func() { v.stepHistory(1) })
+	keys.Handle("Ownership", "filter", v.filter.open)
+	keys.Handle("Ownership", "open", v.descend)
+	keys.Handle("Ownership", "up", v.ascend)
+	for _, action := range batchActions {
+		keys.Handle("Ownership", action, func() { v.runBatch(action) })
+	}
+}
+
+func (v *OwnershipView) toggleSelection() {
+	idx := v.list.GetCurrentItem()
+	if idx < 0 || idx >= len(v.dirs) {
+		return
+	}
+
+	path := v.dirs[idx].Path
+	if v.selected[path] {
+		delete(v.selected, path)
+	} else {
+		v.selected[path] = true
+	}
+
+	v.Refresh(v.repoStats)
+	v.list.SetCurrentItem(idx)
+}
+
+// mergeSelected combines the selected directories into the one with the most changes
+func (v *OwnershipView) mergeSelected() {
+	// Only top-level directories form components
+	if len(v.selected) < 2 || v.onMerge == nil || len(v.trail) > 0 {
+		return
+	}
+
+	var primary *stats.DirStats
+	for _, dir := range v.dirs {
+		if v.selected[dir.Path] && (primary == nil || dir.TotalChanges > primary.TotalChanges) {
+			primary = dir
+		}
+	}
+	if primary == nil {
+		return
+	}
+
+	merges := make(map[string]string)
+	for path := range v.selected {
+		merges[path] = primary.Path
+	}
+
+	v.selected = make(map[string]bool)
+	v.onMerge(merges)
+}
+
 // Refresh updates the view with new data
 func (v *OwnershipView) Refresh(repo *stats.Repository) {
+	if repo == nil {
+		return
+	}
+	v.stopHistory()
 	v.repoStats = repo
 	v.list.Clear()
 
-	// Get sorted directories
+	// Leave the directories no longer in the statistics
+	for len(v.trail) > 0 && repo.GetDir(v.trail[len(v.trail)-1]) == nil {
+		v.trail = v.trail[:len(v.trail)-1]
+	}
+
+	// Get sorted directories of the current level, with the merged ones
+	// matched by the filter as well
 	sortBy := v.columns[v.sortCol]
-	v.dirs = repo.GetOwnership(sortBy, v.sortAsc)
+	var all []*stats.DirStats
+	if len(v.trail) == 0 {
+		all = repo.GetOwnership(sortBy, v.sortAsc)
+		v.list.SetTitle(" " + i18n.T("Directories") + " ")
+	} else {
+		current := v.trail[len(v.trail)-1]
+		all = repo.GetSubdirs(sortBy, v.sortAsc, v.componentPaths(current)...)
+		v.list.SetTitle(fmt.Sprintf(" %s/ ", current))
+	}
+	v.dirs = nil
+	for _, dir := range all {
+		if v.filter.match(append([]string{dir.Path}, dir.Merged...)...) {
+			v.dirs = append(v.dirs, dir)
+		}
+	}
 
 	// Populate list
 	for _, dir := range v.dirs {
@@ -87,10 +251,28 @@ func (v *OwnershipView) Refresh(repo *stats.Repository) {
 		if dirName == "." {
 			dirName = "(root files)"
 		}
+		if len(v.trail) > 0 {
+			dirName = dirName[len(v.trail[len(v.trail)-1])+1:]
+		}
+		if len(dir.Merged) > 0 {
+			dirName += fmt.Sprintf(" [gray](+%d)[-]", len(dir.Merged))
+		}
+		if v.hasSubdirs(dir) {
+			dirName += "[gray]/…[-]"
+		}
+		if v.selected[dir.Path] {
+			dirName = fmt.Sprintf("[blue]◉ %s[-]", dirName)
+		}
+		if v.batch != nil && v.batch.Watched(KindDirs, dir.Path) {
+			dirName = watchMark + dirName
+		}
 
 		// Secondary text with quick stats
 		authorCount := len(dir.Authors)
-		secondary := fmt.Sprintf("%s changes, %d authors", formatChanges(dir.TotalChanges), authorCount)
+		secondary := fmt.Sprintf("%s changes, %s authors", formatNumber(dir.TotalChanges), i18n.Int(authorCount))
+		if v.isTurnoverHotspot(dir) {
+			secondary += " [orange]⟳ turnover[-]"
+		}
 
 		v.list.AddItem(dirName, secondary, 0, nil)
 	}
@@ -102,8 +284,94 @@ func (v *OwnershipView) Refresh(repo *stats.Repository) {
 	}
 
 	// Update info
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] directories | [s] sort by: [green]%s[-] | [r] reverse order",
-		len(v.dirs), v.columns[v.sortCol]))
+	levelText := fmt.Sprintf(" | [%s] open", v.keys.Key("Ownership", "open"))
+	if len(v.trail) > 0 {
+		levelText = fmt.Sprintf(" | [%s] open  [%s] up", v.keys.Key("Ownership", "open"), v.keys.Key("Ownership", "up"))
+	}
+	turnoverText := ""
+	if repo.Previous != nil && len(v.trail) == 0 {
+		turnoverText = fmt.Sprintf(" | [orange]%d[-] turnover hotspots",
+			len(repo.GetTurnoverHotspots(v.turnoverThreshold)))
+	}
+	selectedText := ""
+	if len(v.selected) > 0 {
+		selectedText = fmt.Sprintf(" | [blue]%d[-] selected, [%s] merge  %s",
+			len(v.selected), v.keys.Key("Ownership", "merge"), batchHelp(v.keys, "Ownership"))
+	}
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] directories%s%s%s%s | [%s] sort by: [green]%s[-] | [%s] reverse order",
+		len(all), v.filter.status(len(v.dirs), len(all)), levelText, turnoverText, selectedText, v.keys.Key("Ownership", "sort"), v.columns[v.sortCol], v.keys.Key("Ownership", "reverse")))
+}
+
+// componentPaths returns the directory at path with those merged into it
+func (v *OwnershipView) componentPaths(path string) []string {
+	if dir := v.repoStats.GetDir(path); dir != nil {
+		return append([]string{path}, dir.Merged...)
+	}
+	return []string{path}
+}
+
+// hasSubdirs reports whether the directory can be descended into
+func (v *OwnershipView) hasSubdirs(dir *stats.DirStats) bool {
+	if len(v.trail) == 0 && dir.Path == "." {
+		return false // the root files
+	}
+	for _, path := range append([]string{dir.Path}, dir.Merged...) {
+		if v.repoStats.HasSubdirs(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// descend lists the subdirectories of the selected directory
+func (v *OwnershipView) descend() {
+	idx := v.list.GetCurrentItem()
+	if v.repoStats == nil || idx < 0 || idx >= len(v.dirs) || !v.hasSubdirs(v.dirs[idx]) {
+		return
+	}
+	v.trail = append(v.trail, v.dirs[idx].Path)
+	v.selected = make(map[string]bool)
+	v.filter.clear()
+	v.Refresh(v.repoStats)
+}
+
+// ascend goes back to the parent directory, selecting the one left
+func (v *OwnershipView) ascend() {
+	if len(v.trail) == 0 {
+		return
+	}
+	left := v.trail[len(v.trail)-1]
+	v.trail = v.trail[:len(v.trail)-1]
+	v.selected = make(map[string]bool)
+	v.filter.clear()
+	v.Refresh(v.repoStats)
+	for i, dir := range v.dirs {
+		if dir.Path == left {
+			v.list.SetCurrentItem(i)
+			break
+		}
+	}
+}
+
+func (v *OwnershipView) isTurnoverHotspot(dir *stats.DirStats) bool {
+	return dir.Turnover != nil &&
+		(dir.Turnover.OwnerChanged || dir.Turnover.Shift >= v.turnoverThreshold)
+}
+
+// SetLayout sets the space available for the ownership bars, redrawing the
+// selected directory
+func (v *OwnershipView) SetLayout(layout Layout) {
+	v.layout = layout
+	if v.history != nil {
+		v.showHistoryFrame()
+	} else if i := v.list.GetCurrentItem(); i >= 0 && i < len(v.dirs) {
+		v.showDirectoryDetails(v.dirs[i])
+	}
+}
+
+// SetTurnoverThreshold sets the ownership shift that marks a turnover hotspot
+func (v *OwnershipView) SetTurnoverThreshold(threshold float64) {
+	v.turnoverThreshold = threshold
 }
 
 func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
@@ -116,11 +384,20 @@ func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
 
 	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]\n\n", dirName))
 
+	if len(dir.Merged) > 0 {
+		sb.WriteString(fmt.Sprintf("  [gray]Component also includes: %s[-]\n\n", strings.Join(dir.Merged, ", ")))
+	}
+
 	// Stats summary
 	sb.WriteString(fmt.Sprintf("[yellow]━━━ Overview ━━━[-]\n\n"))
-	sb.WriteString(fmt.Sprintf("  Total Changes:  [cyan]%s[-] lines\n", formatChanges(dir.TotalChanges)))
-	sb.WriteString(fmt.Sprintf("  Total Touches:  [cyan]%d[-] commits\n", dir.TouchCount))
-	sb.WriteString(fmt.Sprintf("  Contributors:   [cyan]%d[-] authors\n", len(dir.Authors)))
+	sb.WriteString(fmt.Sprintf("  Total Changes:  [cyan]%s[-] lines\n", formatNumber(dir.TotalChanges)))
+	sb.WriteString(fmt.Sprintf("  Total Touches:  [cyan]%s[-] commits\n", i18n.Int(dir.TouchCount)))
+	sb.WriteString(fmt.Sprintf("  Contributors:   [cyan]%s[-] authors\n", i18n.Int(len(dir.Authors))))
+
+	// Activity over the selected range
+	if v.repoStats != nil {
+		sb.WriteString(renderDirActivity(v.repoStats.GetDirTimeline(dir.Path)))
+	}
 
 	// Ownership breakdown
 	if len(dir.Authors) > 0 {
@@ -132,7 +409,10 @@ func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
 			authors = append(authors, a)
 		}
 		sort.Slice(authors, func(i, j int) bool {
-			return authors[i].Share > authors[j].Share
+			if authors[i].Share != authors[j].Share {
+				return authors[i].Share > authors[j].Share
+			}
+			return authors[i].Email < authors[j].Email
 		})
 
 		// Calculate max name length for alignment
@@ -147,7 +427,8 @@ func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
 		}
 
 		// Display each author with a visual bar
-		barWidth := 30
+		// Directory list, pane border, rank, name and share around the bar
+		barWidth := v.layout.bar(30, 35+2+maxNameLen+30)
 		for i, author := range authors {
 			name := author.Name
 			if len(name) > 20 {
@@ -178,8 +459,8 @@ func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
 				rank = "  "
 			}
 
-			sb.WriteString(fmt.Sprintf("  %s%-*s [%s]%s[-] [white]%5.1f%%[-] (%d commits)\n",
-				rank, maxNameLen, name, barColor, bar, author.Share, author.Commits))
+			sb.WriteString(fmt.Sprintf("  %s%-*s [%s]%s[-] [white]%5s%%[-] (%s commits)\n",
+				rank, maxNameLen, name, barColor, bar, i18n.Float(author.Share, 1), i18n.Int(author.Commits)))
 		}
 
 		// Ownership concentration indicator
@@ -203,10 +484,237 @@ func (v *OwnershipView) showDirectoryDetails(dir *stats.DirStats) {
 		}
 	}
 
+	// Ownership turnover against the previous period, kept for the top level
+	if len(v.trail) > 0 {
+		// Subdirectories are not compared
+	} else if t := dir.Turnover; t != nil {
+		sb.WriteString("\n[yellow]━━━ Turnover vs Previous Period ━━━[-]\n\n")
+		sb.WriteString(fmt.Sprintf("  Previous Owner:   %s (%.1f%%)\n", t.PrevOwner, t.PrevShare))
+		sb.WriteString(fmt.Sprintf("  Current Owner:    %s (%.1f%%)\n", t.CurrentOwner, t.CurrentShare))
+
+		shiftColor := "green"
+		if v.isTurnoverHotspot(dir) {
+			shiftColor = "orange"
+		}
+		sb.WriteString(fmt.Sprintf("  Ownership Shift:  [%s]%.1f%%[-]", shiftColor, t.Shift))
+		if t.OwnerChanged {
+			sb.WriteString(" [orange](top owner changed)[-]")
+		}
+		sb.WriteString("\n")
+	} else if v.repoStats != nil && v.repoStats.Previous != nil {
+		sb.WriteString("\n[yellow]━━━ Turnover vs Previous Period ━━━[-]\n\n")
+		sb.WriteString("  [gray]No activity in the previous period[-]\n")
+	}
+
 	v.detail.SetText(sb.String())
 	v.detail.SetTitle(fmt.Sprintf(" %s ", dirName))
 }
 
+// SetQueueUpdate sets how the history playback schedules redraws from its
+// timer
+func (v *OwnershipView) SetQueueUpdate(queueUpdate func(func())) {
+	v.queueUpdate = queueUpdate
+}
+
+// toggleHistory plays or pauses the month-by-month ownership of the selected
+// directory, starting over once the last month was shown
+func (v *OwnershipView) toggleHistory() {
+	if v.history != nil && v.history.stop != nil {
+		v.pauseHistory()
+		v.showHistoryFrame()
+		return
+	}
+	if !v.openHistory() {
+		return
+	}
+	if v.history.frame == len(v.history.frames)-1 {
+		v.history.frame = 0
+	}
+	v.showHistoryFrame()
+	if v.queueUpdate == nil || len(v.history.frames) < 2 {
+		return
+	}
+
+	stop := make(chan struct{})
+	v.history.stop = stop
+	go func() {
+		ticker := time.NewTicker(historyFrameDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				v.queueUpdate(func() {
+					// A pause or another directory may have come first
+					if v.history == nil || v.history.stop != stop {
+						return
+					}
+					v.history.frame++
+					if v.history.frame >= len(v.history.frames)-1 {
+						v.history.frame = len(v.history.frames) - 1
+						v.pauseHistory()
+					}
+					v.showHistoryFrame()
+				})
+			}
+		}
+	}()
+}
+
+// stepHistory pauses the history and shows the month delta months away,
+// opening the history at the last month if it is not shown
+func (v *OwnershipView) stepHistory(delta int) {
+	if v.history == nil {
+		if !v.openHistory() {
+			return
+		}
+		v.history.frame = len(v.history.frames) - 1
+	}
+	v.pauseHistory()
+	v.history.frame = max(0, min(len(v.history.frames)-1, v.history.frame+delta))
+	v.showHistoryFrame()
+}
+
+// openHistory loads the ownership history of the selected directory,
+// reporting whether it has any
+func (v *OwnershipView) openHistory() bool {
+	if v.history != nil {
+		return true
+	}
+	idx := v.list.GetCurrentItem()
+	if v.repoStats == nil || idx < 0 || idx >= len(v.dirs) {
+		return false
+	}
+	frames := v.repoStats.GetOwnershipHistory(v.dirs[idx].Path)
+	if len(frames) == 0 {
+		return false
+	}
+	v.history = &ownershipHistory{dir: v.dirs[idx], frames: frames}
+	return true
+}
+
+func (v *OwnershipView) pauseHistory() {
+	if v.history != nil && v.history.stop != nil {
+		close(v.history.stop)
+		v.history.stop = nil
+	}
+}
+
+// stopHistory pauses and leaves the history, back to the details
+func (v *OwnershipView) stopHistory() {
+	v.pauseHistory()
+	v.history = nil
+}
+
+// showHistoryFrame renders the ownership at the end of the current month,
+// with each author's change in share since the month before
+func (v *OwnershipView) showHistoryFrame() {
+	h := v.history
+	frame := h.frames[h.frame]
+
+	dirName := h.dir.Path
+	if dirName == "." {
+		dirName = "(root files)"
+	}
+
+	var sb strings.Builder
+	state := "[yellow]❚❚ paused[-]"
+	if h.stop != nil {
+		state = "[green]▶ playing[-]"
+	}
+	sb.WriteString(fmt.Sprintf("[::b]%s[-:-:-]  %s\n\n", dirName, state))
+
+	// Position of the month within the history
+	width := v.layout.bar(40, 35+2+20)
+	pos := 0
+	if len(h.frames) > 1 {
+		pos = h.frame * (width - 1) / (len(h.frames) - 1)
+	}
+	sb.WriteString(fmt.Sprintf("  [gray]%s[-] %s[white]●[-]%s [gray]%s[-]\n\n",
+		h.frames[0].Month, strings.Repeat("─", pos), strings.Repeat("─", width-1-pos), h.frames[len(h.frames)-1].Month))
+
+	sb.WriteString(fmt.Sprintf("[yellow]━━━ Ownership up to %s ━━━[-]\n\n", frame.Month))
+	sb.WriteString(fmt.Sprintf("  Changes:  [cyan]%s[-] lines, [cyan]+%s[-] this month\n\n",
+		formatNumber(frame.Changes), formatNumber(frame.Added)))
+
+	previous := make(map[string]float64)
+	if h.frame > 0 {
+		for _, a := range h.frames[h.frame-1].Authors {
+			previous[a.Email] = a.Share
+		}
+	}
+	barWidth := v.layout.bar(30, 35+2+20+30)
+	for _, author := range frame.Authors {
+		name := author.Name
+		if len(name) > 20 {
+			name = name[:17] + "..."
+		}
+		filled := min(barWidth, int(author.Share/100*float64(barWidth)))
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+		change := ""
+		if shift := author.Share - previous[author.Email]; h.frame > 0 && shift >= 0.05 {
+			change = fmt.Sprintf("[green]▲%.1f[-]", shift)
+		} else if shift <= -0.05 {
+			change = fmt.Sprintf("[red]▼%.1f[-]", -shift)
+		}
+		sb.WriteString(fmt.Sprintf("  %-20s [%s]%s[-] [white]%5s%%[-] %s\n",
+			name, getOwnershipColor(author.Share), bar, i18n.Float(author.Share, 1), change))
+	}
+	if len(frame.Authors) == 0 {
+		sb.WriteString("  [gray]No changes yet[-]\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n[gray]Month %d of %d | [%s] play/pause  [%s]/[%s] earlier/later  select another directory to leave[-]\n",
+		h.frame+1, len(h.frames), v.keys.Key("Ownership", "animate"), v.keys.Key("Ownership", "earlier"), v.keys.Key("Ownership", "later")))
+
+	v.detail.SetText(sb.String())
+	v.detail.SetTitle(fmt.Sprintf(" %s - %s ", dirName, frame.Month))
+}
+
+func renderDirActivity(timeline *stats.DirTimelineData) string {
+	if len(timeline.Labels) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sparkWidth := 50
+
+	sb.WriteString("\n[yellow]━━━ Activity ━━━[-]\n\n")
+	sb.WriteString(fmt.Sprintf("  Commits: [green]%s[-]\n",
+		components.RenderSparklineWithWidth(timeline.Commits, sparkWidth)))
+	sb.WriteString(fmt.Sprintf("  Churn:   [cyan]%s[-]\n",
+		components.RenderSparklineWithWidth(timeline.Churn, sparkWidth)))
+	sb.WriteString(fmt.Sprintf("           [gray]%s to %s[-]\n\n",
+		timeline.Labels[0], timeline.Labels[len(timeline.Labels)-1]))
+
+	activePct := float64(timeline.ActiveDays) / float64(len(timeline.Labels)) * 100
+	sb.WriteString(fmt.Sprintf("  Active Days:    [cyan]%d[-] of %d (%.1f%%)\n",
+		timeline.ActiveDays, len(timeline.Labels), activePct))
+	if timeline.LastActive != "" {
+		sb.WriteString(fmt.Sprintf("  Last Active:    [cyan]%s[-] %s\n",
+			timeline.LastActive, getDormancyIndicator(timeline)))
+	}
+
+	return sb.String()
+}
+
+func getDormancyIndicator(timeline *stats.DirTimelineData) string {
+	// Count trailing days without commits
+	idle := 0
+	for i := len(timeline.Commits) - 1; i >= 0 && timeline.Commits[i] == 0; i-- {
+		idle++
+	}
+
+	if idle >= 90 {
+		return fmt.Sprintf("[red](dormant, %d days idle)[-]", idle)
+	} else if idle >= 30 {
+		return fmt.Sprintf("[yellow](quiet, %d days idle)[-]", idle)
+	}
+	return "[green](active)[-]"
+}
+
 func getOwnershipColor(share float64) string {
 	if share >= 60 {
 		return "green"
@@ -241,16 +749,6 @@ func estimateBusFactor(authors []*stats.DirAuthorStats) int {
 	return count
 }
 
-func formatChanges(n int) string {
-	if n >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1000000)
-	}
-	if n >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
-	}
-	return fmt.Sprintf("%d", n)
-}
-
 // CycleSortColumn cycles through sort columns
 func (v *OwnershipView) CycleSortColumn() {
 	v.sortCol = (v.sortCol + 1) % len(v.columns)
@@ -261,6 +759,29 @@ func (v *OwnershipView) ReverseSortOrder() {
 	v.sortAsc = !v.sortAsc
 }
 
+// SortState returns the sort column and direction
+func (v *OwnershipView) SortState() (column int, ascending bool) {
+	return v.sortCol, v.sortAsc
+}
+
+// SetSortState restores a sort, ignoring columns the view doesn't have
+func (v *OwnershipView) SetSortState(column int, ascending bool) {
+	if column >= 0 && column < len(v.columns) {
+		v.sortCol, v.sortAsc = column, ascending
+	}
+}
+
+// SetFocusFunc sets the callback moving focus between the list and its
+// filter bar
+func (v *OwnershipView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.filter.focus = focus
+}
+
+// Filtering reports whether the filter bar has focus and takes every key
+func (v *OwnershipView) Filtering() bool {
+	return v.filter.typing
+}
+
 // Root returns the root primitive
 func (v *OwnershipView) Root() tview.Primitive {
 	return v.root
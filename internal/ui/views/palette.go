@@ -0,0 +1,125 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Command is an entry of the command palette
+type Command struct {
+	Name        string
+	Description string
+	Run         func()
+}
+
+// PaletteView is the command palette: it lists commands, narrowed down by
+// typing part of their name or description, and runs the chosen one
+type PaletteView struct {
+	root     *tview.Flex
+	input    *tview.InputField
+	list     *tview.List
+	commands []Command
+	matches  []Command
+	onClose  func()
+}
+
+// NewPaletteView creates a command palette; onClose hides it
+func NewPaletteView(onClose func()) *PaletteView {
+	v := &PaletteView{onClose: onClose}
+	v.setup()
+	return v
+}
+
+func (v *PaletteView) setup() {
+	v.input = tview.NewInputField().
+		SetLabel(" > ").
+		SetLabelColor(tcell.ColorYellow).
+		SetPlaceholder(i18n.T("command or macro")).
+		SetFieldBackgroundColor(tcell.ColorDarkSlateGray)
+	v.input.SetChangedFunc(func(text string) {
+		v.filter()
+	})
+	v.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			v.onClose()
+			return nil
+		case tcell.KeyEnter:
+			v.run()
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			// The list keeps the selection while the input keeps focus
+			v.list.InputHandler()(event, func(tview.Primitive) {})
+			return nil
+		}
+		return event
+	})
+
+	v.list = tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true).
+		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
+
+	dialog := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.input, 1, 0, true).
+		AddItem(v.list, 0, 1, false)
+	dialog.SetBorder(true).SetTitle(" " + i18n.T("Commands") + " ")
+
+	// Center the palette over the main view
+	v.root = tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(dialog, 0, 3, true).
+			AddItem(nil, 0, 1, false), 0, 2, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// Open shows commands with an empty filter
+func (v *PaletteView) Open(commands []Command) {
+	v.commands = commands
+	v.input.SetText("")
+	v.filter()
+}
+
+func (v *PaletteView) filter() {
+	text := strings.ToLower(strings.TrimSpace(v.input.GetText()))
+	v.matches = v.matches[:0]
+	for _, c := range v.commands {
+		if strings.Contains(strings.ToLower(c.Name+" "+c.Description), text) {
+			v.matches = append(v.matches, c)
+		}
+	}
+
+	v.list.Clear()
+	for _, c := range v.matches {
+		v.list.AddItem(tview.Escape(c.Name)+"  [gray]"+tview.Escape(c.Description)+"[-]", "", 0, nil)
+	}
+}
+
+// run closes the palette and runs the selected command
+func (v *PaletteView) run() {
+	idx := v.list.GetCurrentItem()
+	if idx < 0 || idx >= len(v.matches) {
+		return
+	}
+	command := v.matches[idx]
+	v.onClose()
+	command.Run()
+}
+
+// Root returns the root primitive
+func (v *PaletteView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *PaletteView) GetFocusable() tview.Primitive {
+	return v.input
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
 )
 
 // ProgressView displays scanning progress
@@ -30,7 +32,7 @@ func (p *ProgressView) setup() {
 	title := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText("[::b]Scanning Repository[-:-:-]")
+		SetText("[::b]" + i18n.T("Scanning Repository") + "[-:-:-]")
 	title.SetBackgroundColor(tcell.ColorDarkBlue)
 
 	// Progress bar container
@@ -99,13 +101,13 @@ func (p *ProgressView) SetProgress(current, total int) {
 	}
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-	p.progressBar.SetText(fmt.Sprintf("[green]%s[-]\n%.1f%%", bar, pct))
+	p.progressBar.SetText(fmt.Sprintf("[green]%s[-]\n%s%%", bar, i18n.Float(pct, 1)))
 
 	// Update count
 	if p.total > 0 {
-		p.countText.SetText(fmt.Sprintf("[yellow]%d[-] / [yellow]%d[-] commits processed", current, p.total))
+		p.countText.SetText(i18n.T("[yellow]%d[-] / [yellow]%d[-] commits processed", current, p.total))
 	} else {
-		p.countText.SetText(fmt.Sprintf("[yellow]%d[-] commits processed", current))
+		p.countText.SetText(i18n.T("[yellow]%d[-] commits processed", current))
 	}
 }
 
@@ -3,24 +3,37 @@ package views
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
+// Rows of the summary panel, without the review latency
+const summaryHeight = 10
+
 // PullRequestsView displays PR/merge statistics
 type PullRequestsView struct {
 	root      *tview.Flex
 	summary   *tview.TextView
 	table     *tview.Table
+	detail    *tview.TextView
+	content   *tview.Flex
 	info      *tview.TextView
+	prs       []*stats.PRInfo // rows of the PR list, in display order
 	sortCol   int
 	sortAsc   bool
 	columns   []string
 	repoStats *stats.Repository
 	showPRs   bool // Toggle between author view and PR list
+	keys      *KeyMap
+	filter    *tableFilter
 }
 
 // NewPullRequestsView creates a new pull requests view
@@ -40,7 +53,7 @@ func (v *PullRequestsView) setup() {
 	v.summary = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
-	v.summary.SetBorder(true).SetTitle(" Summary ")
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Summary") + " ")
 
 	// Main table
 	v.table = tview.NewTable().
@@ -48,16 +61,37 @@ func (v *PullRequestsView) setup() {
 		SetFixed(1, 0).
 		SetSeparator(' ')
 
+	// Detail pane for the selected PR, shown with the PR list
+	v.detail = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft).
+		SetScrollable(true)
+	v.detail.SetBorder(true).SetTitle(" " + i18n.T("PR Details") + " ")
+
 	// Info bar
 	v.info = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter)
 
+	v.content = tview.NewFlex().
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.detail, 0, 0, false)
+
+	v.filter = newTableFilter(v.table, func() { v.Refresh(v.repoStats) })
+
 	v.root = tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(v.summary, 7, 0, false).
-		AddItem(v.table, 0, 1, true).
+		AddItem(v.summary, summaryHeight, 0, false).
+		AddItem(v.content, 0, 1, true).
+		AddItem(v.filter.input, 0, 0, false).
 		AddItem(v.info, 1, 0, false)
+	v.filter.root = v.root
+
+	v.table.SetSelectionChangedFunc(func(row, column int) {
+		if v.showPRs && row > 0 && row <= len(v.prs) {
+			v.showPRDetails(v.prs[row-1])
+		}
+	})
 
 	v.renderHeader()
 }
@@ -69,7 +103,7 @@ func (v *PullRequestsView) renderHeader() {
 		// PR list columns
 		prColumns := []string{"#", "PR", "Branch", "Merged By", "Size", "Files", "Date"}
 		for col, name := range prColumns {
-			cell := tview.NewTableCell(name).
+			cell := tview.NewTableCell(i18n.T(name)).
 				SetTextColor(tcell.ColorYellow).
 				SetSelectable(false).
 				SetAttributes(tcell.AttrBold)
@@ -79,14 +113,14 @@ func (v *PullRequestsView) renderHeader() {
 				if v.sortAsc {
 					arrow = "▲"
 				}
-				cell.SetText(name + arrow)
+				cell.SetText(i18n.T(name) + arrow)
 			}
 			v.table.SetCell(0, col, cell)
 		}
 	} else {
 		// Author view columns
 		for col, name := range v.columns {
-			cell := tview.NewTableCell(name).
+			cell := tview.NewTableCell(i18n.T(name)).
 				SetTextColor(tcell.ColorYellow).
 				SetSelectable(false).
 				SetAttributes(tcell.AttrBold)
@@ -96,7 +130,7 @@ func (v *PullRequestsView) renderHeader() {
 				if v.sortAsc {
 					arrow = "▲"
 				}
-				cell.SetText(name + arrow)
+				cell.SetText(i18n.T(name) + arrow)
 			}
 			v.table.SetCell(0, col, cell)
 		}
@@ -105,6 +139,9 @@ func (v *PullRequestsView) renderHeader() {
 
 // Refresh updates the view with new data
 func (v *PullRequestsView) Refresh(repo *stats.Repository) {
+	if repo == nil {
+		return
+	}
 	v.repoStats = repo
 	v.renderHeader()
 
@@ -123,8 +160,10 @@ func (v *PullRequestsView) Refresh(repo *stats.Repository) {
 	}
 
 	if v.showPRs {
+		v.content.ResizeItem(v.detail, 50, 0)
 		v.renderPRList(prStats)
 	} else {
+		v.content.ResizeItem(v.detail, 0, 0)
 		v.renderAuthorView(prStats)
 	}
 }
@@ -140,11 +179,11 @@ func (v *PullRequestsView) updateSummary(prStats *stats.PRStatistics) {
 		avgSize = totalChanges / prStats.TotalMerges
 	}
 
-	// Find busiest day
+	// Find busiest day, the earliest one on ties
 	busiestDay := ""
 	maxMerges := 0
 	for day, count := range prStats.DailyMerges {
-		if count > maxMerges {
+		if count > maxMerges || (count == maxMerges && day < busiestDay) {
 			maxMerges = count
 			busiestDay = day
 		}
@@ -159,6 +198,35 @@ func (v *PullRequestsView) updateSummary(prStats *stats.PRStatistics) {
 		content += fmt.Sprintf("  [cyan]Busiest Day:[-]       %s (%d merges)\n", busiestDay, maxMerges)
 	}
 
+	// Weekly throughput, empty weeks included
+	branching := v.repoStats.GetBranchingStats()
+	if len(branching.Weeks) > 0 {
+		content += fmt.Sprintf("  [cyan]Merges/Week:[-]       [green]%s[-]\n", components.RenderSparklineWithWidth(branching.MergesByWeek, 52))
+		content += fmt.Sprintf("  [cyan]Weekly Throughput:[-] median %.1f, mean %.1f over %d weeks\n",
+			branching.MedianPerWeek, branching.MergesPerWeek, len(branching.Weeks))
+		if branching.LongestMergeGap > 0 {
+			content += fmt.Sprintf("  [cyan]Longest Gap:[-]       %d days without a merge\n", branching.LongestMergeGap)
+		}
+	}
+
+	// Review latency, with the GitHub integration
+	height := summaryHeight
+	if prStats.Reviewed > 0 {
+		content += fmt.Sprintf("  [cyan]Time to Merge:[-]     median [yellow]%s[-] over %d PRs from GitHub\n",
+			formatLatency(prStats.MedianTimeToMerge), prStats.Reviewed)
+		content += fmt.Sprintf("  [cyan]First Review:[-]      median [yellow]%s[-] after opening, %.1f reviews/PR, [red]%d[-] merged unreviewed\n",
+			formatLatency(prStats.MedianFirstReview), float64(prStats.Reviews)/float64(prStats.Reviewed), prStats.Unreviewed)
+		if top := prStats.TopReviewers(5); len(top) > 0 {
+			reviewers := make([]string, len(top))
+			for i, login := range top {
+				reviewers[i] = fmt.Sprintf("%s (%d)", tview.Escape(login), prStats.ReviewsBy[login])
+			}
+			content += fmt.Sprintf("  [cyan]Top Reviewers:[-]     %s\n", strings.Join(reviewers, ", "))
+		}
+		height += 3
+	}
+	v.root.ResizeItem(v.summary, height, 0)
+
 	v.summary.SetText(content)
 }
 
@@ -168,7 +236,13 @@ func (v *PullRequestsView) renderAuthorView(prStats *stats.PRStatistics) {
 	if sortBy == "" {
 		sortBy = "merges"
 	}
-	authors := v.repoStats.GetPRLeaderboard(sortBy, v.sortAsc)
+	all := v.repoStats.GetPRLeaderboard(sortBy, v.sortAsc)
+	var authors []*stats.PRAuthorStats
+	for _, author := range all {
+		if v.filter.match(author.Name, author.Email) {
+			authors = append(authors, author)
+		}
+	}
 
 	for i, author := range authors {
 		row := i + 1
@@ -198,9 +272,9 @@ func (v *PullRequestsView) renderAuthorView(prStats *stats.PRStatistics) {
 	}
 
 	// Update info
-	toggleText := "[t] show PR list"
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] contributors | %s | [s] sort, [r] reverse",
-		len(authors), toggleText))
+	toggleText := fmt.Sprintf("[%s] show PR list", v.keys.Key("Pull Requests", "toggle"))
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] contributors%s | %s | %s",
+		len(all), v.filter.status(len(authors), len(all)), toggleText, v.sortHelp()))
 }
 
 func (v *PullRequestsView) renderPRList(prStats *stats.PRStatistics) {
@@ -209,19 +283,33 @@ func (v *PullRequestsView) renderPRList(prStats *stats.PRStatistics) {
 	if v.sortCol < len(sortBy) && sortBy[v.sortCol] != "" {
 		// Use the sort
 	}
-	prs := v.repoStats.GetPRList("date", v.sortAsc, 100)
+	// Up to 100 merges in date order, among all matching the filter when
+	// there is one
+	var prs []*stats.PRInfo
+	all := v.repoStats.GetPRList("date", v.sortAsc, 0)
+	for _, pr := range all {
+		number := ""
+		if pr.PRNumber > 0 {
+			number = fmt.Sprintf("#%d", pr.PRNumber)
+		}
+		if v.filter.match(number, pr.Branch, pr.Subject, pr.MergedBy, pr.MergedByEmail) {
+			prs = append(prs, pr)
+		}
+	}
+	matched := len(prs)
+	prs = prs[:min(100, len(prs))]
 
-	// Sort locally based on column
+	// Sort locally based on column; stable so ties keep the date order
 	switch v.sortCol {
 	case 1: // PR number
-		sort.Slice(prs, func(i, j int) bool {
+		sort.SliceStable(prs, func(i, j int) bool {
 			if v.sortAsc {
 				return prs[i].PRNumber < prs[j].PRNumber
 			}
 			return prs[i].PRNumber > prs[j].PRNumber
 		})
 	case 4: // Size
-		sort.Slice(prs, func(i, j int) bool {
+		sort.SliceStable(prs, func(i, j int) bool {
 			si := prs[i].Additions + prs[i].Deletions
 			sj := prs[j].Additions + prs[j].Deletions
 			if v.sortAsc {
@@ -230,14 +318,14 @@ func (v *PullRequestsView) renderPRList(prStats *stats.PRStatistics) {
 			return si > sj
 		})
 	case 5: // Files
-		sort.Slice(prs, func(i, j int) bool {
+		sort.SliceStable(prs, func(i, j int) bool {
 			if v.sortAsc {
 				return prs[i].FilesCount < prs[j].FilesCount
 			}
 			return prs[i].FilesCount > prs[j].FilesCount
 		})
 	case 6: // Date
-		sort.Slice(prs, func(i, j int) bool {
+		sort.SliceStable(prs, func(i, j int) bool {
 			if v.sortAsc {
 				return prs[i].MergedAt.Before(prs[j].MergedAt)
 			}
@@ -292,14 +380,112 @@ func (v *PullRequestsView) renderPRList(prStats *stats.PRStatistics) {
 		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%d", pr.FilesCount)).
 			SetAlign(tview.AlignRight))
 
-		v.table.SetCell(row, 6, tview.NewTableCell(pr.MergedAt.Format("2006-01-02")).
+		v.table.SetCell(row, 6, tview.NewTableCell(i18n.Date(pr.MergedAt)).
 			SetTextColor(tcell.ColorDarkGray))
 	}
 
+	v.prs = prs
+	if len(prs) > 0 {
+		row, _ := v.table.GetSelection()
+		if row < 1 || row > len(prs) {
+			row = 1
+		}
+		v.showPRDetails(prs[row-1])
+	} else {
+		v.detail.SetText("")
+	}
+
 	// Update info
-	toggleText := "[t] show by author"
-	v.info.SetText(fmt.Sprintf("[yellow]%d[-] merges | %s | [s] sort, [r] reverse",
-		len(prs), toggleText))
+	toggleText := fmt.Sprintf("[%s] show by author", v.keys.Key("Pull Requests", "toggle"))
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] merges%s | %s | %s",
+		len(prs), v.filter.status(matched, len(all)), toggleText, v.sortHelp()))
+}
+
+func (v *PullRequestsView) showPRDetails(pr *stats.PRInfo) {
+	var sb strings.Builder
+
+	if pr.PRNumber > 0 {
+		sb.WriteString(fmt.Sprintf("[::b]PR #%d[-:-:-]\n", pr.PRNumber))
+	} else {
+		sb.WriteString("[::b]Merge[-:-:-]\n")
+	}
+	sb.WriteString(fmt.Sprintf("[gray]%s[-]\n\n", tview.Escape(pr.Subject)))
+
+	sb.WriteString("[yellow]━━━ Metadata ━━━[-]\n\n")
+	if pr.Branch != "" {
+		sb.WriteString(fmt.Sprintf("  Branch:     [cyan]%s[-]\n", tview.Escape(pr.Branch)))
+	}
+	sb.WriteString(fmt.Sprintf("  Merged by:  %s\n", tview.Escape(pr.MergedBy)))
+	sb.WriteString(fmt.Sprintf("              [gray]<%s>[-]\n", pr.MergedByEmail))
+	sb.WriteString(fmt.Sprintf("  Date:       %s\n", formatDateTime(pr.MergedAt)))
+	if len(pr.Hash) >= 10 {
+		sb.WriteString(fmt.Sprintf("  Commit:     [gray]%s[-]\n", pr.Hash[:10]))
+	}
+	sb.WriteString(fmt.Sprintf("  Changes:    [green]+%d[-] [red]-%d[-] in %d files\n", pr.Additions, pr.Deletions, pr.FilesCount))
+
+	if r := pr.Review; r != nil {
+		sb.WriteString("\n[yellow]━━━ Review ━━━[-]\n\n")
+		sb.WriteString(fmt.Sprintf("  Opened by:  %s [gray]in %s[-]\n", tview.Escape(r.Author), r.Repo))
+		sb.WriteString(fmt.Sprintf("  Opened:     %s\n", formatDateTime(r.CreatedAt)))
+		sb.WriteString(fmt.Sprintf("  To merge:   [yellow]%s[-]\n", formatLatency(r.TimeToMerge())))
+		if wait, ok := r.TimeToFirstReview(); ok {
+			sb.WriteString(fmt.Sprintf("  1st review: [yellow]%s[-]\n", formatLatency(wait)))
+			sb.WriteString(fmt.Sprintf("  Reviews:    %d, %d approving\n", r.Reviews, r.Approvals))
+			sb.WriteString(fmt.Sprintf("  Reviewers:  [cyan]%s[-]\n", tview.Escape(strings.Join(r.Reviewers, ", "))))
+		} else {
+			sb.WriteString("  Reviews:    [red]none[-]\n")
+		}
+		if len(r.Labels) > 0 {
+			sb.WriteString(fmt.Sprintf("  Labels:     %s\n", tview.Escape(strings.Join(r.Labels, ", "))))
+		}
+	}
+
+	if dirs := pr.TopDirs(); len(dirs) > 0 {
+		sb.WriteString("\n[yellow]━━━ Directories ━━━[-]\n\n")
+		for i, d := range dirs {
+			if i == 5 {
+				sb.WriteString(fmt.Sprintf("  [gray]... and %d more[-]\n", len(dirs)-i))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("  %-24s [cyan]%6d[-] [gray]%d files[-]\n", truncatePath(d.Path, 24), d.Changes, d.Files))
+		}
+	}
+
+	if len(pr.Files) > 0 {
+		sb.WriteString("\n[yellow]━━━ Files ━━━[-]\n\n")
+		for _, fc := range pr.Files {
+			marker := " "
+			switch fc.Status {
+			case git.FileCreated:
+				marker = "[green]A[-]"
+			case git.FileDeleted:
+				marker = "[red]D[-]"
+			case git.FileRenamed:
+				marker = "[yellow]R[-]"
+			}
+			change := "[gray]binary[-]"
+			if !fc.IsBinary {
+				change = fmt.Sprintf("[green]+%d[-] [red]-%d[-]", fc.Additions, fc.Deletions)
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s %s\n", marker, tview.Escape(truncatePath(fc.FilePath, 30)), change))
+		}
+	} else {
+		sb.WriteString("\n[gray]No file changes recorded for this merge[-]\n")
+	}
+
+	v.detail.SetText(sb.String()).ScrollToBeginning()
+}
+
+// formatLatency renders a review wait in minutes, hours or days
+func formatLatency(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	default:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	}
 }
 
 // ToggleView switches between author view and PR list
@@ -334,6 +520,24 @@ func (v *PullRequestsView) ReverseSortOrder() {
 	v.sortAsc = !v.sortAsc
 }
 
+// SortState returns the sort column and direction
+func (v *PullRequestsView) SortState() (column int, ascending bool) {
+	return v.sortCol, v.sortAsc
+}
+
+// SetSortState restores a sort of the author table, ignoring columns it
+// doesn't have
+func (v *PullRequestsView) SetSortState(column int, ascending bool) {
+	if !v.showPRs && column > 0 && column < len(v.columns) {
+		v.sortCol, v.sortAsc = column, ascending
+	}
+}
+
+// ShowsPRList reports whether the PR list is shown instead of authors
+func (v *PullRequestsView) ShowsPRList() bool {
+	return v.showPRs
+}
+
 // Root returns the root primitive
 func (v *PullRequestsView) Root() tview.Primitive {
 	return v.root
@@ -343,3 +547,25 @@ func (v *PullRequestsView) Root() tview.Primitive {
 func (v *PullRequestsView) GetFocusable() tview.Primitive {
 	return v.table
 }
+
+// SetKeyMap attaches the view's handlers to keys
+func (v *PullRequestsView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Pull Requests", "filter", v.filter.open)
+}
+
+// SetFocusFunc sets the callback moving focus between the table and its
+// filter bar
+func (v *PullRequestsView) SetFocusFunc(focus func(p tview.Primitive)) {
+	v.filter.focus = focus
+}
+
+// Filtering reports whether the filter bar has focus and takes every key
+func (v *PullRequestsView) Filtering() bool {
+	return v.filter.typing
+}
+
+// sortHelp describes the sort keys for the info bar
+func (v *PullRequestsView) sortHelp() string {
+	return fmt.Sprintf("[%s] sort, [%s] reverse", v.keys.Key("Pull Requests", "sort"), v.keys.Key("Pull Requests", "reverse"))
+}
@@ -0,0 +1,191 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// QualityView displays commit message quality: length distribution,
+// Conventional Commits and ticket coverage, and fixup and WIP commits per
+// author
+type QualityView struct {
+	root    *tview.Flex
+	summary *tview.TextView
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewQualityView creates a new commit quality view
+func NewQualityView() *QualityView {
+	v := &QualityView{
+		columns: []string{"#", "Author", "Commits", "Conventional", "Tickets", "Body", "Length p50/90", "fixup!", "WIP"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *QualityView) setup() {
+	v.summary = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+	v.summary.SetBorder(true).SetTitle(" " + i18n.T("Message Quality") + " ")
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.summary, 0, 1, false).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *QualityView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	quality := repo.GetCommitQuality()
+	total := quality.Total
+	if total.Commits == 0 {
+		v.summary.SetText("")
+		v.info.SetText("[gray]No authored commit messages in the range[-]")
+		return
+	}
+
+	v.summary.SetText(v.renderSummary(total))
+
+	for i, author := range quality.Authors {
+		row := i + 1
+		m := author.Messages
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(author.Name).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", m.Commits)).
+			SetAlign(tview.AlignRight))
+
+		for col, percent := range []float64{m.ConventionalPercent(), m.TicketPercent(), m.BodyPercent()} {
+			v.table.SetCell(row, 3+col, tview.NewTableCell(fmt.Sprintf("%.0f%%", percent)).
+				SetTextColor(tcell.GetColor(coverageColor(percent))).
+				SetAlign(tview.AlignRight))
+		}
+
+		lengths := m.LengthPercentiles()
+		lengthColor := tcell.ColorWhite
+		if lengths.P50 < stats.MessageLengthThresholds[0] {
+			lengthColor = tcell.ColorRed
+		}
+		v.table.SetCell(row, 6, tview.NewTableCell(fmt.Sprintf("%d/%d", lengths.P50, lengths.P90)).
+			SetTextColor(lengthColor).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 7, countCell(m.Fixups))
+		v.table.SetCell(row, 8, countCell(m.WIP))
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] messages by [yellow]%d[-] authors | %.0f%% conventional | %.0f%% with a ticket | merge commits excluded",
+		total.Commits, len(quality.Authors), total.ConventionalPercent(), total.TicketPercent()))
+}
+
+func (v *QualityView) renderSummary(total *stats.MessageStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("  [::b]Message Length[-:-:-] [gray](characters of subject and body)[-]\n\n")
+	writeSizeBars(&sb, lengthLabels(stats.MessageLengthThresholds), total.LengthBuckets(), total.Commits, 30)
+	sb.WriteString(fmt.Sprintf("\n  %-18s %s\n", "Percentiles", formatPercentiles(total.LengthPercentiles())))
+
+	sb.WriteString("\n  [::b]Coverage[-:-:-]\n\n")
+	for _, c := range []struct {
+		label string
+		count int
+	}{
+		{"Conventional", total.Conventional},
+		{"Ticket reference", total.TicketRefs},
+		{"With body", total.WithBody},
+	} {
+		percent := safeDivide(float64(c.count), float64(total.Commits)) * 100
+		sb.WriteString(fmt.Sprintf("  %-18s [%s]%s[-] %5.1f%% (%d)\n",
+			c.label, coverageColor(percent), shareBar(percent, 30), percent, c.count))
+	}
+
+	sb.WriteString("\n  [::b]Cleanup[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  %-18s %d [gray]fixup!, squash! and amend! commits left unsquashed[-]\n", "fixup!", total.Fixups))
+	sb.WriteString(fmt.Sprintf("  %-18s %d [gray]work-in-progress commits[-]\n", "WIP", total.WIP))
+
+	return sb.String()
+}
+
+// lengthLabels describes each message length bucket with its range
+func lengthLabels(thresholds []int) []string {
+	labels := make([]string, len(thresholds)+1)
+	lower := 0
+	for i := range labels {
+		if i < len(thresholds) {
+			labels[i] = fmt.Sprintf("%d-%d chars", lower, thresholds[i])
+			lower = thresholds[i] + 1
+		} else {
+			labels[i] = fmt.Sprintf("%d+ chars", lower)
+		}
+	}
+	return labels
+}
+
+// coverageColor colors the share of messages following a good practice
+func coverageColor(percent float64) string {
+	switch {
+	case percent >= 75:
+		return "green"
+	case percent >= 25:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+// countCell shows a count of commits that should not have landed, gray
+// when there are none
+func countCell(count int) *tview.TableCell {
+	cell := tview.NewTableCell(fmt.Sprintf("%d", count)).
+		SetAlign(tview.AlignRight)
+	if count == 0 {
+		return cell.SetTextColor(tcell.ColorDarkGray)
+	}
+	return cell.SetTextColor(tcell.ColorYellow)
+}
+
+// Root returns the root primitive
+func (v *QualityView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *QualityView) GetFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,142 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// QueryView runs ad-hoc filter queries over the statistics
+type QueryView struct {
+	root      *tview.Flex
+	input     *tview.InputField
+	table     *tview.Table
+	info      *tview.TextView
+	repoStats *stats.Repository
+}
+
+// NewQueryView creates a new query view
+func NewQueryView() *QueryView {
+	v := &QueryView{}
+	v.setup()
+	return v
+}
+
+func (v *QueryView) setup() {
+	v.input = tview.NewInputField().
+		SetLabel(" " + i18n.T("Query") + ": ").
+		SetLabelColor(tcell.ColorYellow).
+		SetPlaceholder(`authors where commits > 50 and additions/deletions > 3`).
+		SetFieldBackgroundColor(tcell.ColorDarkSlateGray)
+	v.input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			v.run()
+		}
+	})
+
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.input, 1, 0, true).
+		AddItem(v.table, 0, 1, false).
+		AddItem(v.info, 1, 0, false)
+
+	v.showHelp()
+}
+
+// Refresh updates the view with new data, re-running the current query
+func (v *QueryView) Refresh(repo *stats.Repository) {
+	v.repoStats = repo
+	if strings.TrimSpace(v.input.GetText()) != "" {
+		v.run()
+	}
+}
+
+func (v *QueryView) run() {
+	v.table.Clear()
+
+	query := strings.TrimSpace(v.input.GetText())
+	if query == "" || v.repoStats == nil {
+		v.showHelp()
+		return
+	}
+
+	result, err := v.repoStats.RunQuery(query)
+	if err != nil {
+		v.info.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+
+	v.table.SetCell(0, 0, tview.NewTableCell("#").
+		SetTextColor(tcell.ColorYellow).
+		SetSelectable(false).
+		SetAttributes(tcell.AttrBold))
+	for col, name := range result.Columns {
+		v.table.SetCell(0, col+1, tview.NewTableCell(strings.ToUpper(name[:1])+name[1:]).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	for i, row := range result.Rows {
+		v.table.SetCell(i+1, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+		for col, cell := range row {
+			c := tview.NewTableCell(cell)
+			if col == 0 {
+				c.SetExpansion(1)
+			} else {
+				c.SetAlign(tview.AlignRight)
+			}
+			v.table.SetCell(i+1, col+1, c)
+		}
+	}
+	v.table.ScrollToBeginning()
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] of [yellow]%d[-] %s matched, showing [yellow]%d[-] | [Enter] run  [Tab] results",
+		result.Matched, result.Scanned, tview.Escape(strings.Fields(query)[0]), len(result.Rows)))
+}
+
+// Query returns the text of the query bar
+func (v *QueryView) Query() string {
+	return v.input.GetText()
+}
+
+// SetQuery fills the query bar; it runs with the next Refresh
+func (v *QueryView) SetQuery(query string) {
+	v.input.SetText(query)
+}
+
+func (v *QueryView) showHelp() {
+	v.info.SetText(fmt.Sprintf("[gray]<%s> where <expr> [order by <expr> asc|desc] [limit n] | ops: and or not = != < > ~ + - * /[-]",
+		strings.Join(stats.QueryEntities(), "|")))
+}
+
+// Root returns the root primitive
+func (v *QueryView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *QueryView) GetFocusable() tview.Primitive {
+	return v.input
+}
+
+// GetSecondaryFocusable returns the results table, focused after the input
+func (v *QueryView) GetSecondaryFocusable() tview.Primitive {
+	return v.table
+}
@@ -0,0 +1,139 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// RefactoringView displays the share of refactoring commits per author and directory
+type RefactoringView struct {
+	root       *tview.Flex
+	authors    *tview.Table
+	dirs       *tview.Table
+	info       *tview.TextView
+	authorCols []string
+	dirCols    []string
+}
+
+// NewRefactoringView creates a new refactoring view
+func NewRefactoringView() *RefactoringView {
+	v := &RefactoringView{
+		authorCols: []string{"#", "Author", "Commits", "Refactor", "Share"},
+		dirCols:    []string{"#", "Directory", "Commits", "Refactor", "Share"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *RefactoringView) setup() {
+	v.authors = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	v.authors.SetBorder(true).SetTitle(" " + i18n.T("By Author") + " ")
+
+	v.dirs = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	v.dirs.SetBorder(true).SetTitle(" " + i18n.T("By Directory") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.authors, 0, 1, true).
+			AddItem(v.dirs, 0, 1, false), 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	renderRefactorHeader(v.authors, v.authorCols)
+	renderRefactorHeader(v.dirs, v.dirCols)
+}
+
+func renderRefactorHeader(table *tview.Table, columns []string) {
+	for col, name := range columns {
+		table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *RefactoringView) Refresh(repo *stats.Repository) {
+	authors, dirs := repo.GetRefactorShares()
+
+	renderRefactorRows(v.authors, authors, false)
+	renderRefactorRows(v.dirs, dirs, true)
+
+	total, refactor := 0, 0
+	for _, a := range authors {
+		total += a.Commits
+		refactor += a.RefactorCommits
+	}
+	share := safeDivide(float64(refactor), float64(total)) * 100
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] of [yellow]%d[-] commits are refactoring ([cyan]%.1f%%[-]): mostly renames/moves or balanced add/delete | [Tab] switch table",
+		refactor, total, share))
+}
+
+func renderRefactorRows(table *tview.Table, shares []*stats.RefactorShare, dirs bool) {
+	// Clear existing data rows
+	for row := table.GetRowCount() - 1; row > 0; row-- {
+		table.RemoveRow(row)
+	}
+
+	for i, s := range shares {
+		row := i + 1
+
+		table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		name := tview.NewTableCell(s.Name).SetExpansion(1)
+		if dirs {
+			name.SetTextColor(getDirColor(s.Name))
+		}
+		table.SetCell(row, 1, name)
+
+		table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", s.Commits)).
+			SetAlign(tview.AlignRight))
+
+		table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", s.RefactorCommits)).
+			SetAlign(tview.AlignRight))
+
+		pct := s.Percent()
+		shareColor := tcell.ColorWhite
+		if pct >= 50 {
+			shareColor = tcell.ColorAqua
+		} else if pct >= 25 {
+			shareColor = tcell.ColorGreen
+		}
+		table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.1f%%", pct)).
+			SetTextColor(shareColor).
+			SetAlign(tview.AlignRight))
+	}
+}
+
+// Root returns the root primitive
+func (v *RefactoringView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *RefactoringView) GetFocusable() tview.Primitive {
+	return v.authors
+}
+
+// GetSecondaryFocusable returns the directory table, focused after the author table
+func (v *RefactoringView) GetSecondaryFocusable() tview.Primitive {
+	return v.dirs
+}
@@ -0,0 +1,139 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// Settings are the settings that apply to the parsed history, so changing
+// them recomputes the statistics instead of rescanning
+type Settings struct {
+	Timezone      *time.Location
+	RollingWindow int           // days of the Timeline's rolling average
+	ChurnHalfLife time.Duration // 0 weights all churn alike
+}
+
+// SettingsView edits the settings applied after parsing
+type SettingsView struct {
+	root     *tview.Flex
+	form     *tview.Form
+	timezone *tview.InputField
+	window   *tview.InputField
+	halfLife *tview.InputField
+	info     *tview.TextView
+	onApply  func(Settings)
+}
+
+// NewSettingsView creates a settings view handing applied settings to
+// onApply
+func NewSettingsView(onApply func(Settings)) *SettingsView {
+	v := &SettingsView{onApply: onApply}
+	v.setup()
+	return v
+}
+
+func (v *SettingsView) setup() {
+	v.timezone = tview.NewInputField().
+		SetLabel(i18n.T("Timezone") + ": ").
+		SetPlaceholder("Europe/Tallinn").
+		SetFieldWidth(24)
+	v.window = tview.NewInputField().
+		SetLabel(i18n.T("Rolling average (days)") + ": ").
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetFieldWidth(6)
+	v.halfLife = tview.NewInputField().
+		SetLabel(i18n.T("Churn half-life (days)") + ": ").
+		SetAcceptanceFunc(tview.InputFieldInteger).
+		SetPlaceholder("0").
+		SetFieldWidth(6)
+
+	v.form = tview.NewForm().
+		AddFormItem(v.timezone).
+		AddFormItem(v.window).
+		AddFormItem(v.halfLife).
+		AddButton(i18n.T("Apply"), v.apply)
+	v.form.SetBorder(true).SetTitle(" " + i18n.T("Settings") + " ")
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.form, 11, 0, true).
+		AddItem(v.info, 0, 1, false)
+	v.showHelp()
+}
+
+// Refresh shows the settings in effect
+func (v *SettingsView) Refresh(s Settings) {
+	v.timezone.SetText(s.Timezone.String())
+	v.window.SetText(strconv.Itoa(s.RollingWindow))
+	v.halfLife.SetText("")
+	if s.ChurnHalfLife > 0 {
+		v.halfLife.SetText(strconv.Itoa(int(s.ChurnHalfLife / (24 * time.Hour))))
+	}
+	v.showHelp()
+}
+
+func (v *SettingsView) showHelp() {
+	v.info.SetText(fmt.Sprintf("\n[gray]%s\n\n%s[-]",
+		i18n.T("The timezone and the churn half-life recompute the statistics from the commits of the last scan, without running git again. The rolling average only redraws the Timeline."),
+		i18n.T("Timezones are IANA names such as UTC or America/New_York, or Local.")))
+}
+
+// apply parses the fields and hands the settings over
+func (v *SettingsView) apply() {
+	name := strings.TrimSpace(v.timezone.GetText())
+	tz, err := time.LoadLocation(name)
+	if err != nil || name == "" {
+		v.showError(i18n.T("Unknown timezone: %s", v.timezone.GetText()))
+		return
+	}
+	window, err := strconv.Atoi(v.window.GetText())
+	if err != nil || window < 1 {
+		v.showError(i18n.T("The rolling average needs at least 1 day"))
+		return
+	}
+	days := 0
+	if text := v.halfLife.GetText(); text != "" {
+		if days, err = strconv.Atoi(text); err != nil || days < 0 {
+			v.showError(i18n.T("The churn half-life cannot be negative"))
+			return
+		}
+	}
+
+	v.showHelp()
+	if v.onApply != nil {
+		v.onApply(Settings{
+			Timezone:      tz,
+			RollingWindow: window,
+			ChurnHalfLife: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+}
+
+func (v *SettingsView) showError(message string) {
+	v.info.SetText(fmt.Sprintf("\n[red]%s[-]", tview.Escape(message)))
+}
+
+// Root returns the root primitive
+func (v *SettingsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *SettingsView) GetFocusable() tview.Primitive {
+	return v.form
+}
+
+// Editing reports whether a field has focus, taking typed keys as text
+func (v *SettingsView) Editing() bool {
+	return v.timezone.HasFocus() || v.window.HasFocus() || v.halfLife.HasFocus()
+}
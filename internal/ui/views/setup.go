@@ -1,9 +1,11 @@
 package views
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -11,6 +13,8 @@ import (
 
 	"github.com/audi70r/gitstat/internal/config"
 	"github.com/audi70r/gitstat/internal/git"
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
 )
 
 // SetupView handles directory and date range selection
@@ -20,19 +24,27 @@ type SetupView struct {
 	repoList    *tview.List
 	sinceInput  *tview.InputField
 	untilInput  *tview.InputField
+	refsInput   *tview.InputField
+	pathsInput  *tview.InputField
+	noMerges    *tview.Checkbox
+	firstParent *tview.Checkbox
 	errorText   *tview.TextView
 	config      *config.Config
 	onComplete  func()
 	currentPath string
+	showHidden  bool // hidden directories are listed in the browser
 	app         *tview.Application
+	keys        *KeyMap
 }
 
-// NewSetupView creates a new setup view
-func NewSetupView(cfg *config.Config, onComplete func(), app *tview.Application) *SetupView {
+// NewSetupView creates a new setup view whose keys are the ScopeSetup
+// bindings of keys
+func NewSetupView(cfg *config.Config, onComplete func(), app *tview.Application, keys *KeyMap) *SetupView {
 	s := &SetupView{
 		config:     cfg,
 		onComplete: onComplete,
 		app:        app,
+		keys:       keys,
 	}
 	// Set initial path
 	s.currentPath, _ = os.Getwd()
@@ -45,21 +57,21 @@ func (s *SetupView) setup() {
 	title := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText("[::b]GitStat - Git Repository Analyzer[-:-:-]")
+		SetText("[::b]" + i18n.T("GitStat - Git Repository Analyzer") + "[-:-:-]")
 	title.SetBackgroundColor(tcell.ColorDarkBlue)
 
 	// Instructions
 	instructions := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText("[yellow]Select one or more repositories to analyze[-]")
+		SetText("[yellow]" + i18n.T("Select one or more repositories to analyze") + "[-]")
 
 	// Repository list
 	s.repoList = tview.NewList().
 		ShowSecondaryText(true).
 		SetHighlightFullLine(true).
 		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
-	s.repoList.SetBorder(true).SetTitle(" Selected Repositories (0) ")
+	s.repoList.SetBorder(true).SetTitle(i18n.T(" Selected Repositories (%d) ", 0))
 
 	// Add current directory if it's a git repo
 	if git.IsGitRepo(s.currentPath) {
@@ -68,27 +80,59 @@ func (s *SetupView) setup() {
 
 	// Date inputs in a form
 	dateForm := tview.NewForm()
-	dateForm.SetBorder(true).SetTitle(" Date Range ")
+	dateForm.SetBorder(true).SetTitle(" " + i18n.T("Date Range") + " ")
 
 	s.sinceInput = tview.NewInputField().
-		SetLabel("Since: ").
+		SetLabel(i18n.T("Since") + ": ").
 		SetText(s.config.Since.Format("2006-01-02")).
 		SetFieldWidth(12)
 
 	s.untilInput = tview.NewInputField().
-		SetLabel("Until: ").
+		SetLabel(i18n.T("Until") + ": ").
 		SetText(s.config.Until.Format("2006-01-02")).
 		SetFieldWidth(12)
 
+	s.refsInput = tview.NewInputField().
+		SetLabel(i18n.T("Refs") + ": ").
+		SetText(strings.Join(s.config.ScanRefs, " ")).
+		SetPlaceholder("HEAD").
+		SetFieldWidth(24)
+
+	// Globs prefixed with ! leave paths out
+	globs := append([]string(nil), s.config.IncludeGlobs...)
+	for _, g := range s.config.ExcludeGlobs {
+		globs = append(globs, "!"+g)
+	}
+	s.pathsInput = tview.NewInputField().
+		SetLabel(i18n.T("Paths") + ": ").
+		SetText(strings.Join(globs, " ")).
+		SetPlaceholder("src/** !*.lock").
+		SetFieldWidth(24)
+
+	// History traversal, e.g. to avoid counting merged work twice when
+	// merges and squashed commits coexist
+	s.noMerges = tview.NewCheckbox().
+		SetLabel(i18n.T("No merges") + ": ").
+		SetChecked(s.config.ExcludeMerges).
+		SetChangedFunc(func(checked bool) { s.config.ExcludeMerges = checked })
+	s.firstParent = tview.NewCheckbox().
+		SetLabel(i18n.T("First parent") + ": ").
+		SetChecked(s.config.FirstParent).
+		SetChangedFunc(func(checked bool) { s.config.FirstParent = checked })
+
 	dateForm.AddFormItem(s.sinceInput)
 	dateForm.AddFormItem(s.untilInput)
+	dateForm.AddFormItem(s.refsInput)
+	dateForm.AddFormItem(s.pathsInput)
+	dateForm.AddFormItem(s.noMerges)
+	dateForm.AddFormItem(s.firstParent)
 
 	// Buttons
 	buttonForm := tview.NewForm()
 	buttonForm.SetButtonsAlign(tview.AlignCenter)
-	buttonForm.AddButton("Add Repository", s.showDirBrowser)
-	buttonForm.AddButton("Scan All", s.validate)
-	buttonForm.AddButton("Quit", func() { os.Exit(0) })
+	buttonForm.AddButton(i18n.T("Add Repository"), s.showDirBrowser)
+	buttonForm.AddButton(i18n.T("Scan All"), s.validate)
+	buttonForm.AddButton(i18n.T("Quit"), func() { os.Exit(0) })
 
 	// Error text
 	s.errorText = tview.NewTextView().
@@ -98,7 +142,7 @@ func (s *SetupView) setup() {
 	// Right panel with dates and buttons
 	rightPanel := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(dateForm, 6, 0, false).
+		AddItem(dateForm, 14, 0, false).
 		AddItem(buttonForm, 5, 0, false).
 		AddItem(s.errorText, 2, 0, false)
 
@@ -111,7 +155,7 @@ func (s *SetupView) setup() {
 	help := tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
-		SetText("[yellow]a[-] Add repo  [yellow]d[-] Remove  [yellow]s[-] Since  [yellow]u[-] Until  [yellow]Enter[-] Scan  [yellow]↑↓[-] Navigate")
+		SetText(s.keys.Hints(ScopeSetup))
 	help.SetBackgroundColor(tcell.ColorDarkBlue)
 
 	s.mainFlex = tview.NewFlex().
@@ -126,35 +170,38 @@ func (s *SetupView) setup() {
 	s.root.AddPage("main", s.mainFlex, true, true)
 
 	// Handle repo list input
-	s.repoList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'a', 'A':
-			s.showDirBrowser()
-			return nil
-		case 'd', 'D':
-			s.removeSelectedRepo()
-			return nil
-		case 's':
+	focus := func(p tview.Primitive) func() {
+		return func() {
 			if s.app != nil {
-				s.app.SetFocus(s.sinceInput)
+				s.app.SetFocus(p)
 			}
-			return nil
-		case 'u':
-			if s.app != nil {
-				s.app.SetFocus(s.untilInput)
-			}
-			return nil
 		}
-		switch event.Key() {
-		case tcell.KeyEnter:
-			s.validate()
+	}
+	s.keys.Handle(ScopeSetup, "add", s.showDirBrowser)
+	s.keys.Handle(ScopeSetup, "remove", s.removeSelectedRepo)
+	s.keys.Handle(ScopeSetup, "since", focus(s.sinceInput))
+	s.keys.Handle(ScopeSetup, "until", focus(s.untilInput))
+	s.keys.Handle(ScopeSetup, "refs", focus(s.refsInput))
+	s.keys.Handle(ScopeSetup, "paths", focus(s.pathsInput))
+	s.keys.Handle(ScopeSetup, "upstream", s.toggleUpstream)
+	s.keys.Handle(ScopeSetup, "merges", func() {
+		s.noMerges.SetChecked(!s.noMerges.IsChecked())
+		s.config.ExcludeMerges = s.noMerges.IsChecked()
+	})
+	s.keys.Handle(ScopeSetup, "parent", func() {
+		s.firstParent.SetChecked(!s.firstParent.IsChecked())
+		s.config.FirstParent = s.firstParent.IsChecked()
+	})
+	s.keys.Handle(ScopeSetup, "scan", s.validate)
+	s.repoList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if s.keys.Dispatch(ScopeSetup, true, event) {
 			return nil
 		}
 		return event
 	})
 
-	// Handle escape from date inputs to return to repo list
-	s.sinceInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+	// Handle escape from date and ref inputs to return to repo list
+	backToList := func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter {
 			if s.app != nil {
 				s.app.SetFocus(s.repoList)
@@ -162,16 +209,22 @@ func (s *SetupView) setup() {
 			return nil
 		}
 		return event
-	})
-	s.untilInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter {
+	}
+	s.sinceInput.SetInputCapture(backToList)
+	s.untilInput.SetInputCapture(backToList)
+	s.refsInput.SetInputCapture(backToList)
+	s.pathsInput.SetInputCapture(backToList)
+	toggleBack := func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
 			if s.app != nil {
 				s.app.SetFocus(s.repoList)
 			}
 			return nil
 		}
 		return event
-	})
+	}
+	s.noMerges.SetInputCapture(toggleBack)
+	s.firstParent.SetInputCapture(toggleBack)
 }
 
 func (s *SetupView) addRepo(path string) {
@@ -184,22 +237,63 @@ func (s *SetupView) addRepo(path string) {
 	}
 
 	// Add to list
-	repoName := filepath.Base(path)
-	s.repoList.AddItem(path, fmt.Sprintf("  %s", repoName), 0, nil)
+	s.repoList.AddItem(path, s.repoLabel(path), 0, nil)
 	s.updateRepoCount()
 }
 
+// repoLabel returns the secondary text for a repository in the list
+func (s *SetupView) repoLabel(path string) string {
+	label := fmt.Sprintf("  %s", filepath.Base(path))
+	if path == s.config.UpstreamRepo {
+		label += i18n.T(" (upstream)")
+	}
+	if git.IsShallow(path) {
+		label += " [yellow]" + i18n.T("(shallow clone)") + "[-]"
+	}
+	// Some worktree statistics are approximate for these
+	checkout := git.GetCheckout(path)
+	if checkout.Partial {
+		label += " [gray]" + i18n.T("(partial clone)") + "[-]"
+	}
+	if checkout.Sparse {
+		label += " [gray]" + i18n.T("(sparse checkout)") + "[-]"
+	}
+	return label
+}
+
 func (s *SetupView) removeSelectedRepo() {
 	idx := s.repoList.GetCurrentItem()
 	if idx >= 0 && s.repoList.GetItemCount() > 0 {
+		if path, _ := s.repoList.GetItemText(idx); path == s.config.UpstreamRepo {
+			s.config.UpstreamRepo = ""
+		}
 		s.repoList.RemoveItem(idx)
 		s.updateRepoCount()
 	}
 }
 
+// toggleUpstream marks the selected repository as the upstream of the
+// others, or clears the mark
+func (s *SetupView) toggleUpstream() {
+	idx := s.repoList.GetCurrentItem()
+	if idx < 0 || s.repoList.GetItemCount() == 0 {
+		return
+	}
+	path, _ := s.repoList.GetItemText(idx)
+	if path == s.config.UpstreamRepo {
+		s.config.UpstreamRepo = ""
+	} else {
+		s.config.UpstreamRepo = path
+	}
+	for i := 0; i < s.repoList.GetItemCount(); i++ {
+		main, _ := s.repoList.GetItemText(i)
+		s.repoList.SetItemText(i, main, s.repoLabel(main))
+	}
+}
+
 func (s *SetupView) updateRepoCount() {
 	count := s.repoList.GetItemCount()
-	s.repoList.SetTitle(fmt.Sprintf(" Selected Repositories (%d) ", count))
+	s.repoList.SetTitle(i18n.T(" Selected Repositories (%d) ", count))
 }
 
 func (s *SetupView) validate() {
@@ -211,14 +305,14 @@ func (s *SetupView) validate() {
 	}
 
 	if len(repos) == 0 {
-		s.ShowError("Please add at least one repository")
+		s.ShowError(i18n.T("Please add at least one repository"))
 		return
 	}
 
 	// Validate all repos
 	for _, path := range repos {
 		if !git.IsGitRepo(path) {
-			s.ShowError(fmt.Sprintf("Not a git repo: %s", filepath.Base(path)))
+			s.ShowError(i18n.T("Not a git repo: %s", filepath.Base(path)))
 			return
 		}
 	}
@@ -226,22 +320,44 @@ func (s *SetupView) validate() {
 	// Parse dates
 	since, err := time.Parse("2006-01-02", s.sinceInput.GetText())
 	if err != nil {
-		s.ShowError("Invalid 'Since' date. Use YYYY-MM-DD")
+		s.ShowError(i18n.T("Invalid 'Since' date. Use YYYY-MM-DD"))
 		return
 	}
 	s.config.Since = since
 
 	until, err := time.Parse("2006-01-02", s.untilInput.GetText())
 	if err != nil {
-		s.ShowError("Invalid 'Until' date. Use YYYY-MM-DD")
+		s.ShowError(i18n.T("Invalid 'Until' date. Use YYYY-MM-DD"))
 		return
 	}
 	s.config.Until = until
 
 	if until.Before(since) {
-		s.ShowError("'Until' must be after 'Since'")
+		s.ShowError(i18n.T("'Until' must be after 'Since'"))
+		return
+	}
+
+	// Refs are separated by spaces or commas; none scans HEAD
+	s.config.ScanRefs = strings.FieldsFunc(s.refsInput.GetText(), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	// Path globs are separated like refs
+	var include, exclude []string
+	for _, g := range strings.FieldsFunc(s.pathsInput.GetText(), func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		if excluded, ok := strings.CutPrefix(g, "!"); ok {
+			exclude = append(exclude, excluded)
+		} else {
+			include = append(include, g)
+		}
+	}
+	if err := stats.CheckGlobs(append(include, exclude...)); err != nil {
+		s.ShowError(err.Error())
 		return
 	}
+	s.config.IncludeGlobs, s.config.ExcludeGlobs = include, exclude
 
 	// Update config
 	s.config.RepoPaths = repos
@@ -250,150 +366,88 @@ func (s *SetupView) validate() {
 	}
 
 	s.errorText.SetText("")
-	s.onComplete()
+	s.confirmShallow(repos)
 }
 
-func (s *SetupView) showDirBrowser() {
-	dirList := tview.NewList().
-		ShowSecondaryText(true).
-		SetHighlightFullLine(true).
-		SetSelectedBackgroundColor(tcell.ColorDarkCyan)
-	dirList.SetBorder(true)
-
-	// Help text for browser
-	browserHelp := tview.NewTextView().
-		SetDynamicColors(true).
-		SetTextAlign(tview.AlignCenter).
-		SetText("[yellow]Enter[-] Open folder  [yellow]Space[-] Add repo  [yellow]Esc[-] Close")
-	browserHelp.SetBackgroundColor(tcell.ColorDarkBlue)
-
-	currentPath := s.currentPath
-
-	// Track directory entries for easy lookup
-	type dirEntry struct {
-		name   string
-		isRepo bool
-	}
-	var dirEntries []dirEntry
-
-	var populateList func(path string)
-	populateList = func(path string) {
-		dirList.Clear()
-		dirEntries = nil
-		dirList.SetTitle(fmt.Sprintf(" %s ", path))
-		currentPath = path
-
-		// Parent directory
-		dirList.AddItem("..", "Go up one directory", 0, nil)
-		dirEntries = append(dirEntries, dirEntry{name: "..", isRepo: false})
-
-		// List subdirectories
-		entries, err := os.ReadDir(path)
-		if err != nil {
-			return
+// confirmShallow starts the scan, first warning about shallow clones, whose
+// statistics miss the history before their cut-off. Their full history can
+// be fetched before scanning.
+func (s *SetupView) confirmShallow(repos []string) {
+	var shallow []string
+	for _, path := range repos {
+		if git.IsShallow(path) {
+			shallow = append(shallow, path)
 		}
+	}
+	if len(shallow) == 0 {
+		s.onComplete()
+		return
+	}
 
-		for _, entry := range entries {
-			if entry.IsDir() && !isHiddenDir(entry.Name()) {
-				fullPath := filepath.Join(path, entry.Name())
-				isRepo := git.IsGitRepo(fullPath)
-				if isRepo {
-					dirList.AddItem("[cyan]📦 "+entry.Name()+"[-]", "[Space] to add this repo", 0, nil)
-				} else {
-					dirList.AddItem("   "+entry.Name(), "Directory", 0, nil)
-				}
-				dirEntries = append(dirEntries, dirEntry{name: entry.Name(), isRepo: isRepo})
+	fetch, scan, cancel := i18n.T("Fetch history"), i18n.T("Scan anyway"), i18n.T("Cancel")
+	modal := tview.NewModal().
+		SetText(i18n.T("Shallow clones: %s\n\nTheir history is cut off, so the statistics will be incomplete. Fetch the full history with git fetch --unshallow?",
+			strings.Join(shallow, ", "))).
+		AddButtons([]string{fetch, scan, cancel}).
+		SetDoneFunc(func(_ int, label string) {
+			s.root.RemovePage("shallow")
+			s.root.SwitchToPage("main")
+			if s.app != nil {
+				s.app.SetFocus(s.repoList)
 			}
-		}
+			switch label {
+			case fetch:
+				s.unshallow(shallow)
+			case scan:
+				s.onComplete()
+			}
+		})
+	s.root.AddPage("shallow", modal, true, true)
+	if s.app != nil {
+		s.app.SetFocus(modal)
 	}
+}
 
-	populateList(currentPath)
-
-	// Browser layout
-	browserBox := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(dirList, 0, 1, true).
-		AddItem(browserHelp, 1, 0, false)
-	browserBox.SetBorder(true).SetTitle(" Select Repository ")
-
-	// Modal centered layout
-	modal := tview.NewFlex().
-		AddItem(nil, 0, 1, false).
-		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-			AddItem(nil, 0, 1, false).
-			AddItem(browserBox, 24, 0, true).
-			AddItem(nil, 0, 1, false), 80, 0, true).
-		AddItem(nil, 0, 1, false)
-
-	// Enter navigates into directories
-	dirList.SetSelectedFunc(func(idx int, main, secondary string, shortcut rune) {
-		if idx < 0 || idx >= len(dirEntries) {
+// unshallow fetches the full history of the repositories in the background,
+// then starts the scan
+func (s *SetupView) unshallow(repos []string) {
+	s.errorText.SetText("[yellow]" + i18n.T("Fetching full history...") + "[-]")
+	done := func(err error) {
+		if err != nil {
+			s.ShowError(tview.Escape(err.Error()))
 			return
 		}
-
-		entry := dirEntries[idx]
-
-		if entry.name == ".." {
-			populateList(filepath.Dir(currentPath))
-		} else {
-			// Navigate into directory
-			newPath := filepath.Join(currentPath, entry.name)
-			populateList(newPath)
-		}
-	})
-
-	// Helper to close modal and restore focus
-	closeModal := func() {
-		s.root.RemovePage("browser")
-		s.root.SwitchToPage("main")
-		if s.app != nil {
-			s.app.SetFocus(s.repoList)
+		s.errorText.SetText("")
+		for i := 0; i < s.repoList.GetItemCount(); i++ {
+			main, _ := s.repoList.GetItemText(i)
+			s.repoList.SetItemText(i, main, s.repoLabel(main))
 		}
+		s.onComplete()
 	}
+	if s.app == nil {
+		done(unshallowAll(repos))
+		return
+	}
+	go func() {
+		err := unshallowAll(repos)
+		s.app.QueueUpdateDraw(func() { done(err) })
+	}()
+}
 
-	// Space adds repos, Esc closes
-	dirList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyEsc:
-			closeModal()
-			return nil
-		case tcell.KeyRune:
-			if event.Rune() == ' ' {
-				// Add current selection if it's a repo
-				idx := dirList.GetCurrentItem()
-				if idx >= 0 && idx < len(dirEntries) {
-					entry := dirEntries[idx]
-					if entry.isRepo {
-						newPath := filepath.Join(currentPath, entry.name)
-						s.addRepo(newPath)
-						s.currentPath = currentPath
-						closeModal()
-						return nil
-					} else if entry.name == ".." {
-						// If current dir is a repo, add it
-						if git.IsGitRepo(currentPath) {
-							s.addRepo(currentPath)
-							s.currentPath = currentPath
-							closeModal()
-							return nil
-						}
-					}
-				}
-			}
+func unshallowAll(repos []string) error {
+	for _, path := range repos {
+		if err := git.Unshallow(context.Background(), path); err != nil {
+			return fmt.Errorf("%s: %v", filepath.Base(path), err)
 		}
-		return event
-	})
-
-	// Add browser as a page and switch to it
-	s.root.AddPage("browser", modal, true, true)
-
-	// Set focus on the directory list
-	if s.app != nil {
-		s.app.SetFocus(dirList)
 	}
+	return nil
 }
 
-func isHiddenDir(name string) bool {
-	return len(name) > 0 && name[0] == '.'
+// SetDateRange sets the period the next scan covers
+func (s *SetupView) SetDateRange(since, until time.Time) {
+	s.config.Since, s.config.Until = since, until
+	s.sinceInput.SetText(since.Format("2006-01-02"))
+	s.untilInput.SetText(until.Format("2006-01-02"))
 }
 
 // ShowError displays an error message
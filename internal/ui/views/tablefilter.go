@@ -0,0 +1,103 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+)
+
+// tableFilter is the filter bar of a table view. Opened with "/", it
+// narrows the rows live to those matching the typed text (see fuzzyScore);
+// Enter returns to the table keeping the filter, Esc clears it.
+type tableFilter struct {
+	input    *tview.InputField
+	root     *tview.Flex     // row layout the bar is shown in
+	table    tview.Primitive // the table or list filtered
+	focus    func(p tview.Primitive)
+	onChange func()
+	typing   bool
+}
+
+// newTableFilter creates the filter bar of a table or list; onChange
+// redraws the rows. The view adds the input to its row layout with no
+// height and sets that layout as root.
+func newTableFilter(table tview.Primitive, onChange func()) *tableFilter {
+	f := &tableFilter{table: table, onChange: onChange}
+	f.input = tview.NewInputField().
+		SetLabel(" / ").
+		SetLabelColor(tcell.ColorYellow).
+		SetPlaceholder(i18n.T("filter rows")).
+		SetFieldBackgroundColor(tcell.ColorDarkSlateGray)
+	f.input.SetChangedFunc(func(text string) {
+		f.onChange()
+		switch t := f.table.(type) {
+		case *tview.Table:
+			t.Select(1, 0)
+			t.ScrollToBeginning()
+		case *tview.List:
+			t.SetCurrentItem(0)
+		}
+	})
+	f.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			f.clear()
+			return nil
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyDown:
+			f.leave()
+			return nil
+		}
+		return event
+	})
+	return f
+}
+
+// open shows the bar and moves the focus into it
+func (f *tableFilter) open() {
+	if f.focus == nil {
+		return
+	}
+	f.typing = true
+	f.root.ResizeItem(f.input, 1, 0)
+	f.focus(f.input)
+}
+
+// leave returns the focus to the table, hiding the bar if it is empty
+func (f *tableFilter) leave() {
+	f.typing = false
+	if f.input.GetText() == "" {
+		f.root.ResizeItem(f.input, 0, 0)
+	}
+	if f.focus != nil {
+		f.focus(f.table)
+	}
+}
+
+// clear removes the filter and hides the bar
+func (f *tableFilter) clear() {
+	f.input.SetText("")
+	f.leave()
+}
+
+// match reports whether a row with the given texts passes the filter
+func (f *tableFilter) match(texts ...string) bool {
+	pattern := f.input.GetText()
+	if strings.TrimSpace(pattern) == "" {
+		return true
+	}
+	_, ok := fuzzyScore(pattern, strings.Join(texts, " "))
+	return ok
+}
+
+// status describes the filter for the info bar: how many of total rows
+// match, or nothing without a filter
+func (f *tableFilter) status(shown, total int) string {
+	if f.input.GetText() == "" {
+		return ""
+	}
+	return fmt.Sprintf(" | filter [yellow]%s[-]: %d of %d", tview.Escape(f.input.GetText()), shown, total)
+}
@@ -2,49 +2,124 @@ package views
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/audi70r/gitstat/internal/i18n"
 	"github.com/audi70r/gitstat/internal/stats"
 	"github.com/audi70r/gitstat/internal/ui/components"
 )
 
-// TimelineView displays commits over time
+// TimelineView displays commits over time: a bar chart of the commits per
+// period above the statistics, or over the whole view when expanded. In
+// overlay mode the statistics give way to a list of authors whose commits
+// are drawn over the chart.
 type TimelineView struct {
-	root *tview.Flex
-	text *tview.TextView
+	root     *tview.Flex
+	chart    *components.BarChart
+	lower    *tview.Pages // statistics, or the authors in overlay mode
+	text     *tview.TextView
+	authors  *tview.Table
+	layout   Layout
+	window   int // days of the rolling average, see Config.RollingWindow
+	period   string
+	expanded bool
+	overlay  bool
+	compared []string // emails of the overlaid authors, in selection order
+	holidays map[string]string
+	listed   []*stats.AuthorStats
+	repo     *stats.Repository
+	keys     *KeyMap
+}
+
+// Names of the periods in the chart title, the statistics and the period
+// count
+var periodNames = map[string][3]string{
+	stats.PeriodDay:     {"Daily", "Day", "days"},
+	stats.PeriodWeek:    {"Weekly", "Week", "weeks"},
+	stats.PeriodMonth:   {"Monthly", "Month", "months"},
+	stats.PeriodQuarter: {"Quarterly", "Quarter", "quarters"},
 }
 
 // NewTimelineView creates a new timeline view
 func NewTimelineView() *TimelineView {
-	v := &TimelineView{}
+	v := &TimelineView{window: 7, period: stats.PeriodDay}
 	v.setup()
 	return v
 }
 
 func (v *TimelineView) setup() {
+	v.chart = components.NewBarChart().SetUnit("commits")
+	v.chart.SetBorder(true)
+
 	v.text = tview.NewTextView().
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
 
-	v.root = tview.NewFlex().
-		AddItem(nil, 2, 0, false).
-		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-			AddItem(nil, 1, 0, false).
+	v.authors = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+	v.authors.SetBorder(true)
+
+	v.lower = tview.NewPages().
+		AddPage("statistics", tview.NewFlex().
+			AddItem(nil, 2, 0, false).
 			AddItem(v.text, 0, 1, false).
-			AddItem(nil, 1, 0, false), 0, 1, false).
-		AddItem(nil, 2, 0, false)
+			AddItem(nil, 2, 0, false), true, true).
+		AddPage("authors", v.authors, true, false)
+
+	v.root = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.chart, 0, 3, true).
+		AddItem(v.lower, 0, 2, false)
 }
 
 // Refresh updates the view with new data
 func (v *TimelineView) Refresh(repo *stats.Repository) {
-	timeline := repo.GetTimeline(7)
+	v.repo = repo
+
+	// The rolling average, forecast, crunches and anomalies are daily
+	// whatever the period shown
+	daily := repo.GetTimeline(stats.PeriodDay, v.window)
+	timeline := daily
+	if v.period != stats.PeriodDay {
+		timeline = repo.GetTimeline(v.period, 1)
+	}
 
 	if len(timeline.Values) == 0 {
+		v.chart.SetData(nil, nil).SetTitle("")
 		v.text.SetText("[yellow]No commit data available[-]")
 		return
 	}
+	names := periodNames[v.period]
+	crunches := repo.GetCrunches()
+	anomalies := repo.GetAnomalies()
+
+	// Shade the days off and color the crunches and anomalies in the daily
+	// chart, unless authors are overlaid
+	colors := make(map[int]tcell.Color)
+	var shaded map[int]string
+	legend := ""
+	if v.period == stats.PeriodDay {
+		shaded = daysOff(timeline.Labels, v.holidays)
+		legend = "  [gray]" + i18n.T("shaded: days off") + "[-]"
+		if !v.overlay {
+			colors = dayColors(crunches, anomalies, timeline.Labels)
+			legend = fmt.Sprintf("  [red]%s[-] [yellow]▲ %s[-] [blue]▼ %s[-]", i18n.T("crunch"), i18n.T("spike"), i18n.T("dip")) + legend
+		}
+	}
+	v.chart.SetData(timeline.Labels, timeline.Values).SetBarColors(colors).SetShading(shaded)
+	v.chart.SetOverlays(v.overlays())
+	if v.overlay {
+		v.refreshAuthors()
+	}
+	v.chart.SetTitle(fmt.Sprintf(" %s  [gray][%s] %s[-]%s ", i18n.T(names[0]+" Commits"), v.keys.Key("Timeline", "period"), i18n.T("Period"), legend))
 
 	// Calculate stats
 	var total, maxVal, minVal int
@@ -60,108 +135,253 @@ func (v *TimelineView) Refresh(repo *stats.Repository) {
 	}
 	avg := float64(total) / float64(len(timeline.Values))
 
-	// Get first and last dates
+	// Get first and last periods
 	firstDate := timeline.Labels[0]
 	lastDate := timeline.Labels[len(timeline.Labels)-1]
 
-	// Generate sparkline
-	sparkWidth := 70
-	sparkline := components.RenderSparklineWithWidth(timeline.Values, sparkWidth)
-
-	// Weekly aggregation
-	weeklyValues := aggregateWeekly(timeline.Labels, timeline.Values)
-	weeklySparkline := components.RenderSparkline(weeklyValues)
-
-	// Find peak day
+	// Find peak period
 	peakIdx := 0
 	for i, val := range timeline.Values {
 		if val > timeline.Values[peakIdx] {
 			peakIdx = i
 		}
 	}
-	peakDate := ""
-	if peakIdx < len(timeline.Labels) {
-		peakDate = timeline.Labels[peakIdx]
-	}
+	peakDate := timeline.Labels[peakIdx]
 
-	content := fmt.Sprintf(`[::b]Commits Over Time[-:-:-]
+	content := fmt.Sprintf(`
+  [::b]Statistics[-:-:-]
+
+  Period:             [cyan]%d[-] %s, %s to %s
+  Total Commits:      [cyan]%d[-]
+  Average per %-8s[cyan]%.2f[-]
+  Peak %-14s [green]%d[-] commits in [green]%s[-]
+  Minimum %-11s [red]%d[-] commits
+  Maximum %-11s [green]%d[-] commits
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
 
-  [::b]Daily Activity (sparkline)[-:-:-]
+  [::b]%d-Day Rolling Average[-:-:-]  [gray][%s/%s] window[-]
 
-  [green]%s[-]
+  Current:            [cyan]%.2f[-] commits/day
+  Trend:              %s
 
-  %s to %s
+`,
+		len(timeline.Values), names[2], firstDate, lastDate,
+		total,
+		names[1]+":", avg,
+		names[1]+":", maxVal, peakDate,
+		names[1]+":", minVal,
+		names[1]+":", maxVal,
+		v.window, v.keys.Key("Timeline", "shorter"), v.keys.Key("Timeline", "longer"),
+		daily.RollingAvg[len(daily.RollingAvg)-1],
+		getTrendIndicator(daily.RollingAvg),
+	)
 
-[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+	// Date, padding and the "~N commits (low-high)" suffix
+	content += renderForecast(daily.Forecast(4), v.layout.bar(40, textPadding+41))
+	content += renderCrunches(crunches)
+	content += renderAnomalies(anomalies, 10, v.holidays)
 
-  [::b]Weekly Activity (sparkline)[-:-:-]
+	v.text.SetText(v.layout.fit(content))
+}
 
-  [cyan]%s[-]
+// SetLayout sets the space available for the forecast bars
+func (v *TimelineView) SetLayout(layout Layout) {
+	v.layout = layout
+}
 
-[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+// SetKeyMap sets the key map the key hints are looked up in and handles
+// the author overlay keys
+func (v *TimelineView) SetKeyMap(keys *KeyMap) {
+	v.keys = keys
+	keys.Handle("Timeline", "overlay", v.toggleOverlay)
+	keys.Handle("Timeline", "select", v.toggleAuthor)
+}
 
-  [::b]Statistics[-:-:-]
+// CyclePeriod switches the chart and statistics to the next longer period,
+// from quarters back to days
+func (v *TimelineView) CyclePeriod() {
+	i := slices.Index(stats.TimelinePeriods, v.period)
+	v.period = stats.TimelinePeriods[(i+1)%len(stats.TimelinePeriods)]
+}
 
-  Period:             [cyan]%d[-] days
-  Total Commits:      [cyan]%d[-]
-  Average per Day:    [cyan]%.2f[-]
-  Peak Day:           [green]%d[-] commits on [green]%s[-]
-  Minimum Day:        [red]%d[-] commits
-  Maximum Day:        [green]%d[-] commits
+// ToggleExpanded gives the chart the whole view, hiding the statistics, or
+// shows the statistics again
+func (v *TimelineView) ToggleExpanded() {
+	v.expanded = !v.expanded
+	details := 2
+	if v.expanded {
+		details = 0
+	}
+	v.root.ResizeItem(v.root.GetItem(1), 0, details)
+}
 
-[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
+// SetHolidays sets the days off shaded besides weekends, names by date
+func (v *TimelineView) SetHolidays(holidays map[string]string) {
+	v.holidays = holidays
+}
 
-  [::b]7-Day Rolling Average[-:-:-]
+// SetRollingWindow sets the days the rolling average spans
+func (v *TimelineView) SetRollingWindow(days int) {
+	v.window = days
+}
 
-  Current:            [cyan]%.2f[-] commits/day
-  Trend:              %s
+// renderForecast shows the projected weekly commit range
+func renderForecast(forecast *stats.Forecast, barWidth int) string {
+	var sb strings.Builder
 
-`,
-		sparkline,
-		firstDate, lastDate,
-		weeklySparkline,
-		len(timeline.Values),
-		total,
-		avg,
-		maxVal, peakDate,
-		minVal,
-		maxVal,
-		timeline.RollingAvg[len(timeline.RollingAvg)-1],
-		getTrendIndicator(timeline.RollingAvg),
-	)
+	sb.WriteString("[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Next 4 Weeks (estimate)[-:-:-]\n\n")
 
-	v.text.SetText(content)
-}
+	if forecast == nil {
+		sb.WriteString("  [gray]At least 4 weeks of history are needed for a forecast[-]\n")
+		return sb.String()
+	}
+
+	maxHigh := 0.0
+	for _, w := range forecast.Weeks {
+		maxHigh = math.Max(maxHigh, w.High)
+	}
 
-func aggregateWeekly(labels []string, values []int) []int {
-	if len(values) == 0 {
-		return nil
+	for _, w := range forecast.Weeks {
+		low := int(safeDivide(w.Low, maxHigh) * float64(barWidth))
+		high := int(safeDivide(w.High, maxHigh) * float64(barWidth))
+		sb.WriteString(fmt.Sprintf("  %s  %s[gray]%s[-]%s  [cyan]~%.0f[-] commits [gray](%.0f-%.0f)[-]\n",
+			w.Start,
+			strings.Repeat(" ", low),
+			strings.Repeat("░", high-low),
+			strings.Repeat(" ", barWidth-high),
+			w.Expected, w.Low, w.High))
 	}
 
-	// Group by week
-	weeks := make(map[string]int)
-	weekOrder := make([]string, 0)
+	sb.WriteString(fmt.Sprintf("\n  [gray]Linear trend + weekday pattern fitted on %d days, ~80%% range.\n  Trend: %+.2f commits/day per week. An estimate, not a commitment.[-]\n",
+		forecast.FitDays, forecast.SlopePerWk))
+
+	return sb.String()
+}
 
+// dayColors colors the bars of the daily chart: crunch days red, spikes
+// yellow and dips blue
+func dayColors(crunches []*stats.Crunch, anomalies []*stats.Anomaly, labels []string) map[int]tcell.Color {
+	colors := make(map[int]tcell.Color)
+	index := make(map[string]int, len(labels))
 	for i, label := range labels {
-		// Get week number from date
-		weekKey := label[:7] // YYYY-MM as approximation
-		if _, exists := weeks[weekKey]; !exists {
-			weekOrder = append(weekOrder, weekKey)
+		index[label] = i
+		for _, c := range crunches {
+			if label >= c.Start && label <= c.End {
+				colors[i] = tcell.ColorRed
+			}
+		}
+	}
+	for _, a := range anomalies {
+		i, ok := index[a.Date]
+		if !ok {
+			continue
+		}
+		colors[i] = tcell.ColorBlue
+		if a.Spike {
+			colors[i] = tcell.ColorYellow
+		}
+	}
+	return colors
+}
+
+// daysOff returns the weekends and holidays among the daily labels, each
+// noted with its weekday or the holiday's name
+func daysOff(labels []string, holidays map[string]string) map[int]string {
+	off := make(map[int]string)
+	for i, label := range labels {
+		if name, ok := holidays[label]; ok {
+			off[i] = name
+			continue
+		}
+		day, err := time.Parse("2006-01-02", label)
+		if err == nil && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			off[i] = day.Weekday().String()
+		}
+	}
+	return off
+}
+
+// renderCrunches lists the crunch periods with the tags around them
+func renderCrunches(crunches []*stats.Crunch) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Crunch Periods[-:-:-]\n\n")
+
+	if len(crunches) == 0 {
+		sb.WriteString("  [gray]No sustained spikes above the rolling average[-]\n")
+		return sb.String()
+	}
+
+	for _, c := range crunches {
+		sb.WriteString(fmt.Sprintf("  [red]%s to %s[-]  %d days, [cyan]%d[-] commits, peak %d, [yellow]%.1f×[-] the usual %.1f/day\n",
+			c.Start, c.End, c.Days, c.Commits, c.Peak, c.Intensity(), c.Baseline))
+		if len(c.Tags) == 0 {
+			sb.WriteString("    [gray]no tags within two weeks[-]\n")
+			continue
 		}
-		weeks[weekKey] += values[i]
+		names := make([]string, len(c.Tags))
+		for i, tag := range c.Tags {
+			names[i] = fmt.Sprintf("%s (%s)", tag.Name, tag.Date.Format("2006-01-02"))
+		}
+		sb.WriteString(fmt.Sprintf("    [gray]tags:[-] %s\n", strings.Join(names, ", ")))
 	}
+	sb.WriteString("\n  [gray]At least 3 days with twice the average of the 4 weeks before, drawn red in the daily chart.[-]\n")
+	return sb.String()
+}
 
-	// Sort weeks
-	sort.Strings(weekOrder)
+// renderAnomalies lists the limit most anomalous days in date order, with
+// who committed on them and their largest commits, naming the holidays
+func renderAnomalies(anomalies []*stats.Anomaly, limit int, holidays map[string]string) string {
+	var sb strings.Builder
 
-	result := make([]int, len(weekOrder))
-	for i, week := range weekOrder {
-		result[i] = weeks[week]
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Anomalous Days[-:-:-]\n\n")
+
+	if len(anomalies) == 0 {
+		sb.WriteString("  [gray]No day stands out from the same weekday of the weeks before[-]\n")
+		return sb.String()
+	}
+
+	shown := anomalies
+	if len(shown) > limit {
+		shown = slices.Clone(anomalies)
+		sort.SliceStable(shown, func(i, j int) bool {
+			return math.Abs(shown[i].ZScore) > math.Abs(shown[j].ZScore)
+		})
+		shown = shown[:limit]
+		sort.SliceStable(shown, func(i, j int) bool { return shown[i].Date < shown[j].Date })
 	}
 
-	return result
+	for _, a := range shown {
+		mark := "[blue]▼"
+		if a.Spike {
+			mark = "[yellow]▲"
+		}
+		holiday := ""
+		if name, ok := holidays[a.Date]; ok {
+			holiday = fmt.Sprintf(" [gray::i]%s[-::-]", tview.Escape(name))
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s[-]%s  [cyan]%d[-] commits, usually %.1f [gray](z %+.1f)[-]\n",
+			mark, a.Date, a.Weekday().String()[:3], holiday, a.Commits, a.Baseline, a.ZScore))
+		if len(a.Authors) > 0 {
+			names := make([]string, len(a.Authors))
+			for i, author := range a.Authors {
+				names[i] = fmt.Sprintf("%s %d", tview.Escape(author.Name), author.Commits)
+			}
+			sb.WriteString(fmt.Sprintf("    [gray]authors:[-] %s\n", strings.Join(names, ", ")))
+		}
+		for _, c := range a.Top {
+			sb.WriteString(fmt.Sprintf("    [gray]%s[-] %s [gray](%d lines)[-]\n", c.Hash, tview.Escape(c.Subject), c.Lines))
+		}
+	}
+	if len(shown) < len(anomalies) {
+		sb.WriteString(fmt.Sprintf("\n  [gray]%d less anomalous days not listed[-]\n", len(anomalies)-len(shown)))
+	}
+	sb.WriteString("\n  [gray]Days far from the same weekday of the 8 weeks before, spikes drawn yellow and dips blue in the daily chart.[-]\n")
+	return sb.String()
 }
 
 func getTrendIndicator(rollingAvg []float64) string {
@@ -202,3 +422,17 @@ func getTrendIndicator(rollingAvg []float64) string {
 func (v *TimelineView) Root() tview.Primitive {
 	return v.root
 }
+
+// GetFocusable returns the chart
+func (v *TimelineView) GetFocusable() tview.Primitive {
+	return v.chart
+}
+
+// GetSecondaryFocusable returns the statistics, scrolled after the chart,
+// or the authors in overlay mode
+func (v *TimelineView) GetSecondaryFocusable() tview.Primitive {
+	if v.overlay {
+		return v.authors
+	}
+	return v.text
+}
@@ -0,0 +1,96 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/ui/components"
+)
+
+// Colors of the overlaid authors, in selection order; there are as many
+// authors as colors at most
+var overlayColors = []tcell.Color{tcell.ColorAqua, tcell.ColorFuchsia, tcell.ColorOrange, tcell.ColorLime, tcell.ColorRed}
+
+// toggleOverlay switches between the statistics and the author list,
+// drawing the selected authors over the chart while the list shows
+func (v *TimelineView) toggleOverlay() {
+	v.overlay = !v.overlay
+	if v.overlay {
+		v.lower.SwitchToPage("authors")
+	} else {
+		v.lower.SwitchToPage("statistics")
+	}
+	if v.repo != nil {
+		v.Refresh(v.repo)
+	}
+}
+
+// toggleAuthor adds the author under the cursor to the overlay or removes
+// them; authors beyond the last color are not added
+func (v *TimelineView) toggleAuthor() {
+	row, _ := v.authors.GetSelection()
+	if !v.overlay || row < 1 || row > len(v.listed) {
+		return
+	}
+	email := v.listed[row-1].Email
+	if i := slices.Index(v.compared, email); i >= 0 {
+		v.compared = slices.Delete(v.compared, i, i+1)
+	} else if len(v.compared) < len(overlayColors) {
+		v.compared = append(v.compared, email)
+	}
+	v.Refresh(v.repo)
+}
+
+// overlays returns the commits of the selected authors in the period shown,
+// forgetting authors merged away since they were selected
+func (v *TimelineView) overlays() []components.Series {
+	if !v.overlay {
+		return nil
+	}
+	v.compared = slices.DeleteFunc(v.compared, func(email string) bool {
+		_, ok := v.repo.Authors[email]
+		return !ok
+	})
+	series := make([]components.Series, 0, len(v.compared))
+	for i, email := range v.compared {
+		timeline := v.repo.GetAuthorTimeline(email, v.period)
+		series = append(series, components.Series{
+			Name:   v.repo.Authors[email].Name,
+			Values: timeline.Values,
+			Color:  overlayColors[i],
+		})
+	}
+	return series
+}
+
+// refreshAuthors lists the authors by commits, the overlaid ones marked in
+// their color
+func (v *TimelineView) refreshAuthors() {
+	row, _ := v.authors.GetSelection()
+	v.listed = v.repo.GetLeaderboard("commits", false)
+
+	v.authors.Clear()
+	for col, name := range []string{"", "Author", "Commits"} {
+		v.authors.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, author := range v.listed {
+		mark := tview.NewTableCell("  ")
+		if slot := slices.Index(v.compared, author.Email); slot >= 0 {
+			mark = tview.NewTableCell("━━").SetTextColor(overlayColors[slot])
+		}
+		v.authors.SetCell(i+1, 0, mark)
+		v.authors.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(author.Name)).SetExpansion(1))
+		v.authors.SetCell(i+1, 2, tview.NewTableCell(formatNumber(author.Commits)).SetAlign(tview.AlignRight))
+	}
+	v.authors.Select(max(1, min(row, len(v.listed))), 0)
+
+	v.authors.SetTitle(fmt.Sprintf(" %s  [gray][%s] %s  %d/%d[-] ",
+		i18n.T("Compare Authors"), v.keys.Key("Timeline", "select"), i18n.T("Select"), len(v.compared), len(overlayColors)))
+}
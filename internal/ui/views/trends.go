@@ -0,0 +1,134 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+	"github.com/audi70r/gitstat/internal/ui/components"
+)
+
+// How many of the latest scans the table lists
+const trendRows = 15
+
+// trendMetric is a headline metric charted across scans
+type trendMetric struct {
+	name   string
+	value  func(s stats.Snapshot) int
+	higher bool // whether a rise is good news
+}
+
+var trendMetrics = []trendMetric{
+	{"Commits", func(s stats.Snapshot) int { return s.Commits }, true},
+	{"Churn", func(s stats.Snapshot) int { return s.Churn }, false},
+	{"Bus Factor", func(s stats.Snapshot) int { return s.BusFactor }, true},
+	{"Hotspots", func(s stats.Snapshot) int { return s.Hotspots }, false},
+	{"Active Authors", func(s stats.Snapshot) int { return s.ActiveAuthors }, true},
+}
+
+// TrendsView charts the headline metrics of past scans of the same
+// repositories
+type TrendsView struct {
+	root   *tview.Flex
+	text   *tview.TextView
+	layout Layout
+}
+
+// NewTrendsView creates a new trends view
+func NewTrendsView() *TrendsView {
+	v := &TrendsView{}
+	v.setup()
+	return v
+}
+
+func (v *TrendsView) setup() {
+	v.text = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignLeft)
+
+	v.root = tview.NewFlex().
+		AddItem(nil, 2, 0, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false).
+			AddItem(v.text, 0, 1, true).
+			AddItem(nil, 1, 0, false), 0, 1, true).
+		AddItem(nil, 2, 0, false)
+}
+
+// Refresh updates the view with the snapshots of past scans, oldest first
+func (v *TrendsView) Refresh(snapshots []stats.Snapshot) {
+	if len(snapshots) == 0 {
+		v.text.SetText("[gray]No scans recorded yet. Every scan adds a snapshot; see Config.RecordTrends.[-]")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[::b]Trends Across Scans[-:-:-]\n\n")
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	sb.WriteString(fmt.Sprintf("  [cyan]%d[-] scans from %s to %s\n\n",
+		len(snapshots), formatDateTime(first.At), formatDateTime(last.At)))
+	sb.WriteString("[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+
+	sparkWidth := v.layout.bar(40, textPadding+42)
+	for _, m := range trendMetrics {
+		values := make([]int, len(snapshots))
+		for i, s := range snapshots {
+			values[i] = m.value(s)
+		}
+		latest := values[len(values)-1]
+		change := ""
+		if len(values) > 1 {
+			change = formatTrendChange(latest-values[len(values)-2], m.higher)
+		}
+		sb.WriteString(fmt.Sprintf("  %-16s [green]%s[-]  [cyan]%10s[-] %s\n",
+			i18n.T(m.name), components.RenderSparklineWithWidth(values, sparkWidth), formatNumber(latest), change))
+	}
+
+	sb.WriteString("\n[yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]\n\n")
+	sb.WriteString("  [::b]Latest Scans[-:-:-]\n\n")
+	sb.WriteString(fmt.Sprintf("  [yellow]%-17s %-23s %8s %8s %4s %4s %4s[-]\n",
+		"Scanned", "Period", "Commits", "Churn", "Bus", "Hot", "Act"))
+	start := max(0, len(snapshots)-trendRows)
+	for i := len(snapshots) - 1; i >= start; i-- {
+		s := snapshots[i]
+		sb.WriteString(fmt.Sprintf("  %-17s [gray]%-23s[-] %8s %8s %4d %4d %4d\n",
+			formatDateTime(s.At), i18n.Date(s.Since)+" - "+i18n.Date(s.Until),
+			formatNumber(s.Commits), formatNumber(s.Churn), s.BusFactor, s.Hotspots, s.ActiveAuthors))
+	}
+	sb.WriteString("\n  [gray]Scans of different periods are not directly comparable; the period column shows each scan's range.[-]\n")
+
+	v.text.SetText(v.layout.fit(sb.String()))
+	v.text.ScrollToBeginning()
+}
+
+// formatTrendChange colors the change since the previous scan by whether it
+// is good news
+func formatTrendChange(delta int, higherIsBetter bool) string {
+	if delta == 0 {
+		return "[gray]±0[-]"
+	}
+	color := "green"
+	if (delta > 0) != higherIsBetter {
+		color = "red"
+	}
+	return fmt.Sprintf("[%s]%s[-]", color, formatSigned(delta))
+}
+
+// SetLayout sets the space available for the sparklines
+func (v *TrendsView) SetLayout(layout Layout) {
+	v.layout = layout
+}
+
+// Root returns the root primitive
+func (v *TrendsView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *TrendsView) GetFocusable() tview.Primitive {
+	return v.text
+}
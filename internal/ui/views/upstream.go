@@ -0,0 +1,111 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/audi70r/gitstat/internal/i18n"
+	"github.com/audi70r/gitstat/internal/stats"
+)
+
+// UpstreamView displays per-author upstreamed vs fork-only commits when a
+// fork is scanned together with its upstream repository
+type UpstreamView struct {
+	root    *tview.Flex
+	table   *tview.Table
+	info    *tview.TextView
+	columns []string
+}
+
+// NewUpstreamView creates a new upstream contribution view
+func NewUpstreamView() *UpstreamView {
+	v := &UpstreamView{
+		columns: []string{"#", "Author", "Upstreamed", "Fork-only", "Total", "Upstreamed%"},
+	}
+	v.setup()
+	return v
+}
+
+func (v *UpstreamView) setup() {
+	v.table = tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSeparator(' ')
+
+	v.info = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+
+	v.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(v.table, 0, 1, true).
+		AddItem(v.info, 1, 0, false)
+
+	for col, name := range v.columns {
+		v.table.SetCell(0, col, tview.NewTableCell(i18n.T(name)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+}
+
+// Refresh updates the view with new data
+func (v *UpstreamView) Refresh(repo *stats.Repository) {
+	// Clear existing data rows
+	for row := v.table.GetRowCount() - 1; row > 0; row-- {
+		v.table.RemoveRow(row)
+	}
+
+	if repo.Fork == nil {
+		v.info.SetText("[gray]Not a fork scan: mark the upstream repository with [p] on the setup screen[-]")
+		return
+	}
+
+	contributions := repo.GetForkContributions()
+	upstreamed, forkOnly := 0, 0
+	for i, f := range contributions {
+		row := i + 1
+		upstreamed += f.Upstreamed
+		forkOnly += f.ForkOnly
+
+		v.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkGray).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 1, tview.NewTableCell(f.Name).
+			SetTextColor(tcell.ColorAqua).
+			SetExpansion(1))
+
+		v.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", f.Upstreamed)).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%d", f.ForkOnly)).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignRight))
+
+		v.table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", f.Total())).
+			SetAlign(tview.AlignRight))
+
+		pct := f.UpstreamedPercent()
+		v.table.SetCell(row, 5, tview.NewTableCell(fmt.Sprintf("%.1f%%", pct)).
+			SetTextColor(getBackportColor(pct)).
+			SetAlign(tview.AlignRight))
+	}
+
+	v.info.SetText(fmt.Sprintf("[yellow]%d[-] authors | [green]%d[-] fork commits upstreamed, [yellow]%d[-] fork-only (%.1f%% upstreamed)",
+		len(contributions), upstreamed, forkOnly,
+		safeDivide(float64(upstreamed), float64(upstreamed+forkOnly))*100))
+}
+
+// Root returns the root primitive
+func (v *UpstreamView) Root() tview.Primitive {
+	return v.root
+}
+
+// GetFocusable returns the focusable component
+func (v *UpstreamView) GetFocusable() tview.Primitive {
+	return v.table
+}